@@ -0,0 +1,483 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: parca/alerts/v1alpha1/alerts.proto
+
+package alertsv1alpha1
+
+import (
+	v1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// State is the state of an alert
+type Alert_State int32
+
+const (
+	// STATE_UNKNOWN_UNSPECIFIED unspecified
+	Alert_STATE_UNKNOWN_UNSPECIFIED Alert_State = 0
+	// STATE_PENDING the value is above the threshold, but not for long enough
+	Alert_STATE_PENDING Alert_State = 1
+	// STATE_FIRING the value has been above the threshold for long enough
+	Alert_STATE_FIRING Alert_State = 2
+)
+
+// Enum value maps for Alert_State.
+var (
+	Alert_State_name = map[int32]string{
+		0: "STATE_UNKNOWN_UNSPECIFIED",
+		1: "STATE_PENDING",
+		2: "STATE_FIRING",
+	}
+	Alert_State_value = map[string]int32{
+		"STATE_UNKNOWN_UNSPECIFIED": 0,
+		"STATE_PENDING":             1,
+		"STATE_FIRING":              2,
+	}
+)
+
+func (x Alert_State) Enum() *Alert_State {
+	p := new(Alert_State)
+	*p = x
+	return p
+}
+
+func (x Alert_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Alert_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_parca_alerts_v1alpha1_alerts_proto_enumTypes[0].Descriptor()
+}
+
+func (Alert_State) Type() protoreflect.EnumType {
+	return &file_parca_alerts_v1alpha1_alerts_proto_enumTypes[0]
+}
+
+func (x Alert_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Alert_State.Descriptor instead.
+func (Alert_State) EnumDescriptor() ([]byte, []int) {
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP(), []int{3, 0}
+}
+
+// RulesRequest is the request for the alerting rules
+type RulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulesRequest) Reset() {
+	*x = RulesRequest{}
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulesRequest) ProtoMessage() {}
+
+func (x *RulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulesRequest.ProtoReflect.Descriptor instead.
+func (*RulesRequest) Descriptor() ([]byte, []int) {
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP(), []int{0}
+}
+
+// RulesResponse contains the alerting rules
+type RulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rules are the configured alerting rules
+	Rules         []*AlertingRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulesResponse) Reset() {
+	*x = RulesResponse{}
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulesResponse) ProtoMessage() {}
+
+func (x *RulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulesResponse.ProtoReflect.Descriptor instead.
+func (*RulesResponse) Descriptor() ([]byte, []int) {
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RulesResponse) GetRules() []*AlertingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// AlertingRule is the state of an alerting rule
+type AlertingRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the alert
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// query is the profile query of the rule
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// threshold is the value alerts fire above
+	Threshold float64 `protobuf:"fixed64,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// duration is how long the value has to be above the threshold before an alert fires
+	Duration *durationpb.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// labels are the labels added to the alerts
+	Labels *v1alpha1.LabelSet `protobuf:"bytes,5,opt,name=labels,proto3" json:"labels,omitempty"`
+	// annotations are the annotation templates of the alerts
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// last_evaluation is the time stamp of the last evaluation of the rule
+	LastEvaluation *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_evaluation,json=lastEvaluation,proto3" json:"last_evaluation,omitempty"`
+	// last_error is the error of the last evaluation, if it failed
+	LastError string `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// alerts are the pending and firing alerts of the rule
+	Alerts        []*Alert `protobuf:"bytes,9,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertingRule) Reset() {
+	*x = AlertingRule{}
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertingRule) ProtoMessage() {}
+
+func (x *AlertingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertingRule.ProtoReflect.Descriptor instead.
+func (*AlertingRule) Descriptor() ([]byte, []int) {
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AlertingRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertingRule) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AlertingRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *AlertingRule) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *AlertingRule) GetLabels() *v1alpha1.LabelSet {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AlertingRule) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *AlertingRule) GetLastEvaluation() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEvaluation
+	}
+	return nil
+}
+
+func (x *AlertingRule) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *AlertingRule) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+// Alert is a pending or firing alert
+type Alert struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// state is the state of the alert
+	State Alert_State `protobuf:"varint,1,opt,name=state,proto3,enum=parca.alerts.v1alpha1.Alert_State" json:"state,omitempty"`
+	// labels are the labels of the alert
+	Labels *v1alpha1.LabelSet `protobuf:"bytes,2,opt,name=labels,proto3" json:"labels,omitempty"`
+	// annotations are the expanded annotations of the alert
+	Annotations map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// value is the value of the last evaluation
+	Value float64 `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	// active_at is the time stamp the value went above the threshold
+	ActiveAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=active_at,json=activeAt,proto3" json:"active_at,omitempty"`
+	// fired_at is the time stamp the alert started firing
+	FiredAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_alerts_v1alpha1_alerts_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Alert) GetState() Alert_State {
+	if x != nil {
+		return x.State
+	}
+	return Alert_STATE_UNKNOWN_UNSPECIFIED
+}
+
+func (x *Alert) GetLabels() *v1alpha1.LabelSet {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Alert) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Alert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetActiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveAt
+	}
+	return nil
+}
+
+func (x *Alert) GetFiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FiredAt
+	}
+	return nil
+}
+
+var File_parca_alerts_v1alpha1_alerts_proto protoreflect.FileDescriptor
+
+const file_parca_alerts_v1alpha1_alerts_proto_rawDesc = "" +
+	"\n" +
+	"\"parca/alerts/v1alpha1/alerts.proto\x12\x15parca.alerts.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.parca/profilestore/v1alpha1/profilestore.proto\"\x0e\n" +
+	"\fRulesRequest\"J\n" +
+	"\rRulesResponse\x129\n" +
+	"\x05rules\x18\x01 \x03(\v2#.parca.alerts.v1alpha1.AlertingRuleR\x05rules\"\xfe\x03\n" +
+	"\fAlertingRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x01R\tthreshold\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12=\n" +
+	"\x06labels\x18\x05 \x01(\v2%.parca.profilestore.v1alpha1.LabelSetR\x06labels\x12V\n" +
+	"\vannotations\x18\x06 \x03(\v24.parca.alerts.v1alpha1.AlertingRule.AnnotationsEntryR\vannotations\x12C\n" +
+	"\x0flast_evaluation\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0elastEvaluation\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x124\n" +
+	"\x06alerts\x18\t \x03(\v2\x1c.parca.alerts.v1alpha1.AlertR\x06alerts\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x03\n" +
+	"\x05Alert\x128\n" +
+	"\x05state\x18\x01 \x01(\x0e2\".parca.alerts.v1alpha1.Alert.StateR\x05state\x12=\n" +
+	"\x06labels\x18\x02 \x01(\v2%.parca.profilestore.v1alpha1.LabelSetR\x06labels\x12O\n" +
+	"\vannotations\x18\x03 \x03(\v2-.parca.alerts.v1alpha1.Alert.AnnotationsEntryR\vannotations\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x127\n" +
+	"\tactive_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bactiveAt\x125\n" +
+	"\bfired_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\afiredAt\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
+	"\x05State\x12\x1d\n" +
+	"\x19STATE_UNKNOWN_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_PENDING\x10\x01\x12\x10\n" +
+	"\fSTATE_FIRING\x10\x022z\n" +
+	"\rAlertsService\x12i\n" +
+	"\x05Rules\x12#.parca.alerts.v1alpha1.RulesRequest\x1a$.parca.alerts.v1alpha1.RulesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/alerts/rulesB\xec\x01\n" +
+	"\x19com.parca.alerts.v1alpha1B\vAlertsProtoP\x01ZLgithub.com/parca-dev/parca/gen/proto/go/parca/alerts/v1alpha1;alertsv1alpha1\xa2\x02\x03PAX\xaa\x02\x15Parca.Alerts.V1alpha1\xca\x02\x15Parca\\Alerts\\V1alpha1\xe2\x02!Parca\\Alerts\\V1alpha1\\GPBMetadata\xea\x02\x17Parca::Alerts::V1alpha1b\x06proto3"
+
+var (
+	file_parca_alerts_v1alpha1_alerts_proto_rawDescOnce sync.Once
+	file_parca_alerts_v1alpha1_alerts_proto_rawDescData []byte
+)
+
+func file_parca_alerts_v1alpha1_alerts_proto_rawDescGZIP() []byte {
+	file_parca_alerts_v1alpha1_alerts_proto_rawDescOnce.Do(func() {
+		file_parca_alerts_v1alpha1_alerts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_parca_alerts_v1alpha1_alerts_proto_rawDesc), len(file_parca_alerts_v1alpha1_alerts_proto_rawDesc)))
+	})
+	return file_parca_alerts_v1alpha1_alerts_proto_rawDescData
+}
+
+var file_parca_alerts_v1alpha1_alerts_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_parca_alerts_v1alpha1_alerts_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_parca_alerts_v1alpha1_alerts_proto_goTypes = []any{
+	(Alert_State)(0),              // 0: parca.alerts.v1alpha1.Alert.State
+	(*RulesRequest)(nil),          // 1: parca.alerts.v1alpha1.RulesRequest
+	(*RulesResponse)(nil),         // 2: parca.alerts.v1alpha1.RulesResponse
+	(*AlertingRule)(nil),          // 3: parca.alerts.v1alpha1.AlertingRule
+	(*Alert)(nil),                 // 4: parca.alerts.v1alpha1.Alert
+	nil,                           // 5: parca.alerts.v1alpha1.AlertingRule.AnnotationsEntry
+	nil,                           // 6: parca.alerts.v1alpha1.Alert.AnnotationsEntry
+	(*durationpb.Duration)(nil),   // 7: google.protobuf.Duration
+	(*v1alpha1.LabelSet)(nil),     // 8: parca.profilestore.v1alpha1.LabelSet
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_parca_alerts_v1alpha1_alerts_proto_depIdxs = []int32{
+	3,  // 0: parca.alerts.v1alpha1.RulesResponse.rules:type_name -> parca.alerts.v1alpha1.AlertingRule
+	7,  // 1: parca.alerts.v1alpha1.AlertingRule.duration:type_name -> google.protobuf.Duration
+	8,  // 2: parca.alerts.v1alpha1.AlertingRule.labels:type_name -> parca.profilestore.v1alpha1.LabelSet
+	5,  // 3: parca.alerts.v1alpha1.AlertingRule.annotations:type_name -> parca.alerts.v1alpha1.AlertingRule.AnnotationsEntry
+	9,  // 4: parca.alerts.v1alpha1.AlertingRule.last_evaluation:type_name -> google.protobuf.Timestamp
+	4,  // 5: parca.alerts.v1alpha1.AlertingRule.alerts:type_name -> parca.alerts.v1alpha1.Alert
+	0,  // 6: parca.alerts.v1alpha1.Alert.state:type_name -> parca.alerts.v1alpha1.Alert.State
+	8,  // 7: parca.alerts.v1alpha1.Alert.labels:type_name -> parca.profilestore.v1alpha1.LabelSet
+	6,  // 8: parca.alerts.v1alpha1.Alert.annotations:type_name -> parca.alerts.v1alpha1.Alert.AnnotationsEntry
+	9,  // 9: parca.alerts.v1alpha1.Alert.active_at:type_name -> google.protobuf.Timestamp
+	9,  // 10: parca.alerts.v1alpha1.Alert.fired_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: parca.alerts.v1alpha1.AlertsService.Rules:input_type -> parca.alerts.v1alpha1.RulesRequest
+	2,  // 12: parca.alerts.v1alpha1.AlertsService.Rules:output_type -> parca.alerts.v1alpha1.RulesResponse
+	12, // [12:13] is the sub-list for method output_type
+	11, // [11:12] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_parca_alerts_v1alpha1_alerts_proto_init() }
+func file_parca_alerts_v1alpha1_alerts_proto_init() {
+	if File_parca_alerts_v1alpha1_alerts_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parca_alerts_v1alpha1_alerts_proto_rawDesc), len(file_parca_alerts_v1alpha1_alerts_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_parca_alerts_v1alpha1_alerts_proto_goTypes,
+		DependencyIndexes: file_parca_alerts_v1alpha1_alerts_proto_depIdxs,
+		EnumInfos:         file_parca_alerts_v1alpha1_alerts_proto_enumTypes,
+		MessageInfos:      file_parca_alerts_v1alpha1_alerts_proto_msgTypes,
+	}.Build()
+	File_parca_alerts_v1alpha1_alerts_proto = out.File
+	file_parca_alerts_v1alpha1_alerts_proto_goTypes = nil
+	file_parca_alerts_v1alpha1_alerts_proto_depIdxs = nil
+}
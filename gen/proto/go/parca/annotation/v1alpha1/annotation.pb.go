@@ -0,0 +1,576 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: parca/annotation/v1alpha1/annotation.proto
+
+package annotationv1alpha1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Annotation is an event at a point in time or over a time range
+type Annotation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the annotation, it is assigned on creation
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// selector is the label selector of the series the annotation applies to, for example {job="api"}.
+	// An empty selector applies to all series.
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// start is the time the event started
+	Start *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the time the event ended, it equals start for events at a point in time
+	End *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	// title is a short summary of the event
+	Title string `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	// text is a free-text description of the event
+	Text string `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	// metadata are free-form key value pairs, for example the commit of a deploy
+	Metadata      map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Annotation) Reset() {
+	*x = Annotation{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Annotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotation) ProtoMessage() {}
+
+func (x *Annotation) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotation.ProtoReflect.Descriptor instead.
+func (*Annotation) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Annotation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Annotation) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *Annotation) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Annotation) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Annotation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Annotation) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Annotation) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// CreateAnnotationRequest is the request to create an annotation
+type CreateAnnotationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// selector is the label selector of the series the annotation applies to
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// start is the time the event started
+	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the time the event ended, events without an end are at a point in time. Events must not last longer
+	// than 31 days.
+	End *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// title is a short summary of the event
+	Title string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	// text is a free-text description of the event
+	Text string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	// metadata are free-form key value pairs
+	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnnotationRequest) Reset() {
+	*x = CreateAnnotationRequest{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnnotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnnotationRequest) ProtoMessage() {}
+
+func (x *CreateAnnotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnnotationRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnnotationRequest) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAnnotationRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *CreateAnnotationRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *CreateAnnotationRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *CreateAnnotationRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAnnotationRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateAnnotationRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// CreateAnnotationResponse is the response to creating an annotation
+type CreateAnnotationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// annotation is the created annotation
+	Annotation    *Annotation `protobuf:"bytes,1,opt,name=annotation,proto3" json:"annotation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnnotationResponse) Reset() {
+	*x = CreateAnnotationResponse{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnnotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnnotationResponse) ProtoMessage() {}
+
+func (x *CreateAnnotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnnotationResponse.ProtoReflect.Descriptor instead.
+func (*CreateAnnotationResponse) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAnnotationResponse) GetAnnotation() *Annotation {
+	if x != nil {
+		return x.Annotation
+	}
+	return nil
+}
+
+// QueryAnnotationsRequest is the request for the annotations of a time window
+type QueryAnnotationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start is the beginning of the time window
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the end of the time window, the time window must not be longer than 92 days
+	End *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// query is an optional profile query or label selector, only annotations whose selector matches the labels of
+	// its equality matchers are returned
+	Query         string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAnnotationsRequest) Reset() {
+	*x = QueryAnnotationsRequest{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAnnotationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAnnotationsRequest) ProtoMessage() {}
+
+func (x *QueryAnnotationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAnnotationsRequest.ProtoReflect.Descriptor instead.
+func (*QueryAnnotationsRequest) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueryAnnotationsRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *QueryAnnotationsRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *QueryAnnotationsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// QueryAnnotationsResponse contains the annotations of a time window
+type QueryAnnotationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// annotations are the annotations sorted by start
+	Annotations   []*Annotation `protobuf:"bytes,1,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAnnotationsResponse) Reset() {
+	*x = QueryAnnotationsResponse{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAnnotationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAnnotationsResponse) ProtoMessage() {}
+
+func (x *QueryAnnotationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAnnotationsResponse.ProtoReflect.Descriptor instead.
+func (*QueryAnnotationsResponse) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryAnnotationsResponse) GetAnnotations() []*Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+// DeleteAnnotationRequest is the request to delete an annotation
+type DeleteAnnotationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the annotation
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAnnotationRequest) Reset() {
+	*x = DeleteAnnotationRequest{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAnnotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAnnotationRequest) ProtoMessage() {}
+
+func (x *DeleteAnnotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAnnotationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAnnotationRequest) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteAnnotationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteAnnotationResponse is the response to deleting an annotation
+type DeleteAnnotationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAnnotationResponse) Reset() {
+	*x = DeleteAnnotationResponse{}
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAnnotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAnnotationResponse) ProtoMessage() {}
+
+func (x *DeleteAnnotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_annotation_v1alpha1_annotation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAnnotationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAnnotationResponse) Descriptor() ([]byte, []int) {
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP(), []int{6}
+}
+
+var File_parca_annotation_v1alpha1_annotation_proto protoreflect.FileDescriptor
+
+const file_parca_annotation_v1alpha1_annotation_proto_rawDesc = "" +
+	"\n" +
+	"*parca/annotation/v1alpha1/annotation.proto\x12\x19parca.annotation.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd0\x02\n" +
+	"\n" +
+	"Annotation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bselector\x18\x02 \x01(\tR\bselector\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12O\n" +
+	"\bmetadata\x18\a \x03(\v23.parca.annotation.v1alpha1.Annotation.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xda\x02\n" +
+	"\x17CreateAnnotationRequest\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\\\n" +
+	"\bmetadata\x18\x06 \x03(\v2@.parca.annotation.v1alpha1.CreateAnnotationRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x18CreateAnnotationResponse\x12E\n" +
+	"\n" +
+	"annotation\x18\x01 \x01(\v2%.parca.annotation.v1alpha1.AnnotationR\n" +
+	"annotation\"\x8f\x01\n" +
+	"\x17QueryAnnotationsRequest\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\"c\n" +
+	"\x18QueryAnnotationsResponse\x12G\n" +
+	"\vannotations\x18\x01 \x03(\v2%.parca.annotation.v1alpha1.AnnotationR\vannotations\")\n" +
+	"\x17DeleteAnnotationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18DeleteAnnotationResponse2\xd7\x03\n" +
+	"\x11AnnotationService\x12\x94\x01\n" +
+	"\x10CreateAnnotation\x122.parca.annotation.v1alpha1.CreateAnnotationRequest\x1a3.parca.annotation.v1alpha1.CreateAnnotationResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/annotations\x12\x91\x01\n" +
+	"\x10QueryAnnotations\x122.parca.annotation.v1alpha1.QueryAnnotationsRequest\x1a3.parca.annotation.v1alpha1.QueryAnnotationsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/annotations\x12\x96\x01\n" +
+	"\x10DeleteAnnotation\x122.parca.annotation.v1alpha1.DeleteAnnotationRequest\x1a3.parca.annotation.v1alpha1.DeleteAnnotationResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/annotations/{id}B\x8c\x02\n" +
+	"\x1dcom.parca.annotation.v1alpha1B\x0fAnnotationProtoP\x01ZTgithub.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1;annotationv1alpha1\xa2\x02\x03PAX\xaa\x02\x19Parca.Annotation.V1alpha1\xca\x02\x19Parca\\Annotation\\V1alpha1\xe2\x02%Parca\\Annotation\\V1alpha1\\GPBMetadata\xea\x02\x1bParca::Annotation::V1alpha1b\x06proto3"
+
+var (
+	file_parca_annotation_v1alpha1_annotation_proto_rawDescOnce sync.Once
+	file_parca_annotation_v1alpha1_annotation_proto_rawDescData []byte
+)
+
+func file_parca_annotation_v1alpha1_annotation_proto_rawDescGZIP() []byte {
+	file_parca_annotation_v1alpha1_annotation_proto_rawDescOnce.Do(func() {
+		file_parca_annotation_v1alpha1_annotation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_parca_annotation_v1alpha1_annotation_proto_rawDesc), len(file_parca_annotation_v1alpha1_annotation_proto_rawDesc)))
+	})
+	return file_parca_annotation_v1alpha1_annotation_proto_rawDescData
+}
+
+var file_parca_annotation_v1alpha1_annotation_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_parca_annotation_v1alpha1_annotation_proto_goTypes = []any{
+	(*Annotation)(nil),               // 0: parca.annotation.v1alpha1.Annotation
+	(*CreateAnnotationRequest)(nil),  // 1: parca.annotation.v1alpha1.CreateAnnotationRequest
+	(*CreateAnnotationResponse)(nil), // 2: parca.annotation.v1alpha1.CreateAnnotationResponse
+	(*QueryAnnotationsRequest)(nil),  // 3: parca.annotation.v1alpha1.QueryAnnotationsRequest
+	(*QueryAnnotationsResponse)(nil), // 4: parca.annotation.v1alpha1.QueryAnnotationsResponse
+	(*DeleteAnnotationRequest)(nil),  // 5: parca.annotation.v1alpha1.DeleteAnnotationRequest
+	(*DeleteAnnotationResponse)(nil), // 6: parca.annotation.v1alpha1.DeleteAnnotationResponse
+	nil,                              // 7: parca.annotation.v1alpha1.Annotation.MetadataEntry
+	nil,                              // 8: parca.annotation.v1alpha1.CreateAnnotationRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+}
+var file_parca_annotation_v1alpha1_annotation_proto_depIdxs = []int32{
+	9,  // 0: parca.annotation.v1alpha1.Annotation.start:type_name -> google.protobuf.Timestamp
+	9,  // 1: parca.annotation.v1alpha1.Annotation.end:type_name -> google.protobuf.Timestamp
+	7,  // 2: parca.annotation.v1alpha1.Annotation.metadata:type_name -> parca.annotation.v1alpha1.Annotation.MetadataEntry
+	9,  // 3: parca.annotation.v1alpha1.CreateAnnotationRequest.start:type_name -> google.protobuf.Timestamp
+	9,  // 4: parca.annotation.v1alpha1.CreateAnnotationRequest.end:type_name -> google.protobuf.Timestamp
+	8,  // 5: parca.annotation.v1alpha1.CreateAnnotationRequest.metadata:type_name -> parca.annotation.v1alpha1.CreateAnnotationRequest.MetadataEntry
+	0,  // 6: parca.annotation.v1alpha1.CreateAnnotationResponse.annotation:type_name -> parca.annotation.v1alpha1.Annotation
+	9,  // 7: parca.annotation.v1alpha1.QueryAnnotationsRequest.start:type_name -> google.protobuf.Timestamp
+	9,  // 8: parca.annotation.v1alpha1.QueryAnnotationsRequest.end:type_name -> google.protobuf.Timestamp
+	0,  // 9: parca.annotation.v1alpha1.QueryAnnotationsResponse.annotations:type_name -> parca.annotation.v1alpha1.Annotation
+	1,  // 10: parca.annotation.v1alpha1.AnnotationService.CreateAnnotation:input_type -> parca.annotation.v1alpha1.CreateAnnotationRequest
+	3,  // 11: parca.annotation.v1alpha1.AnnotationService.QueryAnnotations:input_type -> parca.annotation.v1alpha1.QueryAnnotationsRequest
+	5,  // 12: parca.annotation.v1alpha1.AnnotationService.DeleteAnnotation:input_type -> parca.annotation.v1alpha1.DeleteAnnotationRequest
+	2,  // 13: parca.annotation.v1alpha1.AnnotationService.CreateAnnotation:output_type -> parca.annotation.v1alpha1.CreateAnnotationResponse
+	4,  // 14: parca.annotation.v1alpha1.AnnotationService.QueryAnnotations:output_type -> parca.annotation.v1alpha1.QueryAnnotationsResponse
+	6,  // 15: parca.annotation.v1alpha1.AnnotationService.DeleteAnnotation:output_type -> parca.annotation.v1alpha1.DeleteAnnotationResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_parca_annotation_v1alpha1_annotation_proto_init() }
+func file_parca_annotation_v1alpha1_annotation_proto_init() {
+	if File_parca_annotation_v1alpha1_annotation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parca_annotation_v1alpha1_annotation_proto_rawDesc), len(file_parca_annotation_v1alpha1_annotation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_parca_annotation_v1alpha1_annotation_proto_goTypes,
+		DependencyIndexes: file_parca_annotation_v1alpha1_annotation_proto_depIdxs,
+		MessageInfos:      file_parca_annotation_v1alpha1_annotation_proto_msgTypes,
+	}.Build()
+	File_parca_annotation_v1alpha1_annotation_proto = out.File
+	file_parca_annotation_v1alpha1_annotation_proto_goTypes = nil
+	file_parca_annotation_v1alpha1_annotation_proto_depIdxs = nil
+}
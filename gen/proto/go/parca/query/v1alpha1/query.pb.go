@@ -7,8 +7,9 @@
 package queryv1alpha1
 
 import (
-	v1alpha11 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
-	v1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	v1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1"
+	v1alpha12 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	v1alpha11 "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -72,7 +73,7 @@ func (x ProfileDiffSelection_Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ProfileDiffSelection_Mode.Descriptor instead.
 func (ProfileDiffSelection_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{10, 0}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{11, 0}
 }
 
 // Mode is the type of query request
@@ -85,6 +86,8 @@ const (
 	QueryRequest_MODE_DIFF QueryRequest_Mode = 1
 	// MODE_MERGE is a merge query
 	QueryRequest_MODE_MERGE QueryRequest_Mode = 2
+	// MODE_MULTI_METRIC merges two profile types into a single REPORT_TYPE_FLAMEGRAPH_ARROW
+	QueryRequest_MODE_MULTI_METRIC QueryRequest_Mode = 3
 )
 
 // Enum value maps for QueryRequest_Mode.
@@ -93,11 +96,13 @@ var (
 		0: "MODE_SINGLE_UNSPECIFIED",
 		1: "MODE_DIFF",
 		2: "MODE_MERGE",
+		3: "MODE_MULTI_METRIC",
 	}
 	QueryRequest_Mode_value = map[string]int32{
 		"MODE_SINGLE_UNSPECIFIED": 0,
 		"MODE_DIFF":               1,
 		"MODE_MERGE":              2,
+		"MODE_MULTI_METRIC":       3,
 	}
 )
 
@@ -125,7 +130,7 @@ func (x QueryRequest_Mode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use QueryRequest_Mode.Descriptor instead.
 func (QueryRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{11, 0}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{12, 0}
 }
 
 // ReportType is the type of report to return
@@ -154,21 +159,58 @@ const (
 	QueryRequest_REPORT_TYPE_PROFILE_METADATA QueryRequest_ReportType = 8
 	// REPORT_TYPE_FLAMECHART contains flamechart representation of the report
 	QueryRequest_REPORT_TYPE_FLAMECHART QueryRequest_ReportType = 9
+	// REPORT_TYPE_FOLDED contains the stacks in the folded format, one line per stack with the
+	// frames from root to leaf separated by semicolons followed by the value, e.g. `main;foo;bar 1234`.
+	// Diff reports contain the base and the compared value, e.g. `main;foo;bar 100 150`.
+	QueryRequest_REPORT_TYPE_FOLDED QueryRequest_ReportType = 10
+	// REPORT_TYPE_SPEEDSCOPE contains the report as a speedscope file format JSON document.
+	// The timestamp field is not added to the group by fields like for REPORT_TYPE_FLAMECHART: only if the query
+	// is grouped by timestamp an evented profile with the samples laid out by time is returned, otherwise a sampled
+	// profile with identical stacks merged.
+	QueryRequest_REPORT_TYPE_SPEEDSCOPE QueryRequest_ReportType = 11
+	// REPORT_TYPE_CHROME_TRACE contains the flame chart of the report as a Chrome trace event JSON
+	// document, which can be loaded into ui.perfetto.dev. Each label set the query is grouped by
+	// results in a separate track.
+	QueryRequest_REPORT_TYPE_CHROME_TRACE QueryRequest_ReportType = 12
+	// REPORT_TYPE_CALLGRAPH_DOT contains the callgraph rendered in the Graphviz DOT format, an image
+	// can be rendered from it with `dot -Tsvg`.
+	QueryRequest_REPORT_TYPE_CALLGRAPH_DOT QueryRequest_ReportType = 13
+	// REPORT_TYPE_CALLERS_CALLEES contains the direct callers and callees of the functions matching
+	// callers_callees_function, like pprof's peek command.
+	QueryRequest_REPORT_TYPE_CALLERS_CALLEES QueryRequest_ReportType = 15
+	// REPORT_TYPE_DISASSEMBLY contains the disassembly of the function referenced by
+	// disassembly_reference annotated with the samples of each instruction. It requires the
+	// executable to be uploaded.
+	QueryRequest_REPORT_TYPE_DISASSEMBLY QueryRequest_ReportType = 16
+	// REPORT_TYPE_LABEL_BREAKDOWN contains the cumulative value of the functions matching
+	// label_breakdown_function grouped by the values of each label, or only of label_breakdown_label.
+	QueryRequest_REPORT_TYPE_LABEL_BREAKDOWN QueryRequest_ReportType = 17
+	// REPORT_TYPE_HEATMAP contains the values of the top functions, or of the stack roots, per time bucket of the
+	// merge range. Only supported for merge queries.
+	QueryRequest_REPORT_TYPE_HEATMAP QueryRequest_ReportType = 18
 )
 
 // Enum value maps for QueryRequest_ReportType.
 var (
 	QueryRequest_ReportType_name = map[int32]string{
-		0: "REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED",
-		1: "REPORT_TYPE_PPROF",
-		2: "REPORT_TYPE_TOP",
-		3: "REPORT_TYPE_CALLGRAPH",
-		4: "REPORT_TYPE_FLAMEGRAPH_TABLE",
-		5: "REPORT_TYPE_FLAMEGRAPH_ARROW",
-		6: "REPORT_TYPE_SOURCE",
-		7: "REPORT_TYPE_TABLE_ARROW",
-		8: "REPORT_TYPE_PROFILE_METADATA",
-		9: "REPORT_TYPE_FLAMECHART",
+		0:  "REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED",
+		1:  "REPORT_TYPE_PPROF",
+		2:  "REPORT_TYPE_TOP",
+		3:  "REPORT_TYPE_CALLGRAPH",
+		4:  "REPORT_TYPE_FLAMEGRAPH_TABLE",
+		5:  "REPORT_TYPE_FLAMEGRAPH_ARROW",
+		6:  "REPORT_TYPE_SOURCE",
+		7:  "REPORT_TYPE_TABLE_ARROW",
+		8:  "REPORT_TYPE_PROFILE_METADATA",
+		9:  "REPORT_TYPE_FLAMECHART",
+		10: "REPORT_TYPE_FOLDED",
+		11: "REPORT_TYPE_SPEEDSCOPE",
+		12: "REPORT_TYPE_CHROME_TRACE",
+		13: "REPORT_TYPE_CALLGRAPH_DOT",
+		15: "REPORT_TYPE_CALLERS_CALLEES",
+		16: "REPORT_TYPE_DISASSEMBLY",
+		17: "REPORT_TYPE_LABEL_BREAKDOWN",
+		18: "REPORT_TYPE_HEATMAP",
 	}
 	QueryRequest_ReportType_value = map[string]int32{
 		"REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED": 0,
@@ -181,6 +223,14 @@ var (
 		"REPORT_TYPE_TABLE_ARROW":            7,
 		"REPORT_TYPE_PROFILE_METADATA":       8,
 		"REPORT_TYPE_FLAMECHART":             9,
+		"REPORT_TYPE_FOLDED":                 10,
+		"REPORT_TYPE_SPEEDSCOPE":             11,
+		"REPORT_TYPE_CHROME_TRACE":           12,
+		"REPORT_TYPE_CALLGRAPH_DOT":          13,
+		"REPORT_TYPE_CALLERS_CALLEES":        15,
+		"REPORT_TYPE_DISASSEMBLY":            16,
+		"REPORT_TYPE_LABEL_BREAKDOWN":        17,
+		"REPORT_TYPE_HEATMAP":                18,
 	}
 )
 
@@ -208,7 +258,7 @@ func (x QueryRequest_ReportType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use QueryRequest_ReportType.Descriptor instead.
 func (QueryRequest_ReportType) EnumDescriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{11, 1}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{12, 1}
 }
 
 // ProfileTypesRequest is the request to retrieve the list of available profile types.
@@ -417,7 +467,11 @@ type QueryRangeRequest struct {
 	// step is the duration of each sample returned.
 	Step *durationpb.Duration `protobuf:"bytes,5,opt,name=step,proto3" json:"step,omitempty"`
 	// sum_by is the set of labels to sum by
-	SumBy         []string `protobuf:"bytes,6,rep,name=sum_by,json=sumBy,proto3" json:"sum_by,omitempty"`
+	SumBy []string `protobuf:"bytes,6,rep,name=sum_by,json=sumBy,proto3" json:"sum_by,omitempty"`
+	// filter is a set of filters to apply, only samples of stacks matching the
+	// stack filters are included in the series values. Frame filters are not
+	// supported and are rejected.
+	Filter        []*Filter `protobuf:"bytes,7,rep,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -494,11 +548,21 @@ func (x *QueryRangeRequest) GetSumBy() []string {
 	return nil
 }
 
+func (x *QueryRangeRequest) GetFilter() []*Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 // QueryRangeResponse is the set of matching profile values
 type QueryRangeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// series is the set of metrics series that satisfy the query range request
-	Series        []*MetricsSeries `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	Series []*MetricsSeries `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	// annotations are the annotations overlapping the time window whose selector matches the labels of the equality
+	// matchers of the query
+	Annotations   []*v1alpha1.Annotation `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -540,11 +604,18 @@ func (x *QueryRangeResponse) GetSeries() []*MetricsSeries {
 	return nil
 }
 
+func (x *QueryRangeResponse) GetAnnotations() []*v1alpha1.Annotation {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 // MetricsSeries is a set of labels and corresponding sample values
 type MetricsSeries struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// labelset is the set of key value pairs
-	Labelset *v1alpha1.LabelSet `protobuf:"bytes,1,opt,name=labelset,proto3" json:"labelset,omitempty"`
+	Labelset *v1alpha11.LabelSet `protobuf:"bytes,1,opt,name=labelset,proto3" json:"labelset,omitempty"`
 	// samples is the set of top-level cumulative values of the corresponding profiles
 	Samples []*MetricsSample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples,omitempty"`
 	// period_type is the value type of profile period
@@ -585,7 +656,7 @@ func (*MetricsSeries) Descriptor() ([]byte, []int) {
 	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *MetricsSeries) GetLabelset() *v1alpha1.LabelSet {
+func (x *MetricsSeries) GetLabelset() *v1alpha11.LabelSet {
 	if x != nil {
 		return x.Labelset
 	}
@@ -698,7 +769,8 @@ func (x *MetricsSample) GetCount() int32 {
 // MergeProfile contains parameters for a merge request
 type MergeProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// query is the query string to match profiles for merge
+	// query is the query string to match profiles for merge. Derived profile types configured on the server are queried
+	// as derived:<name>{...} and only support the arrow flame graph and table reports.
 	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	// start is the beginning of the evaluation time window
 	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
@@ -759,6 +831,80 @@ func (x *MergeProfile) GetEnd() *timestamppb.Timestamp {
 	return nil
 }
 
+// MultiMetricProfile contains parameters for merging two profile types, for example on-CPU and off-CPU time,
+// into a single flame graph. Both profile types have to have the same unit.
+type MultiMetricProfile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query is the query string of the primary profile type
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// secondary_query is the query string of the secondary profile type
+	SecondaryQuery string `protobuf:"bytes,2,opt,name=secondary_query,json=secondaryQuery,proto3" json:"secondary_query,omitempty"`
+	// start is the beginning of the evaluation time window
+	Start *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the end of the evaluation time window
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiMetricProfile) Reset() {
+	*x = MultiMetricProfile{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiMetricProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiMetricProfile) ProtoMessage() {}
+
+func (x *MultiMetricProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiMetricProfile.ProtoReflect.Descriptor instead.
+func (*MultiMetricProfile) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MultiMetricProfile) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *MultiMetricProfile) GetSecondaryQuery() string {
+	if x != nil {
+		return x.SecondaryQuery
+	}
+	return ""
+}
+
+func (x *MultiMetricProfile) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *MultiMetricProfile) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
 // SingleProfile contains parameters for a single profile query request
 type SingleProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -772,7 +918,7 @@ type SingleProfile struct {
 
 func (x *SingleProfile) Reset() {
 	*x = SingleProfile{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[8]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +930,7 @@ func (x *SingleProfile) String() string {
 func (*SingleProfile) ProtoMessage() {}
 
 func (x *SingleProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[8]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +943,7 @@ func (x *SingleProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SingleProfile.ProtoReflect.Descriptor instead.
 func (*SingleProfile) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{8}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SingleProfile) GetTime() *timestamppb.Timestamp {
@@ -822,14 +968,18 @@ type DiffProfile struct {
 	// b is the second profile to diff
 	B *ProfileDiffSelection `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
 	// absolute diffing, by default comparisons are relative
-	Absolute      *bool `protobuf:"varint,3,opt,name=absolute,proto3,oneof" json:"absolute,omitempty"`
+	Absolute *bool `protobuf:"varint,3,opt,name=absolute,proto3,oneof" json:"absolute,omitempty"`
+	// significance tests per function whether its values in the individual profiles of a and b differ significantly,
+	// using the Mann-Whitney U test. Both selections have to be merges. The two-sided p-values are added as p_value
+	// column to the table and arrow flame graph reports.
+	Significance  bool `protobuf:"varint,4,opt,name=significance,proto3" json:"significance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DiffProfile) Reset() {
 	*x = DiffProfile{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[9]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +991,7 @@ func (x *DiffProfile) String() string {
 func (*DiffProfile) ProtoMessage() {}
 
 func (x *DiffProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[9]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +1004,7 @@ func (x *DiffProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiffProfile.ProtoReflect.Descriptor instead.
 func (*DiffProfile) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{9}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DiffProfile) GetA() *ProfileDiffSelection {
@@ -878,6 +1028,13 @@ func (x *DiffProfile) GetAbsolute() bool {
 	return false
 }
 
+func (x *DiffProfile) GetSignificance() bool {
+	if x != nil {
+		return x.Significance
+	}
+	return false
+}
+
 // ProfileDiffSelection contains the parameters of a diff selection
 type ProfileDiffSelection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -896,7 +1053,7 @@ type ProfileDiffSelection struct {
 
 func (x *ProfileDiffSelection) Reset() {
 	*x = ProfileDiffSelection{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[10]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -908,7 +1065,7 @@ func (x *ProfileDiffSelection) String() string {
 func (*ProfileDiffSelection) ProtoMessage() {}
 
 func (x *ProfileDiffSelection) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[10]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,7 +1078,7 @@ func (x *ProfileDiffSelection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileDiffSelection.ProtoReflect.Descriptor instead.
 func (*ProfileDiffSelection) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{10}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ProfileDiffSelection) GetMode() ProfileDiffSelection_Mode {
@@ -986,6 +1143,7 @@ type QueryRequest struct {
 	//	*QueryRequest_Diff
 	//	*QueryRequest_Merge
 	//	*QueryRequest_Single
+	//	*QueryRequest_MultiMetric
 	Options isQueryRequest_Options `protobuf_oneof:"options"`
 	// report_type is the type of report to return
 	ReportType QueryRequest_ReportType `protobuf:"varint,5,opt,name=report_type,json=reportType,proto3,enum=parca.query.v1alpha1.QueryRequest_ReportType" json:"report_type,omitempty"`
@@ -995,7 +1153,8 @@ type QueryRequest struct {
 	FilterQuery *string `protobuf:"bytes,6,opt,name=filter_query,json=filterQuery,proto3,oneof" json:"filter_query,omitempty"`
 	// node_trim_threshold is the threshold % where the nodes with Value less than this will be removed from the report
 	NodeTrimThreshold *float32 `protobuf:"fixed32,7,opt,name=node_trim_threshold,json=nodeTrimThreshold,proto3,oneof" json:"node_trim_threshold,omitempty"`
-	// group_by indicates the fields to group by
+	// group_by indicates the fields to group by. Grouping a REPORT_TYPE_SPEEDSCOPE report by timestamp returns an
+	// evented instead of a sampled profile.
 	GroupBy *GroupBy `protobuf:"bytes,8,opt,name=group_by,json=groupBy,proto3,oneof" json:"group_by,omitempty"`
 	// source information about the source requested, required if source report is requested
 	SourceReference *SourceReference `protobuf:"bytes,9,opt,name=source_reference,json=sourceReference,proto3,oneof" json:"source_reference,omitempty"`
@@ -1009,13 +1168,52 @@ type QueryRequest struct {
 	Filter []*Filter `protobuf:"bytes,12,rep,name=filter,proto3" json:"filter,omitempty"`
 	// sandwich_by_function is a function name to use for sandwich view functionality
 	SandwichByFunction *string `protobuf:"bytes,13,opt,name=sandwich_by_function,json=sandwichByFunction,proto3,oneof" json:"sandwich_by_function,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// node_count is the maximum number of nodes in callgraph reports, the nodes with the highest
+	// flat values are kept. If unset, nodes with a small cumulative value are pruned instead.
+	NodeCount *uint32 `protobuf:"varint,14,opt,name=node_count,json=nodeCount,proto3,oneof" json:"node_count,omitempty"`
+	// focus is a regular expression, only samples with a frame matching it are kept. Frames are
+	// matched by their function name, filename and mapping file, just like pprof's focus option.
+	// Unlike the regular expressions of filter, the focus, ignore, hide, show, show_from and
+	// prune_from regular expressions are case-sensitive, as they are in pprof.
+	Focus *string `protobuf:"bytes,15,opt,name=focus,proto3,oneof" json:"focus,omitempty"`
+	// ignore is a regular expression, samples with a frame matching it are dropped.
+	Ignore *string `protobuf:"bytes,16,opt,name=ignore,proto3,oneof" json:"ignore,omitempty"`
+	// hide is a regular expression, frames matching it are removed from the stacks while their
+	// samples are kept.
+	Hide *string `protobuf:"bytes,17,opt,name=hide,proto3,oneof" json:"hide,omitempty"`
+	// show is a regular expression, only frames matching it are kept in the stacks while their
+	// samples are kept.
+	Show *string `protobuf:"bytes,18,opt,name=show,proto3,oneof" json:"show,omitempty"`
+	// show_from is a regular expression matched against function names, all frames above the
+	// highest matching frame are removed. Samples without a matching frame are dropped.
+	ShowFrom *string `protobuf:"bytes,19,opt,name=show_from,json=showFrom,proto3,oneof" json:"show_from,omitempty"`
+	// prune_from is a regular expression matched against function names, all frames below the
+	// highest matching frame are removed.
+	PruneFrom *string `protobuf:"bytes,20,opt,name=prune_from,json=pruneFrom,proto3,oneof" json:"prune_from,omitempty"`
+	// callers_callees_function is a regular expression matched against function names, the callers
+	// and callees of the matching functions are returned by the REPORT_TYPE_CALLERS_CALLEES report.
+	CallersCalleesFunction *string `protobuf:"bytes,21,opt,name=callers_callees_function,json=callersCalleesFunction,proto3,oneof" json:"callers_callees_function,omitempty"`
+	// disassembly_reference references the function to disassemble for REPORT_TYPE_DISASSEMBLY
+	DisassemblyReference *DisassemblyReference `protobuf:"bytes,22,opt,name=disassembly_reference,json=disassemblyReference,proto3,oneof" json:"disassembly_reference,omitempty"`
+	// label_breakdown_function is a regular expression matching the names of the functions to break down by
+	// REPORT_TYPE_LABEL_BREAKDOWN. All samples are broken down if it is empty.
+	LabelBreakdownFunction *string `protobuf:"bytes,23,opt,name=label_breakdown_function,json=labelBreakdownFunction,proto3,oneof" json:"label_breakdown_function,omitempty"`
+	// label_breakdown_label limits REPORT_TYPE_LABEL_BREAKDOWN to a single label, all labels are broken down if it is
+	// empty.
+	LabelBreakdownLabel *string `protobuf:"bytes,24,opt,name=label_breakdown_label,json=labelBreakdownLabel,proto3,oneof" json:"label_breakdown_label,omitempty"`
+	// heatmap_bucket_count is the number of time buckets of REPORT_TYPE_HEATMAP, defaults to 60.
+	HeatmapBucketCount *uint32 `protobuf:"varint,25,opt,name=heatmap_bucket_count,json=heatmapBucketCount,proto3,oneof" json:"heatmap_bucket_count,omitempty"`
+	// heatmap_function_count is the number of functions, or stack roots, of REPORT_TYPE_HEATMAP, defaults to 20.
+	HeatmapFunctionCount *uint32 `protobuf:"varint,26,opt,name=heatmap_function_count,json=heatmapFunctionCount,proto3,oneof" json:"heatmap_function_count,omitempty"`
+	// heatmap_by_root groups REPORT_TYPE_HEATMAP by the roots of the stacks instead of by all of their functions.
+	HeatmapByRoot *bool `protobuf:"varint,27,opt,name=heatmap_by_root,json=heatmapByRoot,proto3,oneof" json:"heatmap_by_root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryRequest) Reset() {
 	*x = QueryRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[11]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1027,7 +1225,7 @@ func (x *QueryRequest) String() string {
 func (*QueryRequest) ProtoMessage() {}
 
 func (x *QueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[11]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1040,7 +1238,7 @@ func (x *QueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{11}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *QueryRequest) GetMode() QueryRequest_Mode {
@@ -1084,6 +1282,15 @@ func (x *QueryRequest) GetSingle() *SingleProfile {
 	return nil
 }
 
+func (x *QueryRequest) GetMultiMetric() *MultiMetricProfile {
+	if x != nil {
+		if x, ok := x.Options.(*QueryRequest_MultiMetric); ok {
+			return x.MultiMetric
+		}
+	}
+	return nil
+}
+
 func (x *QueryRequest) GetReportType() QueryRequest_ReportType {
 	if x != nil {
 		return x.ReportType
@@ -1149,6 +1356,104 @@ func (x *QueryRequest) GetSandwichByFunction() string {
 	return ""
 }
 
+func (x *QueryRequest) GetNodeCount() uint32 {
+	if x != nil && x.NodeCount != nil {
+		return *x.NodeCount
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetFocus() string {
+	if x != nil && x.Focus != nil {
+		return *x.Focus
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetIgnore() string {
+	if x != nil && x.Ignore != nil {
+		return *x.Ignore
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetHide() string {
+	if x != nil && x.Hide != nil {
+		return *x.Hide
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetShow() string {
+	if x != nil && x.Show != nil {
+		return *x.Show
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetShowFrom() string {
+	if x != nil && x.ShowFrom != nil {
+		return *x.ShowFrom
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetPruneFrom() string {
+	if x != nil && x.PruneFrom != nil {
+		return *x.PruneFrom
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetCallersCalleesFunction() string {
+	if x != nil && x.CallersCalleesFunction != nil {
+		return *x.CallersCalleesFunction
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetDisassemblyReference() *DisassemblyReference {
+	if x != nil {
+		return x.DisassemblyReference
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetLabelBreakdownFunction() string {
+	if x != nil && x.LabelBreakdownFunction != nil {
+		return *x.LabelBreakdownFunction
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetLabelBreakdownLabel() string {
+	if x != nil && x.LabelBreakdownLabel != nil {
+		return *x.LabelBreakdownLabel
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetHeatmapBucketCount() uint32 {
+	if x != nil && x.HeatmapBucketCount != nil {
+		return *x.HeatmapBucketCount
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetHeatmapFunctionCount() uint32 {
+	if x != nil && x.HeatmapFunctionCount != nil {
+		return *x.HeatmapFunctionCount
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetHeatmapByRoot() bool {
+	if x != nil && x.HeatmapByRoot != nil {
+		return *x.HeatmapByRoot
+	}
+	return false
+}
+
 type isQueryRequest_Options interface {
 	isQueryRequest_Options()
 }
@@ -1168,12 +1473,75 @@ type QueryRequest_Single struct {
 	Single *SingleProfile `protobuf:"bytes,4,opt,name=single,proto3,oneof"`
 }
 
+type QueryRequest_MultiMetric struct {
+	// multi_metric contains the multi metric query options
+	MultiMetric *MultiMetricProfile `protobuf:"bytes,28,opt,name=multi_metric,json=multiMetric,proto3,oneof"`
+}
+
 func (*QueryRequest_Diff) isQueryRequest_Options() {}
 
 func (*QueryRequest_Merge) isQueryRequest_Options() {}
 
 func (*QueryRequest_Single) isQueryRequest_Options() {}
 
+func (*QueryRequest_MultiMetric) isQueryRequest_Options() {}
+
+// DisassemblyReference references the function to disassemble.
+type DisassemblyReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// build_id is the build ID of the executable.
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	// address is an address of the executable as it appears in the profile, the function
+	// containing it is disassembled.
+	Address       uint64 `protobuf:"varint,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisassemblyReference) Reset() {
+	*x = DisassemblyReference{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisassemblyReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisassemblyReference) ProtoMessage() {}
+
+func (x *DisassemblyReference) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisassemblyReference.ProtoReflect.Descriptor instead.
+func (*DisassemblyReference) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DisassemblyReference) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *DisassemblyReference) GetAddress() uint64 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
 // FilterCriteria defines the various criteria that can be used to filter stack frames or stacks
 type FilterCriteria struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1195,7 +1563,7 @@ type FilterCriteria struct {
 
 func (x *FilterCriteria) Reset() {
 	*x = FilterCriteria{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[12]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1207,7 +1575,7 @@ func (x *FilterCriteria) String() string {
 func (*FilterCriteria) ProtoMessage() {}
 
 func (x *FilterCriteria) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[12]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1588,7 @@ func (x *FilterCriteria) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilterCriteria.ProtoReflect.Descriptor instead.
 func (*FilterCriteria) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{12}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *FilterCriteria) GetFunctionName() *StringCondition {
@@ -1278,6 +1646,8 @@ type StringCondition struct {
 	//	*StringCondition_NotContains
 	//	*StringCondition_StartsWith
 	//	*StringCondition_NotStartsWith
+	//	*StringCondition_MatchesRegex
+	//	*StringCondition_NotMatchesRegex
 	Condition     isStringCondition_Condition `protobuf_oneof:"condition"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1285,7 +1655,7 @@ type StringCondition struct {
 
 func (x *StringCondition) Reset() {
 	*x = StringCondition{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[13]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1297,7 +1667,7 @@ func (x *StringCondition) String() string {
 func (*StringCondition) ProtoMessage() {}
 
 func (x *StringCondition) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[13]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1310,7 +1680,7 @@ func (x *StringCondition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StringCondition.ProtoReflect.Descriptor instead.
 func (*StringCondition) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{13}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *StringCondition) GetCondition() isStringCondition_Condition {
@@ -1374,6 +1744,24 @@ func (x *StringCondition) GetNotStartsWith() string {
 	return ""
 }
 
+func (x *StringCondition) GetMatchesRegex() string {
+	if x != nil {
+		if x, ok := x.Condition.(*StringCondition_MatchesRegex); ok {
+			return x.MatchesRegex
+		}
+	}
+	return ""
+}
+
+func (x *StringCondition) GetNotMatchesRegex() string {
+	if x != nil {
+		if x, ok := x.Condition.(*StringCondition_NotMatchesRegex); ok {
+			return x.NotMatchesRegex
+		}
+	}
+	return ""
+}
+
 type isStringCondition_Condition interface {
 	isStringCondition_Condition()
 }
@@ -1408,6 +1796,18 @@ type StringCondition_NotStartsWith struct {
 	NotStartsWith string `protobuf:"bytes,6,opt,name=not_starts_with,json=notStartsWith,proto3,oneof"`
 }
 
+type StringCondition_MatchesRegex struct {
+	// matches_regex matches strings that match the specified RE2 regular expression, case-insensitively like the
+	// other conditions
+	MatchesRegex string `protobuf:"bytes,7,opt,name=matches_regex,json=matchesRegex,proto3,oneof"`
+}
+
+type StringCondition_NotMatchesRegex struct {
+	// not_matches_regex matches strings that do not match the specified RE2 regular expression, case-insensitively
+	// like the other conditions
+	NotMatchesRegex string `protobuf:"bytes,8,opt,name=not_matches_regex,json=notMatchesRegex,proto3,oneof"`
+}
+
 func (*StringCondition_Equal) isStringCondition_Condition() {}
 
 func (*StringCondition_NotEqual) isStringCondition_Condition() {}
@@ -1420,6 +1820,10 @@ func (*StringCondition_StartsWith) isStringCondition_Condition() {}
 
 func (*StringCondition_NotStartsWith) isStringCondition_Condition() {}
 
+func (*StringCondition_MatchesRegex) isStringCondition_Condition() {}
+
+func (*StringCondition_NotMatchesRegex) isStringCondition_Condition() {}
+
 // NumberCondition defines numeric filtering conditions
 type NumberCondition struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1429,6 +1833,9 @@ type NumberCondition struct {
 	//
 	//	*NumberCondition_Equal
 	//	*NumberCondition_NotEqual
+	//	*NumberCondition_GreaterThan
+	//	*NumberCondition_LessThan
+	//	*NumberCondition_Range
 	Condition     isNumberCondition_Condition `protobuf_oneof:"condition"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1436,7 +1843,7 @@ type NumberCondition struct {
 
 func (x *NumberCondition) Reset() {
 	*x = NumberCondition{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[14]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1448,7 +1855,7 @@ func (x *NumberCondition) String() string {
 func (*NumberCondition) ProtoMessage() {}
 
 func (x *NumberCondition) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[14]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1461,7 +1868,7 @@ func (x *NumberCondition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NumberCondition.ProtoReflect.Descriptor instead.
 func (*NumberCondition) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{14}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NumberCondition) GetCondition() isNumberCondition_Condition {
@@ -1489,24 +1896,127 @@ func (x *NumberCondition) GetNotEqual() uint64 {
 	return 0
 }
 
-type isNumberCondition_Condition interface {
-	isNumberCondition_Condition()
+func (x *NumberCondition) GetGreaterThan() uint64 {
+	if x != nil {
+		if x, ok := x.Condition.(*NumberCondition_GreaterThan); ok {
+			return x.GreaterThan
+		}
+	}
+	return 0
 }
 
-type NumberCondition_Equal struct {
-	// equal matches numbers that are exactly equal
-	Equal uint64 `protobuf:"varint,1,opt,name=equal,proto3,oneof"`
+func (x *NumberCondition) GetLessThan() uint64 {
+	if x != nil {
+		if x, ok := x.Condition.(*NumberCondition_LessThan); ok {
+			return x.LessThan
+		}
+	}
+	return 0
 }
 
-type NumberCondition_NotEqual struct {
+func (x *NumberCondition) GetRange() *NumberRange {
+	if x != nil {
+		if x, ok := x.Condition.(*NumberCondition_Range); ok {
+			return x.Range
+		}
+	}
+	return nil
+}
+
+type isNumberCondition_Condition interface {
+	isNumberCondition_Condition()
+}
+
+type NumberCondition_Equal struct {
+	// equal matches numbers that are exactly equal
+	Equal uint64 `protobuf:"varint,1,opt,name=equal,proto3,oneof"`
+}
+
+type NumberCondition_NotEqual struct {
 	// not_equal matches numbers that are not equal
 	NotEqual uint64 `protobuf:"varint,2,opt,name=not_equal,json=notEqual,proto3,oneof"`
 }
 
+type NumberCondition_GreaterThan struct {
+	// greater_than matches numbers that are strictly greater than the specified value
+	GreaterThan uint64 `protobuf:"varint,3,opt,name=greater_than,json=greaterThan,proto3,oneof"`
+}
+
+type NumberCondition_LessThan struct {
+	// less_than matches numbers that are strictly less than the specified value
+	LessThan uint64 `protobuf:"varint,4,opt,name=less_than,json=lessThan,proto3,oneof"`
+}
+
+type NumberCondition_Range struct {
+	// range matches numbers that are within the specified inclusive range
+	Range *NumberRange `protobuf:"bytes,5,opt,name=range,proto3,oneof"`
+}
+
 func (*NumberCondition_Equal) isNumberCondition_Condition() {}
 
 func (*NumberCondition_NotEqual) isNumberCondition_Condition() {}
 
+func (*NumberCondition_GreaterThan) isNumberCondition_Condition() {}
+
+func (*NumberCondition_LessThan) isNumberCondition_Condition() {}
+
+func (*NumberCondition_Range) isNumberCondition_Condition() {}
+
+// NumberRange is an inclusive range of numbers
+type NumberRange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start is the inclusive lower bound of the range
+	Start uint64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the inclusive upper bound of the range
+	End           uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NumberRange) Reset() {
+	*x = NumberRange{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumberRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumberRange) ProtoMessage() {}
+
+func (x *NumberRange) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumberRange.ProtoReflect.Descriptor instead.
+func (*NumberRange) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *NumberRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *NumberRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
 // Filter to apply to the query request
 type Filter struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1523,7 +2033,7 @@ type Filter struct {
 
 func (x *Filter) Reset() {
 	*x = Filter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[15]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1535,7 +2045,7 @@ func (x *Filter) String() string {
 func (*Filter) ProtoMessage() {}
 
 func (x *Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[15]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1548,7 +2058,7 @@ func (x *Filter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Filter.ProtoReflect.Descriptor instead.
 func (*Filter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{15}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Filter) GetFilter() isFilter_Filter {
@@ -1610,7 +2120,7 @@ type StackFilter struct {
 
 func (x *StackFilter) Reset() {
 	*x = StackFilter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[16]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1622,7 +2132,7 @@ func (x *StackFilter) String() string {
 func (*StackFilter) ProtoMessage() {}
 
 func (x *StackFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[16]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1635,7 +2145,7 @@ func (x *StackFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackFilter.ProtoReflect.Descriptor instead.
 func (*StackFilter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{16}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *StackFilter) GetFilter() isStackFilter_Filter {
@@ -1697,7 +2207,7 @@ type FunctionNameStackFilter struct {
 
 func (x *FunctionNameStackFilter) Reset() {
 	*x = FunctionNameStackFilter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[17]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1709,7 +2219,7 @@ func (x *FunctionNameStackFilter) String() string {
 func (*FunctionNameStackFilter) ProtoMessage() {}
 
 func (x *FunctionNameStackFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[17]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1722,7 +2232,7 @@ func (x *FunctionNameStackFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionNameStackFilter.ProtoReflect.Descriptor instead.
 func (*FunctionNameStackFilter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{17}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *FunctionNameStackFilter) GetFunctionToFilter() string {
@@ -1755,7 +2265,7 @@ type FrameFilter struct {
 
 func (x *FrameFilter) Reset() {
 	*x = FrameFilter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[18]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1767,7 +2277,7 @@ func (x *FrameFilter) String() string {
 func (*FrameFilter) ProtoMessage() {}
 
 func (x *FrameFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[18]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1780,7 +2290,7 @@ func (x *FrameFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FrameFilter.ProtoReflect.Descriptor instead.
 func (*FrameFilter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{18}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *FrameFilter) GetFilter() isFrameFilter_Filter {
@@ -1840,7 +2350,7 @@ type BinaryFrameFilter struct {
 
 func (x *BinaryFrameFilter) Reset() {
 	*x = BinaryFrameFilter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[19]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1852,7 +2362,7 @@ func (x *BinaryFrameFilter) String() string {
 func (*BinaryFrameFilter) ProtoMessage() {}
 
 func (x *BinaryFrameFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[19]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1865,7 +2375,7 @@ func (x *BinaryFrameFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BinaryFrameFilter.ProtoReflect.Descriptor instead.
 func (*BinaryFrameFilter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{19}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *BinaryFrameFilter) GetIncludeBinaries() []string {
@@ -1890,7 +2400,7 @@ type RuntimeFilter struct {
 
 func (x *RuntimeFilter) Reset() {
 	*x = RuntimeFilter{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[20]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1902,7 +2412,7 @@ func (x *RuntimeFilter) String() string {
 func (*RuntimeFilter) ProtoMessage() {}
 
 func (x *RuntimeFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[20]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +2425,7 @@ func (x *RuntimeFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RuntimeFilter.ProtoReflect.Descriptor instead.
 func (*RuntimeFilter) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{20}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *RuntimeFilter) GetShowPython() bool {
@@ -1954,7 +2464,7 @@ type SourceReference struct {
 
 func (x *SourceReference) Reset() {
 	*x = SourceReference{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[21]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1966,7 +2476,7 @@ func (x *SourceReference) String() string {
 func (*SourceReference) ProtoMessage() {}
 
 func (x *SourceReference) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[21]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1979,7 +2489,7 @@ func (x *SourceReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SourceReference.ProtoReflect.Descriptor instead.
 func (*SourceReference) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{21}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *SourceReference) GetBuildId() string {
@@ -2015,7 +2525,7 @@ type GroupBy struct {
 
 func (x *GroupBy) Reset() {
 	*x = GroupBy{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[22]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2027,7 +2537,7 @@ func (x *GroupBy) String() string {
 func (*GroupBy) ProtoMessage() {}
 
 func (x *GroupBy) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[22]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2040,7 +2550,7 @@ func (x *GroupBy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupBy.ProtoReflect.Descriptor instead.
 func (*GroupBy) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{22}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GroupBy) GetFields() []string {
@@ -2070,7 +2580,7 @@ type Top struct {
 
 func (x *Top) Reset() {
 	*x = Top{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[23]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2082,7 +2592,7 @@ func (x *Top) String() string {
 func (*Top) ProtoMessage() {}
 
 func (x *Top) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[23]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2095,7 +2605,7 @@ func (x *Top) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Top.ProtoReflect.Descriptor instead.
 func (*Top) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{23}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Top) GetList() []*TopNode {
@@ -2144,7 +2654,7 @@ type TopNode struct {
 
 func (x *TopNode) Reset() {
 	*x = TopNode{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[24]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2156,7 +2666,7 @@ func (x *TopNode) String() string {
 func (*TopNode) ProtoMessage() {}
 
 func (x *TopNode) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[24]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2169,7 +2679,7 @@ func (x *TopNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNode.ProtoReflect.Descriptor instead.
 func (*TopNode) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{24}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TopNode) GetMeta() *TopNodeMeta {
@@ -2204,20 +2714,20 @@ func (x *TopNode) GetDiff() int64 {
 type TopNodeMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// location is the location for the code
-	Location *v1alpha11.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Location *v1alpha12.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
 	// mapping is the mapping into code
-	Mapping *v1alpha11.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	Mapping *v1alpha12.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
 	// function is the function information
-	Function *v1alpha11.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	Function *v1alpha12.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
 	// line is the line location
-	Line          *v1alpha11.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	Line          *v1alpha12.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TopNodeMeta) Reset() {
 	*x = TopNodeMeta{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[25]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2229,7 +2739,7 @@ func (x *TopNodeMeta) String() string {
 func (*TopNodeMeta) ProtoMessage() {}
 
 func (x *TopNodeMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[25]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2242,31 +2752,31 @@ func (x *TopNodeMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNodeMeta.ProtoReflect.Descriptor instead.
 func (*TopNodeMeta) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{25}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *TopNodeMeta) GetLocation() *v1alpha11.Location {
+func (x *TopNodeMeta) GetLocation() *v1alpha12.Location {
 	if x != nil {
 		return x.Location
 	}
 	return nil
 }
 
-func (x *TopNodeMeta) GetMapping() *v1alpha11.Mapping {
+func (x *TopNodeMeta) GetMapping() *v1alpha12.Mapping {
 	if x != nil {
 		return x.Mapping
 	}
 	return nil
 }
 
-func (x *TopNodeMeta) GetFunction() *v1alpha11.Function {
+func (x *TopNodeMeta) GetFunction() *v1alpha12.Function {
 	if x != nil {
 		return x.Function
 	}
 	return nil
 }
 
-func (x *TopNodeMeta) GetLine() *v1alpha11.Line {
+func (x *TopNodeMeta) GetLine() *v1alpha12.Line {
 	if x != nil {
 		return x.Line
 	}
@@ -2290,11 +2800,11 @@ type Flamegraph struct {
 	// string_table holds all deduplicated strings used in the meta data.
 	StringTable []string `protobuf:"bytes,5,rep,name=string_table,json=stringTable,proto3" json:"string_table,omitempty"`
 	// locations deduplicated by their ID to be referenced by nodes.
-	Locations []*v1alpha11.Location `protobuf:"bytes,6,rep,name=locations,proto3" json:"locations,omitempty"`
+	Locations []*v1alpha12.Location `protobuf:"bytes,6,rep,name=locations,proto3" json:"locations,omitempty"`
 	// mapping deduplicated by their ID to be referenced by nodes.
-	Mapping []*v1alpha11.Mapping `protobuf:"bytes,7,rep,name=mapping,proto3" json:"mapping,omitempty"`
+	Mapping []*v1alpha12.Mapping `protobuf:"bytes,7,rep,name=mapping,proto3" json:"mapping,omitempty"`
 	// function deduplicated by their ID to be referenced by nodes.
-	Function []*v1alpha11.Function `protobuf:"bytes,8,rep,name=function,proto3" json:"function,omitempty"`
+	Function []*v1alpha12.Function `protobuf:"bytes,8,rep,name=function,proto3" json:"function,omitempty"`
 	// untrimmed_total is the total weight of the flame graph before trimming.
 	// Use trimmed instead.
 	//
@@ -2308,7 +2818,7 @@ type Flamegraph struct {
 
 func (x *Flamegraph) Reset() {
 	*x = Flamegraph{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[26]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2320,7 +2830,7 @@ func (x *Flamegraph) String() string {
 func (*Flamegraph) ProtoMessage() {}
 
 func (x *Flamegraph) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[26]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2333,7 +2843,7 @@ func (x *Flamegraph) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Flamegraph.ProtoReflect.Descriptor instead.
 func (*Flamegraph) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{26}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Flamegraph) GetRoot() *FlamegraphRootNode {
@@ -2372,21 +2882,21 @@ func (x *Flamegraph) GetStringTable() []string {
 	return nil
 }
 
-func (x *Flamegraph) GetLocations() []*v1alpha11.Location {
+func (x *Flamegraph) GetLocations() []*v1alpha12.Location {
 	if x != nil {
 		return x.Locations
 	}
 	return nil
 }
 
-func (x *Flamegraph) GetMapping() []*v1alpha11.Mapping {
+func (x *Flamegraph) GetMapping() []*v1alpha12.Mapping {
 	if x != nil {
 		return x.Mapping
 	}
 	return nil
 }
 
-func (x *Flamegraph) GetFunction() []*v1alpha11.Function {
+func (x *Flamegraph) GetFunction() []*v1alpha12.Function {
 	if x != nil {
 		return x.Function
 	}
@@ -2411,7 +2921,11 @@ func (x *Flamegraph) GetTrimmed() int64 {
 // Flamegraph is the flame graph report type
 type FlamegraphArrow struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// record is the arrow record containing the actual flamegraph data
+	// record is the arrow record containing the actual flamegraph data. For MODE_MULTI_METRIC the cumulative and flat
+	// values are the ones of the primary profile type, the cumulative_secondary and flat_secondary columns hold the
+	// ones of the secondary profile type. For derived profile types the cumulative and flat values are the ones of the
+	// denominator, the cumulative_numerator and flat_numerator columns hold the ones of the numerator, and ratio and
+	// flat_ratio columns are added.
 	Record []byte `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 	// unit is the unit represented by the flame graph
 	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
@@ -2425,7 +2939,7 @@ type FlamegraphArrow struct {
 
 func (x *FlamegraphArrow) Reset() {
 	*x = FlamegraphArrow{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[27]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2437,7 +2951,7 @@ func (x *FlamegraphArrow) String() string {
 func (*FlamegraphArrow) ProtoMessage() {}
 
 func (x *FlamegraphArrow) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[27]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2450,7 +2964,7 @@ func (x *FlamegraphArrow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlamegraphArrow.ProtoReflect.Descriptor instead.
 func (*FlamegraphArrow) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{27}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *FlamegraphArrow) GetRecord() []byte {
@@ -2485,6 +2999,9 @@ func (x *FlamegraphArrow) GetTrimmed() int64 {
 type Source struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// An arrow record that contains a row per source code line with value and diff columns for flat and cumulative.
+	// Diff profiles additionally have the flat and cumulative values of the base profile in the
+	// cumulative_base_scaled and flat_base_scaled columns. Like the diff columns, they are scaled to the
+	// total of the larger of the two profiles unless the diff is absolute.
 	Record []byte `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 	// The actual source file content.
 	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
@@ -2496,7 +3013,7 @@ type Source struct {
 
 func (x *Source) Reset() {
 	*x = Source{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[28]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2508,7 +3025,7 @@ func (x *Source) String() string {
 func (*Source) ProtoMessage() {}
 
 func (x *Source) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[28]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2521,7 +3038,7 @@ func (x *Source) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Source.ProtoReflect.Descriptor instead.
 func (*Source) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{28}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Source) GetRecord() []byte {
@@ -2560,7 +3077,7 @@ type FlamegraphRootNode struct {
 
 func (x *FlamegraphRootNode) Reset() {
 	*x = FlamegraphRootNode{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[29]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2572,7 +3089,7 @@ func (x *FlamegraphRootNode) String() string {
 func (*FlamegraphRootNode) ProtoMessage() {}
 
 func (x *FlamegraphRootNode) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[29]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2585,7 +3102,7 @@ func (x *FlamegraphRootNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlamegraphRootNode.ProtoReflect.Descriptor instead.
 func (*FlamegraphRootNode) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{29}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *FlamegraphRootNode) GetCumulative() int64 {
@@ -2626,7 +3143,7 @@ type FlamegraphNode struct {
 
 func (x *FlamegraphNode) Reset() {
 	*x = FlamegraphNode{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[30]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2638,7 +3155,7 @@ func (x *FlamegraphNode) String() string {
 func (*FlamegraphNode) ProtoMessage() {}
 
 func (x *FlamegraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[30]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2651,7 +3168,7 @@ func (x *FlamegraphNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlamegraphNode.ProtoReflect.Descriptor instead.
 func (*FlamegraphNode) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{30}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *FlamegraphNode) GetMeta() *FlamegraphNodeMeta {
@@ -2686,13 +3203,13 @@ func (x *FlamegraphNode) GetChildren() []*FlamegraphNode {
 type FlamegraphNodeMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// location is the location for the code
-	Location *v1alpha11.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Location *v1alpha12.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
 	// mapping is the mapping into code
-	Mapping *v1alpha11.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	Mapping *v1alpha12.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
 	// function is the function information
-	Function *v1alpha11.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	Function *v1alpha12.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
 	// line is the line location
-	Line *v1alpha11.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	Line *v1alpha12.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
 	// location_index has the index to the deduplicated location in the location table.
 	LocationIndex uint32 `protobuf:"varint,5,opt,name=location_index,json=locationIndex,proto3" json:"location_index,omitempty"`
 	// line_index is the line index within the referenced location.
@@ -2703,7 +3220,7 @@ type FlamegraphNodeMeta struct {
 
 func (x *FlamegraphNodeMeta) Reset() {
 	*x = FlamegraphNodeMeta{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[31]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2715,7 +3232,7 @@ func (x *FlamegraphNodeMeta) String() string {
 func (*FlamegraphNodeMeta) ProtoMessage() {}
 
 func (x *FlamegraphNodeMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[31]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2728,31 +3245,31 @@ func (x *FlamegraphNodeMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlamegraphNodeMeta.ProtoReflect.Descriptor instead.
 func (*FlamegraphNodeMeta) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{31}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *FlamegraphNodeMeta) GetLocation() *v1alpha11.Location {
+func (x *FlamegraphNodeMeta) GetLocation() *v1alpha12.Location {
 	if x != nil {
 		return x.Location
 	}
 	return nil
 }
 
-func (x *FlamegraphNodeMeta) GetMapping() *v1alpha11.Mapping {
+func (x *FlamegraphNodeMeta) GetMapping() *v1alpha12.Mapping {
 	if x != nil {
 		return x.Mapping
 	}
 	return nil
 }
 
-func (x *FlamegraphNodeMeta) GetFunction() *v1alpha11.Function {
+func (x *FlamegraphNodeMeta) GetFunction() *v1alpha12.Function {
 	if x != nil {
 		return x.Function
 	}
 	return nil
 }
 
-func (x *FlamegraphNodeMeta) GetLine() *v1alpha11.Line {
+func (x *FlamegraphNodeMeta) GetLine() *v1alpha12.Line {
 	if x != nil {
 		return x.Line
 	}
@@ -2790,7 +3307,7 @@ type CallgraphNode struct {
 
 func (x *CallgraphNode) Reset() {
 	*x = CallgraphNode{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[32]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2802,7 +3319,7 @@ func (x *CallgraphNode) String() string {
 func (*CallgraphNode) ProtoMessage() {}
 
 func (x *CallgraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[32]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2815,7 +3332,7 @@ func (x *CallgraphNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallgraphNode.ProtoReflect.Descriptor instead.
 func (*CallgraphNode) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{32}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *CallgraphNode) GetId() string {
@@ -2850,20 +3367,20 @@ func (x *CallgraphNode) GetFlat() int64 {
 type CallgraphNodeMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// location is the location for the code
-	Location *v1alpha11.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Location *v1alpha12.Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
 	// mapping is the mapping into code
-	Mapping *v1alpha11.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	Mapping *v1alpha12.Mapping `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
 	// function is the function information
-	Function *v1alpha11.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	Function *v1alpha12.Function `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
 	// line is the line location
-	Line          *v1alpha11.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	Line          *v1alpha12.Line `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CallgraphNodeMeta) Reset() {
 	*x = CallgraphNodeMeta{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[33]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2875,7 +3392,7 @@ func (x *CallgraphNodeMeta) String() string {
 func (*CallgraphNodeMeta) ProtoMessage() {}
 
 func (x *CallgraphNodeMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[33]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2888,31 +3405,31 @@ func (x *CallgraphNodeMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallgraphNodeMeta.ProtoReflect.Descriptor instead.
 func (*CallgraphNodeMeta) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{33}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *CallgraphNodeMeta) GetLocation() *v1alpha11.Location {
+func (x *CallgraphNodeMeta) GetLocation() *v1alpha12.Location {
 	if x != nil {
 		return x.Location
 	}
 	return nil
 }
 
-func (x *CallgraphNodeMeta) GetMapping() *v1alpha11.Mapping {
+func (x *CallgraphNodeMeta) GetMapping() *v1alpha12.Mapping {
 	if x != nil {
 		return x.Mapping
 	}
 	return nil
 }
 
-func (x *CallgraphNodeMeta) GetFunction() *v1alpha11.Function {
+func (x *CallgraphNodeMeta) GetFunction() *v1alpha12.Function {
 	if x != nil {
 		return x.Function
 	}
 	return nil
 }
 
-func (x *CallgraphNodeMeta) GetLine() *v1alpha11.Line {
+func (x *CallgraphNodeMeta) GetLine() *v1alpha12.Line {
 	if x != nil {
 		return x.Line
 	}
@@ -2931,14 +3448,16 @@ type CallgraphEdge struct {
 	// cumulative is the cumulative value of the edge
 	Cumulative int64 `protobuf:"varint,4,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
 	// is_collapsed indicates if the edge is collapsed
-	IsCollapsed   bool `protobuf:"varint,5,opt,name=is_collapsed,json=isCollapsed,proto3" json:"is_collapsed,omitempty"`
+	IsCollapsed bool `protobuf:"varint,5,opt,name=is_collapsed,json=isCollapsed,proto3" json:"is_collapsed,omitempty"`
+	// is_inlined indicates if the source and target are inlined functions of the same location
+	IsInlined     bool `protobuf:"varint,6,opt,name=is_inlined,json=isInlined,proto3" json:"is_inlined,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CallgraphEdge) Reset() {
 	*x = CallgraphEdge{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[34]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2950,7 +3469,7 @@ func (x *CallgraphEdge) String() string {
 func (*CallgraphEdge) ProtoMessage() {}
 
 func (x *CallgraphEdge) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[34]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2963,7 +3482,7 @@ func (x *CallgraphEdge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallgraphEdge.ProtoReflect.Descriptor instead.
 func (*CallgraphEdge) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{34}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CallgraphEdge) GetId() string {
@@ -3001,37 +3520,45 @@ func (x *CallgraphEdge) GetIsCollapsed() bool {
 	return false
 }
 
-// Callgraph is the callgraph report type
-type Callgraph struct {
+func (x *CallgraphEdge) GetIsInlined() bool {
+	if x != nil {
+		return x.IsInlined
+	}
+	return false
+}
+
+// Disassembly is the disassembly report type
+type Disassembly struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// nodes are the nodes in the callgraph
-	Nodes []*CallgraphNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	// edges are the edges connecting nodes in the callgraph
-	Edges []*CallgraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
-	// cumulative is the total cumulative value of the callgraph
-	// Use total from the top level query response instead.
-	//
-	// Deprecated: Marked as deprecated in parca/query/v1alpha1/query.proto.
-	Cumulative    int64 `protobuf:"varint,3,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	// function is the name of the disassembled function
+	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// start is the address of the first instruction of the function within the executable
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the address after the last instruction of the function within the executable
+	End uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// instructions are the instructions of the function ordered by their address
+	Instructions []*DisassemblyInstruction `protobuf:"bytes,4,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	// unit is the unit of the values
+	Unit          string `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Callgraph) Reset() {
-	*x = Callgraph{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[35]
+func (x *Disassembly) Reset() {
+	*x = Disassembly{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Callgraph) String() string {
+func (x *Disassembly) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Callgraph) ProtoMessage() {}
+func (*Disassembly) ProtoMessage() {}
 
-func (x *Callgraph) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[35]
+func (x *Disassembly) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3042,60 +3569,794 @@ func (x *Callgraph) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Callgraph.ProtoReflect.Descriptor instead.
-func (*Callgraph) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use Disassembly.ProtoReflect.Descriptor instead.
+func (*Disassembly) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *Callgraph) GetNodes() []*CallgraphNode {
+func (x *Disassembly) GetFunction() string {
 	if x != nil {
-		return x.Nodes
+		return x.Function
 	}
-	return nil
+	return ""
 }
 
-func (x *Callgraph) GetEdges() []*CallgraphEdge {
+func (x *Disassembly) GetStart() uint64 {
 	if x != nil {
-		return x.Edges
+		return x.Start
 	}
-	return nil
+	return 0
 }
 
-// Deprecated: Marked as deprecated in parca/query/v1alpha1/query.proto.
-func (x *Callgraph) GetCumulative() int64 {
+func (x *Disassembly) GetEnd() uint64 {
 	if x != nil {
-		return x.Cumulative
+		return x.End
 	}
 	return 0
 }
 
-// QueryResponse is the returned report for the given query
-type QueryResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// report is the generated report
-	//
-	// Types that are valid to be assigned to Report:
-	//
-	//	*QueryResponse_Flamegraph
-	//	*QueryResponse_Pprof
-	//	*QueryResponse_Top
-	//	*QueryResponse_Callgraph
-	//	*QueryResponse_FlamegraphArrow
-	//	*QueryResponse_Source
-	//	*QueryResponse_TableArrow
-	//	*QueryResponse_ProfileMetadata
-	Report isQueryResponse_Report `protobuf_oneof:"report"`
-	// total is the total number of samples shown in the report.
-	Total int64 `protobuf:"varint,9,opt,name=total,proto3" json:"total,omitempty"`
-	// filtered is the number of samples filtered out of the report.
-	Filtered      int64 `protobuf:"varint,10,opt,name=filtered,proto3" json:"filtered,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Disassembly) GetInstructions() []*DisassemblyInstruction {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
 }
 
-func (x *QueryResponse) Reset() {
-	*x = QueryResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[36]
+func (x *Disassembly) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// DisassemblyInstruction is an instruction annotated with the samples of its address. Consecutive
+// instructions of the same source line can be grouped to interleave the source code.
+type DisassemblyInstruction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address is the address of the instruction within the executable
+	Address uint64 `protobuf:"varint,1,opt,name=address,proto3" json:"address,omitempty"`
+	// text is the instruction in the GNU assembler syntax
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// flat is the value of the samples whose leaf is the instruction
+	Flat int64 `protobuf:"varint,3,opt,name=flat,proto3" json:"flat,omitempty"`
+	// cumulative is the value of the samples containing the instruction
+	Cumulative int64 `protobuf:"varint,4,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	// filename is the source file of the instruction, empty if no debug information is available
+	Filename string `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`
+	// line is the source line of the instruction, zero if no debug information is available
+	Line          int64 `protobuf:"varint,6,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisassemblyInstruction) Reset() {
+	*x = DisassemblyInstruction{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisassemblyInstruction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisassemblyInstruction) ProtoMessage() {}
+
+func (x *DisassemblyInstruction) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisassemblyInstruction.ProtoReflect.Descriptor instead.
+func (*DisassemblyInstruction) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DisassemblyInstruction) GetAddress() uint64 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+func (x *DisassemblyInstruction) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DisassemblyInstruction) GetFlat() int64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *DisassemblyInstruction) GetCumulative() int64 {
+	if x != nil {
+		return x.Cumulative
+	}
+	return 0
+}
+
+func (x *DisassemblyInstruction) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DisassemblyInstruction) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+// LabelBreakdown is the label breakdown report type
+type LabelBreakdown struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// labels are the broken down labels ordered by name
+	Labels []*LabelBreakdownLabel `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	// cumulative is the value of the samples containing the requested functions
+	Cumulative int64 `protobuf:"varint,2,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	// unit is the unit of the values
+	Unit          string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelBreakdown) Reset() {
+	*x = LabelBreakdown{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelBreakdown) ProtoMessage() {}
+
+func (x *LabelBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelBreakdown.ProtoReflect.Descriptor instead.
+func (*LabelBreakdown) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LabelBreakdown) GetLabels() []*LabelBreakdownLabel {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LabelBreakdown) GetCumulative() int64 {
+	if x != nil {
+		return x.Cumulative
+	}
+	return 0
+}
+
+func (x *LabelBreakdown) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// LabelBreakdownLabel is the breakdown of the requested functions by the values of a label
+type LabelBreakdownLabel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the label
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// values are the values of the label ordered by their cumulative value, the largest first
+	Values        []*LabelBreakdownValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelBreakdownLabel) Reset() {
+	*x = LabelBreakdownLabel{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelBreakdownLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelBreakdownLabel) ProtoMessage() {}
+
+func (x *LabelBreakdownLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelBreakdownLabel.ProtoReflect.Descriptor instead.
+func (*LabelBreakdownLabel) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LabelBreakdownLabel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LabelBreakdownLabel) GetValues() []*LabelBreakdownValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// LabelBreakdownValue is the value of the requested functions in the samples with a label value
+type LabelBreakdownValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// value is the label value, empty for the samples without the label
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// cumulative is the value of the samples with the label value containing the requested functions
+	Cumulative int64 `protobuf:"varint,2,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	// percentage is the share of the cumulative value in the cumulative value of the requested functions
+	Percentage    float64 `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelBreakdownValue) Reset() {
+	*x = LabelBreakdownValue{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelBreakdownValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelBreakdownValue) ProtoMessage() {}
+
+func (x *LabelBreakdownValue) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelBreakdownValue.ProtoReflect.Descriptor instead.
+func (*LabelBreakdownValue) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LabelBreakdownValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *LabelBreakdownValue) GetCumulative() int64 {
+	if x != nil {
+		return x.Cumulative
+	}
+	return 0
+}
+
+func (x *LabelBreakdownValue) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+// Heatmap is the heatmap report type
+type Heatmap struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start is the start of the first time bucket
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// bucket_duration is the duration of each time bucket
+	BucketDuration *durationpb.Duration `protobuf:"bytes,2,opt,name=bucket_duration,json=bucketDuration,proto3" json:"bucket_duration,omitempty"`
+	// totals are the values of all samples per time bucket
+	Totals []int64 `protobuf:"varint,3,rep,packed,name=totals,proto3" json:"totals,omitempty"`
+	// rows are the top functions, or stack roots, ordered by their total value, the largest first
+	Rows []*HeatmapRow `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"`
+	// unit is the unit of the values
+	Unit          string `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heatmap) Reset() {
+	*x = Heatmap{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heatmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heatmap) ProtoMessage() {}
+
+func (x *Heatmap) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heatmap.ProtoReflect.Descriptor instead.
+func (*Heatmap) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Heatmap) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Heatmap) GetBucketDuration() *durationpb.Duration {
+	if x != nil {
+		return x.BucketDuration
+	}
+	return nil
+}
+
+func (x *Heatmap) GetTotals() []int64 {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *Heatmap) GetRows() []*HeatmapRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Heatmap) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// HeatmapRow is the cumulative value of a function, or stack root, per time bucket
+type HeatmapRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the function
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// total is the cumulative value of the function over all time buckets
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// values are the cumulative values of the function per time bucket
+	Values        []int64 `protobuf:"varint,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeatmapRow) Reset() {
+	*x = HeatmapRow{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeatmapRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapRow) ProtoMessage() {}
+
+func (x *HeatmapRow) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapRow.ProtoReflect.Descriptor instead.
+func (*HeatmapRow) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *HeatmapRow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeatmapRow) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *HeatmapRow) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// CallersCallees is the callers and callees report type
+type CallersCallees struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// functions are the functions matching the requested regular expression, ordered by their
+	// cumulative value
+	Functions []*CallersCalleesFunction `protobuf:"bytes,1,rep,name=functions,proto3" json:"functions,omitempty"`
+	// unit is the unit of the values
+	Unit          string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallersCallees) Reset() {
+	*x = CallersCallees{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallersCallees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallersCallees) ProtoMessage() {}
+
+func (x *CallersCallees) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallersCallees.ProtoReflect.Descriptor instead.
+func (*CallersCallees) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CallersCallees) GetFunctions() []*CallersCalleesFunction {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+func (x *CallersCallees) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// CallersCalleesFunction is a function with its direct callers and callees
+type CallersCalleesFunction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// function is the function itself, its percentages are relative to the total of the report
+	Function *CallersCalleesEntry `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// callers are the functions directly calling the function, ordered by their cumulative value
+	Callers []*CallersCalleesEntry `protobuf:"bytes,2,rep,name=callers,proto3" json:"callers,omitempty"`
+	// callees are the functions directly called by the function, ordered by their cumulative value
+	Callees       []*CallersCalleesEntry `protobuf:"bytes,3,rep,name=callees,proto3" json:"callees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallersCalleesFunction) Reset() {
+	*x = CallersCalleesFunction{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallersCalleesFunction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallersCalleesFunction) ProtoMessage() {}
+
+func (x *CallersCalleesFunction) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallersCalleesFunction.ProtoReflect.Descriptor instead.
+func (*CallersCalleesFunction) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CallersCalleesFunction) GetFunction() *CallersCalleesEntry {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *CallersCalleesFunction) GetCallers() []*CallersCalleesEntry {
+	if x != nil {
+		return x.Callers
+	}
+	return nil
+}
+
+func (x *CallersCalleesFunction) GetCallees() []*CallersCalleesEntry {
+	if x != nil {
+		return x.Callees
+	}
+	return nil
+}
+
+// CallersCalleesEntry is a row of the callers and callees report. For callers and callees the
+// values are those of the call from or to the function and the percentages are relative to the
+// cumulative value of the function.
+type CallersCalleesEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the function name, unsymbolized frames are named by their address
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// filename is the file of the function, or the mapping file of unsymbolized frames
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// flat is the value of the samples ending in the function, or ending in the call for callers
+	// and callees
+	Flat int64 `protobuf:"varint,3,opt,name=flat,proto3" json:"flat,omitempty"`
+	// cumulative is the value of the samples containing the function, or the call for callers and
+	// callees
+	Cumulative int64 `protobuf:"varint,4,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	// flat_percentage is the flat value as a percentage
+	FlatPercentage float64 `protobuf:"fixed64,5,opt,name=flat_percentage,json=flatPercentage,proto3" json:"flat_percentage,omitempty"`
+	// cumulative_percentage is the cumulative value as a percentage
+	CumulativePercentage float64 `protobuf:"fixed64,6,opt,name=cumulative_percentage,json=cumulativePercentage,proto3" json:"cumulative_percentage,omitempty"`
+	// diff is the diff of the cumulative value between two profiles
+	Diff          int64 `protobuf:"varint,7,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallersCalleesEntry) Reset() {
+	*x = CallersCalleesEntry{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallersCalleesEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallersCalleesEntry) ProtoMessage() {}
+
+func (x *CallersCalleesEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallersCalleesEntry.ProtoReflect.Descriptor instead.
+func (*CallersCalleesEntry) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CallersCalleesEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CallersCalleesEntry) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CallersCalleesEntry) GetFlat() int64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *CallersCalleesEntry) GetCumulative() int64 {
+	if x != nil {
+		return x.Cumulative
+	}
+	return 0
+}
+
+func (x *CallersCalleesEntry) GetFlatPercentage() float64 {
+	if x != nil {
+		return x.FlatPercentage
+	}
+	return 0
+}
+
+func (x *CallersCalleesEntry) GetCumulativePercentage() float64 {
+	if x != nil {
+		return x.CumulativePercentage
+	}
+	return 0
+}
+
+func (x *CallersCalleesEntry) GetDiff() int64 {
+	if x != nil {
+		return x.Diff
+	}
+	return 0
+}
+
+// Callgraph is the callgraph report type
+type Callgraph struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// nodes are the nodes in the callgraph
+	Nodes []*CallgraphNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// edges are the edges connecting nodes in the callgraph
+	Edges []*CallgraphEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// cumulative is the total cumulative value of the callgraph
+	// Use total from the top level query response instead.
+	//
+	// Deprecated: Marked as deprecated in parca/query/v1alpha1/query.proto.
+	Cumulative    int64 `protobuf:"varint,3,opt,name=cumulative,proto3" json:"cumulative,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Callgraph) Reset() {
+	*x = Callgraph{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Callgraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Callgraph) ProtoMessage() {}
+
+func (x *Callgraph) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Callgraph.ProtoReflect.Descriptor instead.
+func (*Callgraph) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Callgraph) GetNodes() []*CallgraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Callgraph) GetEdges() []*CallgraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in parca/query/v1alpha1/query.proto.
+func (x *Callgraph) GetCumulative() int64 {
+	if x != nil {
+		return x.Cumulative
+	}
+	return 0
+}
+
+// QueryResponse is the returned report for the given query
+type QueryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// report is the generated report
+	//
+	// Types that are valid to be assigned to Report:
+	//
+	//	*QueryResponse_Flamegraph
+	//	*QueryResponse_Pprof
+	//	*QueryResponse_Top
+	//	*QueryResponse_Callgraph
+	//	*QueryResponse_FlamegraphArrow
+	//	*QueryResponse_Source
+	//	*QueryResponse_TableArrow
+	//	*QueryResponse_ProfileMetadata
+	//	*QueryResponse_Folded
+	//	*QueryResponse_Speedscope
+	//	*QueryResponse_ChromeTrace
+	//	*QueryResponse_CallgraphDot
+	//	*QueryResponse_CallersCallees
+	//	*QueryResponse_Disassembly
+	//	*QueryResponse_LabelBreakdown
+	//	*QueryResponse_Heatmap
+	Report isQueryResponse_Report `protobuf_oneof:"report"`
+	// total is the total number of samples shown in the report.
+	Total int64 `protobuf:"varint,9,opt,name=total,proto3" json:"total,omitempty"`
+	// filtered is the number of samples filtered out of the report.
+	Filtered      int64 `protobuf:"varint,10,opt,name=filtered,proto3" json:"filtered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3107,7 +4368,7 @@ func (x *QueryResponse) String() string {
 func (*QueryResponse) ProtoMessage() {}
 
 func (x *QueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[36]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3120,7 +4381,7 @@ func (x *QueryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{36}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *QueryResponse) GetReport() isQueryResponse_Report {
@@ -3202,6 +4463,78 @@ func (x *QueryResponse) GetProfileMetadata() *ProfileMetadata {
 	return nil
 }
 
+func (x *QueryResponse) GetFolded() []byte {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_Folded); ok {
+			return x.Folded
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetSpeedscope() []byte {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_Speedscope); ok {
+			return x.Speedscope
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetChromeTrace() []byte {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_ChromeTrace); ok {
+			return x.ChromeTrace
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetCallgraphDot() []byte {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_CallgraphDot); ok {
+			return x.CallgraphDot
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetCallersCallees() *CallersCallees {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_CallersCallees); ok {
+			return x.CallersCallees
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetDisassembly() *Disassembly {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_Disassembly); ok {
+			return x.Disassembly
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetLabelBreakdown() *LabelBreakdown {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_LabelBreakdown); ok {
+			return x.LabelBreakdown
+		}
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetHeatmap() *Heatmap {
+	if x != nil {
+		if x, ok := x.Report.(*QueryResponse_Heatmap); ok {
+			return x.Heatmap
+		}
+	}
+	return nil
+}
+
 func (x *QueryResponse) GetTotal() int64 {
 	if x != nil {
 		return x.Total
@@ -3260,6 +4593,46 @@ type QueryResponse_ProfileMetadata struct {
 	ProfileMetadata *ProfileMetadata `protobuf:"bytes,14,opt,name=profile_metadata,json=profileMetadata,proto3,oneof"`
 }
 
+type QueryResponse_Folded struct {
+	// folded is the report in the folded stacks format
+	Folded []byte `protobuf:"bytes,15,opt,name=folded,proto3,oneof"`
+}
+
+type QueryResponse_Speedscope struct {
+	// speedscope is the report in the speedscope file format
+	Speedscope []byte `protobuf:"bytes,16,opt,name=speedscope,proto3,oneof"`
+}
+
+type QueryResponse_ChromeTrace struct {
+	// chrome_trace is the report in the Chrome trace event format
+	ChromeTrace []byte `protobuf:"bytes,17,opt,name=chrome_trace,json=chromeTrace,proto3,oneof"`
+}
+
+type QueryResponse_CallgraphDot struct {
+	// callgraph_dot is the callgraph in the Graphviz DOT format
+	CallgraphDot []byte `protobuf:"bytes,18,opt,name=callgraph_dot,json=callgraphDot,proto3,oneof"`
+}
+
+type QueryResponse_CallersCallees struct {
+	// callers_callees contains the direct callers and callees of the requested functions
+	CallersCallees *CallersCallees `protobuf:"bytes,20,opt,name=callers_callees,json=callersCallees,proto3,oneof"`
+}
+
+type QueryResponse_Disassembly struct {
+	// disassembly contains the annotated disassembly of the requested function
+	Disassembly *Disassembly `protobuf:"bytes,21,opt,name=disassembly,proto3,oneof"`
+}
+
+type QueryResponse_LabelBreakdown struct {
+	// label_breakdown contains the values of the requested functions grouped by label
+	LabelBreakdown *LabelBreakdown `protobuf:"bytes,22,opt,name=label_breakdown,json=labelBreakdown,proto3,oneof"`
+}
+
+type QueryResponse_Heatmap struct {
+	// heatmap contains the values of the top functions per time bucket
+	Heatmap *Heatmap `protobuf:"bytes,23,opt,name=heatmap,proto3,oneof"`
+}
+
 func (*QueryResponse_Flamegraph) isQueryResponse_Report() {}
 
 func (*QueryResponse_Pprof) isQueryResponse_Report() {}
@@ -3276,14 +4649,31 @@ func (*QueryResponse_TableArrow) isQueryResponse_Report() {}
 
 func (*QueryResponse_ProfileMetadata) isQueryResponse_Report() {}
 
-// SeriesRequest is unimplemented
+func (*QueryResponse_Folded) isQueryResponse_Report() {}
+
+func (*QueryResponse_Speedscope) isQueryResponse_Report() {}
+
+func (*QueryResponse_ChromeTrace) isQueryResponse_Report() {}
+
+func (*QueryResponse_CallgraphDot) isQueryResponse_Report() {}
+
+func (*QueryResponse_CallersCallees) isQueryResponse_Report() {}
+
+func (*QueryResponse_Disassembly) isQueryResponse_Report() {}
+
+func (*QueryResponse_LabelBreakdown) isQueryResponse_Report() {}
+
+func (*QueryResponse_Heatmap) isQueryResponse_Report() {}
+
+// SeriesRequest is the request for the series matching a set of selectors
 type SeriesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// match ...
+	// match are the series selectors, a series is returned if it matches any of them.
+	// A selector may omit the profile type, e.g. `{namespace="parca"}`. If empty all series are returned.
 	Match []string `protobuf:"bytes,1,rep,name=match,proto3" json:"match,omitempty"`
-	// start ...
+	// start is the start of the time window to perform the query
 	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
-	// end ...
+	// end is the end of the time window to perform the query
 	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -3291,7 +4681,7 @@ type SeriesRequest struct {
 
 func (x *SeriesRequest) Reset() {
 	*x = SeriesRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[37]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3303,7 +4693,7 @@ func (x *SeriesRequest) String() string {
 func (*SeriesRequest) ProtoMessage() {}
 
 func (x *SeriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[37]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3316,7 +4706,7 @@ func (x *SeriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SeriesRequest.ProtoReflect.Descriptor instead.
 func (*SeriesRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{37}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *SeriesRequest) GetMatch() []string {
@@ -3340,16 +4730,18 @@ func (x *SeriesRequest) GetEnd() *timestamppb.Timestamp {
 	return nil
 }
 
-// SeriesResponse is unimplemented
+// SeriesResponse is the set of series matching the selectors
 type SeriesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// series is the set of matching series
+	Series        []*Series `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SeriesResponse) Reset() {
 	*x = SeriesResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[38]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3358,23 +4750,103 @@ func (x *SeriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SeriesResponse) ProtoMessage() {}
-
-func (x *SeriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[38]
+func (*SeriesResponse) ProtoMessage() {}
+
+func (x *SeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesResponse.ProtoReflect.Descriptor instead.
+func (*SeriesResponse) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SeriesResponse) GetSeries() []*Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// Series is a distinct label set of a profile type
+type Series struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// labelset is the set of key value pairs identifying the series
+	Labelset *v1alpha11.LabelSet `protobuf:"bytes,1,opt,name=labelset,proto3" json:"labelset,omitempty"`
+	// profile_type is the profile type of the series
+	ProfileType *ProfileType `protobuf:"bytes,2,opt,name=profile_type,json=profileType,proto3" json:"profile_type,omitempty"`
+	// first_seen is the timestamp of the first sample of the series within the time window
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// last_seen is the timestamp of the last sample of the series within the time window
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Series) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Series) ProtoMessage() {}
+
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Series) GetLabelset() *v1alpha11.LabelSet {
+	if x != nil {
+		return x.Labelset
+	}
+	return nil
+}
+
+func (x *Series) GetProfileType() *ProfileType {
+	if x != nil {
+		return x.ProfileType
+	}
+	return nil
+}
+
+func (x *Series) GetFirstSeen() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FirstSeen
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use SeriesResponse.ProtoReflect.Descriptor instead.
-func (*SeriesResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{38}
+func (x *Series) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
 }
 
 // LabelsRequest are the request values for labels
@@ -3394,7 +4866,7 @@ type LabelsRequest struct {
 
 func (x *LabelsRequest) Reset() {
 	*x = LabelsRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[39]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3406,7 +4878,7 @@ func (x *LabelsRequest) String() string {
 func (*LabelsRequest) ProtoMessage() {}
 
 func (x *LabelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[39]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3419,7 +4891,7 @@ func (x *LabelsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LabelsRequest.ProtoReflect.Descriptor instead.
 func (*LabelsRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{39}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *LabelsRequest) GetMatch() []string {
@@ -3463,7 +4935,7 @@ type LabelsResponse struct {
 
 func (x *LabelsResponse) Reset() {
 	*x = LabelsResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[40]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3475,7 +4947,7 @@ func (x *LabelsResponse) String() string {
 func (*LabelsResponse) ProtoMessage() {}
 
 func (x *LabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[40]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3488,7 +4960,7 @@ func (x *LabelsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LabelsResponse.ProtoReflect.Descriptor instead.
 func (*LabelsResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{40}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *LabelsResponse) GetLabelNames() []string {
@@ -3524,7 +4996,7 @@ type ValuesRequest struct {
 
 func (x *ValuesRequest) Reset() {
 	*x = ValuesRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[41]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3536,7 +5008,7 @@ func (x *ValuesRequest) String() string {
 func (*ValuesRequest) ProtoMessage() {}
 
 func (x *ValuesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[41]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3549,7 +5021,7 @@ func (x *ValuesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValuesRequest.ProtoReflect.Descriptor instead.
 func (*ValuesRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{41}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *ValuesRequest) GetLabelName() string {
@@ -3600,7 +5072,7 @@ type ValuesResponse struct {
 
 func (x *ValuesResponse) Reset() {
 	*x = ValuesResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[42]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3612,7 +5084,7 @@ func (x *ValuesResponse) String() string {
 func (*ValuesResponse) ProtoMessage() {}
 
 func (x *ValuesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[42]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3625,7 +5097,7 @@ func (x *ValuesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValuesResponse.ProtoReflect.Descriptor instead.
 func (*ValuesResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{42}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *ValuesResponse) GetLabelValues() []string {
@@ -3655,7 +5127,7 @@ type ValueType struct {
 
 func (x *ValueType) Reset() {
 	*x = ValueType{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[43]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3667,7 +5139,7 @@ func (x *ValueType) String() string {
 func (*ValueType) ProtoMessage() {}
 
 func (x *ValueType) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[43]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3680,7 +5152,7 @@ func (x *ValueType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValueType.ProtoReflect.Descriptor instead.
 func (*ValueType) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{43}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *ValueType) GetType() string {
@@ -3710,7 +5182,7 @@ type ShareProfileRequest struct {
 
 func (x *ShareProfileRequest) Reset() {
 	*x = ShareProfileRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[44]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3722,7 +5194,7 @@ func (x *ShareProfileRequest) String() string {
 func (*ShareProfileRequest) ProtoMessage() {}
 
 func (x *ShareProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[44]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3735,7 +5207,7 @@ func (x *ShareProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShareProfileRequest.ProtoReflect.Descriptor instead.
 func (*ShareProfileRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{44}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *ShareProfileRequest) GetQueryRequest() *QueryRequest {
@@ -3763,7 +5235,7 @@ type ShareProfileResponse struct {
 
 func (x *ShareProfileResponse) Reset() {
 	*x = ShareProfileResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[45]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3775,7 +5247,7 @@ func (x *ShareProfileResponse) String() string {
 func (*ShareProfileResponse) ProtoMessage() {}
 
 func (x *ShareProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[45]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3788,7 +5260,7 @@ func (x *ShareProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShareProfileResponse.ProtoReflect.Descriptor instead.
 func (*ShareProfileResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{45}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *ShareProfileResponse) GetLink() string {
@@ -3801,7 +5273,9 @@ func (x *ShareProfileResponse) GetLink() string {
 // TableArrow has the table encoded as a arrow record
 type TableArrow struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// record is the arrow record containing the actual table data
+	// record is the arrow record containing the actual table data. For derived profile types the cumulative and flat
+	// values are the ones of the denominator, the cumulative_numerator and flat_numerator columns hold the ones of the
+	// numerator, and cumulative_ratio and flat_ratio columns are added.
 	Record []byte `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 	// unit is the unit represented by the flame graph
 	Unit          string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
@@ -3811,7 +5285,7 @@ type TableArrow struct {
 
 func (x *TableArrow) Reset() {
 	*x = TableArrow{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[46]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3823,7 +5297,7 @@ func (x *TableArrow) String() string {
 func (*TableArrow) ProtoMessage() {}
 
 func (x *TableArrow) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[46]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3836,7 +5310,7 @@ func (x *TableArrow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableArrow.ProtoReflect.Descriptor instead.
 func (*TableArrow) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{46}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *TableArrow) GetRecord() []byte {
@@ -3866,7 +5340,7 @@ type ProfileMetadata struct {
 
 func (x *ProfileMetadata) Reset() {
 	*x = ProfileMetadata{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[47]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3878,7 +5352,7 @@ func (x *ProfileMetadata) String() string {
 func (*ProfileMetadata) ProtoMessage() {}
 
 func (x *ProfileMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[47]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3891,7 +5365,7 @@ func (x *ProfileMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileMetadata.ProtoReflect.Descriptor instead.
 func (*ProfileMetadata) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{47}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *ProfileMetadata) GetMappingFiles() []string {
@@ -3917,7 +5391,7 @@ type HasProfileDataRequest struct {
 
 func (x *HasProfileDataRequest) Reset() {
 	*x = HasProfileDataRequest{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[48]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3929,7 +5403,7 @@ func (x *HasProfileDataRequest) String() string {
 func (*HasProfileDataRequest) ProtoMessage() {}
 
 func (x *HasProfileDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[48]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3942,7 +5416,7 @@ func (x *HasProfileDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasProfileDataRequest.ProtoReflect.Descriptor instead.
 func (*HasProfileDataRequest) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{48}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{62}
 }
 
 // HasProfileDataResponse is the response indicating whether there is profile data in the store
@@ -3956,7 +5430,7 @@ type HasProfileDataResponse struct {
 
 func (x *HasProfileDataResponse) Reset() {
 	*x = HasProfileDataResponse{}
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[49]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3968,7 +5442,7 @@ func (x *HasProfileDataResponse) String() string {
 func (*HasProfileDataResponse) ProtoMessage() {}
 
 func (x *HasProfileDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[49]
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3981,7 +5455,7 @@ func (x *HasProfileDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasProfileDataResponse.ProtoReflect.Descriptor instead.
 func (*HasProfileDataResponse) Descriptor() ([]byte, []int) {
-	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{49}
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *HasProfileDataResponse) GetHasData() bool {
@@ -3991,11 +5465,304 @@ func (x *HasProfileDataResponse) GetHasData() bool {
 	return false
 }
 
+// TopRegressionsRequest is the request to compare the profiles of two time windows per function
+type TopRegressionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query is the query string to match profiles against, the profiles of each window are merged
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// baseline_start is the start of the baseline time window
+	BaselineStart *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=baseline_start,json=baselineStart,proto3" json:"baseline_start,omitempty"`
+	// baseline_end is the end of the baseline time window
+	BaselineEnd *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=baseline_end,json=baselineEnd,proto3" json:"baseline_end,omitempty"`
+	// candidate_start is the start of the candidate time window
+	CandidateStart *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=candidate_start,json=candidateStart,proto3" json:"candidate_start,omitempty"`
+	// candidate_end is the end of the candidate time window
+	CandidateEnd *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=candidate_end,json=candidateEnd,proto3" json:"candidate_end,omitempty"`
+	// min_significance is the minimum increase of either the flat or the cumulative share of a function, in percentage
+	// points, for it to be reported. Defaults to 0.1 percentage points.
+	MinSignificance *float64 `protobuf:"fixed64,6,opt,name=min_significance,json=minSignificance,proto3,oneof" json:"min_significance,omitempty"`
+	// limit is the maximum number of functions to return, all regressed functions are returned if zero
+	Limit         uint32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRegressionsRequest) Reset() {
+	*x = TopRegressionsRequest{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRegressionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRegressionsRequest) ProtoMessage() {}
+
+func (x *TopRegressionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRegressionsRequest.ProtoReflect.Descriptor instead.
+func (*TopRegressionsRequest) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TopRegressionsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TopRegressionsRequest) GetBaselineStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BaselineStart
+	}
+	return nil
+}
+
+func (x *TopRegressionsRequest) GetBaselineEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BaselineEnd
+	}
+	return nil
+}
+
+func (x *TopRegressionsRequest) GetCandidateStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CandidateStart
+	}
+	return nil
+}
+
+func (x *TopRegressionsRequest) GetCandidateEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CandidateEnd
+	}
+	return nil
+}
+
+func (x *TopRegressionsRequest) GetMinSignificance() float64 {
+	if x != nil && x.MinSignificance != nil {
+		return *x.MinSignificance
+	}
+	return 0
+}
+
+func (x *TopRegressionsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// TopRegressionsResponse is the list of functions whose share of the profile grew the most
+type TopRegressionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// regressions are the regressed functions ordered by their score, the largest first
+	Regressions []*TopRegression `protobuf:"bytes,1,rep,name=regressions,proto3" json:"regressions,omitempty"`
+	// unit is the unit of the sample values, the per second values are in unit per second
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// baseline_total_per_second is the total value of the baseline profile per second of the baseline time window
+	BaselineTotalPerSecond float64 `protobuf:"fixed64,3,opt,name=baseline_total_per_second,json=baselineTotalPerSecond,proto3" json:"baseline_total_per_second,omitempty"`
+	// candidate_total_per_second is the total value of the candidate profile per second of the candidate time window
+	CandidateTotalPerSecond float64 `protobuf:"fixed64,4,opt,name=candidate_total_per_second,json=candidateTotalPerSecond,proto3" json:"candidate_total_per_second,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *TopRegressionsResponse) Reset() {
+	*x = TopRegressionsResponse{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRegressionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRegressionsResponse) ProtoMessage() {}
+
+func (x *TopRegressionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRegressionsResponse.ProtoReflect.Descriptor instead.
+func (*TopRegressionsResponse) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *TopRegressionsResponse) GetRegressions() []*TopRegression {
+	if x != nil {
+		return x.Regressions
+	}
+	return nil
+}
+
+func (x *TopRegressionsResponse) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *TopRegressionsResponse) GetBaselineTotalPerSecond() float64 {
+	if x != nil {
+		return x.BaselineTotalPerSecond
+	}
+	return 0
+}
+
+func (x *TopRegressionsResponse) GetCandidateTotalPerSecond() float64 {
+	if x != nil {
+		return x.CandidateTotalPerSecond
+	}
+	return 0
+}
+
+// TopRegression is the change of a function between the baseline and the candidate profile
+type TopRegression struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// function_name is the name of the function, or the address of unsymbolized locations
+	FunctionName string `protobuf:"bytes,1,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// function_file_name is the file of the function, or the mapping file of unsymbolized locations
+	FunctionFileName string `protobuf:"bytes,2,opt,name=function_file_name,json=functionFileName,proto3" json:"function_file_name,omitempty"`
+	// baseline_flat_per_second is the flat value of the function in the baseline profile per second
+	BaselineFlatPerSecond float64 `protobuf:"fixed64,3,opt,name=baseline_flat_per_second,json=baselineFlatPerSecond,proto3" json:"baseline_flat_per_second,omitempty"`
+	// baseline_cumulative_per_second is the cumulative value of the function in the baseline profile per second
+	BaselineCumulativePerSecond float64 `protobuf:"fixed64,4,opt,name=baseline_cumulative_per_second,json=baselineCumulativePerSecond,proto3" json:"baseline_cumulative_per_second,omitempty"`
+	// candidate_flat_per_second is the flat value of the function in the candidate profile per second
+	CandidateFlatPerSecond float64 `protobuf:"fixed64,5,opt,name=candidate_flat_per_second,json=candidateFlatPerSecond,proto3" json:"candidate_flat_per_second,omitempty"`
+	// candidate_cumulative_per_second is the cumulative value of the function in the candidate profile per second
+	CandidateCumulativePerSecond float64 `protobuf:"fixed64,6,opt,name=candidate_cumulative_per_second,json=candidateCumulativePerSecond,proto3" json:"candidate_cumulative_per_second,omitempty"`
+	// flat_share_change is the change of the flat share of the function in percentage points
+	FlatShareChange float64 `protobuf:"fixed64,7,opt,name=flat_share_change,json=flatShareChange,proto3" json:"flat_share_change,omitempty"`
+	// cumulative_share_change is the change of the cumulative share of the function in percentage points
+	CumulativeShareChange float64 `protobuf:"fixed64,8,opt,name=cumulative_share_change,json=cumulativeShareChange,proto3" json:"cumulative_share_change,omitempty"`
+	// score is the sum of the flat and cumulative share changes, so functions that got more expensive themselves rank
+	// above the functions that only call them
+	Score         float64 `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRegression) Reset() {
+	*x = TopRegression{}
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRegression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRegression) ProtoMessage() {}
+
+func (x *TopRegression) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_query_v1alpha1_query_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRegression.ProtoReflect.Descriptor instead.
+func (*TopRegression) Descriptor() ([]byte, []int) {
+	return file_parca_query_v1alpha1_query_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TopRegression) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+func (x *TopRegression) GetFunctionFileName() string {
+	if x != nil {
+		return x.FunctionFileName
+	}
+	return ""
+}
+
+func (x *TopRegression) GetBaselineFlatPerSecond() float64 {
+	if x != nil {
+		return x.BaselineFlatPerSecond
+	}
+	return 0
+}
+
+func (x *TopRegression) GetBaselineCumulativePerSecond() float64 {
+	if x != nil {
+		return x.BaselineCumulativePerSecond
+	}
+	return 0
+}
+
+func (x *TopRegression) GetCandidateFlatPerSecond() float64 {
+	if x != nil {
+		return x.CandidateFlatPerSecond
+	}
+	return 0
+}
+
+func (x *TopRegression) GetCandidateCumulativePerSecond() float64 {
+	if x != nil {
+		return x.CandidateCumulativePerSecond
+	}
+	return 0
+}
+
+func (x *TopRegression) GetFlatShareChange() float64 {
+	if x != nil {
+		return x.FlatShareChange
+	}
+	return 0
+}
+
+func (x *TopRegression) GetCumulativeShareChange() float64 {
+	if x != nil {
+		return x.CumulativeShareChange
+	}
+	return 0
+}
+
+func (x *TopRegression) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 var File_parca_query_v1alpha1_query_proto protoreflect.FileDescriptor
 
 const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\n" +
-	" parca/query/v1alpha1/query.proto\x12\x14parca.query.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a(parca/metastore/v1alpha1/metastore.proto\x1a.parca/profilestore/v1alpha1/profilestore.proto\"\x91\x01\n" +
+	" parca/query/v1alpha1/query.proto\x12\x14parca.query.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a*parca/annotation/v1alpha1/annotation.proto\x1a(parca/metastore/v1alpha1/metastore.proto\x1a.parca/profilestore/v1alpha1/profilestore.proto\"\x91\x01\n" +
 	"\x13ProfileTypesRequest\x125\n" +
 	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05start\x88\x01\x01\x121\n" +
 	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x03end\x88\x01\x01B\b\n" +
@@ -4013,16 +5780,18 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"periodType\x12\x1f\n" +
 	"\vperiod_unit\x18\x05 \x01(\tR\n" +
 	"periodUnit\x12\x14\n" +
-	"\x05delta\x18\x06 \x01(\bR\x05delta\"\xe5\x01\n" +
+	"\x05delta\x18\x06 \x01(\bR\x05delta\"\x9b\x02\n" +
 	"\x11QueryRangeRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x120\n" +
 	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
 	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\rR\x05limit\x12-\n" +
 	"\x04step\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x04step\x12\x15\n" +
-	"\x06sum_by\x18\x06 \x03(\tR\x05sumBy\"Q\n" +
+	"\x06sum_by\x18\x06 \x03(\tR\x05sumBy\x124\n" +
+	"\x06filter\x18\a \x03(\v2\x1c.parca.query.v1alpha1.FilterR\x06filter\"\x9a\x01\n" +
 	"\x12QueryRangeResponse\x12;\n" +
-	"\x06series\x18\x01 \x03(\v2#.parca.query.v1alpha1.MetricsSeriesR\x06series\"\x95\x02\n" +
+	"\x06series\x18\x01 \x03(\v2#.parca.query.v1alpha1.MetricsSeriesR\x06series\x12G\n" +
+	"\vannotations\x18\x02 \x03(\v2%.parca.annotation.v1alpha1.AnnotationR\vannotations\"\x95\x02\n" +
 	"\rMetricsSeries\x12A\n" +
 	"\blabelset\x18\x01 \x01(\v2%.parca.profilestore.v1alpha1.LabelSetR\blabelset\x12=\n" +
 	"\asamples\x18\x02 \x03(\v2#.parca.query.v1alpha1.MetricsSampleR\asamples\x12@\n" +
@@ -4039,14 +5808,20 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\fMergeProfile\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x120\n" +
 	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"U\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xb3\x01\n" +
+	"\x12MultiMetricProfile\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12'\n" +
+	"\x0fsecondary_query\x18\x02 \x01(\tR\x0esecondaryQuery\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"U\n" +
 	"\rSingleProfile\x12.\n" +
 	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\"\xaf\x01\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\"\xd3\x01\n" +
 	"\vDiffProfile\x128\n" +
 	"\x01a\x18\x01 \x01(\v2*.parca.query.v1alpha1.ProfileDiffSelectionR\x01a\x128\n" +
 	"\x01b\x18\x02 \x01(\v2*.parca.query.v1alpha1.ProfileDiffSelectionR\x01b\x12\x1f\n" +
-	"\babsolute\x18\x03 \x01(\bH\x00R\babsolute\x88\x01\x01B\v\n" +
+	"\babsolute\x18\x03 \x01(\bH\x00R\babsolute\x88\x01\x01\x12\"\n" +
+	"\fsignificance\x18\x04 \x01(\bR\fsignificanceB\v\n" +
 	"\t_absolute\"\x96\x02\n" +
 	"\x14ProfileDiffSelection\x12C\n" +
 	"\x04mode\x18\x01 \x01(\x0e2/.parca.query.v1alpha1.ProfileDiffSelection.ModeR\x04mode\x12:\n" +
@@ -4056,13 +5831,13 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x17MODE_SINGLE_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
 	"MODE_MERGE\x10\x01B\t\n" +
-	"\aoptions\"\xce\n" +
-	"\n" +
+	"\aoptions\"\xd7\x14\n" +
 	"\fQueryRequest\x12;\n" +
 	"\x04mode\x18\x01 \x01(\x0e2'.parca.query.v1alpha1.QueryRequest.ModeR\x04mode\x127\n" +
 	"\x04diff\x18\x02 \x01(\v2!.parca.query.v1alpha1.DiffProfileH\x00R\x04diff\x12:\n" +
 	"\x05merge\x18\x03 \x01(\v2\".parca.query.v1alpha1.MergeProfileH\x00R\x05merge\x12=\n" +
-	"\x06single\x18\x04 \x01(\v2#.parca.query.v1alpha1.SingleProfileH\x00R\x06single\x12N\n" +
+	"\x06single\x18\x04 \x01(\v2#.parca.query.v1alpha1.SingleProfileH\x00R\x06single\x12M\n" +
+	"\fmulti_metric\x18\x1c \x01(\v2(.parca.query.v1alpha1.MultiMetricProfileH\x00R\vmultiMetric\x12N\n" +
 	"\vreport_type\x18\x05 \x01(\x0e2-.parca.query.v1alpha1.QueryRequest.ReportTypeR\n" +
 	"reportType\x12*\n" +
 	"\ffilter_query\x18\x06 \x01(\tB\x02\x18\x01H\x01R\vfilterQuery\x88\x01\x01\x123\n" +
@@ -4073,12 +5848,30 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	" \x01(\v2#.parca.query.v1alpha1.RuntimeFilterB\x02\x18\x01H\x05R\rruntimeFilter\x88\x01\x01\x12/\n" +
 	"\x11invert_call_stack\x18\v \x01(\bH\x06R\x0finvertCallStack\x88\x01\x01\x124\n" +
 	"\x06filter\x18\f \x03(\v2\x1c.parca.query.v1alpha1.FilterR\x06filter\x125\n" +
-	"\x14sandwich_by_function\x18\r \x01(\tH\aR\x12sandwichByFunction\x88\x01\x01\"B\n" +
+	"\x14sandwich_by_function\x18\r \x01(\tH\aR\x12sandwichByFunction\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"node_count\x18\x0e \x01(\rH\bR\tnodeCount\x88\x01\x01\x12\x19\n" +
+	"\x05focus\x18\x0f \x01(\tH\tR\x05focus\x88\x01\x01\x12\x1b\n" +
+	"\x06ignore\x18\x10 \x01(\tH\n" +
+	"R\x06ignore\x88\x01\x01\x12\x17\n" +
+	"\x04hide\x18\x11 \x01(\tH\vR\x04hide\x88\x01\x01\x12\x17\n" +
+	"\x04show\x18\x12 \x01(\tH\fR\x04show\x88\x01\x01\x12 \n" +
+	"\tshow_from\x18\x13 \x01(\tH\rR\bshowFrom\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"prune_from\x18\x14 \x01(\tH\x0eR\tpruneFrom\x88\x01\x01\x12=\n" +
+	"\x18callers_callees_function\x18\x15 \x01(\tH\x0fR\x16callersCalleesFunction\x88\x01\x01\x12d\n" +
+	"\x15disassembly_reference\x18\x16 \x01(\v2*.parca.query.v1alpha1.DisassemblyReferenceH\x10R\x14disassemblyReference\x88\x01\x01\x12=\n" +
+	"\x18label_breakdown_function\x18\x17 \x01(\tH\x11R\x16labelBreakdownFunction\x88\x01\x01\x127\n" +
+	"\x15label_breakdown_label\x18\x18 \x01(\tH\x12R\x13labelBreakdownLabel\x88\x01\x01\x125\n" +
+	"\x14heatmap_bucket_count\x18\x19 \x01(\rH\x13R\x12heatmapBucketCount\x88\x01\x01\x129\n" +
+	"\x16heatmap_function_count\x18\x1a \x01(\rH\x14R\x14heatmapFunctionCount\x88\x01\x01\x12+\n" +
+	"\x0fheatmap_by_root\x18\x1b \x01(\bH\x15R\rheatmapByRoot\x88\x01\x01\"Y\n" +
 	"\x04Mode\x12\x1b\n" +
 	"\x17MODE_SINGLE_UNSPECIFIED\x10\x00\x12\r\n" +
 	"\tMODE_DIFF\x10\x01\x12\x0e\n" +
 	"\n" +
-	"MODE_MERGE\x10\x02\"\xb6\x02\n" +
+	"MODE_MERGE\x10\x02\x12\x15\n" +
+	"\x11MODE_MULTI_METRIC\x10\x03\"\xc0\x04\n" +
 	"\n" +
 	"ReportType\x12*\n" +
 	"\"REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED\x10\x00\x1a\x02\b\x01\x12\x15\n" +
@@ -4090,7 +5883,16 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x12REPORT_TYPE_SOURCE\x10\x06\x12\x1b\n" +
 	"\x17REPORT_TYPE_TABLE_ARROW\x10\a\x12 \n" +
 	"\x1cREPORT_TYPE_PROFILE_METADATA\x10\b\x12\x1a\n" +
-	"\x16REPORT_TYPE_FLAMECHART\x10\tB\t\n" +
+	"\x16REPORT_TYPE_FLAMECHART\x10\t\x12\x16\n" +
+	"\x12REPORT_TYPE_FOLDED\x10\n" +
+	"\x12\x1a\n" +
+	"\x16REPORT_TYPE_SPEEDSCOPE\x10\v\x12\x1c\n" +
+	"\x18REPORT_TYPE_CHROME_TRACE\x10\f\x12\x1d\n" +
+	"\x19REPORT_TYPE_CALLGRAPH_DOT\x10\r\x12\x1f\n" +
+	"\x1bREPORT_TYPE_CALLERS_CALLEES\x10\x0f\x12\x1b\n" +
+	"\x17REPORT_TYPE_DISASSEMBLY\x10\x10\x12\x1f\n" +
+	"\x1bREPORT_TYPE_LABEL_BREAKDOWN\x10\x11\x12\x17\n" +
+	"\x13REPORT_TYPE_HEATMAP\x10\x12\"\x04\b\x0e\x10\x0e*\x19REPORT_TYPE_CALLGRAPH_SVGB\t\n" +
 	"\aoptionsB\x0f\n" +
 	"\r_filter_queryB\x16\n" +
 	"\x14_node_trim_thresholdB\v\n" +
@@ -4098,7 +5900,25 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x11_source_referenceB\x11\n" +
 	"\x0f_runtime_filterB\x14\n" +
 	"\x12_invert_call_stackB\x17\n" +
-	"\x15_sandwich_by_function\"\xaf\x03\n" +
+	"\x15_sandwich_by_functionB\r\n" +
+	"\v_node_countB\b\n" +
+	"\x06_focusB\t\n" +
+	"\a_ignoreB\a\n" +
+	"\x05_hideB\a\n" +
+	"\x05_showB\f\n" +
+	"\n" +
+	"_show_fromB\r\n" +
+	"\v_prune_fromB\x1b\n" +
+	"\x19_callers_callees_functionB\x18\n" +
+	"\x16_disassembly_referenceB\x1b\n" +
+	"\x19_label_breakdown_functionB\x18\n" +
+	"\x16_label_breakdown_labelB\x17\n" +
+	"\x15_heatmap_bucket_countB\x19\n" +
+	"\x17_heatmap_function_countB\x12\n" +
+	"\x10_heatmap_by_root\"K\n" +
+	"\x14DisassemblyReference\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\x04R\aaddress\"\xaf\x03\n" +
 	"\x0eFilterCriteria\x12J\n" +
 	"\rfunction_name\x18\x01 \x01(\v2%.parca.query.v1alpha1.StringConditionR\ffunctionName\x12F\n" +
 	"\vsystem_name\x18\x02 \x01(\v2%.parca.query.v1alpha1.StringConditionR\n" +
@@ -4107,7 +5927,7 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\bfilename\x18\x04 \x01(\v2%.parca.query.v1alpha1.StringConditionR\bfilename\x12?\n" +
 	"\aaddress\x18\x05 \x01(\v2%.parca.query.v1alpha1.NumberConditionR\aaddress\x12F\n" +
 	"\vline_number\x18\x06 \x01(\v2%.parca.query.v1alpha1.NumberConditionR\n" +
-	"lineNumber\"\xe5\x01\n" +
+	"lineNumber\"\xba\x02\n" +
 	"\x0fStringCondition\x12\x16\n" +
 	"\x05equal\x18\x01 \x01(\tH\x00R\x05equal\x12\x1d\n" +
 	"\tnot_equal\x18\x02 \x01(\tH\x00R\bnotEqual\x12\x1c\n" +
@@ -4115,12 +5935,20 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\fnot_contains\x18\x04 \x01(\tH\x00R\vnotContains\x12!\n" +
 	"\vstarts_with\x18\x05 \x01(\tH\x00R\n" +
 	"startsWith\x12(\n" +
-	"\x0fnot_starts_with\x18\x06 \x01(\tH\x00R\rnotStartsWithB\v\n" +
-	"\tcondition\"U\n" +
+	"\x0fnot_starts_with\x18\x06 \x01(\tH\x00R\rnotStartsWith\x12%\n" +
+	"\rmatches_regex\x18\a \x01(\tH\x00R\fmatchesRegex\x12,\n" +
+	"\x11not_matches_regex\x18\b \x01(\tH\x00R\x0fnotMatchesRegexB\v\n" +
+	"\tcondition\"\xd4\x01\n" +
 	"\x0fNumberCondition\x12\x16\n" +
 	"\x05equal\x18\x01 \x01(\x04H\x00R\x05equal\x12\x1d\n" +
-	"\tnot_equal\x18\x02 \x01(\x04H\x00R\bnotEqualB\v\n" +
-	"\tcondition\"\xa2\x01\n" +
+	"\tnot_equal\x18\x02 \x01(\x04H\x00R\bnotEqual\x12#\n" +
+	"\fgreater_than\x18\x03 \x01(\x04H\x00R\vgreaterThan\x12\x1d\n" +
+	"\tless_than\x18\x04 \x01(\x04H\x00R\blessThan\x129\n" +
+	"\x05range\x18\x05 \x01(\v2!.parca.query.v1alpha1.NumberRangeH\x00R\x05rangeB\v\n" +
+	"\tcondition\"5\n" +
+	"\vNumberRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x04R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x04R\x03end\"\xa2\x01\n" +
 	"\x06Filter\x12F\n" +
 	"\fstack_filter\x18\x01 \x01(\v2!.parca.query.v1alpha1.StackFilterH\x00R\vstackFilter\x12F\n" +
 	"\fframe_filter\x18\x02 \x01(\v2!.parca.query.v1alpha1.FrameFilterH\x00R\vframeFilterB\b\n" +
@@ -4221,7 +6049,7 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\blocation\x18\x01 \x01(\v2\".parca.metastore.v1alpha1.LocationR\blocation\x12;\n" +
 	"\amapping\x18\x02 \x01(\v2!.parca.metastore.v1alpha1.MappingR\amapping\x12>\n" +
 	"\bfunction\x18\x03 \x01(\v2\".parca.metastore.v1alpha1.FunctionR\bfunction\x122\n" +
-	"\x04line\x18\x04 \x01(\v2\x1e.parca.metastore.v1alpha1.LineR\x04line\"\x92\x01\n" +
+	"\x04line\x18\x04 \x01(\v2\x1e.parca.metastore.v1alpha1.LineR\x04line\"\xb1\x01\n" +
 	"\rCallgraphEdge\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x16\n" +
@@ -4229,13 +6057,75 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\n" +
 	"cumulative\x18\x04 \x01(\x03R\n" +
 	"cumulative\x12!\n" +
-	"\fis_collapsed\x18\x05 \x01(\bR\visCollapsed\"\xa5\x01\n" +
+	"\fis_collapsed\x18\x05 \x01(\bR\visCollapsed\x12\x1d\n" +
+	"\n" +
+	"is_inlined\x18\x06 \x01(\bR\tisInlined\"\xb7\x01\n" +
+	"\vDisassembly\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x04R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x04R\x03end\x12P\n" +
+	"\finstructions\x18\x04 \x03(\v2,.parca.query.v1alpha1.DisassemblyInstructionR\finstructions\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\"\xaa\x01\n" +
+	"\x16DisassemblyInstruction\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\x04R\aaddress\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04flat\x18\x03 \x01(\x03R\x04flat\x12\x1e\n" +
+	"\n" +
+	"cumulative\x18\x04 \x01(\x03R\n" +
+	"cumulative\x12\x1a\n" +
+	"\bfilename\x18\x05 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04line\x18\x06 \x01(\x03R\x04line\"\x87\x01\n" +
+	"\x0eLabelBreakdown\x12A\n" +
+	"\x06labels\x18\x01 \x03(\v2).parca.query.v1alpha1.LabelBreakdownLabelR\x06labels\x12\x1e\n" +
+	"\n" +
+	"cumulative\x18\x02 \x01(\x03R\n" +
+	"cumulative\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\"l\n" +
+	"\x13LabelBreakdownLabel\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12A\n" +
+	"\x06values\x18\x02 \x03(\v2).parca.query.v1alpha1.LabelBreakdownValueR\x06values\"k\n" +
+	"\x13LabelBreakdownValue\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1e\n" +
+	"\n" +
+	"cumulative\x18\x02 \x01(\x03R\n" +
+	"cumulative\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x03 \x01(\x01R\n" +
+	"percentage\"\xe1\x01\n" +
+	"\aHeatmap\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12B\n" +
+	"\x0fbucket_duration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0ebucketDuration\x12\x16\n" +
+	"\x06totals\x18\x03 \x03(\x03R\x06totals\x124\n" +
+	"\x04rows\x18\x04 \x03(\v2 .parca.query.v1alpha1.HeatmapRowR\x04rows\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\"N\n" +
+	"\n" +
+	"HeatmapRow\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\x03R\x06values\"p\n" +
+	"\x0eCallersCallees\x12J\n" +
+	"\tfunctions\x18\x01 \x03(\v2,.parca.query.v1alpha1.CallersCalleesFunctionR\tfunctions\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\"\xe9\x01\n" +
+	"\x16CallersCalleesFunction\x12E\n" +
+	"\bfunction\x18\x01 \x01(\v2).parca.query.v1alpha1.CallersCalleesEntryR\bfunction\x12C\n" +
+	"\acallers\x18\x02 \x03(\v2).parca.query.v1alpha1.CallersCalleesEntryR\acallers\x12C\n" +
+	"\acallees\x18\x03 \x03(\v2).parca.query.v1alpha1.CallersCalleesEntryR\acallees\"\xeb\x01\n" +
+	"\x13CallersCalleesEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04flat\x18\x03 \x01(\x03R\x04flat\x12\x1e\n" +
+	"\n" +
+	"cumulative\x18\x04 \x01(\x03R\n" +
+	"cumulative\x12'\n" +
+	"\x0fflat_percentage\x18\x05 \x01(\x01R\x0eflatPercentage\x123\n" +
+	"\x15cumulative_percentage\x18\x06 \x01(\x01R\x14cumulativePercentage\x12\x12\n" +
+	"\x04diff\x18\a \x01(\x03R\x04diff\"\xa5\x01\n" +
 	"\tCallgraph\x129\n" +
 	"\x05nodes\x18\x01 \x03(\v2#.parca.query.v1alpha1.CallgraphNodeR\x05nodes\x129\n" +
 	"\x05edges\x18\x02 \x03(\v2#.parca.query.v1alpha1.CallgraphEdgeR\x05edges\x12\"\n" +
 	"\n" +
 	"cumulative\x18\x03 \x01(\x03B\x02\x18\x01R\n" +
-	"cumulative\"\xbc\x04\n" +
+	"cumulative\"\xfd\a\n" +
 	"\rQueryResponse\x12B\n" +
 	"\n" +
 	"flamegraph\x18\x05 \x01(\v2 .parca.query.v1alpha1.FlamegraphH\x00R\n" +
@@ -4247,16 +6137,33 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x06source\x18\f \x01(\v2\x1c.parca.query.v1alpha1.SourceH\x00R\x06source\x12C\n" +
 	"\vtable_arrow\x18\r \x01(\v2 .parca.query.v1alpha1.TableArrowH\x00R\n" +
 	"tableArrow\x12R\n" +
-	"\x10profile_metadata\x18\x0e \x01(\v2%.parca.query.v1alpha1.ProfileMetadataH\x00R\x0fprofileMetadata\x12\x14\n" +
+	"\x10profile_metadata\x18\x0e \x01(\v2%.parca.query.v1alpha1.ProfileMetadataH\x00R\x0fprofileMetadata\x12\x18\n" +
+	"\x06folded\x18\x0f \x01(\fH\x00R\x06folded\x12 \n" +
+	"\n" +
+	"speedscope\x18\x10 \x01(\fH\x00R\n" +
+	"speedscope\x12#\n" +
+	"\fchrome_trace\x18\x11 \x01(\fH\x00R\vchromeTrace\x12%\n" +
+	"\rcallgraph_dot\x18\x12 \x01(\fH\x00R\fcallgraphDot\x12O\n" +
+	"\x0fcallers_callees\x18\x14 \x01(\v2$.parca.query.v1alpha1.CallersCalleesH\x00R\x0ecallersCallees\x12E\n" +
+	"\vdisassembly\x18\x15 \x01(\v2!.parca.query.v1alpha1.DisassemblyH\x00R\vdisassembly\x12O\n" +
+	"\x0flabel_breakdown\x18\x16 \x01(\v2$.parca.query.v1alpha1.LabelBreakdownH\x00R\x0elabelBreakdown\x129\n" +
+	"\aheatmap\x18\x17 \x01(\v2\x1d.parca.query.v1alpha1.HeatmapH\x00R\aheatmap\x12\x14\n" +
 	"\x05total\x18\t \x01(\x03R\x05total\x12\x1a\n" +
 	"\bfiltered\x18\n" +
 	" \x01(\x03R\bfilteredB\b\n" +
-	"\x06report\"\x85\x01\n" +
+	"\x06reportJ\x04\b\x13\x10\x14R\rcallgraph_svg\"\x85\x01\n" +
 	"\rSeriesRequest\x12\x14\n" +
 	"\x05match\x18\x01 \x03(\tR\x05match\x120\n" +
 	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
-	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\x10\n" +
-	"\x0eSeriesResponse\"\xbe\x01\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"F\n" +
+	"\x0eSeriesResponse\x124\n" +
+	"\x06series\x18\x01 \x03(\v2\x1c.parca.query.v1alpha1.SeriesR\x06series\"\x85\x02\n" +
+	"\x06Series\x12A\n" +
+	"\blabelset\x18\x01 \x01(\v2%.parca.profilestore.v1alpha1.LabelSetR\blabelset\x12D\n" +
+	"\fprofile_type\x18\x02 \x01(\v2!.parca.query.v1alpha1.ProfileTypeR\vprofileType\x129\n" +
+	"\n" +
+	"first_seen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\xbe\x01\n" +
 	"\rLabelsRequest\x12\x14\n" +
 	"\x05match\x18\x01 \x03(\tR\x05match\x120\n" +
 	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
@@ -4296,7 +6203,31 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x06labels\x18\x02 \x03(\tR\x06labels\"\x17\n" +
 	"\x15HasProfileDataRequest\"3\n" +
 	"\x16HasProfileDataResponse\x12\x19\n" +
-	"\bhas_data\x18\x01 \x01(\bR\ahasData2\xf1\a\n" +
+	"\bhas_data\x18\x01 \x01(\bR\ahasData\"\x90\x03\n" +
+	"\x15TopRegressionsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12A\n" +
+	"\x0ebaseline_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rbaselineStart\x12=\n" +
+	"\fbaseline_end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vbaselineEnd\x12C\n" +
+	"\x0fcandidate_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecandidateStart\x12?\n" +
+	"\rcandidate_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcandidateEnd\x12.\n" +
+	"\x10min_significance\x18\x06 \x01(\x01H\x00R\x0fminSignificance\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\a \x01(\rR\x05limitB\x13\n" +
+	"\x11_min_significance\"\xeb\x01\n" +
+	"\x16TopRegressionsResponse\x12E\n" +
+	"\vregressions\x18\x01 \x03(\v2#.parca.query.v1alpha1.TopRegressionR\vregressions\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\x129\n" +
+	"\x19baseline_total_per_second\x18\x03 \x01(\x01R\x16baselineTotalPerSecond\x12;\n" +
+	"\x1acandidate_total_per_second\x18\x04 \x01(\x01R\x17candidateTotalPerSecond\"\xdc\x03\n" +
+	"\rTopRegression\x12#\n" +
+	"\rfunction_name\x18\x01 \x01(\tR\ffunctionName\x12,\n" +
+	"\x12function_file_name\x18\x02 \x01(\tR\x10functionFileName\x127\n" +
+	"\x18baseline_flat_per_second\x18\x03 \x01(\x01R\x15baselineFlatPerSecond\x12C\n" +
+	"\x1ebaseline_cumulative_per_second\x18\x04 \x01(\x01R\x1bbaselineCumulativePerSecond\x129\n" +
+	"\x19candidate_flat_per_second\x18\x05 \x01(\x01R\x16candidateFlatPerSecond\x12E\n" +
+	"\x1fcandidate_cumulative_per_second\x18\x06 \x01(\x01R\x1ccandidateCumulativePerSecond\x12*\n" +
+	"\x11flat_share_change\x18\a \x01(\x01R\x0fflatShareChange\x126\n" +
+	"\x17cumulative_share_change\x18\b \x01(\x01R\x15cumulativeShareChange\x12\x14\n" +
+	"\x05score\x18\t \x01(\x01R\x05score2\x82\t\n" +
 	"\fQueryService\x12~\n" +
 	"\n" +
 	"QueryRange\x12'.parca.query.v1alpha1.QueryRangeRequest\x1a(.parca.query.v1alpha1.QueryRangeResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/profiles/query_range\x12i\n" +
@@ -4306,7 +6237,8 @@ const file_parca_query_v1alpha1_query_proto_rawDesc = "" +
 	"\x06Labels\x12#.parca.query.v1alpha1.LabelsRequest\x1a$.parca.query.v1alpha1.LabelsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/profiles/labels\x12\x81\x01\n" +
 	"\x06Values\x12#.parca.query.v1alpha1.ValuesRequest\x1a$.parca.query.v1alpha1.ValuesResponse\",\x82\xd3\xe4\x93\x02&\x12$/profiles/labels/{label_name}/values\x12\x81\x01\n" +
 	"\fShareProfile\x12).parca.query.v1alpha1.ShareProfileRequest\x1a*.parca.query.v1alpha1.ShareProfileResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/profiles/share\x12\x8f\x01\n" +
-	"\x0eHasProfileData\x12+.parca.query.v1alpha1.HasProfileDataRequest\x1a,.parca.query.v1alpha1.HasProfileDataResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/profiles/has_profile_dataB\xe4\x01\n" +
+	"\x0eHasProfileData\x12+.parca.query.v1alpha1.HasProfileDataRequest\x1a,.parca.query.v1alpha1.HasProfileDataResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/profiles/has_profile_data\x12\x8e\x01\n" +
+	"\x0eTopRegressions\x12+.parca.query.v1alpha1.TopRegressionsRequest\x1a,.parca.query.v1alpha1.TopRegressionsResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/profiles/top_regressionsB\xe4\x01\n" +
 	"\x18com.parca.query.v1alpha1B\n" +
 	"QueryProtoP\x01ZJgithub.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1;queryv1alpha1\xa2\x02\x03PQX\xaa\x02\x14Parca.Query.V1alpha1\xca\x02\x14Parca\\Query\\V1alpha1\xe2\x02 Parca\\Query\\V1alpha1\\GPBMetadata\xea\x02\x16Parca::Query::V1alpha1b\x06proto3"
 
@@ -4323,7 +6255,7 @@ func file_parca_query_v1alpha1_query_proto_rawDescGZIP() []byte {
 }
 
 var file_parca_query_v1alpha1_query_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_parca_query_v1alpha1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_parca_query_v1alpha1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_parca_query_v1alpha1_query_proto_goTypes = []any{
 	(ProfileDiffSelection_Mode)(0),  // 0: parca.query.v1alpha1.ProfileDiffSelection.Mode
 	(QueryRequest_Mode)(0),          // 1: parca.query.v1alpha1.QueryRequest.Mode
@@ -4336,157 +6268,208 @@ var file_parca_query_v1alpha1_query_proto_goTypes = []any{
 	(*MetricsSeries)(nil),           // 8: parca.query.v1alpha1.MetricsSeries
 	(*MetricsSample)(nil),           // 9: parca.query.v1alpha1.MetricsSample
 	(*MergeProfile)(nil),            // 10: parca.query.v1alpha1.MergeProfile
-	(*SingleProfile)(nil),           // 11: parca.query.v1alpha1.SingleProfile
-	(*DiffProfile)(nil),             // 12: parca.query.v1alpha1.DiffProfile
-	(*ProfileDiffSelection)(nil),    // 13: parca.query.v1alpha1.ProfileDiffSelection
-	(*QueryRequest)(nil),            // 14: parca.query.v1alpha1.QueryRequest
-	(*FilterCriteria)(nil),          // 15: parca.query.v1alpha1.FilterCriteria
-	(*StringCondition)(nil),         // 16: parca.query.v1alpha1.StringCondition
-	(*NumberCondition)(nil),         // 17: parca.query.v1alpha1.NumberCondition
-	(*Filter)(nil),                  // 18: parca.query.v1alpha1.Filter
-	(*StackFilter)(nil),             // 19: parca.query.v1alpha1.StackFilter
-	(*FunctionNameStackFilter)(nil), // 20: parca.query.v1alpha1.FunctionNameStackFilter
-	(*FrameFilter)(nil),             // 21: parca.query.v1alpha1.FrameFilter
-	(*BinaryFrameFilter)(nil),       // 22: parca.query.v1alpha1.BinaryFrameFilter
-	(*RuntimeFilter)(nil),           // 23: parca.query.v1alpha1.RuntimeFilter
-	(*SourceReference)(nil),         // 24: parca.query.v1alpha1.SourceReference
-	(*GroupBy)(nil),                 // 25: parca.query.v1alpha1.GroupBy
-	(*Top)(nil),                     // 26: parca.query.v1alpha1.Top
-	(*TopNode)(nil),                 // 27: parca.query.v1alpha1.TopNode
-	(*TopNodeMeta)(nil),             // 28: parca.query.v1alpha1.TopNodeMeta
-	(*Flamegraph)(nil),              // 29: parca.query.v1alpha1.Flamegraph
-	(*FlamegraphArrow)(nil),         // 30: parca.query.v1alpha1.FlamegraphArrow
-	(*Source)(nil),                  // 31: parca.query.v1alpha1.Source
-	(*FlamegraphRootNode)(nil),      // 32: parca.query.v1alpha1.FlamegraphRootNode
-	(*FlamegraphNode)(nil),          // 33: parca.query.v1alpha1.FlamegraphNode
-	(*FlamegraphNodeMeta)(nil),      // 34: parca.query.v1alpha1.FlamegraphNodeMeta
-	(*CallgraphNode)(nil),           // 35: parca.query.v1alpha1.CallgraphNode
-	(*CallgraphNodeMeta)(nil),       // 36: parca.query.v1alpha1.CallgraphNodeMeta
-	(*CallgraphEdge)(nil),           // 37: parca.query.v1alpha1.CallgraphEdge
-	(*Callgraph)(nil),               // 38: parca.query.v1alpha1.Callgraph
-	(*QueryResponse)(nil),           // 39: parca.query.v1alpha1.QueryResponse
-	(*SeriesRequest)(nil),           // 40: parca.query.v1alpha1.SeriesRequest
-	(*SeriesResponse)(nil),          // 41: parca.query.v1alpha1.SeriesResponse
-	(*LabelsRequest)(nil),           // 42: parca.query.v1alpha1.LabelsRequest
-	(*LabelsResponse)(nil),          // 43: parca.query.v1alpha1.LabelsResponse
-	(*ValuesRequest)(nil),           // 44: parca.query.v1alpha1.ValuesRequest
-	(*ValuesResponse)(nil),          // 45: parca.query.v1alpha1.ValuesResponse
-	(*ValueType)(nil),               // 46: parca.query.v1alpha1.ValueType
-	(*ShareProfileRequest)(nil),     // 47: parca.query.v1alpha1.ShareProfileRequest
-	(*ShareProfileResponse)(nil),    // 48: parca.query.v1alpha1.ShareProfileResponse
-	(*TableArrow)(nil),              // 49: parca.query.v1alpha1.TableArrow
-	(*ProfileMetadata)(nil),         // 50: parca.query.v1alpha1.ProfileMetadata
-	(*HasProfileDataRequest)(nil),   // 51: parca.query.v1alpha1.HasProfileDataRequest
-	(*HasProfileDataResponse)(nil),  // 52: parca.query.v1alpha1.HasProfileDataResponse
-	(*timestamppb.Timestamp)(nil),   // 53: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),     // 54: google.protobuf.Duration
-	(*v1alpha1.LabelSet)(nil),       // 55: parca.profilestore.v1alpha1.LabelSet
-	(*v1alpha11.Location)(nil),      // 56: parca.metastore.v1alpha1.Location
-	(*v1alpha11.Mapping)(nil),       // 57: parca.metastore.v1alpha1.Mapping
-	(*v1alpha11.Function)(nil),      // 58: parca.metastore.v1alpha1.Function
-	(*v1alpha11.Line)(nil),          // 59: parca.metastore.v1alpha1.Line
+	(*MultiMetricProfile)(nil),      // 11: parca.query.v1alpha1.MultiMetricProfile
+	(*SingleProfile)(nil),           // 12: parca.query.v1alpha1.SingleProfile
+	(*DiffProfile)(nil),             // 13: parca.query.v1alpha1.DiffProfile
+	(*ProfileDiffSelection)(nil),    // 14: parca.query.v1alpha1.ProfileDiffSelection
+	(*QueryRequest)(nil),            // 15: parca.query.v1alpha1.QueryRequest
+	(*DisassemblyReference)(nil),    // 16: parca.query.v1alpha1.DisassemblyReference
+	(*FilterCriteria)(nil),          // 17: parca.query.v1alpha1.FilterCriteria
+	(*StringCondition)(nil),         // 18: parca.query.v1alpha1.StringCondition
+	(*NumberCondition)(nil),         // 19: parca.query.v1alpha1.NumberCondition
+	(*NumberRange)(nil),             // 20: parca.query.v1alpha1.NumberRange
+	(*Filter)(nil),                  // 21: parca.query.v1alpha1.Filter
+	(*StackFilter)(nil),             // 22: parca.query.v1alpha1.StackFilter
+	(*FunctionNameStackFilter)(nil), // 23: parca.query.v1alpha1.FunctionNameStackFilter
+	(*FrameFilter)(nil),             // 24: parca.query.v1alpha1.FrameFilter
+	(*BinaryFrameFilter)(nil),       // 25: parca.query.v1alpha1.BinaryFrameFilter
+	(*RuntimeFilter)(nil),           // 26: parca.query.v1alpha1.RuntimeFilter
+	(*SourceReference)(nil),         // 27: parca.query.v1alpha1.SourceReference
+	(*GroupBy)(nil),                 // 28: parca.query.v1alpha1.GroupBy
+	(*Top)(nil),                     // 29: parca.query.v1alpha1.Top
+	(*TopNode)(nil),                 // 30: parca.query.v1alpha1.TopNode
+	(*TopNodeMeta)(nil),             // 31: parca.query.v1alpha1.TopNodeMeta
+	(*Flamegraph)(nil),              // 32: parca.query.v1alpha1.Flamegraph
+	(*FlamegraphArrow)(nil),         // 33: parca.query.v1alpha1.FlamegraphArrow
+	(*Source)(nil),                  // 34: parca.query.v1alpha1.Source
+	(*FlamegraphRootNode)(nil),      // 35: parca.query.v1alpha1.FlamegraphRootNode
+	(*FlamegraphNode)(nil),          // 36: parca.query.v1alpha1.FlamegraphNode
+	(*FlamegraphNodeMeta)(nil),      // 37: parca.query.v1alpha1.FlamegraphNodeMeta
+	(*CallgraphNode)(nil),           // 38: parca.query.v1alpha1.CallgraphNode
+	(*CallgraphNodeMeta)(nil),       // 39: parca.query.v1alpha1.CallgraphNodeMeta
+	(*CallgraphEdge)(nil),           // 40: parca.query.v1alpha1.CallgraphEdge
+	(*Disassembly)(nil),             // 41: parca.query.v1alpha1.Disassembly
+	(*DisassemblyInstruction)(nil),  // 42: parca.query.v1alpha1.DisassemblyInstruction
+	(*LabelBreakdown)(nil),          // 43: parca.query.v1alpha1.LabelBreakdown
+	(*LabelBreakdownLabel)(nil),     // 44: parca.query.v1alpha1.LabelBreakdownLabel
+	(*LabelBreakdownValue)(nil),     // 45: parca.query.v1alpha1.LabelBreakdownValue
+	(*Heatmap)(nil),                 // 46: parca.query.v1alpha1.Heatmap
+	(*HeatmapRow)(nil),              // 47: parca.query.v1alpha1.HeatmapRow
+	(*CallersCallees)(nil),          // 48: parca.query.v1alpha1.CallersCallees
+	(*CallersCalleesFunction)(nil),  // 49: parca.query.v1alpha1.CallersCalleesFunction
+	(*CallersCalleesEntry)(nil),     // 50: parca.query.v1alpha1.CallersCalleesEntry
+	(*Callgraph)(nil),               // 51: parca.query.v1alpha1.Callgraph
+	(*QueryResponse)(nil),           // 52: parca.query.v1alpha1.QueryResponse
+	(*SeriesRequest)(nil),           // 53: parca.query.v1alpha1.SeriesRequest
+	(*SeriesResponse)(nil),          // 54: parca.query.v1alpha1.SeriesResponse
+	(*Series)(nil),                  // 55: parca.query.v1alpha1.Series
+	(*LabelsRequest)(nil),           // 56: parca.query.v1alpha1.LabelsRequest
+	(*LabelsResponse)(nil),          // 57: parca.query.v1alpha1.LabelsResponse
+	(*ValuesRequest)(nil),           // 58: parca.query.v1alpha1.ValuesRequest
+	(*ValuesResponse)(nil),          // 59: parca.query.v1alpha1.ValuesResponse
+	(*ValueType)(nil),               // 60: parca.query.v1alpha1.ValueType
+	(*ShareProfileRequest)(nil),     // 61: parca.query.v1alpha1.ShareProfileRequest
+	(*ShareProfileResponse)(nil),    // 62: parca.query.v1alpha1.ShareProfileResponse
+	(*TableArrow)(nil),              // 63: parca.query.v1alpha1.TableArrow
+	(*ProfileMetadata)(nil),         // 64: parca.query.v1alpha1.ProfileMetadata
+	(*HasProfileDataRequest)(nil),   // 65: parca.query.v1alpha1.HasProfileDataRequest
+	(*HasProfileDataResponse)(nil),  // 66: parca.query.v1alpha1.HasProfileDataResponse
+	(*TopRegressionsRequest)(nil),   // 67: parca.query.v1alpha1.TopRegressionsRequest
+	(*TopRegressionsResponse)(nil),  // 68: parca.query.v1alpha1.TopRegressionsResponse
+	(*TopRegression)(nil),           // 69: parca.query.v1alpha1.TopRegression
+	(*timestamppb.Timestamp)(nil),   // 70: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 71: google.protobuf.Duration
+	(*v1alpha1.Annotation)(nil),     // 72: parca.annotation.v1alpha1.Annotation
+	(*v1alpha11.LabelSet)(nil),      // 73: parca.profilestore.v1alpha1.LabelSet
+	(*v1alpha12.Location)(nil),      // 74: parca.metastore.v1alpha1.Location
+	(*v1alpha12.Mapping)(nil),       // 75: parca.metastore.v1alpha1.Mapping
+	(*v1alpha12.Function)(nil),      // 76: parca.metastore.v1alpha1.Function
+	(*v1alpha12.Line)(nil),          // 77: parca.metastore.v1alpha1.Line
 }
 var file_parca_query_v1alpha1_query_proto_depIdxs = []int32{
-	53, // 0: parca.query.v1alpha1.ProfileTypesRequest.start:type_name -> google.protobuf.Timestamp
-	53, // 1: parca.query.v1alpha1.ProfileTypesRequest.end:type_name -> google.protobuf.Timestamp
-	5,  // 2: parca.query.v1alpha1.ProfileTypesResponse.types:type_name -> parca.query.v1alpha1.ProfileType
-	53, // 3: parca.query.v1alpha1.QueryRangeRequest.start:type_name -> google.protobuf.Timestamp
-	53, // 4: parca.query.v1alpha1.QueryRangeRequest.end:type_name -> google.protobuf.Timestamp
-	54, // 5: parca.query.v1alpha1.QueryRangeRequest.step:type_name -> google.protobuf.Duration
-	8,  // 6: parca.query.v1alpha1.QueryRangeResponse.series:type_name -> parca.query.v1alpha1.MetricsSeries
-	55, // 7: parca.query.v1alpha1.MetricsSeries.labelset:type_name -> parca.profilestore.v1alpha1.LabelSet
-	9,  // 8: parca.query.v1alpha1.MetricsSeries.samples:type_name -> parca.query.v1alpha1.MetricsSample
-	46, // 9: parca.query.v1alpha1.MetricsSeries.period_type:type_name -> parca.query.v1alpha1.ValueType
-	46, // 10: parca.query.v1alpha1.MetricsSeries.sample_type:type_name -> parca.query.v1alpha1.ValueType
-	53, // 11: parca.query.v1alpha1.MetricsSample.timestamp:type_name -> google.protobuf.Timestamp
-	53, // 12: parca.query.v1alpha1.MergeProfile.start:type_name -> google.protobuf.Timestamp
-	53, // 13: parca.query.v1alpha1.MergeProfile.end:type_name -> google.protobuf.Timestamp
-	53, // 14: parca.query.v1alpha1.SingleProfile.time:type_name -> google.protobuf.Timestamp
-	13, // 15: parca.query.v1alpha1.DiffProfile.a:type_name -> parca.query.v1alpha1.ProfileDiffSelection
-	13, // 16: parca.query.v1alpha1.DiffProfile.b:type_name -> parca.query.v1alpha1.ProfileDiffSelection
-	0,  // 17: parca.query.v1alpha1.ProfileDiffSelection.mode:type_name -> parca.query.v1alpha1.ProfileDiffSelection.Mode
-	10, // 18: parca.query.v1alpha1.ProfileDiffSelection.merge:type_name -> parca.query.v1alpha1.MergeProfile
-	11, // 19: parca.query.v1alpha1.ProfileDiffSelection.single:type_name -> parca.query.v1alpha1.SingleProfile
-	1,  // 20: parca.query.v1alpha1.QueryRequest.mode:type_name -> parca.query.v1alpha1.QueryRequest.Mode
-	12, // 21: parca.query.v1alpha1.QueryRequest.diff:type_name -> parca.query.v1alpha1.DiffProfile
-	10, // 22: parca.query.v1alpha1.QueryRequest.merge:type_name -> parca.query.v1alpha1.MergeProfile
-	11, // 23: parca.query.v1alpha1.QueryRequest.single:type_name -> parca.query.v1alpha1.SingleProfile
-	2,  // 24: parca.query.v1alpha1.QueryRequest.report_type:type_name -> parca.query.v1alpha1.QueryRequest.ReportType
-	25, // 25: parca.query.v1alpha1.QueryRequest.group_by:type_name -> parca.query.v1alpha1.GroupBy
-	24, // 26: parca.query.v1alpha1.QueryRequest.source_reference:type_name -> parca.query.v1alpha1.SourceReference
-	23, // 27: parca.query.v1alpha1.QueryRequest.runtime_filter:type_name -> parca.query.v1alpha1.RuntimeFilter
-	18, // 28: parca.query.v1alpha1.QueryRequest.filter:type_name -> parca.query.v1alpha1.Filter
-	16, // 29: parca.query.v1alpha1.FilterCriteria.function_name:type_name -> parca.query.v1alpha1.StringCondition
-	16, // 30: parca.query.v1alpha1.FilterCriteria.system_name:type_name -> parca.query.v1alpha1.StringCondition
-	16, // 31: parca.query.v1alpha1.FilterCriteria.binary:type_name -> parca.query.v1alpha1.StringCondition
-	16, // 32: parca.query.v1alpha1.FilterCriteria.filename:type_name -> parca.query.v1alpha1.StringCondition
-	17, // 33: parca.query.v1alpha1.FilterCriteria.address:type_name -> parca.query.v1alpha1.NumberCondition
-	17, // 34: parca.query.v1alpha1.FilterCriteria.line_number:type_name -> parca.query.v1alpha1.NumberCondition
-	19, // 35: parca.query.v1alpha1.Filter.stack_filter:type_name -> parca.query.v1alpha1.StackFilter
-	21, // 36: parca.query.v1alpha1.Filter.frame_filter:type_name -> parca.query.v1alpha1.FrameFilter
-	20, // 37: parca.query.v1alpha1.StackFilter.function_name_stack_filter:type_name -> parca.query.v1alpha1.FunctionNameStackFilter
-	15, // 38: parca.query.v1alpha1.StackFilter.criteria:type_name -> parca.query.v1alpha1.FilterCriteria
-	22, // 39: parca.query.v1alpha1.FrameFilter.binary_frame_filter:type_name -> parca.query.v1alpha1.BinaryFrameFilter
-	15, // 40: parca.query.v1alpha1.FrameFilter.criteria:type_name -> parca.query.v1alpha1.FilterCriteria
-	27, // 41: parca.query.v1alpha1.Top.list:type_name -> parca.query.v1alpha1.TopNode
-	28, // 42: parca.query.v1alpha1.TopNode.meta:type_name -> parca.query.v1alpha1.TopNodeMeta
-	56, // 43: parca.query.v1alpha1.TopNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
-	57, // 44: parca.query.v1alpha1.TopNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
-	58, // 45: parca.query.v1alpha1.TopNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
-	59, // 46: parca.query.v1alpha1.TopNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
-	32, // 47: parca.query.v1alpha1.Flamegraph.root:type_name -> parca.query.v1alpha1.FlamegraphRootNode
-	56, // 48: parca.query.v1alpha1.Flamegraph.locations:type_name -> parca.metastore.v1alpha1.Location
-	57, // 49: parca.query.v1alpha1.Flamegraph.mapping:type_name -> parca.metastore.v1alpha1.Mapping
-	58, // 50: parca.query.v1alpha1.Flamegraph.function:type_name -> parca.metastore.v1alpha1.Function
-	33, // 51: parca.query.v1alpha1.FlamegraphRootNode.children:type_name -> parca.query.v1alpha1.FlamegraphNode
-	34, // 52: parca.query.v1alpha1.FlamegraphNode.meta:type_name -> parca.query.v1alpha1.FlamegraphNodeMeta
-	33, // 53: parca.query.v1alpha1.FlamegraphNode.children:type_name -> parca.query.v1alpha1.FlamegraphNode
-	56, // 54: parca.query.v1alpha1.FlamegraphNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
-	57, // 55: parca.query.v1alpha1.FlamegraphNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
-	58, // 56: parca.query.v1alpha1.FlamegraphNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
-	59, // 57: parca.query.v1alpha1.FlamegraphNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
-	36, // 58: parca.query.v1alpha1.CallgraphNode.meta:type_name -> parca.query.v1alpha1.CallgraphNodeMeta
-	56, // 59: parca.query.v1alpha1.CallgraphNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
-	57, // 60: parca.query.v1alpha1.CallgraphNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
-	58, // 61: parca.query.v1alpha1.CallgraphNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
-	59, // 62: parca.query.v1alpha1.CallgraphNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
-	35, // 63: parca.query.v1alpha1.Callgraph.nodes:type_name -> parca.query.v1alpha1.CallgraphNode
-	37, // 64: parca.query.v1alpha1.Callgraph.edges:type_name -> parca.query.v1alpha1.CallgraphEdge
-	29, // 65: parca.query.v1alpha1.QueryResponse.flamegraph:type_name -> parca.query.v1alpha1.Flamegraph
-	26, // 66: parca.query.v1alpha1.QueryResponse.top:type_name -> parca.query.v1alpha1.Top
-	38, // 67: parca.query.v1alpha1.QueryResponse.callgraph:type_name -> parca.query.v1alpha1.Callgraph
-	30, // 68: parca.query.v1alpha1.QueryResponse.flamegraph_arrow:type_name -> parca.query.v1alpha1.FlamegraphArrow
-	31, // 69: parca.query.v1alpha1.QueryResponse.source:type_name -> parca.query.v1alpha1.Source
-	49, // 70: parca.query.v1alpha1.QueryResponse.table_arrow:type_name -> parca.query.v1alpha1.TableArrow
-	50, // 71: parca.query.v1alpha1.QueryResponse.profile_metadata:type_name -> parca.query.v1alpha1.ProfileMetadata
-	53, // 72: parca.query.v1alpha1.SeriesRequest.start:type_name -> google.protobuf.Timestamp
-	53, // 73: parca.query.v1alpha1.SeriesRequest.end:type_name -> google.protobuf.Timestamp
-	53, // 74: parca.query.v1alpha1.LabelsRequest.start:type_name -> google.protobuf.Timestamp
-	53, // 75: parca.query.v1alpha1.LabelsRequest.end:type_name -> google.protobuf.Timestamp
-	53, // 76: parca.query.v1alpha1.ValuesRequest.start:type_name -> google.protobuf.Timestamp
-	53, // 77: parca.query.v1alpha1.ValuesRequest.end:type_name -> google.protobuf.Timestamp
-	14, // 78: parca.query.v1alpha1.ShareProfileRequest.query_request:type_name -> parca.query.v1alpha1.QueryRequest
-	6,  // 79: parca.query.v1alpha1.QueryService.QueryRange:input_type -> parca.query.v1alpha1.QueryRangeRequest
-	14, // 80: parca.query.v1alpha1.QueryService.Query:input_type -> parca.query.v1alpha1.QueryRequest
-	40, // 81: parca.query.v1alpha1.QueryService.Series:input_type -> parca.query.v1alpha1.SeriesRequest
-	3,  // 82: parca.query.v1alpha1.QueryService.ProfileTypes:input_type -> parca.query.v1alpha1.ProfileTypesRequest
-	42, // 83: parca.query.v1alpha1.QueryService.Labels:input_type -> parca.query.v1alpha1.LabelsRequest
-	44, // 84: parca.query.v1alpha1.QueryService.Values:input_type -> parca.query.v1alpha1.ValuesRequest
-	47, // 85: parca.query.v1alpha1.QueryService.ShareProfile:input_type -> parca.query.v1alpha1.ShareProfileRequest
-	51, // 86: parca.query.v1alpha1.QueryService.HasProfileData:input_type -> parca.query.v1alpha1.HasProfileDataRequest
-	7,  // 87: parca.query.v1alpha1.QueryService.QueryRange:output_type -> parca.query.v1alpha1.QueryRangeResponse
-	39, // 88: parca.query.v1alpha1.QueryService.Query:output_type -> parca.query.v1alpha1.QueryResponse
-	41, // 89: parca.query.v1alpha1.QueryService.Series:output_type -> parca.query.v1alpha1.SeriesResponse
-	4,  // 90: parca.query.v1alpha1.QueryService.ProfileTypes:output_type -> parca.query.v1alpha1.ProfileTypesResponse
-	43, // 91: parca.query.v1alpha1.QueryService.Labels:output_type -> parca.query.v1alpha1.LabelsResponse
-	45, // 92: parca.query.v1alpha1.QueryService.Values:output_type -> parca.query.v1alpha1.ValuesResponse
-	48, // 93: parca.query.v1alpha1.QueryService.ShareProfile:output_type -> parca.query.v1alpha1.ShareProfileResponse
-	52, // 94: parca.query.v1alpha1.QueryService.HasProfileData:output_type -> parca.query.v1alpha1.HasProfileDataResponse
-	87, // [87:95] is the sub-list for method output_type
-	79, // [79:87] is the sub-list for method input_type
-	79, // [79:79] is the sub-list for extension type_name
-	79, // [79:79] is the sub-list for extension extendee
-	0,  // [0:79] is the sub-list for field type_name
+	70,  // 0: parca.query.v1alpha1.ProfileTypesRequest.start:type_name -> google.protobuf.Timestamp
+	70,  // 1: parca.query.v1alpha1.ProfileTypesRequest.end:type_name -> google.protobuf.Timestamp
+	5,   // 2: parca.query.v1alpha1.ProfileTypesResponse.types:type_name -> parca.query.v1alpha1.ProfileType
+	70,  // 3: parca.query.v1alpha1.QueryRangeRequest.start:type_name -> google.protobuf.Timestamp
+	70,  // 4: parca.query.v1alpha1.QueryRangeRequest.end:type_name -> google.protobuf.Timestamp
+	71,  // 5: parca.query.v1alpha1.QueryRangeRequest.step:type_name -> google.protobuf.Duration
+	21,  // 6: parca.query.v1alpha1.QueryRangeRequest.filter:type_name -> parca.query.v1alpha1.Filter
+	8,   // 7: parca.query.v1alpha1.QueryRangeResponse.series:type_name -> parca.query.v1alpha1.MetricsSeries
+	72,  // 8: parca.query.v1alpha1.QueryRangeResponse.annotations:type_name -> parca.annotation.v1alpha1.Annotation
+	73,  // 9: parca.query.v1alpha1.MetricsSeries.labelset:type_name -> parca.profilestore.v1alpha1.LabelSet
+	9,   // 10: parca.query.v1alpha1.MetricsSeries.samples:type_name -> parca.query.v1alpha1.MetricsSample
+	60,  // 11: parca.query.v1alpha1.MetricsSeries.period_type:type_name -> parca.query.v1alpha1.ValueType
+	60,  // 12: parca.query.v1alpha1.MetricsSeries.sample_type:type_name -> parca.query.v1alpha1.ValueType
+	70,  // 13: parca.query.v1alpha1.MetricsSample.timestamp:type_name -> google.protobuf.Timestamp
+	70,  // 14: parca.query.v1alpha1.MergeProfile.start:type_name -> google.protobuf.Timestamp
+	70,  // 15: parca.query.v1alpha1.MergeProfile.end:type_name -> google.protobuf.Timestamp
+	70,  // 16: parca.query.v1alpha1.MultiMetricProfile.start:type_name -> google.protobuf.Timestamp
+	70,  // 17: parca.query.v1alpha1.MultiMetricProfile.end:type_name -> google.protobuf.Timestamp
+	70,  // 18: parca.query.v1alpha1.SingleProfile.time:type_name -> google.protobuf.Timestamp
+	14,  // 19: parca.query.v1alpha1.DiffProfile.a:type_name -> parca.query.v1alpha1.ProfileDiffSelection
+	14,  // 20: parca.query.v1alpha1.DiffProfile.b:type_name -> parca.query.v1alpha1.ProfileDiffSelection
+	0,   // 21: parca.query.v1alpha1.ProfileDiffSelection.mode:type_name -> parca.query.v1alpha1.ProfileDiffSelection.Mode
+	10,  // 22: parca.query.v1alpha1.ProfileDiffSelection.merge:type_name -> parca.query.v1alpha1.MergeProfile
+	12,  // 23: parca.query.v1alpha1.ProfileDiffSelection.single:type_name -> parca.query.v1alpha1.SingleProfile
+	1,   // 24: parca.query.v1alpha1.QueryRequest.mode:type_name -> parca.query.v1alpha1.QueryRequest.Mode
+	13,  // 25: parca.query.v1alpha1.QueryRequest.diff:type_name -> parca.query.v1alpha1.DiffProfile
+	10,  // 26: parca.query.v1alpha1.QueryRequest.merge:type_name -> parca.query.v1alpha1.MergeProfile
+	12,  // 27: parca.query.v1alpha1.QueryRequest.single:type_name -> parca.query.v1alpha1.SingleProfile
+	11,  // 28: parca.query.v1alpha1.QueryRequest.multi_metric:type_name -> parca.query.v1alpha1.MultiMetricProfile
+	2,   // 29: parca.query.v1alpha1.QueryRequest.report_type:type_name -> parca.query.v1alpha1.QueryRequest.ReportType
+	28,  // 30: parca.query.v1alpha1.QueryRequest.group_by:type_name -> parca.query.v1alpha1.GroupBy
+	27,  // 31: parca.query.v1alpha1.QueryRequest.source_reference:type_name -> parca.query.v1alpha1.SourceReference
+	26,  // 32: parca.query.v1alpha1.QueryRequest.runtime_filter:type_name -> parca.query.v1alpha1.RuntimeFilter
+	21,  // 33: parca.query.v1alpha1.QueryRequest.filter:type_name -> parca.query.v1alpha1.Filter
+	16,  // 34: parca.query.v1alpha1.QueryRequest.disassembly_reference:type_name -> parca.query.v1alpha1.DisassemblyReference
+	18,  // 35: parca.query.v1alpha1.FilterCriteria.function_name:type_name -> parca.query.v1alpha1.StringCondition
+	18,  // 36: parca.query.v1alpha1.FilterCriteria.system_name:type_name -> parca.query.v1alpha1.StringCondition
+	18,  // 37: parca.query.v1alpha1.FilterCriteria.binary:type_name -> parca.query.v1alpha1.StringCondition
+	18,  // 38: parca.query.v1alpha1.FilterCriteria.filename:type_name -> parca.query.v1alpha1.StringCondition
+	19,  // 39: parca.query.v1alpha1.FilterCriteria.address:type_name -> parca.query.v1alpha1.NumberCondition
+	19,  // 40: parca.query.v1alpha1.FilterCriteria.line_number:type_name -> parca.query.v1alpha1.NumberCondition
+	20,  // 41: parca.query.v1alpha1.NumberCondition.range:type_name -> parca.query.v1alpha1.NumberRange
+	22,  // 42: parca.query.v1alpha1.Filter.stack_filter:type_name -> parca.query.v1alpha1.StackFilter
+	24,  // 43: parca.query.v1alpha1.Filter.frame_filter:type_name -> parca.query.v1alpha1.FrameFilter
+	23,  // 44: parca.query.v1alpha1.StackFilter.function_name_stack_filter:type_name -> parca.query.v1alpha1.FunctionNameStackFilter
+	17,  // 45: parca.query.v1alpha1.StackFilter.criteria:type_name -> parca.query.v1alpha1.FilterCriteria
+	25,  // 46: parca.query.v1alpha1.FrameFilter.binary_frame_filter:type_name -> parca.query.v1alpha1.BinaryFrameFilter
+	17,  // 47: parca.query.v1alpha1.FrameFilter.criteria:type_name -> parca.query.v1alpha1.FilterCriteria
+	30,  // 48: parca.query.v1alpha1.Top.list:type_name -> parca.query.v1alpha1.TopNode
+	31,  // 49: parca.query.v1alpha1.TopNode.meta:type_name -> parca.query.v1alpha1.TopNodeMeta
+	74,  // 50: parca.query.v1alpha1.TopNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
+	75,  // 51: parca.query.v1alpha1.TopNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
+	76,  // 52: parca.query.v1alpha1.TopNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
+	77,  // 53: parca.query.v1alpha1.TopNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
+	35,  // 54: parca.query.v1alpha1.Flamegraph.root:type_name -> parca.query.v1alpha1.FlamegraphRootNode
+	74,  // 55: parca.query.v1alpha1.Flamegraph.locations:type_name -> parca.metastore.v1alpha1.Location
+	75,  // 56: parca.query.v1alpha1.Flamegraph.mapping:type_name -> parca.metastore.v1alpha1.Mapping
+	76,  // 57: parca.query.v1alpha1.Flamegraph.function:type_name -> parca.metastore.v1alpha1.Function
+	36,  // 58: parca.query.v1alpha1.FlamegraphRootNode.children:type_name -> parca.query.v1alpha1.FlamegraphNode
+	37,  // 59: parca.query.v1alpha1.FlamegraphNode.meta:type_name -> parca.query.v1alpha1.FlamegraphNodeMeta
+	36,  // 60: parca.query.v1alpha1.FlamegraphNode.children:type_name -> parca.query.v1alpha1.FlamegraphNode
+	74,  // 61: parca.query.v1alpha1.FlamegraphNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
+	75,  // 62: parca.query.v1alpha1.FlamegraphNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
+	76,  // 63: parca.query.v1alpha1.FlamegraphNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
+	77,  // 64: parca.query.v1alpha1.FlamegraphNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
+	39,  // 65: parca.query.v1alpha1.CallgraphNode.meta:type_name -> parca.query.v1alpha1.CallgraphNodeMeta
+	74,  // 66: parca.query.v1alpha1.CallgraphNodeMeta.location:type_name -> parca.metastore.v1alpha1.Location
+	75,  // 67: parca.query.v1alpha1.CallgraphNodeMeta.mapping:type_name -> parca.metastore.v1alpha1.Mapping
+	76,  // 68: parca.query.v1alpha1.CallgraphNodeMeta.function:type_name -> parca.metastore.v1alpha1.Function
+	77,  // 69: parca.query.v1alpha1.CallgraphNodeMeta.line:type_name -> parca.metastore.v1alpha1.Line
+	42,  // 70: parca.query.v1alpha1.Disassembly.instructions:type_name -> parca.query.v1alpha1.DisassemblyInstruction
+	44,  // 71: parca.query.v1alpha1.LabelBreakdown.labels:type_name -> parca.query.v1alpha1.LabelBreakdownLabel
+	45,  // 72: parca.query.v1alpha1.LabelBreakdownLabel.values:type_name -> parca.query.v1alpha1.LabelBreakdownValue
+	70,  // 73: parca.query.v1alpha1.Heatmap.start:type_name -> google.protobuf.Timestamp
+	71,  // 74: parca.query.v1alpha1.Heatmap.bucket_duration:type_name -> google.protobuf.Duration
+	47,  // 75: parca.query.v1alpha1.Heatmap.rows:type_name -> parca.query.v1alpha1.HeatmapRow
+	49,  // 76: parca.query.v1alpha1.CallersCallees.functions:type_name -> parca.query.v1alpha1.CallersCalleesFunction
+	50,  // 77: parca.query.v1alpha1.CallersCalleesFunction.function:type_name -> parca.query.v1alpha1.CallersCalleesEntry
+	50,  // 78: parca.query.v1alpha1.CallersCalleesFunction.callers:type_name -> parca.query.v1alpha1.CallersCalleesEntry
+	50,  // 79: parca.query.v1alpha1.CallersCalleesFunction.callees:type_name -> parca.query.v1alpha1.CallersCalleesEntry
+	38,  // 80: parca.query.v1alpha1.Callgraph.nodes:type_name -> parca.query.v1alpha1.CallgraphNode
+	40,  // 81: parca.query.v1alpha1.Callgraph.edges:type_name -> parca.query.v1alpha1.CallgraphEdge
+	32,  // 82: parca.query.v1alpha1.QueryResponse.flamegraph:type_name -> parca.query.v1alpha1.Flamegraph
+	29,  // 83: parca.query.v1alpha1.QueryResponse.top:type_name -> parca.query.v1alpha1.Top
+	51,  // 84: parca.query.v1alpha1.QueryResponse.callgraph:type_name -> parca.query.v1alpha1.Callgraph
+	33,  // 85: parca.query.v1alpha1.QueryResponse.flamegraph_arrow:type_name -> parca.query.v1alpha1.FlamegraphArrow
+	34,  // 86: parca.query.v1alpha1.QueryResponse.source:type_name -> parca.query.v1alpha1.Source
+	63,  // 87: parca.query.v1alpha1.QueryResponse.table_arrow:type_name -> parca.query.v1alpha1.TableArrow
+	64,  // 88: parca.query.v1alpha1.QueryResponse.profile_metadata:type_name -> parca.query.v1alpha1.ProfileMetadata
+	48,  // 89: parca.query.v1alpha1.QueryResponse.callers_callees:type_name -> parca.query.v1alpha1.CallersCallees
+	41,  // 90: parca.query.v1alpha1.QueryResponse.disassembly:type_name -> parca.query.v1alpha1.Disassembly
+	43,  // 91: parca.query.v1alpha1.QueryResponse.label_breakdown:type_name -> parca.query.v1alpha1.LabelBreakdown
+	46,  // 92: parca.query.v1alpha1.QueryResponse.heatmap:type_name -> parca.query.v1alpha1.Heatmap
+	70,  // 93: parca.query.v1alpha1.SeriesRequest.start:type_name -> google.protobuf.Timestamp
+	70,  // 94: parca.query.v1alpha1.SeriesRequest.end:type_name -> google.protobuf.Timestamp
+	55,  // 95: parca.query.v1alpha1.SeriesResponse.series:type_name -> parca.query.v1alpha1.Series
+	73,  // 96: parca.query.v1alpha1.Series.labelset:type_name -> parca.profilestore.v1alpha1.LabelSet
+	5,   // 97: parca.query.v1alpha1.Series.profile_type:type_name -> parca.query.v1alpha1.ProfileType
+	70,  // 98: parca.query.v1alpha1.Series.first_seen:type_name -> google.protobuf.Timestamp
+	70,  // 99: parca.query.v1alpha1.Series.last_seen:type_name -> google.protobuf.Timestamp
+	70,  // 100: parca.query.v1alpha1.LabelsRequest.start:type_name -> google.protobuf.Timestamp
+	70,  // 101: parca.query.v1alpha1.LabelsRequest.end:type_name -> google.protobuf.Timestamp
+	70,  // 102: parca.query.v1alpha1.ValuesRequest.start:type_name -> google.protobuf.Timestamp
+	70,  // 103: parca.query.v1alpha1.ValuesRequest.end:type_name -> google.protobuf.Timestamp
+	15,  // 104: parca.query.v1alpha1.ShareProfileRequest.query_request:type_name -> parca.query.v1alpha1.QueryRequest
+	70,  // 105: parca.query.v1alpha1.TopRegressionsRequest.baseline_start:type_name -> google.protobuf.Timestamp
+	70,  // 106: parca.query.v1alpha1.TopRegressionsRequest.baseline_end:type_name -> google.protobuf.Timestamp
+	70,  // 107: parca.query.v1alpha1.TopRegressionsRequest.candidate_start:type_name -> google.protobuf.Timestamp
+	70,  // 108: parca.query.v1alpha1.TopRegressionsRequest.candidate_end:type_name -> google.protobuf.Timestamp
+	69,  // 109: parca.query.v1alpha1.TopRegressionsResponse.regressions:type_name -> parca.query.v1alpha1.TopRegression
+	6,   // 110: parca.query.v1alpha1.QueryService.QueryRange:input_type -> parca.query.v1alpha1.QueryRangeRequest
+	15,  // 111: parca.query.v1alpha1.QueryService.Query:input_type -> parca.query.v1alpha1.QueryRequest
+	53,  // 112: parca.query.v1alpha1.QueryService.Series:input_type -> parca.query.v1alpha1.SeriesRequest
+	3,   // 113: parca.query.v1alpha1.QueryService.ProfileTypes:input_type -> parca.query.v1alpha1.ProfileTypesRequest
+	56,  // 114: parca.query.v1alpha1.QueryService.Labels:input_type -> parca.query.v1alpha1.LabelsRequest
+	58,  // 115: parca.query.v1alpha1.QueryService.Values:input_type -> parca.query.v1alpha1.ValuesRequest
+	61,  // 116: parca.query.v1alpha1.QueryService.ShareProfile:input_type -> parca.query.v1alpha1.ShareProfileRequest
+	65,  // 117: parca.query.v1alpha1.QueryService.HasProfileData:input_type -> parca.query.v1alpha1.HasProfileDataRequest
+	67,  // 118: parca.query.v1alpha1.QueryService.TopRegressions:input_type -> parca.query.v1alpha1.TopRegressionsRequest
+	7,   // 119: parca.query.v1alpha1.QueryService.QueryRange:output_type -> parca.query.v1alpha1.QueryRangeResponse
+	52,  // 120: parca.query.v1alpha1.QueryService.Query:output_type -> parca.query.v1alpha1.QueryResponse
+	54,  // 121: parca.query.v1alpha1.QueryService.Series:output_type -> parca.query.v1alpha1.SeriesResponse
+	4,   // 122: parca.query.v1alpha1.QueryService.ProfileTypes:output_type -> parca.query.v1alpha1.ProfileTypesResponse
+	57,  // 123: parca.query.v1alpha1.QueryService.Labels:output_type -> parca.query.v1alpha1.LabelsResponse
+	59,  // 124: parca.query.v1alpha1.QueryService.Values:output_type -> parca.query.v1alpha1.ValuesResponse
+	62,  // 125: parca.query.v1alpha1.QueryService.ShareProfile:output_type -> parca.query.v1alpha1.ShareProfileResponse
+	66,  // 126: parca.query.v1alpha1.QueryService.HasProfileData:output_type -> parca.query.v1alpha1.HasProfileDataResponse
+	68,  // 127: parca.query.v1alpha1.QueryService.TopRegressions:output_type -> parca.query.v1alpha1.TopRegressionsResponse
+	119, // [119:128] is the sub-list for method output_type
+	110, // [110:119] is the sub-list for method input_type
+	110, // [110:110] is the sub-list for extension type_name
+	110, // [110:110] is the sub-list for extension extendee
+	0,   // [0:110] is the sub-list for field type_name
 }
 
 func init() { file_parca_query_v1alpha1_query_proto_init() }
@@ -4495,41 +6478,47 @@ func file_parca_query_v1alpha1_query_proto_init() {
 		return
 	}
 	file_parca_query_v1alpha1_query_proto_msgTypes[0].OneofWrappers = []any{}
-	file_parca_query_v1alpha1_query_proto_msgTypes[9].OneofWrappers = []any{}
-	file_parca_query_v1alpha1_query_proto_msgTypes[10].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[10].OneofWrappers = []any{}
+	file_parca_query_v1alpha1_query_proto_msgTypes[11].OneofWrappers = []any{
 		(*ProfileDiffSelection_Merge)(nil),
 		(*ProfileDiffSelection_Single)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[11].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[12].OneofWrappers = []any{
 		(*QueryRequest_Diff)(nil),
 		(*QueryRequest_Merge)(nil),
 		(*QueryRequest_Single)(nil),
+		(*QueryRequest_MultiMetric)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[13].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[15].OneofWrappers = []any{
 		(*StringCondition_Equal)(nil),
 		(*StringCondition_NotEqual)(nil),
 		(*StringCondition_Contains)(nil),
 		(*StringCondition_NotContains)(nil),
 		(*StringCondition_StartsWith)(nil),
 		(*StringCondition_NotStartsWith)(nil),
+		(*StringCondition_MatchesRegex)(nil),
+		(*StringCondition_NotMatchesRegex)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[14].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[16].OneofWrappers = []any{
 		(*NumberCondition_Equal)(nil),
 		(*NumberCondition_NotEqual)(nil),
+		(*NumberCondition_GreaterThan)(nil),
+		(*NumberCondition_LessThan)(nil),
+		(*NumberCondition_Range)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[15].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[18].OneofWrappers = []any{
 		(*Filter_StackFilter)(nil),
 		(*Filter_FrameFilter)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[16].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[19].OneofWrappers = []any{
 		(*StackFilter_FunctionNameStackFilter)(nil),
 		(*StackFilter_Criteria)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[18].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[21].OneofWrappers = []any{
 		(*FrameFilter_BinaryFrameFilter)(nil),
 		(*FrameFilter_Criteria)(nil),
 	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[36].OneofWrappers = []any{
+	file_parca_query_v1alpha1_query_proto_msgTypes[49].OneofWrappers = []any{
 		(*QueryResponse_Flamegraph)(nil),
 		(*QueryResponse_Pprof)(nil),
 		(*QueryResponse_Top)(nil),
@@ -4538,17 +6527,26 @@ func file_parca_query_v1alpha1_query_proto_init() {
 		(*QueryResponse_Source)(nil),
 		(*QueryResponse_TableArrow)(nil),
 		(*QueryResponse_ProfileMetadata)(nil),
-	}
-	file_parca_query_v1alpha1_query_proto_msgTypes[39].OneofWrappers = []any{}
-	file_parca_query_v1alpha1_query_proto_msgTypes[41].OneofWrappers = []any{}
-	file_parca_query_v1alpha1_query_proto_msgTypes[44].OneofWrappers = []any{}
+		(*QueryResponse_Folded)(nil),
+		(*QueryResponse_Speedscope)(nil),
+		(*QueryResponse_ChromeTrace)(nil),
+		(*QueryResponse_CallgraphDot)(nil),
+		(*QueryResponse_CallersCallees)(nil),
+		(*QueryResponse_Disassembly)(nil),
+		(*QueryResponse_LabelBreakdown)(nil),
+		(*QueryResponse_Heatmap)(nil),
+	}
+	file_parca_query_v1alpha1_query_proto_msgTypes[53].OneofWrappers = []any{}
+	file_parca_query_v1alpha1_query_proto_msgTypes[55].OneofWrappers = []any{}
+	file_parca_query_v1alpha1_query_proto_msgTypes[58].OneofWrappers = []any{}
+	file_parca_query_v1alpha1_query_proto_msgTypes[64].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parca_query_v1alpha1_query_proto_rawDesc), len(file_parca_query_v1alpha1_query_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   50,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -30,6 +30,14 @@ func (r *QueryRangeRequest) Validate() error {
 	)
 }
 
+// Validate the SeriesRequest.
+func (r *SeriesRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Start, validation.Required),
+		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
+	)
+}
+
 // Validate the QueryRequest.
 func (r *QueryRequest) Validate() error {
 	err := validation.ValidateStruct(r,
@@ -0,0 +1,982 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: parca/view/v1alpha1/view.proto
+
+package viewv1alpha1
+
+import (
+	v1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// View is a named query
+type View struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the view, it is assigned on creation
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// name is the name of the view
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// description is the description of the view
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// query is the query of the view
+	Query *ViewQuery `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// created_at is the time the view was created
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// updated_at is the time the view was last updated
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *View) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *View) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *View) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *View) GetQuery() *ViewQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *View) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *View) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ViewQuery is the query of a view
+type ViewQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// selector is the query string to match profiles against
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// time_range is the time range of the query
+	TimeRange *TimeRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// filter is a varying set of filter to apply to the query
+	Filter []*v1alpha1.Filter `protobuf:"bytes,3,rep,name=filter,proto3" json:"filter,omitempty"`
+	// group_by indicates the fields to group by
+	GroupBy *v1alpha1.GroupBy `protobuf:"bytes,4,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// report_type is the type of report to return
+	ReportType v1alpha1.QueryRequest_ReportType `protobuf:"varint,5,opt,name=report_type,json=reportType,proto3,enum=parca.query.v1alpha1.QueryRequest_ReportType" json:"report_type,omitempty"`
+	// sandwich_by_function is a function name to use for sandwich view functionality
+	SandwichByFunction *string `protobuf:"bytes,6,opt,name=sandwich_by_function,json=sandwichByFunction,proto3,oneof" json:"sandwich_by_function,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ViewQuery) Reset() {
+	*x = ViewQuery{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewQuery) ProtoMessage() {}
+
+func (x *ViewQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewQuery.ProtoReflect.Descriptor instead.
+func (*ViewQuery) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ViewQuery) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *ViewQuery) GetTimeRange() *TimeRange {
+	if x != nil {
+		return x.TimeRange
+	}
+	return nil
+}
+
+func (x *ViewQuery) GetFilter() []*v1alpha1.Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ViewQuery) GetGroupBy() *v1alpha1.GroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *ViewQuery) GetReportType() v1alpha1.QueryRequest_ReportType {
+	if x != nil {
+		return x.ReportType
+	}
+	return v1alpha1.QueryRequest_ReportType(0)
+}
+
+func (x *ViewQuery) GetSandwichByFunction() string {
+	if x != nil && x.SandwichByFunction != nil {
+		return *x.SandwichByFunction
+	}
+	return ""
+}
+
+// TimeRange is an absolute time range or a range relative to the time the view is read
+type TimeRange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// range is the time range
+	//
+	// Types that are valid to be assigned to Range:
+	//
+	//	*TimeRange_Absolute
+	//	*TimeRange_Relative
+	Range         isTimeRange_Range `protobuf_oneof:"range"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TimeRange) GetRange() isTimeRange_Range {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *TimeRange) GetAbsolute() *AbsoluteTimeRange {
+	if x != nil {
+		if x, ok := x.Range.(*TimeRange_Absolute); ok {
+			return x.Absolute
+		}
+	}
+	return nil
+}
+
+func (x *TimeRange) GetRelative() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Range.(*TimeRange_Relative); ok {
+			return x.Relative
+		}
+	}
+	return nil
+}
+
+type isTimeRange_Range interface {
+	isTimeRange_Range()
+}
+
+type TimeRange_Absolute struct {
+	// absolute is a fixed time range
+	Absolute *AbsoluteTimeRange `protobuf:"bytes,1,opt,name=absolute,proto3,oneof"`
+}
+
+type TimeRange_Relative struct {
+	// relative is the duration up to the time the view is read, for example the last hour
+	Relative *durationpb.Duration `protobuf:"bytes,2,opt,name=relative,proto3,oneof"`
+}
+
+func (*TimeRange_Absolute) isTimeRange_Range() {}
+
+func (*TimeRange_Relative) isTimeRange_Range() {}
+
+// AbsoluteTimeRange is a fixed time range
+type AbsoluteTimeRange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start is the beginning of the time range
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the end of the time range
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbsoluteTimeRange) Reset() {
+	*x = AbsoluteTimeRange{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbsoluteTimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbsoluteTimeRange) ProtoMessage() {}
+
+func (x *AbsoluteTimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbsoluteTimeRange.ProtoReflect.Descriptor instead.
+func (*AbsoluteTimeRange) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AbsoluteTimeRange) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *AbsoluteTimeRange) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// CreateViewRequest is the request to create a view
+type CreateViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the view
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// description is the description of the view
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// query is the query of the view
+	Query         *ViewQuery `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateViewRequest) Reset() {
+	*x = CreateViewRequest{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViewRequest) ProtoMessage() {}
+
+func (x *CreateViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViewRequest.ProtoReflect.Descriptor instead.
+func (*CreateViewRequest) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetQuery() *ViewQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// CreateViewResponse is the response to creating a view
+type CreateViewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// view is the created view
+	View          *View `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateViewResponse) Reset() {
+	*x = CreateViewResponse{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViewResponse) ProtoMessage() {}
+
+func (x *CreateViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViewResponse.ProtoReflect.Descriptor instead.
+func (*CreateViewResponse) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateViewResponse) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+// GetViewRequest is the request for a view
+type GetViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the view
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViewRequest) Reset() {
+	*x = GetViewRequest{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViewRequest) ProtoMessage() {}
+
+func (x *GetViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViewRequest.ProtoReflect.Descriptor instead.
+func (*GetViewRequest) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetViewResponse contains a view
+type GetViewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// view is the requested view
+	View *View `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	// query_request is the merge query request of the view, relative time ranges are resolved at the time of the request
+	QueryRequest  *v1alpha1.QueryRequest `protobuf:"bytes,2,opt,name=query_request,json=queryRequest,proto3" json:"query_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViewResponse) Reset() {
+	*x = GetViewResponse{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViewResponse) ProtoMessage() {}
+
+func (x *GetViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViewResponse.ProtoReflect.Descriptor instead.
+func (*GetViewResponse) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetViewResponse) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+func (x *GetViewResponse) GetQueryRequest() *v1alpha1.QueryRequest {
+	if x != nil {
+		return x.QueryRequest
+	}
+	return nil
+}
+
+// ListViewsRequest is the request for all views
+type ListViewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsRequest) Reset() {
+	*x = ListViewsRequest{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsRequest) ProtoMessage() {}
+
+func (x *ListViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListViewsRequest) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{8}
+}
+
+// ListViewsResponse contains all views
+type ListViewsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// views are the views sorted by name
+	Views         []*View `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsResponse) Reset() {
+	*x = ListViewsResponse{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsResponse) ProtoMessage() {}
+
+func (x *ListViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListViewsResponse) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListViewsResponse) GetViews() []*View {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+// UpdateViewRequest is the request to update a view
+type UpdateViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// view is the updated view, the timestamps are ignored
+	View          *View `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateViewRequest) Reset() {
+	*x = UpdateViewRequest{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViewRequest) ProtoMessage() {}
+
+func (x *UpdateViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateViewRequest) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateViewRequest) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+// UpdateViewResponse is the response to updating a view
+type UpdateViewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// view is the updated view
+	View          *View `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateViewResponse) Reset() {
+	*x = UpdateViewResponse{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViewResponse) ProtoMessage() {}
+
+func (x *UpdateViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViewResponse.ProtoReflect.Descriptor instead.
+func (*UpdateViewResponse) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateViewResponse) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+// DeleteViewRequest is the request to delete a view
+type DeleteViewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the view
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewRequest) Reset() {
+	*x = DeleteViewRequest{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewRequest) ProtoMessage() {}
+
+func (x *DeleteViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteViewRequest) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteViewResponse is the response to deleting a view
+type DeleteViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewResponse) Reset() {
+	*x = DeleteViewResponse{}
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewResponse) ProtoMessage() {}
+
+func (x *DeleteViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_view_v1alpha1_view_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewResponse.ProtoReflect.Descriptor instead.
+func (*DeleteViewResponse) Descriptor() ([]byte, []int) {
+	return file_parca_view_v1alpha1_view_proto_rawDescGZIP(), []int{13}
+}
+
+var File_parca_view_v1alpha1_view_proto protoreflect.FileDescriptor
+
+const file_parca_view_v1alpha1_view_proto_rawDesc = "" +
+	"\n" +
+	"\x1eparca/view/v1alpha1/view.proto\x12\x13parca.view.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a parca/query/v1alpha1/query.proto\"\xf8\x01\n" +
+	"\x04View\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x124\n" +
+	"\x05query\x18\x04 \x01(\v2\x1e.parca.view.v1alpha1.ViewQueryR\x05query\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\x02\n" +
+	"\tViewQuery\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12=\n" +
+	"\n" +
+	"time_range\x18\x02 \x01(\v2\x1e.parca.view.v1alpha1.TimeRangeR\ttimeRange\x124\n" +
+	"\x06filter\x18\x03 \x03(\v2\x1c.parca.query.v1alpha1.FilterR\x06filter\x128\n" +
+	"\bgroup_by\x18\x04 \x01(\v2\x1d.parca.query.v1alpha1.GroupByR\agroupBy\x12N\n" +
+	"\vreport_type\x18\x05 \x01(\x0e2-.parca.query.v1alpha1.QueryRequest.ReportTypeR\n" +
+	"reportType\x125\n" +
+	"\x14sandwich_by_function\x18\x06 \x01(\tH\x00R\x12sandwichByFunction\x88\x01\x01B\x17\n" +
+	"\x15_sandwich_by_function\"\x93\x01\n" +
+	"\tTimeRange\x12D\n" +
+	"\babsolute\x18\x01 \x01(\v2&.parca.view.v1alpha1.AbsoluteTimeRangeH\x00R\babsolute\x127\n" +
+	"\brelative\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\brelativeB\a\n" +
+	"\x05range\"s\n" +
+	"\x11AbsoluteTimeRange\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\x7f\n" +
+	"\x11CreateViewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x124\n" +
+	"\x05query\x18\x03 \x01(\v2\x1e.parca.view.v1alpha1.ViewQueryR\x05query\"C\n" +
+	"\x12CreateViewResponse\x12-\n" +
+	"\x04view\x18\x01 \x01(\v2\x19.parca.view.v1alpha1.ViewR\x04view\" \n" +
+	"\x0eGetViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\x01\n" +
+	"\x0fGetViewResponse\x12-\n" +
+	"\x04view\x18\x01 \x01(\v2\x19.parca.view.v1alpha1.ViewR\x04view\x12G\n" +
+	"\rquery_request\x18\x02 \x01(\v2\".parca.query.v1alpha1.QueryRequestR\fqueryRequest\"\x12\n" +
+	"\x10ListViewsRequest\"D\n" +
+	"\x11ListViewsResponse\x12/\n" +
+	"\x05views\x18\x01 \x03(\v2\x19.parca.view.v1alpha1.ViewR\x05views\"B\n" +
+	"\x11UpdateViewRequest\x12-\n" +
+	"\x04view\x18\x01 \x01(\v2\x19.parca.view.v1alpha1.ViewR\x04view\"C\n" +
+	"\x12UpdateViewResponse\x12-\n" +
+	"\x04view\x18\x01 \x01(\v2\x19.parca.view.v1alpha1.ViewR\x04view\"#\n" +
+	"\x11DeleteViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12DeleteViewResponse2\xc9\x04\n" +
+	"\vViewService\x12p\n" +
+	"\n" +
+	"CreateView\x12&.parca.view.v1alpha1.CreateViewRequest\x1a'.parca.view.v1alpha1.CreateViewResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/views\x12i\n" +
+	"\aGetView\x12#.parca.view.v1alpha1.GetViewRequest\x1a$.parca.view.v1alpha1.GetViewResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/views/{id}\x12j\n" +
+	"\tListViews\x12%.parca.view.v1alpha1.ListViewsRequest\x1a&.parca.view.v1alpha1.ListViewsResponse\"\x0e\x82\xd3\xe4\x93\x02\b\x12\x06/views\x12}\n" +
+	"\n" +
+	"UpdateView\x12&.parca.view.v1alpha1.UpdateViewRequest\x1a'.parca.view.v1alpha1.UpdateViewResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x04view\x1a\x10/views/{view.id}\x12r\n" +
+	"\n" +
+	"DeleteView\x12&.parca.view.v1alpha1.DeleteViewRequest\x1a'.parca.view.v1alpha1.DeleteViewResponse\"\x13\x82\xd3\xe4\x93\x02\r*\v/views/{id}B\xdc\x01\n" +
+	"\x17com.parca.view.v1alpha1B\tViewProtoP\x01ZHgithub.com/parca-dev/parca/gen/proto/go/parca/view/v1alpha1;viewv1alpha1\xa2\x02\x03PVX\xaa\x02\x13Parca.View.V1alpha1\xca\x02\x13Parca\\View\\V1alpha1\xe2\x02\x1fParca\\View\\V1alpha1\\GPBMetadata\xea\x02\x15Parca::View::V1alpha1b\x06proto3"
+
+var (
+	file_parca_view_v1alpha1_view_proto_rawDescOnce sync.Once
+	file_parca_view_v1alpha1_view_proto_rawDescData []byte
+)
+
+func file_parca_view_v1alpha1_view_proto_rawDescGZIP() []byte {
+	file_parca_view_v1alpha1_view_proto_rawDescOnce.Do(func() {
+		file_parca_view_v1alpha1_view_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_parca_view_v1alpha1_view_proto_rawDesc), len(file_parca_view_v1alpha1_view_proto_rawDesc)))
+	})
+	return file_parca_view_v1alpha1_view_proto_rawDescData
+}
+
+var file_parca_view_v1alpha1_view_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_parca_view_v1alpha1_view_proto_goTypes = []any{
+	(*View)(nil),                          // 0: parca.view.v1alpha1.View
+	(*ViewQuery)(nil),                     // 1: parca.view.v1alpha1.ViewQuery
+	(*TimeRange)(nil),                     // 2: parca.view.v1alpha1.TimeRange
+	(*AbsoluteTimeRange)(nil),             // 3: parca.view.v1alpha1.AbsoluteTimeRange
+	(*CreateViewRequest)(nil),             // 4: parca.view.v1alpha1.CreateViewRequest
+	(*CreateViewResponse)(nil),            // 5: parca.view.v1alpha1.CreateViewResponse
+	(*GetViewRequest)(nil),                // 6: parca.view.v1alpha1.GetViewRequest
+	(*GetViewResponse)(nil),               // 7: parca.view.v1alpha1.GetViewResponse
+	(*ListViewsRequest)(nil),              // 8: parca.view.v1alpha1.ListViewsRequest
+	(*ListViewsResponse)(nil),             // 9: parca.view.v1alpha1.ListViewsResponse
+	(*UpdateViewRequest)(nil),             // 10: parca.view.v1alpha1.UpdateViewRequest
+	(*UpdateViewResponse)(nil),            // 11: parca.view.v1alpha1.UpdateViewResponse
+	(*DeleteViewRequest)(nil),             // 12: parca.view.v1alpha1.DeleteViewRequest
+	(*DeleteViewResponse)(nil),            // 13: parca.view.v1alpha1.DeleteViewResponse
+	(*timestamppb.Timestamp)(nil),         // 14: google.protobuf.Timestamp
+	(*v1alpha1.Filter)(nil),               // 15: parca.query.v1alpha1.Filter
+	(*v1alpha1.GroupBy)(nil),              // 16: parca.query.v1alpha1.GroupBy
+	(v1alpha1.QueryRequest_ReportType)(0), // 17: parca.query.v1alpha1.QueryRequest.ReportType
+	(*durationpb.Duration)(nil),           // 18: google.protobuf.Duration
+	(*v1alpha1.QueryRequest)(nil),         // 19: parca.query.v1alpha1.QueryRequest
+}
+var file_parca_view_v1alpha1_view_proto_depIdxs = []int32{
+	1,  // 0: parca.view.v1alpha1.View.query:type_name -> parca.view.v1alpha1.ViewQuery
+	14, // 1: parca.view.v1alpha1.View.created_at:type_name -> google.protobuf.Timestamp
+	14, // 2: parca.view.v1alpha1.View.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: parca.view.v1alpha1.ViewQuery.time_range:type_name -> parca.view.v1alpha1.TimeRange
+	15, // 4: parca.view.v1alpha1.ViewQuery.filter:type_name -> parca.query.v1alpha1.Filter
+	16, // 5: parca.view.v1alpha1.ViewQuery.group_by:type_name -> parca.query.v1alpha1.GroupBy
+	17, // 6: parca.view.v1alpha1.ViewQuery.report_type:type_name -> parca.query.v1alpha1.QueryRequest.ReportType
+	3,  // 7: parca.view.v1alpha1.TimeRange.absolute:type_name -> parca.view.v1alpha1.AbsoluteTimeRange
+	18, // 8: parca.view.v1alpha1.TimeRange.relative:type_name -> google.protobuf.Duration
+	14, // 9: parca.view.v1alpha1.AbsoluteTimeRange.start:type_name -> google.protobuf.Timestamp
+	14, // 10: parca.view.v1alpha1.AbsoluteTimeRange.end:type_name -> google.protobuf.Timestamp
+	1,  // 11: parca.view.v1alpha1.CreateViewRequest.query:type_name -> parca.view.v1alpha1.ViewQuery
+	0,  // 12: parca.view.v1alpha1.CreateViewResponse.view:type_name -> parca.view.v1alpha1.View
+	0,  // 13: parca.view.v1alpha1.GetViewResponse.view:type_name -> parca.view.v1alpha1.View
+	19, // 14: parca.view.v1alpha1.GetViewResponse.query_request:type_name -> parca.query.v1alpha1.QueryRequest
+	0,  // 15: parca.view.v1alpha1.ListViewsResponse.views:type_name -> parca.view.v1alpha1.View
+	0,  // 16: parca.view.v1alpha1.UpdateViewRequest.view:type_name -> parca.view.v1alpha1.View
+	0,  // 17: parca.view.v1alpha1.UpdateViewResponse.view:type_name -> parca.view.v1alpha1.View
+	4,  // 18: parca.view.v1alpha1.ViewService.CreateView:input_type -> parca.view.v1alpha1.CreateViewRequest
+	6,  // 19: parca.view.v1alpha1.ViewService.GetView:input_type -> parca.view.v1alpha1.GetViewRequest
+	8,  // 20: parca.view.v1alpha1.ViewService.ListViews:input_type -> parca.view.v1alpha1.ListViewsRequest
+	10, // 21: parca.view.v1alpha1.ViewService.UpdateView:input_type -> parca.view.v1alpha1.UpdateViewRequest
+	12, // 22: parca.view.v1alpha1.ViewService.DeleteView:input_type -> parca.view.v1alpha1.DeleteViewRequest
+	5,  // 23: parca.view.v1alpha1.ViewService.CreateView:output_type -> parca.view.v1alpha1.CreateViewResponse
+	7,  // 24: parca.view.v1alpha1.ViewService.GetView:output_type -> parca.view.v1alpha1.GetViewResponse
+	9,  // 25: parca.view.v1alpha1.ViewService.ListViews:output_type -> parca.view.v1alpha1.ListViewsResponse
+	11, // 26: parca.view.v1alpha1.ViewService.UpdateView:output_type -> parca.view.v1alpha1.UpdateViewResponse
+	13, // 27: parca.view.v1alpha1.ViewService.DeleteView:output_type -> parca.view.v1alpha1.DeleteViewResponse
+	23, // [23:28] is the sub-list for method output_type
+	18, // [18:23] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_parca_view_v1alpha1_view_proto_init() }
+func file_parca_view_v1alpha1_view_proto_init() {
+	if File_parca_view_v1alpha1_view_proto != nil {
+		return
+	}
+	file_parca_view_v1alpha1_view_proto_msgTypes[1].OneofWrappers = []any{}
+	file_parca_view_v1alpha1_view_proto_msgTypes[2].OneofWrappers = []any{
+		(*TimeRange_Absolute)(nil),
+		(*TimeRange_Relative)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parca_view_v1alpha1_view_proto_rawDesc), len(file_parca_view_v1alpha1_view_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_parca_view_v1alpha1_view_proto_goTypes,
+		DependencyIndexes: file_parca_view_v1alpha1_view_proto_depIdxs,
+		MessageInfos:      file_parca_view_v1alpha1_view_proto_msgTypes,
+	}.Build()
+	File_parca_view_v1alpha1_view_proto = out.File
+	file_parca_view_v1alpha1_view_proto_goTypes = nil
+	file_parca_view_v1alpha1_view_proto_depIdxs = nil
+}
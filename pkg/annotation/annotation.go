@@ -0,0 +1,317 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const (
+	objectSuffix = ".json"
+
+	// daysDir holds an empty marker object per annotation and UTC day it
+	// overlaps, so only the days of a time window are listed to find its
+	// annotations.
+	daysDir     = "days"
+	dayLayout   = "2006-01-02"
+	dayDuration = 24 * time.Hour
+
+	// maxAnnotationDuration and maxWindowDuration bound the number of days
+	// markers are written and listed for.
+	maxAnnotationDuration = 31 * dayDuration
+	maxWindowDuration     = 92 * dayDuration
+)
+
+var errAnnotationNotFound = errors.New("annotation not found")
+
+// Service stores annotations in an object storage bucket.
+type Service struct {
+	pb.UnimplementedAnnotationServiceServer
+
+	logger log.Logger
+	bucket objstore.Bucket
+
+	// mtx serializes the deletes of annotations.
+	mtx sync.Mutex
+}
+
+// NewService returns a Service storing annotations in the bucket.
+func NewService(logger log.Logger, bucket objstore.Bucket) *Service {
+	return &Service{
+		logger: log.With(logger, "component", "annotation"),
+		bucket: bucket,
+	}
+}
+
+// CreateAnnotation stores a new annotation.
+func (s *Service) CreateAnnotation(ctx context.Context, req *pb.CreateAnnotationRequest) (*pb.CreateAnnotationResponse, error) {
+	if _, err := parseSelector(req.GetSelector()); err != nil {
+		return nil, err
+	}
+	if req.GetStart() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start is required")
+	}
+	end := req.GetEnd()
+	if end == nil {
+		end = req.GetStart()
+	}
+	if end.AsTime().Before(req.GetStart().AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "end must not be before start")
+	}
+	if end.AsTime().Sub(req.GetStart().AsTime()) > maxAnnotationDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "annotations must not last longer than %d days", maxAnnotationDuration/dayDuration)
+	}
+
+	a := &pb.Annotation{
+		Id:       uuid.NewString(),
+		Selector: req.GetSelector(),
+		Start:    req.GetStart(),
+		End:      end,
+		Title:    req.GetTitle(),
+		Text:     req.GetText(),
+		Metadata: req.GetMetadata(),
+	}
+	if err := s.write(ctx, a); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateAnnotationResponse{Annotation: a}, nil
+}
+
+// QueryAnnotations returns the annotations overlapping the time window whose
+// selector matches the labels of the equality matchers of the query.
+func (s *Service) QueryAnnotations(ctx context.Context, req *pb.QueryAnnotationsRequest) (*pb.QueryAnnotationsResponse, error) {
+	if req.GetStart() == nil || req.GetEnd() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start and end are required")
+	}
+
+	annotations, err := s.FindAnnotations(ctx, req.GetStart().AsTime(), req.GetEnd().AsTime(), req.GetQuery())
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.QueryAnnotationsResponse{Annotations: annotations}, nil
+}
+
+// FindAnnotations returns the annotations overlapping the time window whose
+// selector matches the labels of the equality matchers of the query, all
+// annotations of the time window are returned for an empty query.
+func (s *Service) FindAnnotations(ctx context.Context, start, end time.Time, query string) ([]*pb.Annotation, error) {
+	if end.Sub(start) > maxWindowDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "the time window must not be longer than %d days", maxWindowDuration/dayDuration)
+	}
+
+	var lbls labels.Labels
+	if query != "" {
+		qp, _, err := profile.ParseSelector(query)
+		if err != nil {
+			return nil, err
+		}
+		b := labels.NewScratchBuilder(len(qp.Matchers))
+		for _, m := range qp.Matchers {
+			if m.Type == labels.MatchEqual {
+				b.Add(m.Name, m.Value)
+			}
+		}
+		b.Sort()
+		lbls = b.Labels()
+	}
+
+	return s.find(ctx, start, end, func(matchers []*labels.Matcher) bool {
+		return query == "" || matches(matchers, lbls)
+	})
+}
+
+// DeleteAnnotation deletes an annotation.
+func (s *Service) DeleteAnnotation(ctx context.Context, req *pb.DeleteAnnotationRequest) (*pb.DeleteAnnotationResponse, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %q", req.GetId())
+	}
+
+	a, err := s.read(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, errAnnotationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// The markers are deleted first, so they never point to a deleted
+	// annotation unless deleting it failed.
+	for _, day := range days(a.GetStart().AsTime(), a.GetEnd().AsTime()) {
+		if err := s.bucket.Delete(ctx, markerPath(day, a.GetId())); err != nil && !s.bucket.IsObjNotFoundErr(err) {
+			return nil, status.Errorf(codes.Internal, "delete annotation: %v", err)
+		}
+	}
+	if err := s.bucket.Delete(ctx, objectPath(a.GetId())); err != nil && !s.bucket.IsObjNotFoundErr(err) {
+		return nil, status.Errorf(codes.Internal, "delete annotation: %v", err)
+	}
+
+	return &pb.DeleteAnnotationResponse{}, nil
+}
+
+// find returns the annotations overlapping the time window whose selector
+// matchers are accepted by match, sorted by start. Only the markers of the
+// days overlapping the window are listed.
+func (s *Service) find(ctx context.Context, start, end time.Time, match func([]*labels.Matcher) bool) ([]*pb.Annotation, error) {
+	ids := map[string]struct{}{}
+	for _, day := range days(start, end) {
+		err := s.bucket.Iter(ctx, path.Join(daysDir, day)+objstore.DirDelim, func(name string) error {
+			ids[path.Base(name)] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list annotations: %w", err)
+		}
+	}
+
+	annotations := []*pb.Annotation{}
+	for id := range ids {
+		a, err := s.read(ctx, id)
+		if err != nil {
+			if errors.Is(err, errAnnotationNotFound) {
+				// The annotation was deleted while listing.
+				continue
+			}
+			return nil, err
+		}
+		if a.GetStart().AsTime().After(end) || a.GetEnd().AsTime().Before(start) {
+			continue
+		}
+
+		matchers, err := parseSelector(a.GetSelector())
+		if err != nil {
+			return nil, err
+		}
+		if match(matchers) {
+			annotations = append(annotations, a)
+		}
+	}
+
+	sort.Slice(annotations, func(i, j int) bool {
+		si, sj := annotations[i].GetStart().AsTime(), annotations[j].GetStart().AsTime()
+		if !si.Equal(sj) {
+			return si.Before(sj)
+		}
+		return annotations[i].Id < annotations[j].Id
+	})
+
+	return annotations, nil
+}
+
+func (s *Service) read(ctx context.Context, id string) (*pb.Annotation, error) {
+	r, err := s.bucket.Get(ctx, objectPath(id))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, errAnnotationNotFound
+		}
+		return nil, fmt.Errorf("fetch annotation from object storage: %w", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read annotation from object storage: %w", err)
+	}
+
+	a := &pb.Annotation{}
+	if err := protojson.Unmarshal(content, a); err != nil {
+		return nil, fmt.Errorf("unmarshal annotation: %w", err)
+	}
+	return a, nil
+}
+
+// write uploads the annotation before its markers, so the markers never point
+// to a missing annotation.
+func (s *Service) write(ctx context.Context, a *pb.Annotation) error {
+	content, err := protojson.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("marshal annotation: %w", err)
+	}
+
+	if err := s.bucket.Upload(ctx, objectPath(a.GetId()), bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("write annotation to object storage: %w", err)
+	}
+	for _, day := range days(a.GetStart().AsTime(), a.GetEnd().AsTime()) {
+		if err := s.bucket.Upload(ctx, markerPath(day, a.GetId()), bytes.NewReader(nil)); err != nil {
+			return fmt.Errorf("write annotation marker to object storage: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseSelector returns the matchers of a selector, the empty selector
+// matches all series.
+func parseSelector(selector string) ([]*labels.Matcher, error) {
+	if selector == "" {
+		return nil, nil
+	}
+
+	qp, _, err := profile.ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return qp.Matchers, nil
+}
+
+func matches(matchers []*labels.Matcher, lbls labels.Labels) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// days returns the UTC days overlapping the time range.
+func days(start, end time.Time) []string {
+	res := []string{}
+	for day := start.UTC().Truncate(dayDuration); !day.After(end); day = day.Add(dayDuration) {
+		res = append(res, day.Format(dayLayout))
+	}
+	return res
+}
+
+func objectPath(id string) string {
+	return id + objectSuffix
+}
+
+func markerPath(day, id string) string {
+	return path.Join(daysDir, day, id)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1"
+)
+
+// countingBucket counts the listed directories.
+type countingBucket struct {
+	objstore.Bucket
+	iters []string
+}
+
+func (b *countingBucket) Iter(ctx context.Context, dir string, f func(string) error, options ...objstore.IterOption) error {
+	b.iters = append(b.iters, dir)
+	return b.Bucket.Iter(ctx, dir, f, options...)
+}
+
+func titles(annotations []*pb.Annotation) []string {
+	res := make([]string, 0, len(annotations))
+	for _, a := range annotations {
+		res = append(res, a.Title)
+	}
+	return res
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	bucket := &countingBucket{Bucket: objstore.NewInMemBucket()}
+	s := NewService(log.NewNopLogger(), bucket)
+	base := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(base.Add(d)) }
+
+	for _, req := range []*pb.CreateAnnotationRequest{
+		{Title: "api deploy", Selector: `{job="api"}`, Start: ts(0), Metadata: map[string]string{"commit": "abc123"}},
+		{Title: "db incident", Selector: `{job="db"}`, Start: ts(-time.Hour), End: ts(30 * time.Minute)},
+		{Title: "config flip", Start: ts(2 * time.Hour)},
+		{Title: "old api deploy", Selector: `{job="api"}`, Start: ts(-24 * time.Hour)},
+		{Title: "db migration", Selector: `{job="db"}`, Start: ts(-48 * time.Hour), End: ts(-22 * time.Hour)},
+	} {
+		_, err := s.CreateAnnotation(ctx, req)
+		require.NoError(t, err, req.Title)
+	}
+
+	resp, err := s.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{Start: ts(-time.Minute), End: ts(3 * time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, []string{"db incident", "api deploy", "config flip"}, titles(resp.Annotations))
+	require.Equal(t, "abc123", resp.Annotations[1].Metadata["commit"])
+	require.True(t, resp.Annotations[1].Start.AsTime().Equal(resp.Annotations[1].End.AsTime()))
+
+	// Annotations without a selector apply to all series.
+	resp, err = s.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{
+		Start: ts(-time.Minute),
+		End:   ts(3 * time.Hour),
+		Query: `parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"api deploy", "config flip"}, titles(resp.Annotations))
+
+	// Only the days of the time window are listed.
+	bucket.iters = nil
+	found, err := s.FindAnnotations(ctx, base.Add(-time.Minute), base.Add(time.Minute), `{job="db", instance="a"}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"db incident"}, titles(found))
+	require.Equal(t, []string{"days/2026-01-02/"}, bucket.iters)
+
+	// Annotations spanning several days are found on each of them.
+	found, err = s.FindAnnotations(ctx, base.Add(-47*time.Hour), base.Add(-46*time.Hour), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"db migration"}, titles(found))
+	found, err = s.FindAnnotations(ctx, base.Add(-25*time.Hour), base.Add(-23*time.Hour), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"db migration", "old api deploy"}, titles(found))
+
+	_, err = s.DeleteAnnotation(ctx, &pb.DeleteAnnotationRequest{Id: found[0].Id})
+	require.NoError(t, err)
+	for _, day := range []string{"2025-12-31", "2026-01-01"} {
+		exists, err := bucket.Exists(ctx, markerPath(day, found[0].Id))
+		require.NoError(t, err)
+		require.False(t, exists, day)
+	}
+
+	_, err = s.DeleteAnnotation(ctx, &pb.DeleteAnnotationRequest{Id: resp.Annotations[0].Id})
+	require.NoError(t, err)
+	_, err = s.DeleteAnnotation(ctx, &pb.DeleteAnnotationRequest{Id: resp.Annotations[0].Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = s.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{Start: ts(-time.Minute), End: ts(3 * time.Hour)})
+	require.NoError(t, err)
+	require.Equal(t, []string{"db incident", "config flip"}, titles(resp.Annotations))
+}
+
+func TestServiceInvalid(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(log.NewNopLogger(), objstore.NewInMemBucket())
+	now := time.Now()
+
+	for _, req := range []*pb.CreateAnnotationRequest{
+		{Title: "no start"},
+		{Title: "invalid selector", Selector: "{job=", Start: timestamppb.New(now)},
+		{Title: "end before start", Start: timestamppb.New(now), End: timestamppb.New(now.Add(-time.Minute))},
+		{Title: "too long", Start: timestamppb.New(now), End: timestamppb.New(now.Add(32 * 24 * time.Hour))},
+	} {
+		_, err := s.CreateAnnotation(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), req.Title)
+	}
+
+	_, err := s.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.QueryAnnotations(ctx, &pb.QueryAnnotationsRequest{
+		Start: timestamppb.New(time.Unix(0, 0)),
+		End:   timestamppb.New(now),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.DeleteAnnotation(ctx, &pb.DeleteAnnotationRequest{Id: "annotation"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -18,6 +18,8 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/labels"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/parca-dev/parca/pkg/profile"
 )
@@ -130,6 +132,10 @@ func QueryToFilters(query string, startNanos, endNanos int64) (string, []interfa
 	if err != nil {
 		return "", nil, qp, err
 	}
+	if qp.Derived != "" {
+		// Derived profile types are evaluated by the query API.
+		return "", nil, qp, status.Errorf(codes.InvalidArgument, "derived profile type %q can only be merged", qp.Derived)
+	}
 
 	// Profile type filter
 	profileFilter, profileArgs := ProfileTypeFilter(qp)
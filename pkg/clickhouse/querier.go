@@ -15,7 +15,10 @@ package clickhouse
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,6 +36,7 @@ import (
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
 	"github.com/parca-dev/parca/pkg/symbolizer"
 )
 
@@ -43,6 +47,7 @@ type Querier struct {
 	tracer     trace.Tracer
 	mem        memory.Allocator
 	symbolizer symbolizer.SymbolizationClient
+	semantics  *profile.SemanticsRegistry
 }
 
 // NewQuerier creates a new ClickHouse querier.
@@ -52,13 +57,18 @@ func NewQuerier(
 	tracer trace.Tracer,
 	mem memory.Allocator,
 	sym symbolizer.SymbolizationClient,
+	semantics *profile.SemanticsRegistry,
 ) *Querier {
+	if semantics == nil {
+		semantics = profile.NewSemanticsRegistry()
+	}
 	return &Querier{
 		client:     client,
 		logger:     logger,
 		tracer:     tracer,
 		mem:        mem,
 		symbolizer: sym,
+		semantics:  semantics,
 	}
 }
 
@@ -262,6 +272,170 @@ func (q *Querier) ProfileTypes(
 	return result, nil
 }
 
+// Series returns the distinct label sets and profile types of the series
+// matching any of the selectors, along with the first and last time a sample
+// was seen within the time window.
+func (q *Querier) Series(
+	ctx context.Context,
+	match []string,
+	start, end time.Time,
+) ([]*pb.Series, error) {
+	ctx, span := q.tracer.Start(ctx, "ClickHouse/Series")
+	defer span.End()
+
+	var (
+		selectorConditions []string
+		args               []interface{}
+	)
+	for _, selector := range match {
+		qp, hasProfileType, err := profile.ParseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		var conditions []string
+		if hasProfileType {
+			profileFilter, profileArgs := ProfileTypeFilter(qp)
+			conditions = append(conditions, profileFilter)
+			args = append(args, profileArgs...)
+		}
+
+		labelFilter, labelArgs, err := LabelMatchersToSQL(qp.Matchers)
+		if err != nil {
+			return nil, err
+		}
+		if labelFilter != "" {
+			conditions = append(conditions, labelFilter)
+			args = append(args, labelArgs...)
+		}
+
+		if len(conditions) == 0 {
+			// A selector without any matchers matches every series.
+			selectorConditions = nil
+			args = nil
+			break
+		}
+		selectorConditions = append(selectorConditions, "("+strings.Join(conditions, " AND ")+")")
+	}
+
+	var conditions []string
+	if len(selectorConditions) > 0 {
+		conditions = append(conditions, "("+strings.Join(selectorConditions, " OR ")+")")
+	}
+
+	timeFilter, timeArgs := TimeRangeFilter(start.UnixNano(), end.UnixNano())
+	conditions = append(conditions, timeFilter)
+	args = append(args, timeArgs...)
+
+	where, args := BuildWhereClause(conditions, args)
+
+	// Labels are grouped by their string representation, as it is forbidden to
+	// group by dynamic types in ClickHouse by default.
+	sqlQuery := fmt.Sprintf(`
+		SELECT
+			name,
+			sample_type,
+			sample_unit,
+			period_type,
+			period_unit,
+			(duration > 0) as delta,
+			toString(labels) as labels_json,
+			min(time_nanos) as first_seen,
+			max(time_nanos) as last_seen
+		FROM %s
+		%s
+		GROUP BY name, sample_type, sample_unit, period_type, period_unit, delta, labels_json
+		ORDER BY name, sample_type, sample_unit, period_type, period_unit, delta, labels_json
+	`, q.client.FullTableName(), where)
+
+	rows, err := q.client.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*pb.Series
+	for rows.Next() {
+		var (
+			pt         pb.ProfileType
+			labelsJSON string
+			firstSeen  int64
+			lastSeen   int64
+		)
+		if err := rows.Scan(
+			&pt.Name,
+			&pt.SampleType,
+			&pt.SampleUnit,
+			&pt.PeriodType,
+			&pt.PeriodUnit,
+			&pt.Delta,
+			&labelsJSON,
+			&firstSeen,
+			&lastSeen,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan series: %w", err)
+		}
+
+		ls, err := labelsFromJSON(labelsJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, &pb.Series{
+			Labelset:    &profilestorepb.LabelSet{Labels: ls},
+			ProfileType: &pt,
+			FirstSeen:   timestamppb.New(time.Unix(0, firstSeen)),
+			LastSeen:    timestamppb.New(time.Unix(0, lastSeen)),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// labelsFromJSON converts the string representation of the labels JSON column
+// to a label set sorted by name. Label names containing dots are stored as
+// nested objects by ClickHouse, so these are flattened again.
+func labelsFromJSON(s string) ([]*profilestorepb.Label, error) {
+	if s == "" {
+		return []*profilestorepb.Label{}, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode labels: %w", err)
+	}
+
+	var res []*profilestorepb.Label
+	var flatten func(prefix string, obj map[string]interface{})
+	flatten = func(prefix string, obj map[string]interface{}) {
+		for k, v := range obj {
+			name := prefix + k
+			switch v := v.(type) {
+			case map[string]interface{}:
+				flatten(name+".", v)
+			case string:
+				if v != "" {
+					res = append(res, &profilestorepb.Label{Name: name, Value: v})
+				}
+			case nil:
+			default:
+				res = append(res, &profilestorepb.Label{Name: name, Value: fmt.Sprint(v)})
+			}
+		}
+	}
+	flatten("", obj)
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
 // HasProfileData checks if there is any profile data in the store.
 func (q *Querier) HasProfileData(ctx context.Context) (bool, error) {
 	types, err := q.ProfileTypes(ctx, time.UnixMilli(0), time.UnixMilli(0))
@@ -281,6 +455,7 @@ func (q *Querier) QueryRange(
 	step time.Duration,
 	limit uint32,
 	sumBy []string,
+	filters []*pb.Filter,
 ) ([]*pb.MetricsSeries, error) {
 	ctx, span := q.tracer.Start(ctx, "ClickHouse/QueryRange")
 	defer span.End()
@@ -290,6 +465,15 @@ func (q *Querier) QueryRange(
 		return nil, err
 	}
 
+	matcher, err := profilefilter.NewMatcher(filters)
+	if err != nil {
+		return nil, err
+	}
+	if matcher.HasFrameFilters() {
+		// Series values are sums over whole stacks, dropping frames doesn't change them.
+		return nil, status.Error(codes.InvalidArgument, "frame filters are not supported in range queries, use stack filters instead")
+	}
+
 	// The step cannot be lower than 1s
 	if step < time.Second {
 		step = time.Second
@@ -324,6 +508,40 @@ func (q *Querier) QueryRange(
 		outerLabelSelects = strings.Join(outerSelects, ", ") + ", "
 	}
 
+	filter := profileFilter + " AND time_nanos >= ? AND time_nanos <= ?"
+	filterArgs := append(append([]interface{}{}, profileArgs...), start, end)
+	if labelFilter != "" {
+		filter += " AND " + labelFilter
+		filterArgs = append(filterArgs, labelArgs...)
+	}
+
+	// Snapshots of gauges and cumulative values must not be summed.
+	aggregation := q.semantics.Lookup(qp).Aggregation
+	snapshotsFactorSelect, snapshotsFactorTuple := "", ""
+	switch aggregation {
+	case profile.AggregationAverage:
+		snapshotsFactorSelect = ", uniqExact(toString(labels)) / uniqExact(time_nanos, toString(labels)) as snapshots_factor"
+		snapshotsFactorTuple = ", snapshots_factor"
+	case profile.AggregationLatest:
+		latest, latestArgs := q.latestSnapshotsFilter(filter, filterArgs, step)
+		filter += " AND " + latest
+		filterArgs = append(filterArgs, latestArgs...)
+	}
+
+	var filtered map[seriesKey]int64
+	if matcher.HasStackFilters() {
+		filtered, err = q.stackFilteredSeriesValues(
+			ctx,
+			step,
+			sumBy, sumBySelects,
+			filter, filterArgs,
+			matcher,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Build the query with a subquery that aggregates by timestamp and labels,
 	// then an outer query that groups by labels and collects samples into an array.
 	// This reduces the number of rows returned and moves grouping logic to ClickHouse.
@@ -334,25 +552,16 @@ func (q *Querier) QueryRange(
 			sum(value) as total_sum,
 			min(duration) as duration_min
 			%s
+			%s
 		FROM %s
 		WHERE %s
-		  AND time_nanos >= ? AND time_nanos <= ?
-	`, sumBySelects, table, profileFilter)
+		GROUP BY ALL
+		ORDER BY timestamp_bucket
+	`, snapshotsFactorSelect, sumBySelects, table, filter)
 
 	// Build args in the correct order matching placeholder positions
 	args := []interface{}{step.Nanoseconds(), step.Nanoseconds()}
-	args = append(args, profileArgs...)
-	args = append(args, start, end)
-
-	if labelFilter != "" {
-		innerQuery += " AND " + labelFilter
-		args = append(args, labelArgs...)
-	}
-
-	innerQuery += `
-		GROUP BY ALL
-		ORDER BY timestamp_bucket
-	`
+	args = append(args, filterArgs...)
 
 	// Outer query groups by labels and collects (timestamp, value, duration) tuples.
 	// GROUP BY ALL handles both cases: with labels it groups by them, without labels
@@ -360,10 +569,10 @@ func (q *Querier) QueryRange(
 	sqlQuery := fmt.Sprintf(`
 		SELECT
 			%s
-			groupArray(tuple(timestamp_bucket, total_sum, duration_min)) as samples
+			groupArray(tuple(timestamp_bucket, total_sum, duration_min%s)) as samples
 		FROM (%s)
 		GROUP BY ALL
-	`, outerLabelSelects, innerQuery)
+	`, outerLabelSelects, snapshotsFactorTuple, innerQuery)
 
 	rows, err := q.client.Query(ctx, sqlQuery, args...)
 	if err != nil {
@@ -408,6 +617,13 @@ func (q *Querier) QueryRange(
 			totalSum := sample[1].(int64)
 			durationMin := sample[2].(int64)
 
+			if filtered != nil {
+				totalSum = filtered[seriesKey{labels: strings.Join(labelValues, labelValueSeparator), ts: timestampBucket}]
+			}
+			if aggregation == profile.AggregationAverage {
+				totalSum = int64(math.Round(float64(totalSum) * sample[3].(float64)))
+			}
+
 			// Calculate value per second
 			valuePerSecond := float64(totalSum)
 			if durationMin > 0 {
@@ -450,6 +666,129 @@ func (q *Querier) QueryRange(
 	return resSeries, nil
 }
 
+// labelValueSeparator joins the sumBy label values of a series into a single
+// map key. It cannot be part of a valid UTF-8 label value.
+const labelValueSeparator = "\xff"
+
+type seriesKey struct {
+	labels string
+	ts     int64
+}
+
+// stackFilteredSeriesValues queries the value sums of a range query grouped by
+// stacktrace in addition to the timestamp bucket and labels. The stacks are
+// symbolized so the stack filters of the matcher can be applied, and the values
+// of the matching stacks are summed up per timestamp bucket and labelset.
+func (q *Querier) stackFilteredSeriesValues(
+	ctx context.Context,
+	step time.Duration,
+	sumBy []string,
+	sumBySelects string,
+	filter string,
+	filterArgs []interface{},
+	matcher *profilefilter.Matcher,
+) (map[seriesKey]int64, error) {
+	ctx, span := q.tracer.Start(ctx, "ClickHouse/stackFilteredSeriesValues")
+	defer span.End()
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT
+			stacktrace.address,
+			stacktrace.mapping_start,
+			stacktrace.mapping_limit,
+			stacktrace.mapping_offset,
+			stacktrace.mapping_file,
+			stacktrace.mapping_build_id,
+			stacktrace.line_number,
+			stacktrace.function_name,
+			stacktrace.function_system_name,
+			stacktrace.function_filename,
+			stacktrace.function_start_line,
+			sum(value) as value_sum,
+			intDiv(time_nanos, ?) * ? as timestamp_bucket
+			%s
+		FROM %s
+		WHERE %s
+		GROUP BY ALL
+	`, sumBySelects, q.client.FullTableName(), filter)
+
+	args := []interface{}{step.Nanoseconds(), step.Nanoseconds()}
+	args = append(args, filterArgs...)
+
+	rows, err := q.client.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute stack filtered query range: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		samples []sampleData
+		keys    []seriesKey
+	)
+	for rows.Next() {
+		var (
+			s           sampleData
+			bucket      int64
+			labelValues = make([]string, len(sumBy))
+		)
+		scanArgs := []interface{}{
+			&s.addresses,
+			&s.mappingStarts,
+			&s.mappingLimits,
+			&s.mappingOffsets,
+			&s.mappingFiles,
+			&s.mappingBuildIDs,
+			&s.lineNumbers,
+			&s.functionNames,
+			&s.functionSystemNames,
+			&s.functionFilenames,
+			&s.functionStartLines,
+			&s.value,
+			&bucket,
+		}
+		for i := range labelValues {
+			scanArgs = append(scanArgs, &labelValues[i])
+		}
+
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		samples = append(samples, s)
+		keys = append(keys, seriesKey{labels: strings.Join(labelValues, labelValueSeparator), ts: bucket})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	records, err := q.samplesToArrowRecords(ctx, samples, false)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	res := map[seriesKey]int64{}
+	for _, record := range records {
+		r, err := profile.NewRecordReader(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(record.NumRows()); i++ {
+			if matcher.StackMatches(r, i) {
+				res[keys[i]] += r.Value.Value(i)
+			}
+		}
+	}
+
+	return res, nil
+}
+
 // QuerySingle executes a point query for a single timestamp.
 func (q *Querier) QuerySingle(
 	ctx context.Context,
@@ -598,6 +937,28 @@ func (q *Querier) QueryMerge(
 		groupByLabels = ", " + strings.Join(labels, ", ")
 	}
 
+	filter := profileFilter + " AND time_nanos >= ? AND time_nanos <= ?"
+	filterArgs := append(append([]interface{}{}, profileArgs...), startNanos, endNanos)
+	if labelFilter != "" {
+		filter += " AND " + labelFilter
+		filterArgs = append(filterArgs, labelArgs...)
+	}
+
+	// Snapshots of gauges and cumulative values must not be summed.
+	valueSum := "sum(value)"
+	switch q.mergeAggregation(qp, aggregateByLabels) {
+	case profile.AggregationAverage:
+		factor, err := q.snapshotsFactor(ctx, filter, filterArgs)
+		if err != nil {
+			return profile.Profile{}, err
+		}
+		valueSum = fmt.Sprintf("toInt64(round(sum(value) * %v))", factor)
+	case profile.AggregationLatest:
+		latest, latestArgs := q.latestSnapshotsFilter(filter, filterArgs, 0)
+		filter += " AND " + latest
+		filterArgs = append(filterArgs, latestArgs...)
+	}
+
 	// Query aggregates by stacktrace, period, and optional labels.
 	// Period is grouped by (like FrostDB's ColumnPeriod in selectMerge).
 	// Duration uses the query time range for per-second calculation (matching FrostDB).
@@ -616,23 +977,14 @@ func (q *Querier) QueryMerge(
 			stacktrace.function_system_name,
 			stacktrace.function_filename,
 			stacktrace.function_start_line,
-			sum(value) as value_sum,
+			%s as value_sum,
 			'' as labels_json,
 			CAST(%d AS Int64) as sample_duration,
 			period as sample_period
 		FROM %s
 		WHERE %s
-		  AND time_nanos >= ? AND time_nanos <= ?
-	`, queryDuration, table, profileFilter)
-
-	// Build args in the correct order matching placeholder positions
-	args := append([]interface{}{}, profileArgs...)
-	args = append(args, startNanos, endNanos)
-
-	if labelFilter != "" {
-		sqlQuery += " AND " + labelFilter
-		args = append(args, labelArgs...)
-	}
+	`, valueSum, queryDuration, table, filter)
+	args := filterArgs
 
 	sqlQuery += fmt.Sprintf(`
 		GROUP BY
@@ -834,13 +1186,7 @@ func (q *Querier) rowsToArrowRecords(
 	},
 	invertCallStacks bool,
 ) ([]arrow.RecordBatch, error) {
-	_, span := q.tracer.Start(ctx, "ClickHouse/rowsToArrowRecords")
-	defer span.End()
-
-	// First pass: collect all sample data and build symbolization requests
 	var samples []sampleData
-	locationIndex := make(map[string]map[uint64]*profile.Location) // buildID -> address -> location
-
 	for rows.Next() {
 		var s sampleData
 		if err := rows.Scan(
@@ -864,7 +1210,30 @@ func (q *Querier) rowsToArrowRecords(
 		}
 
 		samples = append(samples, s)
+	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return q.samplesToArrowRecords(ctx, samples, invertCallStacks)
+}
+
+// samplesToArrowRecords symbolizes the locations of the samples that have not
+// been symbolized yet and converts the samples to Arrow records. The rows of
+// the records are in the same order as the samples.
+func (q *Querier) samplesToArrowRecords(
+	ctx context.Context,
+	samples []sampleData,
+	invertCallStacks bool,
+) ([]arrow.RecordBatch, error) {
+	_, span := q.tracer.Start(ctx, "ClickHouse/samplesToArrowRecords")
+	defer span.End()
+
+	// First pass: build symbolization requests
+	locationIndex := make(map[string]map[uint64]*profile.Location) // buildID -> address -> location
+
+	for _, s := range samples {
 		// Collect locations that need symbolization
 		for i := 0; i < len(s.addresses); i++ {
 			buildID := ""
@@ -911,10 +1280,6 @@ func (q *Querier) rowsToArrowRecords(
 		}
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
-	}
-
 	// Call symbolizer for each build ID
 	for buildID, addrMap := range locationIndex {
 		locs := make([]*profile.Location, 0, len(addrMap))
@@ -1002,6 +1367,7 @@ func (q *Querier) rowsToArrowRecords(
 				for _, line := range symbolizedLoc.Lines {
 					w.Line.Append(true)
 					w.LineNumber.Append(line.Line)
+					w.ColumnNumber.Append(0) // Column info not available from symbolized locations
 					if line.Function != nil {
 						if err := w.FunctionName.Append([]byte(line.Function.Name)); err != nil {
 							level.Error(q.logger).Log("msg", "failed to append function name", "err", err)
@@ -1025,6 +1391,7 @@ func (q *Querier) rowsToArrowRecords(
 				w.Lines.Append(true)
 				w.Line.Append(true)
 				w.LineNumber.Append(s.lineNumbers[idx])
+				w.ColumnNumber.Append(0)
 				if err := w.FunctionName.Append([]byte(s.functionNames[idx])); err != nil {
 					level.Error(q.logger).Log("msg", "failed to append function name", "err", err)
 				}
@@ -0,0 +1,370 @@
+// Copyright 2024-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// fakeConn answers queries with the rows returned by the query function, all
+// other methods of the connection are not implemented.
+type fakeConn struct {
+	driver.Conn
+
+	query func(query string, args []any) [][]any
+}
+
+func (c *fakeConn) Query(_ context.Context, query string, args ...any) (driver.Rows, error) {
+	return &fakeRows{rows: c.query(query, args)}, nil
+}
+
+type fakeRows struct {
+	driver.Rows
+
+	rows [][]any
+	row  []any
+}
+
+func (r *fakeRows) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	r.row, r.rows = r.rows[0], r.rows[1:]
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if len(dest) != len(r.row) {
+		return fmt.Errorf("scan %d columns into %d destinations", len(r.row), len(dest))
+	}
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.row[i]))
+	}
+	return nil
+}
+
+func (r *fakeRows) Err() error   { return nil }
+func (r *fakeRows) Close() error { return nil }
+
+func newFakeQuerier(query func(query string, args []any) [][]any) *Querier {
+	return NewQuerier(
+		&Client{conn: &fakeConn{query: query}, cfg: Config{Database: "parca", Table: "stacktraces"}},
+		log.NewNopLogger(),
+		noop.NewTracerProvider().Tracer(""),
+		memory.DefaultAllocator,
+		nil,
+		nil,
+	)
+}
+
+func TestLabelsFromJSON(t *testing.T) {
+	ls, err := labelsFromJSON(`{"pod":"parca-0","job":"parca","app":{"kubernetes":{"io/name":"parca"}},"empty":"","replica":1}`)
+	require.NoError(t, err)
+	require.Equal(t, []*profilestorepb.Label{
+		{Name: "app.kubernetes.io/name", Value: "parca"},
+		{Name: "job", Value: "parca"},
+		{Name: "pod", Value: "parca-0"},
+		{Name: "replica", Value: "1"},
+	}, ls)
+
+	ls, err = labelsFromJSON("")
+	require.NoError(t, err)
+	require.Empty(t, ls)
+
+	_, err = labelsFromJSON("{")
+	require.Error(t, err)
+}
+
+// testSample is a row of the stacktraces table, its stack is ordered from the
+// leaf to the root.
+type testSample struct {
+	stack    []string
+	instance string
+	bucket   int64
+	value    int64
+	duration int64
+}
+
+// rangeQueryRows aggregates the samples like ClickHouse does for the range
+// query and the stack filtered range query, grouped by the instance label.
+func rangeQueryRows(samples []testSample) func(string, []any) [][]any {
+	return func(query string, _ []any) [][]any {
+		rows := [][]any{}
+		if strings.Contains(query, "stacktrace.address") {
+			for _, s := range samples {
+				addresses := make([]uint64, len(s.stack))
+				for i := range s.stack {
+					addresses[i] = uint64(0x1000 * (i + 1))
+				}
+				empty := make([]string, len(s.stack))
+				zeros := make([]int64, len(s.stack))
+				rows = append(rows, []any{
+					addresses, make([]uint64, len(s.stack)), make([]uint64, len(s.stack)), make([]uint64, len(s.stack)),
+					empty, empty, zeros, s.stack, s.stack, empty, zeros,
+					s.value, s.bucket, s.instance,
+				})
+			}
+			return rows
+		}
+
+		series := map[string][][]any{}
+		instances := []string{}
+		for _, s := range samples {
+			if _, ok := series[s.instance]; !ok {
+				instances = append(instances, s.instance)
+			}
+			found := false
+			for _, sample := range series[s.instance] {
+				if sample[0].(int64) == s.bucket {
+					sample[1] = sample[1].(int64) + s.value
+					found = true
+				}
+			}
+			if !found {
+				series[s.instance] = append(series[s.instance], []any{s.bucket, s.value, s.duration})
+			}
+		}
+		for _, instance := range instances {
+			rows = append(rows, []any{instance, series[instance]})
+		}
+		return rows
+	}
+}
+
+func seriesValues(series []*pb.MetricsSeries) map[string][]int64 {
+	res := map[string][]int64{}
+	for _, s := range series {
+		key := ""
+		for _, l := range s.Labelset.Labels {
+			key += l.Name + "=" + l.Value
+		}
+		for _, sample := range s.Samples {
+			res[key] = append(res[key], sample.Value)
+		}
+	}
+	return res
+}
+
+func functionNameStackFilter(name string) []*pb.Filter {
+	return []*pb.Filter{{
+		Filter: &pb.Filter_StackFilter{
+			StackFilter: &pb.StackFilter{
+				Filter: &pb.StackFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_Equal{Equal: name},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestQueryRangeStackFilters(t *testing.T) {
+	ctx := context.Background()
+	step := 10 * time.Second
+	start, end := time.Unix(1000, 0), time.Unix(1060, 0)
+	b0, b1 := start.UnixNano(), start.Add(step).UnixNano()
+
+	t.Run("delta", func(t *testing.T) {
+		duration := step.Nanoseconds()
+		q := newFakeQuerier(rangeQueryRows([]testSample{
+			{stack: []string{"compute", "main"}, instance: "a", bucket: b0, value: 30, duration: duration},
+			{stack: []string{"read", "main"}, instance: "a", bucket: b0, value: 10, duration: duration},
+			{stack: []string{"compute", "main"}, instance: "a", bucket: b1, value: 20, duration: duration},
+			{stack: []string{"read", "main"}, instance: "b", bucket: b0, value: 40, duration: duration},
+		}))
+
+		query := `test:samples:count:cpu:nanoseconds:delta{job="app"}`
+		all, err := q.QueryRange(ctx, query, start, end, step, 0, []string{"instance"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]int64{"instance=a": {40, 20}, "instance=b": {40}}, seriesValues(all))
+
+		res, err := q.QueryRange(ctx, query, start, end, step, 0, []string{"instance"}, functionNameStackFilter("compute"))
+		require.NoError(t, err)
+		// Buckets without matching stacks are kept with a zero value.
+		require.Equal(t, map[string][]int64{"instance=a": {30, 20}, "instance=b": {0}}, seriesValues(res))
+
+		// The per-second values are scaled by the share of the matching stacks.
+		for i, s := range res {
+			for j, sample := range s.Samples {
+				unfiltered := all[i].Samples[j]
+				require.Equal(t, unfiltered.Duration, sample.Duration)
+				require.InDelta(t, unfiltered.ValuePerSecond*float64(sample.Value)/float64(unfiltered.Value), sample.ValuePerSecond, 1e-9)
+			}
+		}
+	})
+
+	t.Run("non-delta", func(t *testing.T) {
+		q := newFakeQuerier(rangeQueryRows([]testSample{
+			{stack: []string{"compute", "main"}, instance: "a", bucket: b0, value: 300},
+			{stack: []string{"read", "main"}, instance: "a", bucket: b0, value: 100},
+			{stack: []string{"read", "main"}, instance: "a", bucket: b1, value: 50},
+		}))
+
+		res, err := q.QueryRange(ctx, `test:inuse_space:bytes:space:bytes{job="app"}`, start, end, step, 0, []string{"instance"}, functionNameStackFilter("read"))
+		require.NoError(t, err)
+		require.Equal(t, map[string][]int64{"instance=a": {100, 50}}, seriesValues(res))
+		for _, sample := range res[0].Samples {
+			require.Equal(t, float64(sample.Value), sample.ValuePerSecond)
+		}
+	})
+
+	t.Run("frame filters", func(t *testing.T) {
+		q := newFakeQuerier(rangeQueryRows(nil))
+		_, err := q.QueryRange(ctx, `test:inuse_space:bytes:space:bytes{}`, start, end, step, 0, nil, []*pb.Filter{{
+			Filter: &pb.Filter_FrameFilter{
+				FrameFilter: &pb.FrameFilter{
+					Filter: &pb.FrameFilter_Criteria{
+						Criteria: &pb.FilterCriteria{
+							FunctionName: &pb.StringCondition{
+								Condition: &pb.StringCondition_Equal{Equal: "main"},
+							},
+						},
+					},
+				},
+			},
+		}})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSeries(t *testing.T) {
+	ctx := context.Background()
+	start, end := time.Unix(1000, 0), time.Unix(2000, 0)
+
+	var (
+		gotQuery string
+		gotArgs  []any
+	)
+	q := newFakeQuerier(func(query string, args []any) [][]any {
+		gotQuery, gotArgs = query, args
+		return [][]any{
+			{"memory", "inuse_space", "bytes", "space", "bytes", false, `{"job":"api"}`, int64(1100), int64(1900)},
+			{"process_cpu", "samples", "count", "cpu", "nanoseconds", true, `{"job":"api","instance":"a"}`, int64(1000), int64(2000)},
+		}
+	})
+
+	res, err := q.Series(ctx, []string{
+		`process_cpu:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		`{job="api"}`,
+	}, start, end)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "memory", res[0].ProfileType.Name)
+	require.False(t, res[0].ProfileType.Delta)
+	require.True(t, res[1].ProfileType.Delta)
+	require.Equal(t, []*profilestorepb.Label{
+		{Name: "instance", Value: "a"},
+		{Name: "job", Value: "api"},
+	}, res[1].Labelset.Labels)
+	require.Equal(t, int64(1000), res[1].FirstSeen.AsTime().UnixNano())
+	require.Equal(t, int64(2000), res[1].LastSeen.AsTime().UnixNano())
+
+	// The selectors are combined with OR, the profile type of the first one
+	// is matched as well as its labels.
+	require.Contains(t, gotQuery, ") OR (")
+	require.Contains(t, gotQuery, "GROUP BY name, sample_type, sample_unit, period_type, period_unit, delta, labels_json")
+	require.Equal(t, []any{
+		"process_cpu", "samples", "count", "cpu", "nanoseconds", "api",
+		"api",
+		start.UnixNano(), end.UnixNano(),
+	}, gotArgs)
+
+	// A selector without matchers matches all series.
+	_, err = q.Series(ctx, []string{`{job="api"}`, `{}`}, start, end)
+	require.NoError(t, err)
+	require.NotContains(t, gotQuery, " OR ")
+	require.Equal(t, []any{start.UnixNano(), end.UnixNano()}, gotArgs)
+
+	_, err = q.Series(ctx, []string{`{job=`}, start, end)
+	require.Error(t, err)
+}
+
+func TestGaugeSnapshots(t *testing.T) {
+	ctx := context.Background()
+	step := 10 * time.Second
+	start, end := time.Unix(1000, 0), time.Unix(1060, 0)
+	query := `goroutine:goroutine:count:goroutine:count{job="app"}`
+
+	var queries []string
+	q := newFakeQuerier(func(query string, args []any) [][]any {
+		require.Equal(t, strings.Count(query, "?"), len(args))
+		queries = append(queries, query)
+		switch {
+		case strings.Contains(query, "groupArray"):
+			sample := []any{start.UnixNano(), int64(30), int64(0)}
+			if strings.Contains(query, "snapshots_factor") {
+				sample = append(sample, 0.5)
+			}
+			return [][]any{{"a", [][]any{sample}}}
+		case strings.Contains(query, "as snapshots"):
+			// Two series with two snapshots each.
+			return [][]any{{uint64(2), uint64(4)}}
+		}
+		return nil
+	})
+
+	t.Run("average", func(t *testing.T) {
+		queries = nil
+		res, err := q.QueryRange(ctx, query, start, end, step, 0, []string{"instance"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]int64{"instance=a": {15}}, seriesValues(res))
+
+		queries = nil
+		_, err = q.QueryMerge(ctx, query, start, end, nil, false, "")
+		require.NoError(t, err)
+		require.Len(t, queries, 2)
+		require.Contains(t, queries[1], "toInt64(round(sum(value) * 0.5)) as value_sum")
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		q.semantics = profile.NewSemanticsRegistry()
+		require.NoError(t, q.semantics.Register("goroutine:goroutine:count", profile.Semantics{
+			Kind:        profile.ValueKindGauge,
+			Aggregation: profile.AggregationLatest,
+		}))
+
+		queries = nil
+		res, err := q.QueryRange(ctx, query, start, end, step, 0, []string{"instance"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]int64{"instance=a": {30}}, seriesValues(res))
+		require.Contains(t, queries[0], "(toString(labels), intDiv(time_nanos, ?), time_nanos) IN (")
+
+		queries = nil
+		_, err = q.QueryMerge(ctx, query, start, end, nil, false, "")
+		require.NoError(t, err)
+		require.Len(t, queries, 1)
+		require.Contains(t, queries[0], "(toString(labels), time_nanos) IN (")
+		require.Contains(t, queries[0], "sum(value) as value_sum")
+	})
+}
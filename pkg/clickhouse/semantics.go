@@ -0,0 +1,92 @@
+// Copyright 2024-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// mergeAggregation returns how the snapshots of the queried profile type are
+// aggregated by a merge.
+func (q *Querier) mergeAggregation(qp profile.QueryParts, aggregateByLabels []string) profile.Aggregation {
+	if slices.Contains(aggregateByLabels, profile.ColumnTimestamp) {
+		// The snapshots are kept apart anyway.
+		return profile.AggregationSum
+	}
+	return q.semantics.Lookup(qp).Aggregation
+}
+
+// snapshotsFactor returns the number of series divided by the number of
+// snapshots matching the filter. Multiplying the sum of all snapshots with it
+// averages the snapshots per series.
+func (q *Querier) snapshotsFactor(ctx context.Context, filter string, args []interface{}) (float64, error) {
+	ctx, span := q.tracer.Start(ctx, "ClickHouse/snapshotsFactor")
+	defer span.End()
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT
+			uniqExact(toString(labels)) as series,
+			uniqExact(time_nanos, toString(labels)) as snapshots
+		FROM %s
+		WHERE %s
+	`, q.client.FullTableName(), filter)
+
+	rows, err := q.client.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var series, snapshots uint64
+	for rows.Next() {
+		if err := rows.Scan(&series, &snapshots); err != nil {
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if snapshots == 0 {
+		return 1, nil
+	}
+	return float64(series) / float64(snapshots), nil
+}
+
+// latestSnapshotsFilter returns a condition matching only the latest snapshot
+// of every series among the ones matching the filter. With a step the latest
+// snapshot of every step is matched.
+func (q *Querier) latestSnapshotsFilter(filter string, args []interface{}, step time.Duration) (string, []interface{}) {
+	if step <= 0 {
+		return fmt.Sprintf(`(toString(labels), time_nanos) IN (
+			SELECT toString(labels), max(time_nanos)
+			FROM %s
+			WHERE %s
+			GROUP BY toString(labels)
+		)`, q.client.FullTableName(), filter), args
+	}
+
+	res := []interface{}{step.Nanoseconds(), step.Nanoseconds()}
+	return fmt.Sprintf(`(toString(labels), intDiv(time_nanos, ?), time_nanos) IN (
+		SELECT toString(labels), intDiv(time_nanos, ?) as bucket, max(time_nanos)
+		FROM %s
+		WHERE %s
+		GROUP BY toString(labels), bucket
+	)`, q.client.FullTableName(), filter), append(res, args...)
+}
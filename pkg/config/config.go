@@ -32,6 +32,8 @@ import (
 	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/thanos-io/objstore/client"
 	"gopkg.in/yaml.v3"
+
+	"github.com/parca-dev/parca/pkg/profile"
 )
 
 const (
@@ -44,19 +46,166 @@ const (
 
 // Config holds all the configuration information for Parca.
 type Config struct {
-	ObjectStorage *ObjectStorage  `yaml:"object_storage,omitempty"`
-	ScrapeConfigs []*ScrapeConfig `yaml:"scrape_configs,omitempty"`
+	ObjectStorage       *ObjectStorage              `yaml:"object_storage,omitempty"`
+	ScrapeConfigs       []*ScrapeConfig             `yaml:"scrape_configs,omitempty"`
+	RecordingRules      []*RecordingRule            `yaml:"recording_rules,omitempty"`
+	AlertingRules       []*AlertingRule             `yaml:"alerting_rules,omitempty"`
+	Alerting            *AlertingConfig             `yaml:"alerting,omitempty"`
+	ProfileTypes        []*ProfileTypeConfig        `yaml:"profile_types,omitempty"`
+	DerivedProfileTypes []*DerivedProfileTypeConfig `yaml:"derived_profile_types,omitempty"`
 }
 
 type ObjectStorage struct {
 	Bucket *client.BucketConfig `yaml:"bucket,omitempty"`
 }
 
+// RecordingRule configures a profile query that is evaluated periodically and
+// exported as a Prometheus gauge.
+type RecordingRule struct {
+	// The name of the exported metric.
+	Record string `yaml:"record"`
+	// The profile query, for example
+	// parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}.
+	Query string `yaml:"query"`
+	// Only stacks matching all filters are counted.
+	StackFilters []*StackFilter `yaml:"stack_filters,omitempty"`
+	// The labels the exported metric is labelled by.
+	SumBy []string `yaml:"sum_by,omitempty"`
+	// How frequently to evaluate the rule, each evaluation covers the
+	// profiles of the last interval.
+	Interval model.Duration `yaml:"interval,omitempty"`
+}
+
+// StackFilter matches stacks containing a frame whose fields contain all of
+// the set values.
+type StackFilter struct {
+	FunctionName string `yaml:"function_name,omitempty"`
+	Binary       string `yaml:"binary,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *RecordingRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain RecordingRule
+	unmarshalled := plain{
+		Interval: model.Duration(time.Minute),
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+	*r = RecordingRule(unmarshalled)
+	return nil
+}
+
+// AlertingRule configures a profile query that is evaluated periodically and
+// fires an alert per series whose value exceeds the threshold.
+type AlertingRule struct {
+	// The name of the alert.
+	Alert string `yaml:"alert"`
+	// The profile query, for example
+	// parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}.
+	Query string `yaml:"query"`
+	// Only stacks matching all filters are counted.
+	StackFilters []*StackFilter `yaml:"stack_filters,omitempty"`
+	// Share compares the share of the filtered stacks in all stacks of the
+	// query, between 0 and 1, instead of their value.
+	Share bool `yaml:"share,omitempty"`
+	// The labels the alerts are split by.
+	SumBy []string `yaml:"sum_by,omitempty"`
+	// The alert fires if the value is above the threshold.
+	Threshold float64 `yaml:"threshold"`
+	// How long the value has to be above the threshold before the alert
+	// fires.
+	For model.Duration `yaml:"for,omitempty"`
+	// How frequently to evaluate the rule, each evaluation covers the
+	// profiles of the last interval.
+	Interval model.Duration `yaml:"interval,omitempty"`
+	// Labels added to the alerts.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Annotations of the alerts. They are templated with the $labels and
+	// $value of the alert, for example {{ $labels.job }}.
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *AlertingRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain AlertingRule
+	unmarshalled := plain{
+		Interval: model.Duration(time.Minute),
+	}
+	if err := unmarshal(&unmarshalled); err != nil {
+		return err
+	}
+	*r = AlertingRule(unmarshalled)
+	return nil
+}
+
+// AlertingConfig configures where alerts are sent to.
+type AlertingConfig struct {
+	Webhooks []*WebhookConfig `yaml:"webhooks,omitempty"`
+}
+
+// WebhookConfig configures a webhook that receives firing and resolved alerts
+// in the JSON format of Alertmanager webhooks.
+type WebhookConfig struct {
+	URL              string                        `yaml:"url"`
+	HTTPClientConfig commonconfig.HTTPClientConfig `yaml:"http_config,omitempty"`
+	// The timeout of a notification.
+	Timeout model.Duration `yaml:"timeout,omitempty"`
+}
+
+// ProfileTypeConfig configures how the snapshots of a profile type are
+// aggregated when they are merged over a time range.
+type ProfileTypeConfig struct {
+	// The profile type of the form <name>:<sample-type>:<sample-unit>, for
+	// example goroutine:goroutine:count.
+	ProfileType string `yaml:"profile_type"`
+	// What the values measure, one of cumulative, delta or gauge.
+	Kind string `yaml:"kind"`
+	// How the snapshots are aggregated, one of sum, average or latest.
+	// Defaults to average for gauges, latest for cumulative values and sum
+	// otherwise.
+	Aggregation string `yaml:"aggregation,omitempty"`
+}
+
+// Semantics returns the profile type semantics of the config.
+func (c *ProfileTypeConfig) Semantics() profile.Semantics {
+	return profile.Semantics{
+		Kind:        profile.ValueKind(c.Kind),
+		Aggregation: profile.Aggregation(c.Aggregation),
+	}
+}
+
+// DerivedProfileTypeConfig configures a virtual profile type whose values are
+// the ratio of two profile types, queried as derived:<name>{...}.
+type DerivedProfileTypeConfig struct {
+	Name string `yaml:"name"`
+	// The profile types of the form
+	// <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>[:delta],
+	// for example instructions per cycle divides the instructions by the
+	// cycles.
+	Numerator   string `yaml:"numerator"`
+	Denominator string `yaml:"denominator"`
+}
+
+// DerivedType returns the derived profile type of the config.
+func (c *DerivedProfileTypeConfig) DerivedType() profile.DerivedType {
+	return profile.DerivedType{
+		Name:        c.Name,
+		Numerator:   c.Numerator,
+		Denominator: c.Denominator,
+	}
+}
+
 // Validate returns an error if the config is not valid.
 func (c *Config) Validate() error {
 	if err := validation.ValidateStruct(c,
 		validation.Field(&c.ObjectStorage, validation.Required, ObjectStorageValid),
 		validation.Field(&c.ScrapeConfigs, ScrapeConfigsValid),
+		validation.Field(&c.RecordingRules, RecordingRulesValid),
+		validation.Field(&c.AlertingRules, AlertingRulesValid),
+		validation.Field(&c.Alerting, AlertingValid),
+		validation.Field(&c.ProfileTypes, ProfileTypesValid),
+		validation.Field(&c.DerivedProfileTypes, DerivedProfileTypesValid),
 	); err != nil {
 		return err
 	}
@@ -334,3 +334,190 @@ object_storage:
 	require.NoError(t, err)
 	require.Equal(t, expected, c)
 }
+
+func TestLoadProfileTypes(t *testing.T) {
+	t.Parallel()
+
+	profileTypesYAML := `
+object_storage:
+  bucket:
+    type: "FILESYSTEM"
+    config:
+      directory: "./data"
+profile_types:
+  - profile_type: 'connections:open:count'
+    kind: 'gauge'
+    aggregation: 'latest'
+  - profile_type: 'memory:inuse_space:bytes'
+    kind: 'gauge'
+`
+
+	config, err := Load(profileTypesYAML)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	require.Equal(t, []*ProfileTypeConfig{
+		{ProfileType: "connections:open:count", Kind: "gauge", Aggregation: "latest"},
+		{ProfileType: "memory:inuse_space:bytes", Kind: "gauge"},
+	}, config.ProfileTypes)
+
+	config.ProfileTypes = append(config.ProfileTypes, &ProfileTypeConfig{ProfileType: "connections:open", Kind: "gauge"})
+	require.Error(t, config.Validate())
+
+	config.ProfileTypes[2] = &ProfileTypeConfig{ProfileType: "connections:open:count", Kind: "gauge"}
+	err = config.Validate()
+	require.Error(t, err)
+	require.Equal(t, "ProfileTypes: duplicate profile_type found in profile types: connections:open:count.", err.Error())
+
+	config.ProfileTypes[2] = &ProfileTypeConfig{ProfileType: "connections:closed:count", Kind: "counter"}
+	require.Error(t, config.Validate())
+}
+
+func TestLoadDerivedProfileTypes(t *testing.T) {
+	t.Parallel()
+
+	derivedYAML := `
+object_storage:
+  bucket:
+    type: "FILESYSTEM"
+    config:
+      directory: "./data"
+derived_profile_types:
+  - name: 'ipc'
+    numerator: 'perf:instructions:count:instructions:count'
+    denominator: 'perf:cycles:count:cycles:count'
+`
+
+	config, err := Load(derivedYAML)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	require.Equal(t, []*DerivedProfileTypeConfig{{
+		Name:        "ipc",
+		Numerator:   "perf:instructions:count:instructions:count",
+		Denominator: "perf:cycles:count:cycles:count",
+	}}, config.DerivedProfileTypes)
+
+	config.DerivedProfileTypes = append(config.DerivedProfileTypes, &DerivedProfileTypeConfig{
+		Name:        "ipc",
+		Numerator:   "perf:instructions:count:instructions:count",
+		Denominator: "perf:cycles:count:cycles:count",
+	})
+	err = config.Validate()
+	require.Error(t, err)
+	require.Equal(t, "DerivedProfileTypes: duplicate name found in derived profile types: ipc.", err.Error())
+
+	config.DerivedProfileTypes[1] = &DerivedProfileTypeConfig{Name: "cpi", Numerator: "perf:cycles", Denominator: "perf:instructions"}
+	require.Error(t, config.Validate())
+}
+
+func TestLoadRecordingRules(t *testing.T) {
+	t.Parallel()
+
+	rulesYAML := `
+object_storage:
+  bucket:
+    type: "FILESYSTEM"
+    config:
+      directory: "./data"
+recording_rules:
+  - record: 'parca_flate_cpu_nanoseconds'
+    query: 'parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}'
+    stack_filters:
+      - function_name: 'compress/flate'
+    sum_by: ['instance']
+  - record: 'parca_goroutines'
+    query: 'goroutine:goroutine:count:goroutine:count{}'
+    interval: 10s
+`
+
+	config, err := Load(rulesYAML)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	require.Equal(t, []*RecordingRule{{
+		Record:       "parca_flate_cpu_nanoseconds",
+		Query:        `parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		StackFilters: []*StackFilter{{FunctionName: "compress/flate"}},
+		SumBy:        []string{"instance"},
+		Interval:     model.Duration(time.Minute),
+	}, {
+		Record:   "parca_goroutines",
+		Query:    "goroutine:goroutine:count:goroutine:count{}",
+		Interval: model.Duration(10 * time.Second),
+	}}, config.RecordingRules)
+
+	invalid := []*RecordingRule{
+		{Record: "parca-goroutines", Query: "goroutine:goroutine:count:goroutine:count{}", Interval: model.Duration(time.Minute)},
+		{Record: "parca_goroutines", Query: "goroutine{}", Interval: model.Duration(time.Minute)},
+		{Record: "parca_goroutines", Query: "goroutine:goroutine:count:goroutine:count{}", SumBy: []string{"job-name"}, Interval: model.Duration(time.Minute)},
+		{Record: "parca_goroutines", Query: "goroutine:goroutine:count:goroutine:count{}", StackFilters: []*StackFilter{{}}, Interval: model.Duration(time.Minute)},
+		{Record: "parca_goroutines", Query: "goroutine:goroutine:count:goroutine:count{}"},
+		{Record: "parca_flate_cpu_nanoseconds", Query: "goroutine:goroutine:count:goroutine:count{}", Interval: model.Duration(time.Minute)},
+	}
+	for _, r := range invalid {
+		config.RecordingRules = []*RecordingRule{config.RecordingRules[0], r}
+		require.Error(t, config.Validate(), r.Record)
+	}
+}
+
+func TestLoadAlertingRules(t *testing.T) {
+	t.Parallel()
+
+	rulesYAML := `
+object_storage:
+  bucket:
+    type: "FILESYSTEM"
+    config:
+      directory: "./data"
+alerting_rules:
+  - alert: 'FlateCPUHigh'
+    query: 'parca_agent:samples:count:cpu:nanoseconds:delta{}'
+    stack_filters:
+      - function_name: 'compress/flate'
+    share: true
+    sum_by: ['job']
+    threshold: 0.15
+    for: 10m
+    labels:
+      severity: 'warning'
+    annotations:
+      summary: '{{ $labels.job }} spends {{ $value }} of CPU in flate'
+alerting:
+  webhooks:
+    - url: 'http://alertmanager:9093/api/v1/alerts'
+      timeout: 5s
+`
+
+	config, err := Load(rulesYAML)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	require.Equal(t, []*AlertingRule{{
+		Alert:        "FlateCPUHigh",
+		Query:        "parca_agent:samples:count:cpu:nanoseconds:delta{}",
+		StackFilters: []*StackFilter{{FunctionName: "compress/flate"}},
+		Share:        true,
+		SumBy:        []string{"job"},
+		Threshold:    0.15,
+		For:          model.Duration(10 * time.Minute),
+		Interval:     model.Duration(time.Minute),
+		Labels:       map[string]string{"severity": "warning"},
+		Annotations:  map[string]string{"summary": "{{ $labels.job }} spends {{ $value }} of CPU in flate"},
+	}}, config.AlertingRules)
+	require.Len(t, config.Alerting.Webhooks, 1)
+	require.Equal(t, "http://alertmanager:9093/api/v1/alerts", config.Alerting.Webhooks[0].URL)
+	require.Equal(t, model.Duration(5*time.Second), config.Alerting.Webhooks[0].Timeout)
+
+	invalid := []*AlertingRule{
+		{Query: "goroutine:goroutine:count:goroutine:count{}", Interval: model.Duration(time.Minute)},
+		{Alert: "GoroutinesHigh", Query: "goroutine{}", Interval: model.Duration(time.Minute)},
+		{Alert: "GoroutinesHigh", Query: "goroutine:goroutine:count:goroutine:count{}", Share: true, Interval: model.Duration(time.Minute)},
+		{Alert: "GoroutinesHigh", Query: "goroutine:goroutine:count:goroutine:count{}", Labels: map[string]string{"a-b": "c"}, Interval: model.Duration(time.Minute)},
+		{Alert: "FlateCPUHigh", Query: "goroutine:goroutine:count:goroutine:count{}", Interval: model.Duration(time.Minute)},
+	}
+	for _, r := range invalid {
+		config.AlertingRules = []*AlertingRule{config.AlertingRules[0], r}
+		require.Error(t, config.Validate(), r.Alert)
+	}
+
+	config.AlertingRules = nil
+	config.Alerting.Webhooks[0].URL = "alertmanager:9093"
+	require.Error(t, config.Validate())
+}
@@ -16,10 +16,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/prometheus/common/model"
 	"github.com/thanos-io/objstore/client"
+
+	"github.com/parca-dev/parca/pkg/profile"
 )
 
 // ObjectStorageValid is the ValidRule.
@@ -89,3 +93,204 @@ func (v ScrapeConfigsValidRule) Validate(value interface{}) error {
 
 	return nil
 }
+
+// ProfileTypesValid is the ValidRule.
+var ProfileTypesValid = ProfileTypesValidRule{}
+
+// ProfileTypesValidRule is a validation rule for the Config. It implements the validation.Rule interface.
+type ProfileTypesValidRule struct{}
+
+// Validate returns an error if the profile types are not valid.
+func (v ProfileTypesValidRule) Validate(value interface{}) error {
+	pts, ok := value.([]*ProfileTypeConfig)
+	if !ok {
+		return errors.New("ProfileTypes array is invalid")
+	}
+
+	seen := map[string]struct{}{}
+	registry := profile.NewSemanticsRegistry()
+	for _, pt := range pts {
+		if pt == nil {
+			return errors.New("empty or null profile type")
+		}
+		if _, ok := seen[pt.ProfileType]; ok {
+			return fmt.Errorf("duplicate profile_type found in profile types: %s", pt.ProfileType)
+		}
+		seen[pt.ProfileType] = struct{}{}
+
+		if err := registry.Register(pt.ProfileType, pt.Semantics()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DerivedProfileTypesValid is the ValidRule.
+var DerivedProfileTypesValid = DerivedProfileTypesValidRule{}
+
+// DerivedProfileTypesValidRule is a validation rule for the Config. It implements the validation.Rule interface.
+type DerivedProfileTypesValidRule struct{}
+
+// Validate returns an error if the derived profile types are not valid.
+func (v DerivedProfileTypesValidRule) Validate(value interface{}) error {
+	dts, ok := value.([]*DerivedProfileTypeConfig)
+	if !ok {
+		return errors.New("DerivedProfileTypes array is invalid")
+	}
+
+	seen := map[string]struct{}{}
+	for _, dt := range dts {
+		if dt == nil {
+			return errors.New("empty or null derived profile type")
+		}
+		if _, ok := seen[dt.Name]; ok {
+			return fmt.Errorf("duplicate name found in derived profile types: %s", dt.Name)
+		}
+		seen[dt.Name] = struct{}{}
+
+		if err := dt.DerivedType().Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordingRulesValid is the ValidRule.
+var RecordingRulesValid = RecordingRulesValidRule{}
+
+// RecordingRulesValidRule is a validation rule for the Config. It implements the validation.Rule interface.
+type RecordingRulesValidRule struct{}
+
+// Validate returns an error if the recording rules are not valid.
+func (v RecordingRulesValidRule) Validate(value interface{}) error {
+	rules, ok := value.([]*RecordingRule)
+	if !ok {
+		return errors.New("RecordingRules array is invalid")
+	}
+
+	seen := map[string]struct{}{}
+	for _, r := range rules {
+		if r == nil {
+			return errors.New("empty or null recording rule")
+		}
+		if !model.LegacyValidation.IsValidMetricName(r.Record) {
+			return fmt.Errorf("invalid record name %q", r.Record)
+		}
+		if _, ok := seen[r.Record]; ok {
+			return fmt.Errorf("duplicate record found in recording rules: %s", r.Record)
+		}
+		seen[r.Record] = struct{}{}
+
+		if err := validateRuleQuery("recording rule "+r.Record, r.Query, r.StackFilters, r.SumBy, r.Interval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AlertingRulesValid is the ValidRule.
+var AlertingRulesValid = AlertingRulesValidRule{}
+
+// AlertingRulesValidRule is a validation rule for the Config. It implements the validation.Rule interface.
+type AlertingRulesValidRule struct{}
+
+// Validate returns an error if the alerting rules are not valid.
+func (v AlertingRulesValidRule) Validate(value interface{}) error {
+	rules, ok := value.([]*AlertingRule)
+	if !ok {
+		return errors.New("AlertingRules array is invalid")
+	}
+
+	seen := map[string]struct{}{}
+	for _, r := range rules {
+		if r == nil {
+			return errors.New("empty or null alerting rule")
+		}
+		if r.Alert == "" {
+			return errors.New("alerting rule without alert name")
+		}
+		if _, ok := seen[r.Alert]; ok {
+			return fmt.Errorf("duplicate alert found in alerting rules: %s", r.Alert)
+		}
+		seen[r.Alert] = struct{}{}
+
+		if err := validateRuleQuery("alerting rule "+r.Alert, r.Query, r.StackFilters, r.SumBy, r.Interval); err != nil {
+			return err
+		}
+		if r.Share && len(r.StackFilters) == 0 {
+			return fmt.Errorf("alerting rule %q compares the share of its stack filters, but has none", r.Alert)
+		}
+		if r.For < 0 {
+			return fmt.Errorf("for of alerting rule %q must not be negative", r.Alert)
+		}
+		for name := range r.Labels {
+			if !model.LegacyValidation.IsValidLabelName(name) {
+				return fmt.Errorf("invalid label %q in alerting rule %q", name, r.Alert)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRuleQuery(rule, query string, stackFilters []*StackFilter, sumBy []string, interval model.Duration) error {
+	qp, err := profile.ParseQuery(query)
+	if err != nil {
+		return fmt.Errorf("invalid query of %s: %w", rule, err)
+	}
+	if qp.Derived != "" {
+		return fmt.Errorf("%s cannot query derived profile type %q", rule, qp.Derived)
+	}
+	for _, f := range stackFilters {
+		if f == nil || (f.FunctionName == "" && f.Binary == "") {
+			return fmt.Errorf("empty stack filter in %s", rule)
+		}
+	}
+	for _, l := range sumBy {
+		if !model.LegacyValidation.IsValidLabelName(l) {
+			return fmt.Errorf("invalid sum_by label %q in %s", l, rule)
+		}
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval of %s must be positive", rule)
+	}
+	return nil
+}
+
+// AlertingValid is the ValidRule.
+var AlertingValid = AlertingValidRule{}
+
+// AlertingValidRule is a validation rule for the Config. It implements the validation.Rule interface.
+type AlertingValidRule struct{}
+
+// Validate returns an error if the alerting config is not valid.
+func (v AlertingValidRule) Validate(value interface{}) error {
+	c, ok := value.(*AlertingConfig)
+	if !ok {
+		return errors.New("alerting config is invalid")
+	}
+	if c == nil {
+		return nil
+	}
+
+	for _, w := range c.Webhooks {
+		if w == nil {
+			return errors.New("empty or null webhook")
+		}
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook url %q", w.URL)
+		}
+		if err := w.HTTPClientConfig.Validate(); err != nil {
+			return err
+		}
+		if w.Timeout < 0 {
+			return fmt.Errorf("timeout of webhook %q must not be negative", w.URL)
+		}
+	}
+
+	return nil
+}
@@ -60,12 +60,16 @@ import (
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/yaml.v3"
 
+	alertspb "github.com/parca-dev/parca/gen/proto/go/parca/alerts/v1alpha1"
+	annotationpb "github.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1"
 	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	scrapepb "github.com/parca-dev/parca/gen/proto/go/parca/scrape/v1alpha1"
 	sharepb "github.com/parca-dev/parca/gen/proto/go/parca/share/v1alpha1"
 	telemetry "github.com/parca-dev/parca/gen/proto/go/parca/telemetry/v1alpha1"
+	viewpb "github.com/parca-dev/parca/gen/proto/go/parca/view/v1alpha1"
+	"github.com/parca-dev/parca/pkg/annotation"
 	"github.com/parca-dev/parca/pkg/badgerlogger"
 	"github.com/parca-dev/parca/pkg/clickhouse"
 	"github.com/parca-dev/parca/pkg/config"
@@ -77,12 +81,15 @@ import (
 	"github.com/parca-dev/parca/pkg/profile"
 	"github.com/parca-dev/parca/pkg/profilestore"
 	queryservice "github.com/parca-dev/parca/pkg/query"
+	"github.com/parca-dev/parca/pkg/rules"
 	"github.com/parca-dev/parca/pkg/scrape"
 	"github.com/parca-dev/parca/pkg/server"
+	"github.com/parca-dev/parca/pkg/share"
 	"github.com/parca-dev/parca/pkg/signedrequests"
 	"github.com/parca-dev/parca/pkg/symbolizer"
 	telemetryservice "github.com/parca-dev/parca/pkg/telemetry"
 	"github.com/parca-dev/parca/pkg/tracer"
+	"github.com/parca-dev/parca/pkg/view"
 	"github.com/parca-dev/parca/ui"
 )
 
@@ -122,6 +129,8 @@ type Flags struct {
 
 	ProfileShareServer string `default:"api.pprof.me:443" help:"gRPC address to send share profile requests to."`
 
+	Share FlagsShare `embed:"" prefix:"share-"`
+
 	StoreAddress       string            `kong:"help='gRPC address to send profiles and symbols to.'"`
 	BearerToken        string            `kong:"help='Bearer token to authenticate with store.',env='PARCA_BEARER_TOKEN'"`
 	BearerTokenFile    string            `kong:"help='File to read bearer token from to authenticate with store.'"`
@@ -174,6 +183,13 @@ type FlagsDebuginfod struct {
 	HTTPRequestTimeout time.Duration `default:"5m" help:"Timeout duration for HTTP request to upstream debuginfod server. Defaults to 5m"`
 }
 
+// FlagsShare configures the self-hosted profile sharing.
+type FlagsShare struct {
+	Enabled     bool          `default:"false" help:"Store shared profiles in the object storage bucket and serve them from this instance instead of uploading them to the profile share server."`
+	Expiry      time.Duration `default:"0" help:"Duration after which shared profiles are deleted. 0 keeps them forever."`
+	ExternalURL string        `default:"" help:"URL this instance is reachable at, used in links to shared profiles. Defaults to the path prefix."`
+}
+
 // FlagsClickHouse configures the ClickHouse storage backend.
 type FlagsClickHouse struct {
 	Enabled  bool   `kong:"help='Enable ClickHouse storage backend instead of FrostDB.',default='false',hidden=''"`
@@ -329,6 +345,14 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	semantics := profile.NewSemanticsRegistry()
+	for _, pt := range cfg.ProfileTypes {
+		if err := semantics.Register(pt.ProfileType, pt.Semantics()); err != nil {
+			level.Error(logger).Log("msg", "failed to register profile type", "err", err, "profile_type", pt.ProfileType)
+			return err
+		}
+	}
+
 	// Initialize storage backend - either ClickHouse or FrostDB
 	var (
 		profileIngester ingester.Ingester
@@ -375,6 +399,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				flags.Symbolizer.ExternalAddr2linePath,
 				symbolizer.WithDemangleMode(flags.Symbolizer.DemangleMode),
 			),
+			semantics,
 		)
 
 		// We still need the schema for ProfileColumnStore
@@ -509,6 +534,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			),
 			queryDemangler,
 			memory.DefaultAllocator,
+			semantics,
 		)
 
 		s = profilestore.NewProfileColumnStore(
@@ -529,15 +555,47 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			otelgrpc.WithPropagators(propagators),
 		)),
 	}
-	conn, err := grpc.NewClient(flags.ProfileShareServer, opts...)
-	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection to ProfileShareServer: %s, %w", flags.ProfileShareServer, err)
+	var (
+		shareService *share.Service
+		shareClient  sharepb.ShareServiceClient
+	)
+	if flags.Share.Enabled {
+		externalURL := flags.Share.ExternalURL
+		if externalURL == "" {
+			externalURL = flags.PathPrefix
+		}
+		shareService = share.NewService(
+			logger,
+			tracerProvider.Tracer("share"),
+			objstore.NewPrefixedBucket(bucket, "share"),
+			memory.DefaultAllocator,
+			parcacol.NewArrowToProfileConverter(
+				tracerProvider.Tracer("arrow_to_profile_converter"),
+				kv.NewKeyMaker(),
+			),
+			externalURL,
+			flags.Share.Expiry,
+		)
+		shareClient = shareService.Client()
+	} else {
+		conn, err := grpc.NewClient(flags.ProfileShareServer, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC connection to ProfileShareServer: %s, %w", flags.ProfileShareServer, err)
+		}
+		shareClient = sharepb.NewShareServiceClient(conn)
+	}
+
+	derivedTypes := make([]profile.DerivedType, 0, len(cfg.DerivedProfileTypes))
+	for _, dt := range cfg.DerivedProfileTypes {
+		derivedTypes = append(derivedTypes, dt.DerivedType())
 	}
 
+	annotationService := annotation.NewService(logger, objstore.NewPrefixedBucket(bucket, "annotations"))
+
 	q := queryservice.NewColumnQueryAPI(
 		logger,
 		tracerProvider.Tracer("query-service"),
-		sharepb.NewShareServiceClient(conn),
+		shareClient,
 		querier,
 		memory.DefaultAllocator,
 		parcacol.NewArrowToProfileConverter(
@@ -548,8 +606,17 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			debuginfoBucket,
 			debuginfodClients,
 		),
+		queryservice.NewBucketDebuginfoFinder(
+			debuginfoMetadata,
+			debuginfo.NewFetcher(debuginfodClients, debuginfoBucket),
+			flags.Debuginfo.CacheDir,
+		),
+		derivedTypes,
+		annotationService,
 	)
 
+	viewService := view.NewService(logger, objstore.NewPrefixedBucket(bucket, "views"))
+
 	t := telemetryservice.NewTelemetry(
 		logger,
 	)
@@ -574,6 +641,12 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	rulesManager := rules.NewManager(logger, reg, querier)
+	if err := rulesManager.ApplyConfig(cfg); err != nil {
+		level.Error(logger).Log("msg", "failed to apply rules config", "err", err)
+		return err
+	}
+
 	reloaders := []config.ComponentReloader{
 		{
 			Name: "scrape_sd",
@@ -587,6 +660,12 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				return m.ApplyConfig(cfg.ScrapeConfigs)
 			},
 		},
+		{
+			Name: "rules",
+			Reloader: func(cfg *config.Config) error {
+				return rulesManager.ApplyConfig(cfg)
+			},
+		},
 	}
 
 	cfgReloader, err := config.NewConfigReloader(logger, reg, flags.ConfigPath, reloaders)
@@ -655,6 +734,38 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		func() error {
 			var err error
 
+			pprof.Do(ctx, pprof.Labels("parca_component", "rules"), func(ctx context.Context) {
+				err = rulesManager.Run(ctx)
+			})
+
+			return err
+		},
+		func(_ error) {
+			level.Debug(logger).Log("msg", "rules manager exiting")
+			cancel()
+		},
+	)
+	if shareService != nil && flags.Share.Expiry > 0 {
+		gr.Add(
+			func() error {
+				var err error
+
+				pprof.Do(ctx, pprof.Labels("parca_component", "share"), func(ctx context.Context) {
+					err = shareService.Run(ctx, time.Hour)
+				})
+
+				return err
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "share expiry exiting")
+				cancel()
+			},
+		)
+	}
+	gr.Add(
+		func() error {
+			var err error
+
 			pprof.Do(ctx, pprof.Labels("parca_component", "config_reloader"), func(ctx context.Context) {
 				err = cfgReloader.Run(ctx)
 			})
@@ -688,6 +799,12 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 						otelgrpcprofilingpb.RegisterProfilesServiceServer(srv, s)
 						querypb.RegisterQueryServiceServer(srv, q)
 						scrapepb.RegisterScrapeServiceServer(srv, m)
+						alertspb.RegisterAlertsServiceServer(srv, rulesManager)
+						if shareService != nil {
+							sharepb.RegisterShareServiceServer(srv, shareService)
+						}
+						viewpb.RegisterViewServiceServer(srv, viewService)
+						annotationpb.RegisterAnnotationServiceServer(srv, annotationService)
 						telemetry.RegisterTelemetryServiceServer(srv, t)
 
 						if err := debuginfopb.RegisterDebuginfoServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
@@ -710,6 +827,24 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 							return err
 						}
 
+						if err := alertspb.RegisterAlertsServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+							return err
+						}
+
+						if shareService != nil {
+							if err := sharepb.RegisterShareServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+								return err
+							}
+						}
+
+						if err := viewpb.RegisterViewServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+							return err
+						}
+
+						if err := annotationpb.RegisterAnnotationServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+							return err
+						}
+
 						if err := telemetry.RegisterTelemetryServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
 							return err
 						}
@@ -255,10 +255,14 @@ func TestConsistency(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	ts := timestamppb.New(timestamp.Time(1608199718549)) // time_nanos of the profile divided by 1e6
@@ -366,10 +370,14 @@ func TestPGOE2e(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	res, err := api.Query(ctx, &querypb.QueryRequest{
@@ -466,10 +474,14 @@ func TestLabels(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	ts := timestamppb.New(timestamp.Time(1677488315039)) // time_nanos of the profile divided by 1e6
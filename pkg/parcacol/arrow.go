@@ -15,7 +15,9 @@ package parcacol
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 	"unsafe"
 
@@ -180,6 +182,9 @@ func (c *ArrowToProfileConverter) Convert(
 				lines := make([]profile.LocationLine, 0, llOffsetEnd-llOffsetStart)
 
 				for k := int(llOffsetStart); k < int(llOffsetEnd); k++ {
+					if line.IsNull(k) { // Ignore null lines; they have been filtered out.
+						continue
+					}
 					name := ""
 					if lineFunctionName.IsValid(k) {
 						name = string(lineFunctionNameDict.Value(lineFunctionName.GetValueIndex(k)))
@@ -365,3 +370,135 @@ func BuildArrowLocations(allocator memory.Allocator, stacktraces []*pb.Stacktrac
 func stringToBytes(s string) []byte {
 	return unsafe.Slice(unsafe.StringData(s), len(s))
 }
+
+func unsafeString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// LocationResolver converts the locations of Arrow records into profile
+// locations with the same IDs as Convert. Unlike Convert it caches the
+// locations of the current record, so each distinct location is only
+// converted once no matter how many samples reference it. The returned
+// locations are shared and must not be modified.
+type LocationResolver struct {
+	key *kv.KeyMaker
+
+	r     *profile.RecordReader
+	cache map[string]*profile.Location
+	buf   []byte
+}
+
+func (c *ArrowToProfileConverter) NewLocationResolver() *LocationResolver {
+	return &LocationResolver{
+		key:   c.key,
+		cache: map[string]*profile.Location{},
+	}
+}
+
+// Reset prepares the resolver for the locations of the given record. The
+// cached locations are dropped as the dictionaries are per record.
+func (lr *LocationResolver) Reset(r *profile.RecordReader) {
+	lr.r = r
+	clear(lr.cache)
+}
+
+// Location returns the location at index j of the record's location list
+// values.
+func (lr *LocationResolver) Location(j int) *profile.Location {
+	r := lr.r
+	address := r.Address.Value(j)
+	llOffsetStart, llOffsetEnd := r.Lines.ValueOffsets(j)
+
+	// The key needs to identify the location within the record. The
+	// dictionary indices are as good as their values here.
+	lr.buf = lr.buf[:0]
+	lr.buf = appendDictIndex(lr.buf, r.MappingFileIndices, j)
+	lr.buf = appendDictIndex(lr.buf, r.MappingBuildIDIndices, j)
+	lr.buf = binary.BigEndian.AppendUint64(lr.buf, r.MappingStart.Value(j))
+	lr.buf = binary.BigEndian.AppendUint64(lr.buf, r.MappingLimit.Value(j))
+	lr.buf = binary.BigEndian.AppendUint64(lr.buf, r.MappingOffset.Value(j))
+	lr.buf = binary.BigEndian.AppendUint64(lr.buf, address)
+	for k := int(llOffsetStart); k < int(llOffsetEnd); k++ {
+		if r.Line.IsNull(k) {
+			continue
+		}
+		lr.buf = appendDictIndex(lr.buf, r.LineFunctionNameIndices, k)
+		lr.buf = appendDictIndex(lr.buf, r.LineFunctionSystemNameIndices, k)
+		lr.buf = appendDictIndex(lr.buf, r.LineFunctionFilenameIndices, k)
+		lr.buf = binary.BigEndian.AppendUint64(lr.buf, uint64(r.LineFunctionStartLine.Value(k)))
+		lr.buf = binary.BigEndian.AppendUint64(lr.buf, uint64(r.LineNumber.Value(k)))
+	}
+
+	if loc, ok := lr.cache[unsafeString(lr.buf)]; ok {
+		return loc
+	}
+
+	lines := make([]profile.LocationLine, 0, llOffsetEnd-llOffsetStart)
+	for k := int(llOffsetStart); k < int(llOffsetEnd); k++ {
+		if r.Line.IsNull(k) {
+			continue
+		}
+		name := dictString(r.LineFunctionNameIndices, r.LineFunctionNameDict, k)
+		systemName := dictString(r.LineFunctionSystemNameIndices, r.LineFunctionSystemNameDict, k)
+		filename := dictString(r.LineFunctionFilenameIndices, r.LineFunctionFilenameDict, k)
+		startLine := int64(0)
+		if r.LineFunctionStartLine.IsValid(k) {
+			startLine = r.LineFunctionStartLine.Value(k)
+		}
+		var f *pb.Function
+		if name != "" || systemName != "" || filename != "" || startLine != 0 {
+			f = &pb.Function{
+				Name:       name,
+				SystemName: systemName,
+				Filename:   filename,
+				StartLine:  startLine,
+			}
+			f.Id = lr.key.MakeFunctionID(f)
+		}
+		lines = append(lines, profile.LocationLine{
+			Line:     r.LineNumber.Value(k),
+			Function: f,
+		})
+	}
+
+	start := r.MappingStart.Value(j)
+	limit := r.MappingLimit.Value(j)
+	offset := r.MappingOffset.Value(j)
+	buildID := dictString(r.MappingBuildIDIndices, r.MappingBuildIDDict, j)
+	file := dictString(r.MappingFileIndices, r.MappingFileDict, j)
+	var m *pb.Mapping
+	if start != 0 || limit != 0 || offset != 0 || buildID != "" || file != "" {
+		m = &pb.Mapping{
+			Start:   start,
+			Limit:   limit,
+			Offset:  offset,
+			File:    file,
+			BuildId: buildID,
+		}
+		m.Id = lr.key.MakeMappingID(m)
+	}
+
+	loc := &profile.Location{
+		Address: address,
+		Mapping: m,
+		Lines:   lines,
+	}
+	loc.ID = lr.key.MakeProfileLocationID(loc)
+	lr.cache[string(lr.buf)] = loc
+
+	return loc
+}
+
+func appendDictIndex(b []byte, indices *array.Uint32, i int) []byte {
+	if indices.IsNull(i) {
+		return binary.BigEndian.AppendUint32(b, math.MaxUint32)
+	}
+	return binary.BigEndian.AppendUint32(b, indices.Value(i))
+}
+
+func dictString(indices *array.Uint32, dict *array.Binary, i int) string {
+	if indices.IsNull(i) {
+		return ""
+	}
+	return string(dict.Value(int(indices.Value(i))))
+}
@@ -14,12 +14,15 @@
 package parcacol
 
 import (
+	"context"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/stretchr/testify/require"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/kv"
 	"github.com/parca-dev/parca/pkg/profile"
 )
 
@@ -59,3 +62,93 @@ func TestBuildArrowLocations(t *testing.T) {
 	require.NoError(t, err)
 	defer r.Release()
 }
+
+func TestLocationResolver(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	w := profile.NewWriter(mem, nil)
+	defer w.Release()
+
+	type testLine struct {
+		function string
+		line     int64
+	}
+	appendLocation := func(address uint64, mappingFile string, lines ...testLine) {
+		w.Locations.Append(true)
+		w.Addresses.Append(address)
+		if mappingFile == "" {
+			w.MappingStart.Append(0)
+			w.MappingLimit.Append(0)
+			w.MappingOffset.Append(0)
+			w.MappingFile.AppendNull()
+			w.MappingBuildID.AppendNull()
+		} else {
+			w.MappingStart.Append(0x1000)
+			w.MappingLimit.Append(0x9000)
+			w.MappingOffset.Append(0)
+			require.NoError(t, w.MappingFile.Append([]byte(mappingFile)))
+			require.NoError(t, w.MappingBuildID.Append([]byte("build-id")))
+		}
+		if len(lines) == 0 {
+			w.Lines.AppendNull()
+			return
+		}
+		w.Lines.Append(true)
+		for _, l := range lines {
+			w.Line.Append(true)
+			w.LineNumber.Append(l.line)
+			w.ColumnNumber.Append(0)
+			require.NoError(t, w.FunctionName.Append([]byte(l.function)))
+			require.NoError(t, w.FunctionSystemName.Append([]byte(l.function)))
+			require.NoError(t, w.FunctionFilename.Append([]byte("main.py")))
+			w.FunctionStartLine.Append(1)
+		}
+	}
+	appendSample := func(value int64) {
+		w.Value.Append(value)
+		w.Diff.Append(0)
+		w.TimeNanos.Append(1)
+		w.Period.Append(1)
+	}
+
+	w.LocationsList.Append(true)
+	appendLocation(0x1100, "/bin/app", testLine{function: "foo", line: 1})
+	// Interpreted frames have no address, so the lines identify the location.
+	appendLocation(0, "", testLine{function: "inner", line: 3}, testLine{function: "outer", line: 5})
+	appendSample(1)
+
+	w.LocationsList.Append(true)
+	appendLocation(0x1100, "/bin/app", testLine{function: "foo", line: 1})
+	appendLocation(0, "", testLine{function: "inner", line: 4}, testLine{function: "outer", line: 5})
+	appendLocation(0x1200, "/bin/app")
+	appendSample(2)
+
+	rec := w.RecordBuilder.NewRecordBatch()
+	defer rec.Release()
+
+	converter := NewArrowToProfileConverter(nil, kv.NewKeyMaker())
+	op, err := converter.Convert(context.Background(), profile.Profile{Samples: []arrow.RecordBatch{rec}})
+	require.NoError(t, err)
+
+	r, err := profile.NewRecordReader(rec)
+	require.NoError(t, err)
+
+	lr := converter.NewLocationResolver()
+	lr.Reset(r)
+
+	resolved := [][]*profile.Location{}
+	for i := 0; i < int(rec.NumRows()); i++ {
+		beg, end := r.Locations.ValueOffsets(i)
+		locations := []*profile.Location{}
+		for j := int(beg); j < int(end); j++ {
+			locations = append(locations, lr.Location(j))
+		}
+		resolved = append(resolved, locations)
+		require.Equal(t, op.Samples[i].Locations, locations)
+	}
+
+	// Identical locations are only converted once.
+	require.Same(t, resolved[0][0], resolved[1][0])
+	require.NotEqual(t, resolved[0][1].ID, resolved[1][1].ID)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -43,6 +44,7 @@ import (
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	compactDictionary "github.com/parca-dev/parca/pkg/compactdictionary"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
 	"github.com/parca-dev/parca/pkg/symbolizer"
 )
 
@@ -59,7 +61,11 @@ func NewQuerier(
 	sym symbolizer.SymbolizationClient,
 	demangler profile.Demangler,
 	pool memory.Allocator,
+	semantics *profile.SemanticsRegistry,
 ) *Querier {
+	if semantics == nil {
+		semantics = profile.NewSemanticsRegistry()
+	}
 	return &Querier{
 		logger:     logger,
 		tracer:     tracer,
@@ -68,6 +74,7 @@ func NewQuerier(
 		symbolizer: sym,
 		demangler:  demangler,
 		pool:       pool,
+		semantics:  semantics,
 	}
 }
 
@@ -79,6 +86,7 @@ type Querier struct {
 	demangler  profile.Demangler
 	tracer     trace.Tracer
 	pool       memory.Allocator
+	semantics  *profile.SemanticsRegistry
 }
 
 func (q *Querier) Labels(
@@ -257,6 +265,10 @@ func QueryToFilterExprs(query string) (profile.QueryParts, []logicalplan.Expr, e
 	if err != nil {
 		return qp, nil, err
 	}
+	if qp.Derived != "" {
+		// Derived profile types are evaluated by the query API.
+		return qp, nil, status.Errorf(codes.InvalidArgument, "derived profile type %q can only be merged", qp.Derived)
+	}
 
 	labelFilterExpressions, err := MatchersToBooleanExpressions(qp.Matchers)
 	if err != nil {
@@ -288,12 +300,22 @@ func (q *Querier) QueryRange(
 	step time.Duration,
 	limit uint32,
 	sumBy []string,
+	filters []*pb.Filter,
 ) ([]*pb.MetricsSeries, error) {
 	queryParts, selectorExprs, err := QueryToFilterExprs(query)
 	if err != nil {
 		return nil, err
 	}
 
+	matcher, err := profilefilter.NewMatcher(filters)
+	if err != nil {
+		return nil, err
+	}
+	if matcher.HasFrameFilters() {
+		// Series values are sums over whole stacks, dropping frames doesn't change them.
+		return nil, status.Error(codes.InvalidArgument, "frame filters are not supported in range queries, use stack filters instead")
+	}
+
 	start := startTime.UnixNano()
 	end := endTime.UnixNano()
 
@@ -312,10 +334,11 @@ func (q *Querier) QueryRange(
 			step,
 			queryParts.Meta,
 			sumBy,
+			matcher,
 		)
 	}
 
-	return q.queryRangeNonDelta(ctx, filterExpr, step, sumBy)
+	return q.queryRangeNonDelta(ctx, filterExpr, step, sumBy, matcher, q.semantics.Lookup(queryParts).Aggregation)
 }
 
 const (
@@ -329,6 +352,7 @@ func (q *Querier) queryRangeDelta(
 	step time.Duration,
 	m profile.Meta,
 	sumBy []string,
+	matcher *profilefilter.Matcher,
 ) ([]*pb.MetricsSeries, error) {
 	resultType := m.SampleType
 
@@ -409,6 +433,30 @@ func (q *Querier) queryRangeDelta(
 		).Alias(ValuePerSecond)
 	}
 
+	var filtered map[seriesKey]seriesValue
+	if matcher.HasStackFilters() {
+		var err error
+		filtered, err = q.stackFilteredSeriesValues(
+			ctx,
+			q.engine.ScanTable(q.tableName).
+				Filter(filterExpr).
+				Project(append(preProjection, logicalplan.Col(profile.ColumnStacktrace))...).
+				Aggregate(
+					[]*logicalplan.AggregationFunction{totalSum, totalCount},
+					append(append([]logicalplan.Expr{
+						logicalplan.Col(TimestampBucket),
+					}, getSumByAggregateExprs(sumBy)...), logicalplan.Col(profile.ColumnStacktrace)),
+				),
+			TimestampBucket,
+			totalSumColumn,
+			totalCountColumn,
+			matcher,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err := q.engine.ScanTable(q.tableName).
 		Filter(filterExpr).
 		Project(preProjection...).
@@ -558,6 +606,17 @@ func (q *Querier) queryRangeDelta(
 			duration := ar.Column(columnIndices.Duration).(*array.Int64).Value(i)
 			count := ar.Column(columnIndices.Count).(*array.Int64).Value(i)
 
+			if filtered != nil {
+				// Only the share of the bucket's value that belongs to stacks
+				// matching the filters is kept, the duration stays the same.
+				v := filtered[seriesKey{labels: s, ts: ts}]
+				if valueSum != 0 {
+					valuePerSecond = valuePerSecond * float64(v.sum) / float64(valueSum)
+				}
+				valueSum = v.sum
+				count = v.count
+			}
+
 			series := resSeries[index]
 			series.Samples = append(series.Samples, &pb.MetricsSample{
 				Timestamp:      timestamppb.New(time.Unix(0, ts)),
@@ -588,7 +647,14 @@ func getSumByAggregateExprs(sumBy []string) []logicalplan.Expr {
 	return exprs
 }
 
-func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan.Expr, step time.Duration, _ []string) ([]*pb.MetricsSeries, error) {
+func (q *Querier) queryRangeNonDelta(
+	ctx context.Context,
+	filterExpr logicalplan.Expr,
+	step time.Duration,
+	_ []string,
+	matcher *profilefilter.Matcher,
+	aggregation profile.Aggregation,
+) ([]*pb.MetricsSeries, error) {
 	records := []arrow.RecordBatch{}
 	defer func() {
 		for _, r := range records {
@@ -599,6 +665,32 @@ func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan
 
 	valueSum := logicalplan.Sum(logicalplan.Col(profile.ColumnValue))
 	valueSumColumn := valueSum.Name()
+
+	var filtered map[seriesKey]seriesValue
+	if matcher.HasStackFilters() {
+		var err error
+		filtered, err = q.stackFilteredSeriesValues(
+			ctx,
+			q.engine.ScanTable(q.tableName).
+				Filter(filterExpr).
+				Aggregate(
+					[]*logicalplan.AggregationFunction{valueSum},
+					[]logicalplan.Expr{
+						logicalplan.Col(profile.ColumnTimeNanos),
+						logicalplan.DynCol(profile.ColumnLabels),
+						logicalplan.Col(profile.ColumnStacktrace),
+					},
+				),
+			profile.ColumnTimeNanos,
+			valueSumColumn,
+			"",
+			matcher,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err := q.engine.ScanTable(q.tableName).
 		Filter(filterExpr).
 		Aggregate(
@@ -697,6 +789,9 @@ func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan
 
 			ts := ar.Column(columnIndices[profile.ColumnTimeNanos].index).(*array.Int64).Value(i)
 			value := ar.Column(columnIndices[valueSumColumn].index).(*array.Int64).Value(i)
+			if filtered != nil {
+				value = filtered[seriesKey{labels: s, ts: ts}].sum
+			}
 
 			// Each step bucket will only return one of the timestamps and its value.
 			// For this reason we'll take each timestamp and divide it by the step seconds.
@@ -713,7 +808,19 @@ func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan
 			}
 			if sampleIdx, found := resSeriesBuckets[index][tsBucket]; found {
 				// We already have a MetricsSample for this timestamp bucket, increment its count.
-				resSeries[index].Samples[sampleIdx].Count++
+				sample := resSeries[index].Samples[sampleIdx]
+				sample.Count++
+				switch aggregation {
+				case profile.AggregationAverage:
+					// The sum is divided by the count below.
+					sample.Value += value
+				case profile.AggregationLatest:
+					if ts > sample.Timestamp.AsTime().UnixNano() {
+						sample.Timestamp = timestamppb.New(time.Unix(0, ts))
+						sample.Value = value
+						sample.ValuePerSecond = float64(value)
+					}
+				}
 				continue
 			}
 
@@ -729,6 +836,15 @@ func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan
 		}
 	}
 
+	if aggregation == profile.AggregationAverage {
+		for _, series := range resSeries {
+			for _, sample := range series.Samples {
+				sample.ValuePerSecond = float64(sample.Value) / float64(sample.Count)
+				sample.Value = int64(math.Round(sample.ValuePerSecond))
+			}
+		}
+	}
+
 	// This is horrible and should be fixed. The data is sorted in the storage, we should not have to sort it here.
 	for _, series := range resSeries {
 		sort.Slice(series.Samples, func(i, j int) bool {
@@ -739,6 +855,122 @@ func (q *Querier) queryRangeNonDelta(ctx context.Context, filterExpr logicalplan
 	return resSeries, nil
 }
 
+type seriesKey struct {
+	labels string
+	ts     int64
+}
+
+type seriesValue struct {
+	sum   int64
+	count int64
+}
+
+// stackFilteredSeriesValues executes the given aggregation, which must be
+// grouped by the stacktrace column in addition to the series' timestamp and
+// labels. The stacks are symbolized so the stack filters of the matcher can
+// be applied, and the values of the matching stacks are summed up per
+// timestamp and labelset.
+func (q *Querier) stackFilteredSeriesValues(
+	ctx context.Context,
+	builder query.Builder,
+	timestampColumn string,
+	sumColumn string,
+	countColumn string,
+	matcher *profilefilter.Matcher,
+) (map[seriesKey]seriesValue, error) {
+	ctx, span := q.tracer.Start(ctx, "Querier/stackFilteredSeriesValues")
+	defer span.End()
+
+	records := []arrow.RecordBatch{}
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+
+	err := builder.Execute(ctx, func(ctx context.Context, r arrow.RecordBatch) error {
+		r.Retain()
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	symbolized, err := q.SymbolizeArrowRecord(ctx, records, sumColumn, profile.QueryParts{}, false, "")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, r := range symbolized {
+			r.Release()
+		}
+	}()
+
+	labelSet := labels.NewScratchBuilder(64)
+	res := map[seriesKey]seriesValue{}
+	for i, ar := range records {
+		matcher.Reset()
+		r, err := profile.NewRecordReader(symbolized[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		schema := ar.Schema()
+		indices := schema.FieldIndices(timestampColumn)
+		if len(indices) != 1 {
+			return nil, ErrMissingColumn{Column: timestampColumn, Columns: len(indices)}
+		}
+		timestamps := ar.Column(indices[0]).(*array.Int64)
+
+		var counts *array.Int64
+		if countColumn != "" {
+			indices = schema.FieldIndices(countColumn)
+			if len(indices) != 1 {
+				return nil, ErrMissingColumn{Column: countColumn, Columns: len(indices)}
+			}
+			counts = ar.Column(indices[0]).(*array.Int64)
+		}
+
+		labelColumnIndices := []int{}
+		for j, field := range schema.Fields() {
+			if strings.HasPrefix(field.Name, profile.ColumnLabelsPrefix) {
+				labelColumnIndices = append(labelColumnIndices, j)
+			}
+		}
+
+		for j := 0; j < int(ar.NumRows()); j++ {
+			if !matcher.StackMatches(r, j) {
+				continue
+			}
+
+			labelSet.Reset()
+			for _, labelColumnIndex := range labelColumnIndices {
+				col, ok := ar.Column(labelColumnIndex).(*array.Dictionary)
+				if !ok || col.IsNull(j) {
+					continue
+				}
+
+				v := StringValueFromDictionary(col, j)
+				if len(v) > 0 {
+					labelSet.Add(strings.TrimPrefix(schema.Field(labelColumnIndex).Name, profile.ColumnLabelsPrefix), v)
+				}
+			}
+			labelSet.Sort()
+
+			key := seriesKey{labels: labelSet.Labels().String(), ts: timestamps.Value(j)}
+			v := res[key]
+			v.sum += r.Value.Value(j)
+			if counts != nil {
+				v.count += counts.Value(j)
+			}
+			res[key] = v
+		}
+	}
+
+	return res, nil
+}
+
 func (q *Querier) ProfileTypes(
 	ctx context.Context,
 	startTime time.Time,
@@ -838,6 +1070,243 @@ func (q *Querier) ProfileTypes(
 	return res, nil
 }
 
+// Series returns the distinct label sets and profile types of the series
+// matching any of the selectors, along with the first and last time a sample
+// was seen within the time window.
+func (q *Querier) Series(
+	ctx context.Context,
+	match []string,
+	startTime, endTime time.Time,
+) ([]*pb.Series, error) {
+	ctx, span := q.tracer.Start(ctx, "Querier/Series")
+	defer span.End()
+
+	timeExprs := []logicalplan.Expr{
+		logicalplan.Col(profile.ColumnTimeNanos).GtEq(logicalplan.Literal(startTime.UnixNano())),
+		logicalplan.Col(profile.ColumnTimeNanos).LtEq(logicalplan.Literal(endTime.UnixNano())),
+	}
+
+	if len(match) == 0 {
+		// Without selectors every series matches.
+		match = []string{"{}"}
+	}
+
+	res := []*pb.Series{}
+	seriesIndex := map[string]int{}
+	for _, selector := range match {
+		qp, hasProfileType, err := profile.ParseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		var filterExprs []logicalplan.Expr
+		if hasProfileType {
+			_, filterExprs, err = QueryToFilterExprs(selector)
+		} else {
+			filterExprs, err = MatchersToBooleanExpressions(qp.Matchers)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := q.series(ctx, append(filterExprs, timeExprs...), seriesIndex, &res); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i].ProfileType, res[j].ProfileType
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if ka, kb := profileTypeKey(a), profileTypeKey(b); ka != kb {
+			return ka < kb
+		}
+		return labelSetString(res[i].Labelset) < labelSetString(res[j].Labelset)
+	})
+
+	return res, nil
+}
+
+const seriesDeltaColumn = "delta"
+
+// series executes the series query for a single selector and merges the
+// results into res, seriesIndex maps the key of each series to its index in
+// res.
+func (q *Querier) series(
+	ctx context.Context,
+	filterExprs []logicalplan.Expr,
+	seriesIndex map[string]int,
+	res *[]*pb.Series,
+) error {
+	firstSeen := logicalplan.Min(logicalplan.Col(profile.ColumnTimeNanos))
+	lastSeen := logicalplan.Max(logicalplan.Col(profile.ColumnTimeNanos))
+
+	// Only whether the profile type is a delta type is needed, grouping by
+	// the raw duration would split series whose samples have different
+	// durations.
+	delta := logicalplan.Col(profile.ColumnDuration).Gt(logicalplan.Literal(int64(0))).Alias(seriesDeltaColumn)
+
+	return q.engine.ScanTable(q.tableName).
+		Filter(logicalplan.And(filterExprs...)).
+		Project(
+			logicalplan.Col(profile.ColumnName),
+			logicalplan.Col(profile.ColumnSampleType),
+			logicalplan.Col(profile.ColumnSampleUnit),
+			logicalplan.Col(profile.ColumnPeriodType),
+			logicalplan.Col(profile.ColumnPeriodUnit),
+			delta,
+			logicalplan.DynCol(profile.ColumnLabels),
+			logicalplan.Col(profile.ColumnTimeNanos),
+		).
+		Aggregate(
+			[]*logicalplan.AggregationFunction{
+				firstSeen,
+				lastSeen,
+			},
+			[]logicalplan.Expr{
+				logicalplan.Col(profile.ColumnName),
+				logicalplan.Col(profile.ColumnSampleType),
+				logicalplan.Col(profile.ColumnSampleUnit),
+				logicalplan.Col(profile.ColumnPeriodType),
+				logicalplan.Col(profile.ColumnPeriodUnit),
+				logicalplan.Col(seriesDeltaColumn),
+				logicalplan.DynCol(profile.ColumnLabels),
+			},
+		).
+		Execute(ctx, func(ctx context.Context, ar arrow.RecordBatch) error {
+			nameColumn, err := DictionaryFromRecord(ar, profile.ColumnName)
+			if err != nil {
+				return err
+			}
+
+			sampleTypeColumn, err := DictionaryFromRecord(ar, profile.ColumnSampleType)
+			if err != nil {
+				return err
+			}
+
+			sampleUnitColumn, err := DictionaryFromRecord(ar, profile.ColumnSampleUnit)
+			if err != nil {
+				return err
+			}
+
+			periodTypeColumn, err := DictionaryFromRecord(ar, profile.ColumnPeriodType)
+			if err != nil {
+				return err
+			}
+
+			periodUnitColumn, err := DictionaryFromRecord(ar, profile.ColumnPeriodUnit)
+			if err != nil {
+				return err
+			}
+
+			deltaColumn, err := BooleanFieldFromRecord(ar, seriesDeltaColumn)
+			if err != nil {
+				return err
+			}
+
+			schema := ar.Schema()
+			columns := map[string]*array.Int64{
+				firstSeen.Name(): nil,
+				lastSeen.Name():  nil,
+			}
+			for name := range columns {
+				indices := schema.FieldIndices(name)
+				if len(indices) != 1 {
+					return ErrMissingColumn{Column: name, Columns: len(indices)}
+				}
+				col, ok := ar.Column(indices[0]).(*array.Int64)
+				if !ok {
+					return fmt.Errorf("expected column %q to be an int64 column, got %T", name, ar.Column(indices[0]))
+				}
+				columns[name] = col
+			}
+
+			labelColumnIndices := []int{}
+			for i, field := range schema.Fields() {
+				if strings.HasPrefix(field.Name, profile.ColumnLabelsPrefix) {
+					labelColumnIndices = append(labelColumnIndices, i)
+				}
+			}
+
+			labelSet := labels.NewScratchBuilder(64)
+			for i := 0; i < int(ar.NumRows()); i++ {
+				pt := &pb.ProfileType{
+					Name:       StringValueFromDictionary(nameColumn, i),
+					SampleType: StringValueFromDictionary(sampleTypeColumn, i),
+					SampleUnit: StringValueFromDictionary(sampleUnitColumn, i),
+					PeriodType: StringValueFromDictionary(periodTypeColumn, i),
+					PeriodUnit: StringValueFromDictionary(periodUnitColumn, i),
+					Delta:      deltaColumn.Value(i),
+				}
+
+				labelSet.Reset()
+				for _, labelColumnIndex := range labelColumnIndices {
+					col, ok := ar.Column(labelColumnIndex).(*array.Dictionary)
+					if !ok || col.IsNull(i) {
+						continue
+					}
+
+					v := StringValueFromDictionary(col, i)
+					if len(v) > 0 {
+						labelSet.Add(strings.TrimPrefix(schema.Field(labelColumnIndex).Name, profile.ColumnLabelsPrefix), v)
+					}
+				}
+				labelSet.Sort()
+				ls := labelSet.Labels()
+
+				first := columns[firstSeen.Name()].Value(i)
+				last := columns[lastSeen.Name()].Value(i)
+
+				key := profileTypeKey(pt) + ls.String()
+				if index, ok := seriesIndex[key]; ok {
+					series := (*res)[index]
+					if first < series.FirstSeen.AsTime().UnixNano() {
+						series.FirstSeen = timestamppb.New(time.Unix(0, first))
+					}
+					if last > series.LastSeen.AsTime().UnixNano() {
+						series.LastSeen = timestamppb.New(time.Unix(0, last))
+					}
+					continue
+				}
+
+				pbLabelSet := make([]*profilestorepb.Label, 0, ls.Len())
+				ls.Range(func(l labels.Label) {
+					pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
+						Name:  l.Name,
+						Value: l.Value,
+					})
+				})
+
+				*res = append(*res, &pb.Series{
+					Labelset:    &profilestorepb.LabelSet{Labels: pbLabelSet},
+					ProfileType: pt,
+					FirstSeen:   timestamppb.New(time.Unix(0, first)),
+					LastSeen:    timestamppb.New(time.Unix(0, last)),
+				})
+				seriesIndex[key] = len(*res) - 1
+			}
+
+			return nil
+		})
+}
+
+func profileTypeKey(pt *pb.ProfileType) string {
+	key := fmt.Sprintf("%s:%s:%s:%s:%s", pt.Name, pt.SampleType, pt.SampleUnit, pt.PeriodType, pt.PeriodUnit)
+	if pt.Delta {
+		key = fmt.Sprintf("%s:delta", key)
+	}
+	return key
+}
+
+func labelSetString(ls *profilestorepb.LabelSet) string {
+	b := labels.NewScratchBuilder(len(ls.GetLabels()))
+	for _, l := range ls.GetLabels() {
+		b.Add(l.Name, l.Value)
+	}
+	return b.Labels().String()
+}
+
 func (q *Querier) HasProfileData(ctx context.Context) (bool, error) {
 	types, err := q.ProfileTypes(ctx, time.UnixMilli(0), time.UnixMilli(0))
 	if err != nil {
@@ -1439,6 +1908,23 @@ func (q *Querier) selectMerge(
 		)...,
 	)
 
+	// Snapshots of gauges and cumulative values must not be summed.
+	aggregation := q.mergeAggregation(queryParts, groupByLabels)
+	var snaps snapshots
+	if aggregation != profile.AggregationSum {
+		snaps, err = q.snapshots(ctx, filterExpr)
+		if err != nil {
+			return nil, "", queryParts, err
+		}
+		if aggregation == profile.AggregationLatest && len(snaps.latest) > 0 {
+			latest, err := snaps.latestFilter()
+			if err != nil {
+				return nil, "", queryParts, err
+			}
+			filterExpr = logicalplan.And(filterExpr, latest)
+		}
+	}
+
 	totalSum := logicalplan.Sum(logicalplan.Col(profile.ColumnValue))
 
 	columnsGroupBy := []logicalplan.Expr{
@@ -1509,6 +1995,15 @@ func (q *Querier) selectMerge(
 		return nil, "", queryParts, err
 	}
 
+	if aggregation == profile.AggregationAverage {
+		averaged, err := averageSnapshots(q.pool, records, "sum(value)", snaps)
+		releaseRecords(records)
+		if err != nil {
+			return nil, "", queryParts, err
+		}
+		records = averaged
+	}
+
 	queryParts.Meta.SampleType = resultType
 	queryParts.Meta.Timestamp = start
 
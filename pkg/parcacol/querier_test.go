@@ -0,0 +1,367 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-kit/log"
+	pprofprofile "github.com/google/pprof/profile"
+	columnstore "github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/ingester"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilestore"
+)
+
+// testSample is a sample of a test profile, its stack is ordered from the
+// leaf to the root.
+type testSample struct {
+	stack []string
+	value int64
+}
+
+// testProfile is a pprof profile written to the test store.
+type testProfile struct {
+	labels     map[string]string
+	time       time.Time
+	duration   time.Duration
+	sampleType *pprofprofile.ValueType
+	periodType *pprofprofile.ValueType
+	period     int64
+	samples    []testSample
+}
+
+func newTestQuerier(t *testing.T, profiles ...testProfile) *Querier {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { col.Close() })
+	colDB, err := col.DB(ctx, "parca")
+	require.NoError(t, err)
+	table, err := colDB.Table("stacktraces", columnstore.NewTableConfig(profile.SchemaDefinition()))
+	require.NoError(t, err)
+	schema, err := profile.Schema()
+	require.NoError(t, err)
+
+	store := profilestore.NewProfileColumnStore(
+		prometheus.NewRegistry(),
+		logger,
+		tracer,
+		ingester.NewIngester(logger, table),
+		schema,
+		memory.DefaultAllocator,
+	)
+
+	for _, p := range profiles {
+		lbls := make([]*profilestorepb.Label, 0, len(p.labels))
+		for name, value := range p.labels {
+			lbls = append(lbls, &profilestorepb.Label{Name: name, Value: value})
+		}
+
+		_, err := store.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+			Series: []*profilestorepb.RawProfileSeries{{
+				Labels:  &profilestorepb.LabelSet{Labels: lbls},
+				Samples: []*profilestorepb.RawSample{{RawProfile: p.encode(t)}},
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	return NewQuerier(
+		logger,
+		tracer,
+		query.NewEngine(memory.DefaultAllocator, colDB.TableProvider()),
+		"stacktraces",
+		nil,
+		nil,
+		memory.DefaultAllocator,
+		nil,
+	)
+}
+
+func (p testProfile) encode(t *testing.T) []byte {
+	t.Helper()
+
+	m := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x100000, File: "app"}
+	res := &pprofprofile.Profile{
+		SampleType:    []*pprofprofile.ValueType{p.sampleType},
+		PeriodType:    p.periodType,
+		Period:        p.period,
+		TimeNanos:     p.time.UnixNano(),
+		DurationNanos: p.duration.Nanoseconds(),
+		Mapping:       []*pprofprofile.Mapping{m},
+	}
+
+	functions := map[string]*pprofprofile.Function{}
+	locations := map[string]*pprofprofile.Location{}
+	for _, s := range p.samples {
+		sample := &pprofprofile.Sample{Value: []int64{s.value}}
+		for _, name := range s.stack {
+			loc, ok := locations[name]
+			if !ok {
+				fn := &pprofprofile.Function{ID: uint64(len(functions) + 1), Name: name, Filename: name + ".go"}
+				functions[name] = fn
+				res.Function = append(res.Function, fn)
+
+				loc = &pprofprofile.Location{
+					ID:      uint64(len(locations) + 1),
+					Mapping: m,
+					Address: 0x1000 * uint64(len(locations)+2),
+					Line:    []pprofprofile.Line{{Function: fn, Line: 1}},
+				}
+				locations[name] = loc
+				res.Location = append(res.Location, loc)
+			}
+			sample.Location = append(sample.Location, loc)
+		}
+		res.Sample = append(res.Sample, sample)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, res.Write(buf))
+	return buf.Bytes()
+}
+
+func functionNameStackFilter(name string) []*pb.Filter {
+	return []*pb.Filter{{
+		Filter: &pb.Filter_StackFilter{
+			StackFilter: &pb.StackFilter{
+				Filter: &pb.StackFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_Equal{Equal: name},
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func seriesValues(series []*pb.MetricsSeries) map[string][]int64 {
+	res := map[string][]int64{}
+	for _, s := range series {
+		key := ""
+		for _, l := range s.Labelset.Labels {
+			key += l.Name + "=" + l.Value
+		}
+		for _, sample := range s.Samples {
+			res[key] = append(res[key], sample.Value)
+		}
+	}
+	return res
+}
+
+func TestQueryRangeStackFiltersDelta(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+
+	cpu := func(instance string, ts time.Time, compute, read int64) testProfile {
+		return testProfile{
+			labels:     map[string]string{"__name__": "test", "job": "app", "instance": instance},
+			time:       ts,
+			duration:   10 * time.Second,
+			sampleType: &pprofprofile.ValueType{Type: "samples", Unit: "count"},
+			periodType: &pprofprofile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+			period:     10_000_000,
+			samples: []testSample{
+				{stack: []string{"compute", "main"}, value: compute},
+				{stack: []string{"read", "main"}, value: read},
+			},
+		}
+	}
+	q := newTestQuerier(t,
+		cpu("a", start, 3, 1),
+		cpu("a", start.Add(10*time.Second), 2, 2),
+		cpu("b", start, 1, 4),
+	)
+
+	query := `test:samples:count:cpu:nanoseconds:delta{job="app"}`
+	all, err := q.QueryRange(ctx, query, start, start.Add(time.Minute), 10*time.Second, 0, []string{"instance"}, nil)
+	require.NoError(t, err)
+	filtered, err := q.QueryRange(ctx, query, start, start.Add(time.Minute), 10*time.Second, 0, []string{"instance"}, functionNameStackFilter("compute"))
+	require.NoError(t, err)
+
+	// Samples are weighted by the period.
+	require.Equal(t, map[string][]int64{
+		"instance=a": {40_000_000, 40_000_000},
+		"instance=b": {50_000_000},
+	}, seriesValues(all))
+	require.Equal(t, map[string][]int64{
+		"instance=a": {30_000_000, 20_000_000},
+		"instance=b": {10_000_000},
+	}, seriesValues(filtered))
+
+	// The per-second values are scaled by the share of the matching stacks,
+	// the duration of the samples stays the same.
+	unfilteredSeries := map[string]*pb.MetricsSeries{}
+	for _, s := range all {
+		unfilteredSeries[s.Labelset.String()] = s
+	}
+	for _, s := range filtered {
+		for j, sample := range s.Samples {
+			unfiltered := unfilteredSeries[s.Labelset.String()].Samples[j]
+			require.Equal(t, unfiltered.Duration, sample.Duration)
+			require.Equal(t, int32(1), sample.Count)
+			require.InDelta(t, unfiltered.ValuePerSecond*float64(sample.Value)/float64(unfiltered.Value), sample.ValuePerSecond, 1e-9)
+			require.InDelta(t, float64(sample.Value)/float64(10*time.Second), sample.ValuePerSecond, 1e-9)
+		}
+	}
+}
+
+func TestQueryRangeStackFiltersNonDelta(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+
+	heap := func(ts time.Time, compute, read int64) testProfile {
+		return testProfile{
+			labels:     map[string]string{"__name__": "test", "job": "app"},
+			time:       ts,
+			sampleType: &pprofprofile.ValueType{Type: "inuse_space", Unit: "bytes"},
+			periodType: &pprofprofile.ValueType{Type: "space", Unit: "bytes"},
+			period:     1,
+			samples: []testSample{
+				{stack: []string{"compute", "main"}, value: compute},
+				{stack: []string{"read", "main"}, value: read},
+			},
+		}
+	}
+	q := newTestQuerier(t,
+		heap(start, 300, 100),
+		heap(start.Add(10*time.Second), 200, 50),
+	)
+
+	query := `test:inuse_space:bytes:space:bytes{job="app"}`
+	res, err := q.QueryRange(ctx, query, start, start.Add(time.Minute), 10*time.Second, 0, nil, functionNameStackFilter("read"))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int64{"job=app": {100, 50}}, seriesValues(res))
+	for _, sample := range res[0].Samples {
+		require.Equal(t, float64(sample.Value), sample.ValuePerSecond)
+	}
+
+	_, err = q.QueryRange(ctx, query, start, start.Add(time.Minute), 10*time.Second, 0, nil, []*pb.Filter{{
+		Filter: &pb.Filter_FrameFilter{
+			FrameFilter: &pb.FrameFilter{
+				Filter: &pb.FrameFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_Equal{Equal: "main"},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSeries(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+
+	cpu := func(job string, ts time.Time) testProfile {
+		return testProfile{
+			labels:     map[string]string{"__name__": "process_cpu", "job": job},
+			time:       ts,
+			duration:   10 * time.Second,
+			sampleType: &pprofprofile.ValueType{Type: "samples", Unit: "count"},
+			periodType: &pprofprofile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+			period:     10_000_000,
+			samples:    []testSample{{stack: []string{"main"}, value: 1}},
+		}
+	}
+	heap := func(job string, ts time.Time) testProfile {
+		return testProfile{
+			labels:     map[string]string{"__name__": "memory", "job": job},
+			time:       ts,
+			sampleType: &pprofprofile.ValueType{Type: "inuse_space", Unit: "bytes"},
+			periodType: &pprofprofile.ValueType{Type: "space", Unit: "bytes"},
+			period:     1,
+			samples:    []testSample{{stack: []string{"main"}, value: 1}},
+		}
+	}
+	q := newTestQuerier(t,
+		cpu("api", start),
+		cpu("api", start.Add(10*time.Second)),
+		// Samples of the same series with different durations are still one series.
+		testProfile{
+			labels:     map[string]string{"__name__": "process_cpu", "job": "api"},
+			time:       start.Add(20 * time.Second),
+			duration:   5 * time.Second,
+			sampleType: &pprofprofile.ValueType{Type: "samples", Unit: "count"},
+			periodType: &pprofprofile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+			period:     10_000_000,
+			samples:    []testSample{{stack: []string{"main"}, value: 1}},
+		},
+		cpu("db", start.Add(30*time.Second)),
+		heap("api", start.Add(10*time.Second)),
+		cpu("old", start.Add(-time.Hour)),
+	)
+
+	key := func(s *pb.Series) string {
+		return profileTypeKey(s.ProfileType) + labelSetString(s.Labelset)
+	}
+
+	res, err := q.Series(ctx, nil, start, start.Add(time.Minute))
+	require.NoError(t, err)
+	keys := []string{}
+	for _, s := range res {
+		keys = append(keys, key(s))
+	}
+	require.Equal(t, []string{
+		`memory:inuse_space:bytes:space:bytes{job="api"}`,
+		`process_cpu:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		`process_cpu:samples:count:cpu:nanoseconds:delta{job="db"}`,
+	}, keys)
+	require.True(t, start.Equal(res[1].FirstSeen.AsTime()))
+	require.True(t, start.Add(20*time.Second).Equal(res[1].LastSeen.AsTime()))
+
+	// Selectors with and without a profile type, series matching several
+	// selectors are only returned once.
+	res, err = q.Series(ctx, []string{
+		`process_cpu:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		`{job="api"}`,
+		`{job="does-not-exist"}`,
+	}, start, start.Add(time.Minute))
+	require.NoError(t, err)
+	keys = keys[:0]
+	for _, s := range res {
+		keys = append(keys, key(s))
+	}
+	require.Equal(t, []string{
+		`memory:inuse_space:bytes:space:bytes{job="api"}`,
+		`process_cpu:samples:count:cpu:nanoseconds:delta{job="api"}`,
+	}, keys)
+
+	_, err = q.Series(ctx, []string{`{job=`}, start, start.Add(time.Minute))
+	require.Error(t, err)
+}
@@ -0,0 +1,199 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// mergeAggregation returns how the snapshots of the queried profile type are
+// aggregated by a merge.
+func (q *Querier) mergeAggregation(qp profile.QueryParts, groupByLabels []string) profile.Aggregation {
+	if slices.Contains(groupByLabels, profile.ColumnTimestamp) {
+		// The snapshots are kept apart anyway.
+		return profile.AggregationSum
+	}
+	return q.semantics.Lookup(qp).Aggregation
+}
+
+// snapshots are the snapshots of the series matching a query.
+type snapshots struct {
+	count int
+	// latest holds the latest snapshot per series.
+	latest map[string]snapshot
+	// labelNames are the label names of all series.
+	labelNames map[string]struct{}
+}
+
+type snapshot struct {
+	labels    labels.Labels
+	timeNanos int64
+}
+
+func (q *Querier) snapshots(ctx context.Context, filterExpr logicalplan.Expr) (snapshots, error) {
+	ctx, span := q.tracer.Start(ctx, "Querier/snapshots")
+	defer span.End()
+
+	res := snapshots{
+		latest:     map[string]snapshot{},
+		labelNames: map[string]struct{}{},
+	}
+	labelSet := labels.NewScratchBuilder(64)
+	err := q.engine.ScanTable(q.tableName).
+		Filter(filterExpr).
+		Distinct(
+			logicalplan.Col(profile.ColumnTimeNanos),
+			logicalplan.DynCol(profile.ColumnLabels),
+		).
+		Execute(ctx, func(ctx context.Context, ar arrow.RecordBatch) error {
+			var timeNanos *array.Int64
+			labelColumns := map[string]*array.Dictionary{}
+			for i, field := range ar.Schema().Fields() {
+				switch {
+				case field.Name == profile.ColumnTimeNanos:
+					timeNanos = ar.Column(i).(*array.Int64)
+				case strings.HasPrefix(field.Name, profile.ColumnLabelsPrefix):
+					col, ok := ar.Column(i).(*array.Dictionary)
+					if !ok {
+						return fmt.Errorf("expected column %q to be a dictionary column, got %T", field.Name, ar.Column(i))
+					}
+					labelColumns[strings.TrimPrefix(field.Name, profile.ColumnLabelsPrefix)] = col
+				}
+			}
+			if timeNanos == nil {
+				return fmt.Errorf("%s column not found", profile.ColumnTimeNanos)
+			}
+
+			for i := 0; i < int(ar.NumRows()); i++ {
+				labelSet.Reset()
+				for name, col := range labelColumns {
+					if col.IsNull(i) {
+						continue
+					}
+					if v := StringValueFromDictionary(col, i); len(v) > 0 {
+						labelSet.Add(name, v)
+						res.labelNames[name] = struct{}{}
+					}
+				}
+				labelSet.Sort()
+				lset := labelSet.Labels()
+				series := lset.String()
+
+				res.count++
+				if ts := timeNanos.Value(i); ts > res.latest[series].timeNanos {
+					res.latest[series] = snapshot{labels: lset, timeNanos: ts}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return snapshots{}, err
+	}
+	return res, nil
+}
+
+// latestFilter matches the latest snapshot of every series. A series is
+// matched by all label names, the ones it doesn't have must be null.
+func (s snapshots) latestFilter() (logicalplan.Expr, error) {
+	names := make([]string, 0, len(s.labelNames))
+	for name := range s.labelNames {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	exprs := make([]logicalplan.Expr, 0, len(s.latest))
+	for _, snap := range s.latest {
+		seriesExprs := make([]logicalplan.Expr, 0, len(names)+1)
+		seriesExprs = append(seriesExprs, logicalplan.Col(profile.ColumnTimeNanos).Eq(logicalplan.Literal(snap.timeNanos)))
+		for _, name := range names {
+			// An empty value matches null.
+			expr, err := MatcherToBooleanExpression(&labels.Matcher{
+				Type:  labels.MatchEqual,
+				Name:  name,
+				Value: snap.labels.Get(name),
+			})
+			if err != nil {
+				return nil, err
+			}
+			seriesExprs = append(seriesExprs, expr)
+		}
+		exprs = append(exprs, logicalplan.And(seriesExprs...))
+	}
+	return logicalplan.Or(exprs...), nil
+}
+
+// averageSnapshots returns the records with their summed values divided by
+// the average number of snapshots per series.
+func averageSnapshots(
+	pool memory.Allocator,
+	records []arrow.RecordBatch,
+	valueColumn string,
+	s snapshots,
+) ([]arrow.RecordBatch, error) {
+	factor := 1.0
+	if s.count > 0 {
+		factor = float64(len(s.latest)) / float64(s.count)
+	}
+
+	res := make([]arrow.RecordBatch, 0, len(records))
+	for _, r := range records {
+		indices := r.Schema().FieldIndices(valueColumn)
+		if len(indices) != 1 {
+			releaseRecords(res)
+			return nil, fmt.Errorf("expected 1 column named %q, got %d", valueColumn, len(indices))
+		}
+		values, ok := r.Column(indices[0]).(*array.Int64)
+		if !ok {
+			releaseRecords(res)
+			return nil, fmt.Errorf("expected column %q to be an int64 column, got %T", valueColumn, r.Column(indices[0]))
+		}
+
+		b := array.NewInt64Builder(pool)
+		b.Reserve(values.Len())
+		for i := 0; i < values.Len(); i++ {
+			if values.IsNull(i) {
+				b.AppendNull()
+				continue
+			}
+			b.Append(int64(math.Round(float64(values.Value(i)) * factor)))
+		}
+		averaged := b.NewInt64Array()
+		b.Release()
+
+		cols := make([]arrow.Array, r.NumCols())
+		copy(cols, r.Columns())
+		cols[indices[0]] = averaged
+		res = append(res, array.NewRecordBatch(r.Schema(), cols, r.NumRows()))
+		averaged.Release()
+	}
+	return res, nil
+}
+
+func releaseRecords(records []arrow.RecordBatch) {
+	for _, r := range records {
+		r.Release()
+	}
+}
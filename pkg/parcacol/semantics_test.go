@@ -0,0 +1,126 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestAverageSnapshots(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewInt64Builder(mem)
+	b.AppendValues([]int64{30, 7}, nil)
+	b.AppendNull()
+	values := b.NewInt64Array()
+	b.Release()
+	defer values.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "sum(value)", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	r := array.NewRecordBatch(schema, []arrow.Array{values}, 3)
+	defer r.Release()
+
+	// Two series with three snapshots each.
+	s := snapshots{count: 6, latest: map[string]snapshot{
+		`{job="a"}`: {labels: labels.FromStrings("job", "a"), timeNanos: 3},
+		`{job="b"}`: {labels: labels.FromStrings("job", "b"), timeNanos: 3},
+	}}
+	res, err := averageSnapshots(mem, []arrow.RecordBatch{r}, "sum(value)", s)
+	require.NoError(t, err)
+	defer releaseRecords(res)
+
+	require.Len(t, res, 1)
+	averaged := res[0].Column(0).(*array.Int64)
+	require.Equal(t, int64(10), averaged.Value(0))
+	require.Equal(t, int64(2), averaged.Value(1))
+	require.True(t, averaged.IsNull(2))
+
+	_, err = averageSnapshots(mem, []arrow.RecordBatch{r}, "value", s)
+	require.Error(t, err)
+}
+
+func TestMergeAggregation(t *testing.T) {
+	q := NewQuerier(nil, nil, nil, "", nil, nil, nil, nil)
+	qp, err := profile.ParseQuery("goroutine:goroutine:count:goroutine:count{}")
+	require.NoError(t, err)
+
+	require.Equal(t, profile.AggregationAverage, q.mergeAggregation(qp, nil))
+	require.Equal(t, profile.AggregationSum, q.mergeAggregation(qp, []string{profile.ColumnTimestamp}))
+}
+
+func TestQueryMergeGaugeSnapshots(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1000, 0)
+
+	goroutines := func(lbls map[string]string, ts time.Time, value int64) testProfile {
+		lbls["__name__"] = "goroutine"
+		return testProfile{
+			labels:     lbls,
+			time:       ts,
+			sampleType: &pprofprofile.ValueType{Type: "goroutine", Unit: "count"},
+			periodType: &pprofprofile.ValueType{Type: "goroutine", Unit: "count"},
+			period:     1,
+			samples:    []testSample{{stack: []string{"main"}, value: value}},
+		}
+	}
+	// Series a has an additional label and its snapshots are taken five
+	// seconds after the ones of series b.
+	q := newTestQuerier(t,
+		goroutines(map[string]string{"instance": "a", "zone": "z"}, start.Add(5*time.Second), 10),
+		goroutines(map[string]string{"instance": "a", "zone": "z"}, start.Add(10*time.Second), 20),
+		goroutines(map[string]string{"instance": "b"}, start, 4),
+		goroutines(map[string]string{"instance": "b"}, start.Add(5*time.Second), 6),
+	)
+
+	total := func() int64 {
+		t.Helper()
+		p, err := q.QueryMerge(ctx, "goroutine:goroutine:count:goroutine:count{}", start, start.Add(time.Minute), nil, false, "")
+		require.NoError(t, err)
+
+		var res int64
+		for _, r := range p.Samples {
+			reader, err := profile.NewRecordReader(r)
+			require.NoError(t, err)
+			for i := 0; i < int(r.NumRows()); i++ {
+				res += reader.Value.Value(i)
+			}
+			r.Release()
+		}
+		return res
+	}
+
+	// Both series have two snapshots.
+	require.Equal(t, int64((10+20+4+6)/2), total())
+
+	q.semantics = profile.NewSemanticsRegistry()
+	require.NoError(t, q.semantics.Register("goroutine:goroutine:count", profile.Semantics{
+		Kind:        profile.ValueKindGauge,
+		Aggregation: profile.AggregationLatest,
+	}))
+	// The first snapshot of series a was taken at the same time as the
+	// latest one of series b, it must not be counted.
+	require.Equal(t, int64(20+6), total())
+}
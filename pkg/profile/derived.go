@@ -0,0 +1,67 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+const (
+	// DerivedTypePrefix prefixes the names of derived profile types in
+	// queries, for example derived:ipc{job="app"}.
+	DerivedTypePrefix = "derived"
+	// DerivedTypeUnit is the sample unit of derived profile types.
+	DerivedTypeUnit = "ratio"
+)
+
+// DerivedType is a virtual profile type whose values are the ratio of two
+// profile types sampling the same stacks, for example instructions per cycle.
+type DerivedType struct {
+	Name string
+	// Numerator and Denominator are profile types of the form
+	// <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>[:delta].
+	Numerator   string
+	Denominator string
+}
+
+// Validate returns an error if the derived type is not valid.
+func (d DerivedType) Validate() error {
+	if d.Name == "" || strings.ContainsAny(d.Name, ":{}") {
+		return fmt.Errorf("invalid derived profile type name %q", d.Name)
+	}
+	for _, pt := range []string{d.Numerator, d.Denominator} {
+		qp, err := ParseQuery(pt + "{}")
+		if err != nil {
+			return fmt.Errorf("invalid profile type %q of derived profile type %q: %w", pt, d.Name, err)
+		}
+		if qp.Derived != "" {
+			return fmt.Errorf("derived profile type %q cannot be derived from derived profile type %q", d.Name, pt)
+		}
+	}
+	return nil
+}
+
+// Queries returns the queries of the numerator and denominator with the
+// matchers of a query of the derived type.
+func (d DerivedType) Queries(matchers []*labels.Matcher) (string, string) {
+	sel := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		sel = append(sel, m.String())
+	}
+	selector := "{" + strings.Join(sel, ",") + "}"
+	return d.Numerator + selector, d.Denominator + selector
+}
@@ -0,0 +1,54 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivedType(t *testing.T) {
+	qp, err := ParseQuery(`derived:ipc{job="app",comm=~"api.*"}`)
+	require.NoError(t, err)
+	require.Equal(t, "ipc", qp.Derived)
+	require.Equal(t, ValueType{Type: "ipc", Unit: DerivedTypeUnit}, qp.Meta.SampleType)
+	require.Len(t, qp.Matchers, 2)
+
+	qp, err = ParseQuery(`perf:cycles:count:cycles:count{}`)
+	require.NoError(t, err)
+	require.Empty(t, qp.Derived)
+
+	_, err = ParseQuery(`derived:{}`)
+	require.Error(t, err)
+
+	d := DerivedType{
+		Name:        "ipc",
+		Numerator:   "perf:instructions:count:instructions:count",
+		Denominator: "perf:cycles:count:cycles:count",
+	}
+	require.NoError(t, d.Validate())
+	numerator, denominator := d.Queries(qp.Matchers)
+	require.Equal(t, "perf:instructions:count:instructions:count{}", numerator)
+	require.Equal(t, "perf:cycles:count:cycles:count{}", denominator)
+
+	qp, err = ParseQuery(`derived:ipc{job="app",comm=~"api.*"}`)
+	require.NoError(t, err)
+	numerator, _ = d.Queries(qp.Matchers)
+	require.Equal(t, `perf:instructions:count:instructions:count{job="app",comm=~"api.*"}`, numerator)
+
+	require.Error(t, DerivedType{Name: "ipc:x", Numerator: d.Numerator, Denominator: d.Denominator}.Validate())
+	require.Error(t, DerivedType{Name: "ipc", Numerator: "perf:instructions", Denominator: d.Denominator}.Validate())
+	require.Error(t, DerivedType{Name: "ipc", Numerator: d.Numerator, Denominator: "derived:cpi"}.Validate())
+}
@@ -29,6 +29,10 @@ import (
 
 const (
 	ColumnLabelsPrefix = ColumnLabels + "."
+
+	// ColumnValueSecondary is an optional column of sample records, which
+	// holds the value of a second profile type sampling the same stacks.
+	ColumnValueSecondary = "value_secondary"
 )
 
 var LocationsField = arrow.Field{
@@ -204,10 +208,33 @@ type QueryParts struct {
 	Meta     Meta
 	Delta    bool
 	Matchers []*labels.Matcher
+	// Derived is the name of the queried derived profile type, if any.
+	Derived string
+}
+
+// ParseSelector parses a series selector. Unlike ParseQuery the profile type
+// is optional, the returned bool reports whether the selector contained one.
+func ParseSelector(selector string) (QueryParts, bool, error) {
+	parsedSelector, err := parser.ParseMetricSelector(selector)
+	if err != nil {
+		return QueryParts{}, false, status.Error(codes.InvalidArgument, "failed to parse selector")
+	}
+
+	sel := make([]*labels.Matcher, 0, len(parsedSelector))
+	for _, matcher := range parsedSelector {
+		if matcher.Name == labels.MetricName {
+			qp, err := ParseQuery(selector)
+			return qp, err == nil, err
+		}
+		sel = append(sel, matcher)
+	}
+
+	return QueryParts{Matchers: sel}, false, nil
 }
 
 // ParseQuery parses a Parca query string into its components.
-// The query format is: <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>[:delta]{label=value,...}.
+// The query format is: <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>[:delta]{label=value,...},
+// or derived:<name>{label=value,...} for derived profile types.
 func ParseQuery(query string) (QueryParts, error) {
 	parsedSelector, err := parser.ParseMetricSelector(query)
 	if err != nil {
@@ -228,6 +255,19 @@ func ParseQuery(query string) (QueryParts, error) {
 	}
 
 	parts := strings.Split(nameLabel.Value, ":")
+	if len(parts) == 2 && parts[0] == DerivedTypePrefix && parts[1] != "" {
+		return QueryParts{
+			Meta: Meta{
+				Name: parts[1],
+				SampleType: ValueType{
+					Type: parts[1],
+					Unit: DerivedTypeUnit,
+				},
+			},
+			Matchers: sel,
+			Derived:  parts[1],
+		}, nil
+	}
 	if len(parts) != 5 && len(parts) != 6 {
 		return QueryParts{}, status.Errorf(codes.InvalidArgument, "profile-type selection must be of the form <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>(:delta), got(%d): %q", len(parts), nameLabel.Value)
 	}
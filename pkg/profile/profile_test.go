@@ -81,3 +81,23 @@ func TestParseQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSelector(t *testing.T) {
+	qp, hasProfileType, err := ParseSelector(`process_cpu:samples:count:cpu:nanoseconds:delta{node="test"}`)
+	require.NoError(t, err)
+	require.True(t, hasProfileType)
+	require.Equal(t, "process_cpu", qp.Meta.Name)
+	require.True(t, qp.Delta)
+	require.Len(t, qp.Matchers, 1)
+
+	qp, hasProfileType, err = ParseSelector(`{node="test",container=~"app.*"}`)
+	require.NoError(t, err)
+	require.False(t, hasProfileType)
+	require.Len(t, qp.Matchers, 2)
+
+	_, _, err = ParseSelector(`invalid{`)
+	require.Error(t, err)
+
+	_, _, err = ParseSelector(`process_cpu{node="test"}`)
+	require.Error(t, err)
+}
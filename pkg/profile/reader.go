@@ -63,6 +63,9 @@ type RecordReader struct {
 
 	Value *array.Int64
 	Diff  *array.Int64
+	// ValueSecondary is nil unless the record has the optional
+	// ColumnValueSecondary column.
+	ValueSecondary *array.Int64
 }
 
 func NewReader(p Profile) (Reader, error) {
@@ -163,6 +166,8 @@ func NewRecordReader(ar arrow.RecordBatch) (*RecordReader, error) {
 			rr.Value = ar.Column(i).(*array.Int64)
 		case "diff":
 			rr.Diff = ar.Column(i).(*array.Int64)
+		case ColumnValueSecondary:
+			rr.ValueSecondary = ar.Column(i).(*array.Int64)
 		case ColumnTimestamp:
 			rr.Timestamp = ar.Column(i).(*array.Int64)
 		case ColumnPeriod:
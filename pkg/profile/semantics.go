@@ -0,0 +1,144 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ValueKind describes what the values of a profile type measure.
+type ValueKind string
+
+const (
+	// ValueKindCumulative values count up since the start of the process,
+	// like allocations or mutex contentions.
+	ValueKindCumulative ValueKind = "cumulative"
+	// ValueKindDelta values cover the duration of the profile, like CPU
+	// time or the allocations since the previous profile.
+	ValueKindDelta ValueKind = "delta"
+	// ValueKindGauge values are a snapshot of the process's current state,
+	// like the in-use memory or the goroutines.
+	ValueKindGauge ValueKind = "gauge"
+)
+
+// Aggregation is how the snapshots of a profile type are aggregated when they
+// are merged over a time range.
+type Aggregation string
+
+const (
+	// AggregationSum sums the values of all snapshots.
+	AggregationSum Aggregation = "sum"
+	// AggregationAverage divides the sum of the values by the number of
+	// snapshots per series.
+	AggregationAverage Aggregation = "average"
+	// AggregationLatest only uses the latest snapshot of every series.
+	AggregationLatest Aggregation = "latest"
+)
+
+// Semantics are the semantics of a profile type.
+type Semantics struct {
+	Kind        ValueKind
+	Aggregation Aggregation
+}
+
+// DefaultAggregation returns the aggregation of the value kind unless it is
+// configured otherwise.
+func (k ValueKind) DefaultAggregation() Aggregation {
+	switch k {
+	case ValueKindGauge:
+		return AggregationAverage
+	case ValueKindCumulative:
+		// The latest snapshot already counts everything its predecessors
+		// counted, summing them would count it again for every snapshot.
+		return AggregationLatest
+	default:
+		return AggregationSum
+	}
+}
+
+// defaultSemantics covers the pprof profile types scraped by default. All
+// other profile types are treated as deltas.
+var defaultSemantics = map[string]ValueKind{
+	"memory:alloc_objects:count":  ValueKindCumulative,
+	"memory:alloc_space:bytes":    ValueKindCumulative,
+	"memory:inuse_objects:count":  ValueKindGauge,
+	"memory:inuse_space:bytes":    ValueKindGauge,
+	"block:contentions:count":     ValueKindCumulative,
+	"block:delay:nanoseconds":     ValueKindCumulative,
+	"mutex:contentions:count":     ValueKindCumulative,
+	"mutex:delay:nanoseconds":     ValueKindCumulative,
+	"goroutine:goroutine:count":   ValueKindGauge,
+	"process_cpu:samples:count":   ValueKindDelta,
+	"process_cpu:cpu:nanoseconds": ValueKindDelta,
+}
+
+// SemanticsRegistry holds the semantics of profile types, identified by their
+// name, sample type and sample unit, for example goroutine:goroutine:count.
+type SemanticsRegistry struct {
+	mtx   sync.RWMutex
+	types map[string]Semantics
+}
+
+// NewSemanticsRegistry returns a registry of the semantics of the pprof
+// profile types scraped by default.
+func NewSemanticsRegistry() *SemanticsRegistry {
+	r := &SemanticsRegistry{types: make(map[string]Semantics, len(defaultSemantics))}
+	for t, k := range defaultSemantics {
+		r.types[t] = Semantics{Kind: k, Aggregation: k.DefaultAggregation()}
+	}
+	return r
+}
+
+// Register sets the semantics of the profile type of the form
+// <name>:<sample-type>:<sample-unit>. The aggregation defaults to the one of
+// the value kind.
+func (r *SemanticsRegistry) Register(profileType string, s Semantics) error {
+	if len(strings.Split(profileType, ":")) != 3 {
+		return fmt.Errorf("profile type must be of the form <name>:<sample-type>:<sample-unit>, got %q", profileType)
+	}
+	switch s.Kind {
+	case ValueKindCumulative, ValueKindDelta, ValueKindGauge:
+	default:
+		return fmt.Errorf("unknown value kind %q of profile type %q", s.Kind, profileType)
+	}
+	switch s.Aggregation {
+	case "":
+		s.Aggregation = s.Kind.DefaultAggregation()
+	case AggregationSum, AggregationAverage, AggregationLatest:
+	default:
+		return fmt.Errorf("unknown aggregation %q of profile type %q", s.Aggregation, profileType)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.types[profileType] = s
+	return nil
+}
+
+// Lookup returns the semantics of the queried profile type. Delta queries are
+// always summed, unknown profile types are treated as deltas.
+func (r *SemanticsRegistry) Lookup(qp QueryParts) Semantics {
+	if qp.Delta {
+		return Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}
+	}
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if s, ok := r.types[qp.Meta.Name+":"+qp.Meta.SampleType.Type+":"+qp.Meta.SampleType.Unit]; ok {
+		return s
+	}
+	return Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}
+}
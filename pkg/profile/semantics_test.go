@@ -0,0 +1,51 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemanticsRegistry(t *testing.T) {
+	r := NewSemanticsRegistry()
+	lookup := func(query string) Semantics {
+		qp, err := ParseQuery(query)
+		require.NoError(t, err)
+		return r.Lookup(qp)
+	}
+
+	require.Equal(t, Semantics{Kind: ValueKindGauge, Aggregation: AggregationAverage}, lookup("goroutine:goroutine:count:goroutine:count{}"))
+	require.Equal(t, Semantics{Kind: ValueKindGauge, Aggregation: AggregationAverage}, lookup("memory:inuse_space:bytes:space:bytes{}"))
+	require.Equal(t, Semantics{Kind: ValueKindCumulative, Aggregation: AggregationLatest}, lookup("memory:alloc_space:bytes:space:bytes{}"))
+	require.Equal(t, Semantics{Kind: ValueKindCumulative, Aggregation: AggregationLatest}, lookup("mutex:delay:nanoseconds:contentions:count{}"))
+	require.Equal(t, Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}, lookup("memory:alloc_space:bytes:space:bytes:delta{}"))
+	require.Equal(t, Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}, lookup("process_cpu:samples:count:cpu:nanoseconds:delta{}"))
+	require.Equal(t, Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}, lookup("connections:open:count:open:count{}"))
+
+	require.NoError(t, r.Register("connections:open:count", Semantics{Kind: ValueKindGauge}))
+	require.Equal(t, Semantics{Kind: ValueKindGauge, Aggregation: AggregationAverage}, lookup("connections:open:count:open:count{}"))
+	require.NoError(t, r.Register("requests:handled:count", Semantics{Kind: ValueKindCumulative}))
+	require.Equal(t, Semantics{Kind: ValueKindCumulative, Aggregation: AggregationLatest}, lookup("requests:handled:count:handled:count{}"))
+	require.NoError(t, r.Register("goroutine:goroutine:count", Semantics{Kind: ValueKindGauge, Aggregation: AggregationLatest}))
+	require.Equal(t, Semantics{Kind: ValueKindGauge, Aggregation: AggregationLatest}, lookup("goroutine:goroutine:count:goroutine:count{}"))
+	// Delta queries are summed regardless of the registered semantics.
+	require.NoError(t, r.Register("memory:inuse_space:bytes", Semantics{Kind: ValueKindGauge, Aggregation: AggregationLatest}))
+	require.Equal(t, Semantics{Kind: ValueKindDelta, Aggregation: AggregationSum}, lookup("memory:inuse_space:bytes:space:bytes:delta{}"))
+
+	require.Error(t, r.Register("connections:open", Semantics{Kind: ValueKindGauge}))
+	require.Error(t, r.Register("connections:open:count", Semantics{Kind: "counter"}))
+	require.Error(t, r.Register("connections:open:count", Semantics{Kind: ValueKindGauge, Aggregation: "max"}))
+}
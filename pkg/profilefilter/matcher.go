@@ -0,0 +1,689 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilefilter
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// stackMatchesFilter checks if a stack matches the given filter criteria.
+func stackMatchesFilter(
+	m *Matcher,
+	r *profile.RecordReader,
+	firstStart, lastEnd, locStart, locEnd int,
+	filter *pb.FilterCriteria,
+) bool {
+	if fnCond := filter.GetFunctionName(); fnCond != nil {
+		if r.LineFunctionNameIndices.Len() == 0 {
+			return handleUnsymbolizedFunctionCondition(fnCond)
+		}
+		return matchesFunctionNameInRange(m, r, firstStart, lastEnd, fnCond)
+	}
+
+	if binCond := filter.GetBinary(); binCond != nil {
+		found := false
+		for locIdx := locStart; locIdx < locEnd; locIdx++ {
+			if r.MappingStart.IsValid(locIdx) {
+				if m.matchesMappingFile(r.MappingFileDict, int(r.MappingFileIndices.Value(locIdx)), binCond) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if sysCond := filter.GetSystemName(); sysCond != nil {
+		if r.LineFunctionSystemNameIndices.Len() == 0 {
+			return handleUnsymbolizedFunctionCondition(sysCond)
+		}
+		if !matchesSystemNameInRange(m, r, firstStart, lastEnd, sysCond) {
+			return false
+		}
+	}
+
+	if fileCond := filter.GetFilename(); fileCond != nil {
+		if r.LineFunctionFilenameIndices.Len() == 0 {
+			return handleUnsymbolizedFunctionCondition(fileCond)
+		}
+		if !matchesFilenameInRange(m, r, firstStart, lastEnd, fileCond) {
+			return false
+		}
+	}
+
+	if addrCond := filter.GetAddress(); addrCond != nil {
+		found := false
+		for locIdx := locStart; locIdx < locEnd; locIdx++ {
+			if r.Address.IsValid(locIdx) {
+				address := r.Address.Value(locIdx)
+				if matchesNumberCondition(address, addrCond) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if lineCond := filter.GetLineNumber(); lineCond != nil {
+		if !matchesLineNumberInRange(r, firstStart, lastEnd, lineCond) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func handleUnsymbolizedFunctionCondition(fnCond *pb.StringCondition) bool {
+	// For positive conditions no function names means no matches, for
+	// negative conditions no function names means the condition is satisfied.
+	return isNegativeStringCondition(fnCond)
+}
+
+// isNegativeStringCondition reports whether the condition matches values
+// that do NOT satisfy it, in which case all values in a stack have to match
+// (AND logic) rather than any of them (OR logic).
+func isNegativeStringCondition(cond *pb.StringCondition) bool {
+	switch cond.GetCondition().(type) {
+	case *pb.StringCondition_NotContains,
+		*pb.StringCondition_NotEqual,
+		*pb.StringCondition_NotStartsWith,
+		*pb.StringCondition_NotMatchesRegex:
+		return true
+	}
+	return false
+}
+
+func matchesFunctionNameInRange(m *Matcher, r *profile.RecordReader, firstStart, lastEnd int, fnCond *pb.StringCondition) bool {
+	// For NotContains/NotEqual/NotStartsWith/NotMatchesRegex, we need ALL functions to not match the target (AND logic)
+	// For Contains/Equal/StartsWith/MatchesRegex, we need ANY function to match the target (OR logic)
+	isNegativeCondition := isNegativeStringCondition(fnCond)
+
+	// Iterate through all line indices in the range
+	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
+		if lineIndex >= r.LineFunctionNameIndices.Len() {
+			break
+		}
+
+		// Check if this line has a valid function name
+		if r.LineFunctionNameIndices.IsValid(lineIndex) {
+			fnIndex := int(r.LineFunctionNameIndices.Value(lineIndex))
+
+			if isNegativeCondition {
+				// For negative conditions (NotContains/NotEqual), if ANY function matches the negative condition, return false
+				if !m.matchesDictValue(r.LineFunctionNameDict, fnIndex, fnCond) {
+					return false
+				}
+			} else {
+				// For positive conditions (Contains/Equal), if ANY function matches, return true
+				if m.matchesDictValue(r.LineFunctionNameDict, fnIndex, fnCond) {
+					return true
+				}
+			}
+		}
+	}
+
+	if isNegativeCondition {
+		// For negative conditions, if we got here, ALL functions passed the negative condition
+		return true
+	} else {
+		// For positive conditions, if we got here, NO function matched
+		return false
+	}
+}
+
+func matchesSystemNameInRange(m *Matcher, r *profile.RecordReader, firstStart, lastEnd int, sysCond *pb.StringCondition) bool {
+	isNegativeCondition := isNegativeStringCondition(sysCond)
+
+	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
+		if lineIndex >= r.LineFunctionSystemNameIndices.Len() {
+			break
+		}
+
+		if r.LineFunctionSystemNameIndices.IsValid(lineIndex) {
+			sysIndex := int(r.LineFunctionSystemNameIndices.Value(lineIndex))
+
+			if isNegativeCondition {
+				if !m.matchesDictValue(r.LineFunctionSystemNameDict, sysIndex, sysCond) {
+					return false
+				}
+			} else {
+				if m.matchesDictValue(r.LineFunctionSystemNameDict, sysIndex, sysCond) {
+					return true
+				}
+			}
+		}
+	}
+
+	return isNegativeCondition
+}
+
+func matchesFilenameInRange(m *Matcher, r *profile.RecordReader, firstStart, lastEnd int, fileCond *pb.StringCondition) bool {
+	isNegativeCondition := isNegativeStringCondition(fileCond)
+
+	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
+		if lineIndex >= r.LineFunctionFilenameIndices.Len() {
+			break
+		}
+
+		if r.LineFunctionFilenameIndices.IsValid(lineIndex) {
+			fileIndex := int(r.LineFunctionFilenameIndices.Value(lineIndex))
+
+			if isNegativeCondition {
+				if !m.matchesDictValue(r.LineFunctionFilenameDict, fileIndex, fileCond) {
+					return false
+				}
+			} else {
+				if m.matchesDictValue(r.LineFunctionFilenameDict, fileIndex, fileCond) {
+					return true
+				}
+			}
+		}
+	}
+
+	return isNegativeCondition
+}
+
+func matchesLineNumberInRange(r *profile.RecordReader, firstStart, lastEnd int, lineCond *pb.NumberCondition) bool {
+	isNegativeCondition := false
+	switch lineCond.GetCondition().(type) {
+	case *pb.NumberCondition_NotEqual:
+		isNegativeCondition = true
+	}
+
+	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
+		if lineIndex >= r.LineNumber.Len() {
+			break
+		}
+
+		if r.LineNumber.IsValid(lineIndex) {
+			lineNumber := uint64(r.LineNumber.Value(lineIndex))
+
+			if isNegativeCondition {
+				if !matchesNumberCondition(lineNumber, lineCond) {
+					return false
+				}
+			} else {
+				if matchesNumberCondition(lineNumber, lineCond) {
+					return true
+				}
+			}
+		}
+	}
+
+	return isNegativeCondition
+}
+
+// matchesFrameFilter checks if a single frame matches the filter criteria.
+func matchesFrameFilter(m *Matcher, r *profile.RecordReader, locationIndex, lineIndex int, filter *pb.FilterCriteria) bool {
+	if fnCond := filter.GetFunctionName(); fnCond != nil {
+		// If lineIndex is -1, skip function name check
+		if lineIndex >= 0 {
+			// If unsymbolized, always return false
+			if r.LineFunctionNameIndices.Len() == 0 {
+				return false
+			}
+			if r.LineFunctionNameIndices.IsValid(lineIndex) {
+				fnIndex := int(r.LineFunctionNameIndices.Value(lineIndex))
+				if !m.matchesDictValue(r.LineFunctionNameDict, fnIndex, fnCond) {
+					return false
+				}
+			} else {
+				// Frame has no function name, so function name filter doesn't match
+				return false
+			}
+		}
+	}
+
+	if binCond := filter.GetBinary(); binCond != nil {
+		if r.MappingStart.IsValid(locationIndex) {
+			if !m.matchesMappingFile(r.MappingFileDict, int(r.MappingFileIndices.Value(locationIndex)), binCond) {
+				return false
+			}
+		}
+	}
+
+	if sysCond := filter.GetSystemName(); sysCond != nil {
+		// If lineIndex is -1, skip system name check
+		if lineIndex >= 0 {
+			if r.LineFunctionSystemNameIndices.Len() == 0 {
+				return false
+			}
+			if r.LineFunctionSystemNameIndices.IsValid(lineIndex) {
+				sysIndex := int(r.LineFunctionSystemNameIndices.Value(lineIndex))
+				if !m.matchesDictValue(r.LineFunctionSystemNameDict, sysIndex, sysCond) {
+					return false
+				}
+			} else {
+				// Frame has no system name, so system name filter doesn't match
+				return false
+			}
+		}
+	}
+
+	if fileCond := filter.GetFilename(); fileCond != nil {
+		// If lineIndex is -1, skip filename check
+		if lineIndex >= 0 {
+			if r.LineFunctionFilenameIndices.Len() == 0 {
+				return false
+			}
+			if r.LineFunctionFilenameIndices.IsValid(lineIndex) {
+				fileIndex := int(r.LineFunctionFilenameIndices.Value(lineIndex))
+				if !m.matchesDictValue(r.LineFunctionFilenameDict, fileIndex, fileCond) {
+					return false
+				}
+			} else {
+				// Frame has no filename, so filename filter doesn't match
+				return false
+			}
+		}
+	}
+
+	if addrCond := filter.GetAddress(); addrCond != nil {
+		if r.Address.IsValid(locationIndex) {
+			address := r.Address.Value(locationIndex)
+			if !matchesNumberCondition(address, addrCond) {
+				return false
+			}
+		} else {
+			// Frame has no address, so address filter doesn't match
+			return false
+		}
+	}
+
+	if lineCond := filter.GetLineNumber(); lineCond != nil {
+		// If lineIndex is -1, skip line number check
+		if lineIndex >= 0 {
+			if r.LineNumber.IsValid(lineIndex) {
+				lineNumber := uint64(r.LineNumber.Value(lineIndex))
+				if !matchesNumberCondition(lineNumber, lineCond) {
+					return false
+				}
+			} else {
+				// Frame has no line number, so line number filter doesn't match
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// toLower converts ASCII byte to lowercase without allocation.
+// Non-ASCII bytes are left unchanged.
+func toLower(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
+
+// equalFoldBytes performs case-insensitive comparison without allocation.
+func equalFoldBytes(s, t []byte) bool {
+	if len(s) != len(t) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if toLower(s[i]) != toLower(t[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFoldBytes performs case-insensitive contains check without allocation.
+func containsFoldBytes(s, substr []byte) bool {
+	if len(substr) == 0 {
+		return true
+	}
+	if len(substr) > len(s) {
+		return false
+	}
+
+	// Search for substring
+	for i := 0; i <= len(s)-len(substr); i++ {
+		// Check if substring matches at position i
+		match := true
+		for j := 0; j < len(substr); j++ {
+			if toLower(s[i+j]) != toLower(substr[j]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefixFoldBytes performs case-insensitive prefix check without allocation.
+func hasPrefixFoldBytes(s, prefix []byte) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if toLower(s[i]) != toLower(prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matcher evaluates the criteria of stack and frame filters against the rows
+// of profile records. Regular expressions are compiled once when the matcher
+// is created and each string condition is evaluated at most once per
+// dictionary entry of the record being filtered, instead of once per row.
+type Matcher struct {
+	stackFilters []*pb.FilterCriteria
+	frameFilters []*pb.FilterCriteria
+
+	pprof pprofRegexps
+
+	regexps       map[*pb.StringCondition]*regexp.Regexp
+	dictMatches   map[dictMatchKey][]dictMatch
+	regexpMatches map[regexpMatchKey][]dictMatch
+}
+
+type dictMatchKey struct {
+	cond *pb.StringCondition
+	dict *array.Binary
+}
+
+type dictMatch uint8
+
+const (
+	dictMatchUnknown dictMatch = iota
+	dictMatchTrue
+	dictMatchFalse
+)
+
+// NewMatcher creates a Matcher for the given filters. An InvalidArgument
+// error is returned if any of the conditions are invalid.
+func NewMatcher(filters []*pb.Filter) (*Matcher, error) {
+	return NewMatcherWithPprofOptions(filters, PprofOptions{})
+}
+
+// NewMatcherWithPprofOptions creates a Matcher for the given filters that
+// additionally applies the pprof options. An InvalidArgument error is
+// returned if any of the conditions or options are invalid.
+func NewMatcherWithPprofOptions(filters []*pb.Filter, opts PprofOptions) (*Matcher, error) {
+	pprof, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Matcher{
+		stackFilters:  make([]*pb.FilterCriteria, 0),
+		frameFilters:  make([]*pb.FilterCriteria, 0),
+		pprof:         pprof,
+		regexps:       map[*pb.StringCondition]*regexp.Regexp{},
+		dictMatches:   map[dictMatchKey][]dictMatch{},
+		regexpMatches: map[regexpMatchKey][]dictMatch{},
+	}
+
+	for _, filter := range filters {
+		var criteria *pb.FilterCriteria
+		if stackFilter := filter.GetStackFilter(); stackFilter != nil {
+			if criteria = stackFilter.GetCriteria(); criteria != nil {
+				m.stackFilters = append(m.stackFilters, criteria)
+			}
+		}
+		if frameFilter := filter.GetFrameFilter(); frameFilter != nil {
+			if criteria = frameFilter.GetCriteria(); criteria != nil {
+				m.frameFilters = append(m.frameFilters, criteria)
+			}
+		}
+		if criteria == nil {
+			continue
+		}
+
+		for _, cond := range []*pb.StringCondition{
+			criteria.GetFunctionName(),
+			criteria.GetSystemName(),
+			criteria.GetBinary(),
+			criteria.GetFilename(),
+		} {
+			if err := m.compile(cond); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, cond := range []*pb.NumberCondition{
+			criteria.GetAddress(),
+			criteria.GetLineNumber(),
+		} {
+			if r := cond.GetRange(); r != nil && r.GetStart() > r.GetEnd() {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid number range: start %d is greater than end %d", r.GetStart(), r.GetEnd())
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// HasStackFilters returns true if any of the filters or pprof options drops
+// whole stacks.
+func (m *Matcher) HasStackFilters() bool {
+	return len(m.stackFilters) > 0 ||
+		m.pprof.focus != nil ||
+		m.pprof.ignore != nil ||
+		m.pprof.showFrom != nil
+}
+
+// HasFrameFilters returns true if any of the filters only drops frames
+// instead of whole stacks.
+func (m *Matcher) HasFrameFilters() bool {
+	return len(m.frameFilters) > 0
+}
+
+// StackMatches checks if the stack of the given row matches all stack filters
+// as well as the focus and ignore options. Rows without any locations match
+// unless a focus is set.
+func (m *Matcher) StackMatches(r *profile.RecordReader, row int) bool {
+	if !m.pprofStackMatches(r, row) {
+		return false
+	}
+	if len(m.stackFilters) == 0 {
+		return true
+	}
+
+	lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(row)
+	if lOffsetStart >= lOffsetEnd {
+		return true
+	}
+
+	firstStart, _ := r.Lines.ValueOffsets(int(lOffsetStart))
+	_, lastEnd := r.Lines.ValueOffsets(int(lOffsetEnd - 1))
+
+	for _, filter := range m.stackFilters {
+		if !stackMatchesFilter(
+			m,
+			r,
+			int(firstStart),
+			int(lastEnd-1),
+			int(lOffsetStart),
+			int(lOffsetEnd),
+			filter,
+		) {
+			return false
+		}
+	}
+	return true
+}
+
+// FrameMatches checks if a single frame matches all frame filters as well as
+// the hide and show options. A lineIndex of -1 denotes an unsymbolized
+// location, in which case only the location level criteria are checked.
+func (m *Matcher) FrameMatches(r *profile.RecordReader, locationIndex, lineIndex int) bool {
+	if !m.pprofFrameMatches(r, locationIndex, lineIndex) {
+		return false
+	}
+	// If no frame filters are provided, keep all frames
+	for _, filter := range m.frameFilters {
+		if !matchesFrameFilter(m, r, locationIndex, lineIndex, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// compile compiles the regular expression of a regex condition, other
+// conditions are ignored. Like the other string conditions, regular
+// expressions match case-insensitively.
+func (m *Matcher) compile(cond *pb.StringCondition) error {
+	var expr string
+	switch c := cond.GetCondition().(type) {
+	case *pb.StringCondition_MatchesRegex:
+		expr = c.MatchesRegex
+	case *pb.StringCondition_NotMatchesRegex:
+		expr = c.NotMatchesRegex
+	default:
+		return nil
+	}
+
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid regular expression %q: %v", expr, err)
+	}
+	m.regexps[cond] = re
+	return nil
+}
+
+// Reset drops the cached dictionary matches, it should be called before
+// matching rows of a record with different dictionaries.
+func (m *Matcher) Reset() {
+	clear(m.dictMatches)
+	clear(m.regexpMatches)
+}
+
+// matchesString checks if a value matches a string condition.
+func (m *Matcher) matchesString(value []byte, cond *pb.StringCondition) bool {
+	switch cond.GetCondition().(type) {
+	case *pb.StringCondition_MatchesRegex:
+		return m.regexps[cond].Match(value)
+	case *pb.StringCondition_NotMatchesRegex:
+		return !m.regexps[cond].Match(value)
+	default:
+		return matchesStringCondition(value, cond)
+	}
+}
+
+// matchesDictValue checks if the dictionary entry at index i matches the string condition.
+func (m *Matcher) matchesDictValue(dict *array.Binary, i int, cond *pb.StringCondition) bool {
+	return m.matchesDict(dict, i, cond, false)
+}
+
+// matchesMappingFile checks if the base name of the mapping file at index i
+// of the dictionary matches the string condition.
+func (m *Matcher) matchesMappingFile(dict *array.Binary, i int, cond *pb.StringCondition) bool {
+	return m.matchesDict(dict, i, cond, true)
+}
+
+func (m *Matcher) matchesDict(dict *array.Binary, i int, cond *pb.StringCondition, baseName bool) bool {
+	key := dictMatchKey{cond: cond, dict: dict}
+	matches, ok := m.dictMatches[key]
+	if !ok {
+		matches = make([]dictMatch, dict.Len())
+		m.dictMatches[key] = matches
+	}
+
+	switch matches[i] {
+	case dictMatchTrue:
+		return true
+	case dictMatchFalse:
+		return false
+	}
+
+	value := dict.Value(i)
+	if baseName {
+		if lastSlash := bytes.LastIndex(value, []byte("/")); lastSlash >= 0 {
+			value = value[lastSlash+1:]
+		}
+	}
+
+	if m.matchesString(value, cond) {
+		matches[i] = dictMatchTrue
+		return true
+	}
+	matches[i] = dictMatchFalse
+	return false
+}
+
+// matchesStringCondition checks if a value matches a string condition.
+// Regex conditions are evaluated by the Matcher, as they need to be
+// compiled first.
+func matchesStringCondition(value []byte, condition *pb.StringCondition) bool {
+	if condition == nil {
+		return true
+	}
+
+	switch condition.GetCondition().(type) {
+	case *pb.StringCondition_Equal:
+		target := []byte(condition.GetEqual())
+		return equalFoldBytes(value, target)
+	case *pb.StringCondition_NotEqual:
+		target := []byte(condition.GetNotEqual())
+		return !equalFoldBytes(value, target)
+	case *pb.StringCondition_Contains:
+		target := []byte(condition.GetContains())
+		return containsFoldBytes(value, target)
+	case *pb.StringCondition_NotContains:
+		target := []byte(condition.GetNotContains())
+		return !containsFoldBytes(value, target)
+	case *pb.StringCondition_StartsWith:
+		target := []byte(condition.GetStartsWith())
+		return hasPrefixFoldBytes(value, target)
+	case *pb.StringCondition_NotStartsWith:
+		target := []byte(condition.GetNotStartsWith())
+		return !hasPrefixFoldBytes(value, target)
+	default:
+		return true
+	}
+}
+
+// matchesNumberCondition checks if a numeric value matches a number condition.
+func matchesNumberCondition(value uint64, condition *pb.NumberCondition) bool {
+	if condition == nil {
+		return true
+	}
+
+	switch condition.GetCondition().(type) {
+	case *pb.NumberCondition_Equal:
+		return value == condition.GetEqual()
+	case *pb.NumberCondition_NotEqual:
+		return value != condition.GetNotEqual()
+	case *pb.NumberCondition_GreaterThan:
+		return value > condition.GetGreaterThan()
+	case *pb.NumberCondition_LessThan:
+		return value < condition.GetLessThan()
+	case *pb.NumberCondition_Range:
+		r := condition.GetRange()
+		return value >= r.GetStart() && value <= r.GetEnd()
+	default:
+		return true
+	}
+}
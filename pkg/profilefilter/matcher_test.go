@@ -0,0 +1,161 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilefilter
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// newTestRecordReader creates a record with one row per stack, each stack
+// being a list of function names of symbolized frames.
+func newTestRecordReader(t *testing.T, mem memory.Allocator, stacks [][]string) *profile.RecordReader {
+	t.Helper()
+
+	w := profile.NewWriter(mem, nil)
+	t.Cleanup(w.Release)
+
+	for _, stack := range stacks {
+		w.LocationsList.Append(true)
+		for i, fn := range stack {
+			w.Locations.Append(true)
+			w.Addresses.Append(uint64(0x1000 * (i + 1)))
+			w.MappingStart.Append(0x1000)
+			w.MappingLimit.Append(0x8000)
+			w.MappingOffset.Append(0x0)
+			w.MappingFile.Append([]byte("/usr/bin/app"))
+			w.MappingBuildID.Append([]byte(""))
+			w.Lines.Append(true)
+			w.Line.Append(true)
+			w.LineNumber.Append(int64(10 * (i + 1)))
+			w.ColumnNumber.Append(0)
+			w.FunctionName.Append([]byte(fn))
+			w.FunctionSystemName.Append([]byte(fn))
+			w.FunctionFilename.Append([]byte("main.go"))
+			w.FunctionStartLine.Append(1)
+		}
+		w.Value.Append(1)
+		w.Diff.Append(0)
+		w.TimeNanos.Append(1)
+		w.Period.Append(1)
+	}
+
+	rec := w.RecordBuilder.NewRecordBatch()
+	t.Cleanup(rec.Release)
+
+	r, err := profile.NewRecordReader(rec)
+	require.NoError(t, err)
+	return r
+}
+
+func TestMatcherStackMatches(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	t.Cleanup(func() { mem.AssertSize(t, 0) })
+
+	r := newTestRecordReader(t, mem, [][]string{
+		{"main", "encoding/json.Marshal", "reflect.Value.Field"},
+		{"main", "net/http.(*conn).serve"},
+		{},
+	})
+
+	m, err := NewMatcher([]*pb.Filter{{
+		Filter: &pb.Filter_StackFilter{
+			StackFilter: &pb.StackFilter{
+				Filter: &pb.StackFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_Equal{Equal: "encoding/json.Marshal"},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.True(t, m.HasStackFilters())
+
+	require.True(t, m.StackMatches(r, 0))
+	require.False(t, m.StackMatches(r, 1))
+	// Stacks without any locations are never dropped.
+	require.True(t, m.StackMatches(r, 2))
+}
+
+func TestMatcherFrameMatches(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	t.Cleanup(func() { mem.AssertSize(t, 0) })
+
+	r := newTestRecordReader(t, mem, [][]string{
+		{"main", "runtime.gcBgMarkWorker"},
+	})
+
+	m, err := NewMatcher([]*pb.Filter{{
+		Filter: &pb.Filter_FrameFilter{
+			FrameFilter: &pb.FrameFilter{
+				Filter: &pb.FrameFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_NotStartsWith{NotStartsWith: "runtime."},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.False(t, m.HasStackFilters())
+
+	// Without stack filters every stack matches.
+	require.True(t, m.StackMatches(r, 0))
+	require.True(t, m.FrameMatches(r, 0, 0))
+	require.False(t, m.FrameMatches(r, 1, 1))
+}
+
+func TestNewMatcherInvalidConditions(t *testing.T) {
+	_, err := NewMatcher([]*pb.Filter{{
+		Filter: &pb.Filter_StackFilter{
+			StackFilter: &pb.StackFilter{
+				Filter: &pb.StackFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						FunctionName: &pb.StringCondition{
+							Condition: &pb.StringCondition_MatchesRegex{MatchesRegex: "("},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = NewMatcher([]*pb.Filter{{
+		Filter: &pb.Filter_FrameFilter{
+			FrameFilter: &pb.FrameFilter{
+				Filter: &pb.FrameFilter_Criteria{
+					Criteria: &pb.FilterCriteria{
+						LineNumber: &pb.NumberCondition{
+							Condition: &pb.NumberCondition_Range{Range: &pb.NumberRange{Start: 10, End: 1}},
+						},
+					},
+				},
+			},
+		},
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
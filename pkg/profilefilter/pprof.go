@@ -0,0 +1,265 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilefilter
+
+import (
+	"regexp"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// PprofOptions are the focus, ignore, hide, show, show_from and prune_from
+// options known from pprof. Each of them is a regular expression, which is
+// case-sensitive like in pprof, empty options are not applied.
+type PprofOptions struct {
+	// Focus keeps only the samples with a frame matching it.
+	Focus string
+	// Ignore drops the samples with a frame matching it.
+	Ignore string
+	// Hide removes the frames matching it, their samples are kept.
+	Hide string
+	// Show removes the frames not matching it, their samples are kept.
+	Show string
+	// ShowFrom removes the frames above the highest frame whose function
+	// name matches it. Samples without a match are dropped.
+	ShowFrom string
+	// PruneFrom removes the frames below the highest frame whose function
+	// name matches it.
+	PruneFrom string
+}
+
+type pprofRegexps struct {
+	focus, ignore, hide, show, showFrom, pruneFrom *regexp.Regexp
+}
+
+func (o PprofOptions) compile() (pprofRegexps, error) {
+	var (
+		res pprofRegexps
+		err error
+	)
+	for _, opt := range []struct {
+		name string
+		expr string
+		re   **regexp.Regexp
+	}{
+		{"focus", o.Focus, &res.focus},
+		{"ignore", o.Ignore, &res.ignore},
+		{"hide", o.Hide, &res.hide},
+		{"show", o.Show, &res.show},
+		{"show_from", o.ShowFrom, &res.showFrom},
+		{"prune_from", o.PruneFrom, &res.pruneFrom},
+	} {
+		if opt.expr == "" {
+			continue
+		}
+		if *opt.re, err = regexp.Compile(opt.expr); err != nil {
+			return pprofRegexps{}, status.Errorf(codes.InvalidArgument, "invalid %s regular expression %q: %v", opt.name, opt.expr, err)
+		}
+	}
+	return res, nil
+}
+
+// IsEmpty returns true if none of the options are set.
+func (o PprofOptions) IsEmpty() bool {
+	return o == PprofOptions{}
+}
+
+// frame identifies a frame of a stack by its location and line index. The
+// line index of unsymbolized locations is -1.
+type frame struct {
+	location, line int
+}
+
+// below returns true if the frame is closer to the leaf than the other
+// frame. Locations and their lines are both ordered from leaf to root.
+func (f frame) below(o frame) bool {
+	if f.location != o.location {
+		return f.location < o.location
+	}
+	return f.line < o.line
+}
+
+// StackRange is the part of a stack that is kept by the show_from and
+// prune_from options.
+type StackRange struct {
+	leaf, root       frame
+	hasLeaf, hasRoot bool
+}
+
+// Contains returns true if the frame at the given location and line index is
+// part of the range. A lineIndex of -1 denotes an unsymbolized location.
+func (s StackRange) Contains(locationIndex, lineIndex int) bool {
+	f := frame{location: locationIndex, line: lineIndex}
+	if s.hasLeaf && f.below(s.leaf) {
+		return false
+	}
+	if s.hasRoot && s.root.below(f) {
+		return false
+	}
+	return true
+}
+
+// StackRange returns the part of the row's stack that is kept by the
+// show_from and prune_from options. False is returned if show_from is set
+// and no frame matches it, in which case the whole stack is dropped.
+func (m *Matcher) StackRange(r *profile.RecordReader, row int) (StackRange, bool) {
+	var s StackRange
+	if m.pprof.showFrom == nil && m.pprof.pruneFrom == nil {
+		return s, true
+	}
+
+	lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(row)
+
+	if re := m.pprof.pruneFrom; re != nil {
+	prune:
+		for j := int(lOffsetEnd) - 1; j >= int(lOffsetStart); j-- {
+			lineStart, lineEnd := r.Lines.ValueOffsets(j)
+			for k := int(lineEnd) - 1; k >= int(lineStart); k-- {
+				if m.functionMatchesRegexp(r, k, re) {
+					s.leaf, s.hasLeaf = frame{location: j, line: k}, true
+					break prune
+				}
+			}
+		}
+	}
+
+	if re := m.pprof.showFrom; re != nil {
+	show:
+		for j := int(lOffsetEnd) - 1; j >= int(lOffsetStart); j-- {
+			lineStart, lineEnd := r.Lines.ValueOffsets(j)
+			for k := int(lineEnd) - 1; k >= int(lineStart); k-- {
+				if m.functionMatchesRegexp(r, k, re) {
+					s.root, s.hasRoot = frame{location: j, line: k}, true
+					break show
+				}
+			}
+		}
+		if !s.hasRoot {
+			return s, false
+		}
+	}
+
+	return s, true
+}
+
+// pprofStackMatches checks the stack of the given row against the focus and
+// ignore options.
+func (m *Matcher) pprofStackMatches(r *profile.RecordReader, row int) bool {
+	if m.pprof.focus == nil && m.pprof.ignore == nil {
+		return true
+	}
+
+	lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(row)
+	focused := m.pprof.focus == nil
+	for j := int(lOffsetStart); j < int(lOffsetEnd); j++ {
+		if m.pprof.ignore != nil && m.locationMatchesRegexp(r, j, m.pprof.ignore) {
+			return false
+		}
+		if !focused && m.locationMatchesRegexp(r, j, m.pprof.focus) {
+			focused = true
+		}
+	}
+	return focused
+}
+
+// pprofFrameMatches checks a single frame against the hide and show options.
+func (m *Matcher) pprofFrameMatches(r *profile.RecordReader, locationIndex, lineIndex int) bool {
+	if m.pprof.hide != nil && m.frameMatchesRegexp(r, locationIndex, lineIndex, m.pprof.hide) {
+		return false
+	}
+	if m.pprof.show != nil && !m.frameMatchesRegexp(r, locationIndex, lineIndex, m.pprof.show) {
+		return false
+	}
+	return true
+}
+
+// locationMatchesRegexp checks if the mapping file or any of the lines of a
+// location match the regular expression.
+func (m *Matcher) locationMatchesRegexp(r *profile.RecordReader, locationIndex int, re *regexp.Regexp) bool {
+	if m.mappingFileMatchesRegexp(r, locationIndex, re) {
+		return true
+	}
+	lineStart, lineEnd := r.Lines.ValueOffsets(locationIndex)
+	for k := int(lineStart); k < int(lineEnd); k++ {
+		if m.lineMatchesRegexp(r, k, re) {
+			return true
+		}
+	}
+	return false
+}
+
+// frameMatchesRegexp checks if the mapping file of the location or the
+// function name or filename of the line match the regular expression. A
+// lineIndex of -1 denotes an unsymbolized location.
+func (m *Matcher) frameMatchesRegexp(r *profile.RecordReader, locationIndex, lineIndex int, re *regexp.Regexp) bool {
+	if m.mappingFileMatchesRegexp(r, locationIndex, re) {
+		return true
+	}
+	return lineIndex >= 0 && m.lineMatchesRegexp(r, lineIndex, re)
+}
+
+func (m *Matcher) lineMatchesRegexp(r *profile.RecordReader, lineIndex int, re *regexp.Regexp) bool {
+	if m.functionMatchesRegexp(r, lineIndex, re) {
+		return true
+	}
+	return r.LineFunctionFilenameIndices.Len() > 0 &&
+		r.LineFunctionFilenameIndices.IsValid(lineIndex) &&
+		m.matchesDictRegexp(r.LineFunctionFilenameDict, int(r.LineFunctionFilenameIndices.Value(lineIndex)), re)
+}
+
+func (m *Matcher) functionMatchesRegexp(r *profile.RecordReader, lineIndex int, re *regexp.Regexp) bool {
+	return r.LineFunctionNameIndices.Len() > 0 &&
+		r.LineFunctionNameIndices.IsValid(lineIndex) &&
+		m.matchesDictRegexp(r.LineFunctionNameDict, int(r.LineFunctionNameIndices.Value(lineIndex)), re)
+}
+
+func (m *Matcher) mappingFileMatchesRegexp(r *profile.RecordReader, locationIndex int, re *regexp.Regexp) bool {
+	return r.MappingStart.IsValid(locationIndex) &&
+		r.MappingFileIndices.IsValid(locationIndex) &&
+		m.matchesDictRegexp(r.MappingFileDict, int(r.MappingFileIndices.Value(locationIndex)), re)
+}
+
+type regexpMatchKey struct {
+	re   *regexp.Regexp
+	dict *array.Binary
+}
+
+// matchesDictRegexp checks if the dictionary entry at index i matches the
+// regular expression, the result is cached just like for string conditions.
+func (m *Matcher) matchesDictRegexp(dict *array.Binary, i int, re *regexp.Regexp) bool {
+	key := regexpMatchKey{re: re, dict: dict}
+	matches, ok := m.regexpMatches[key]
+	if !ok {
+		matches = make([]dictMatch, dict.Len())
+		m.regexpMatches[key] = matches
+	}
+
+	switch matches[i] {
+	case dictMatchTrue:
+		return true
+	case dictMatchFalse:
+		return false
+	}
+
+	if re.Match(dict.Value(i)) {
+		matches[i] = dictMatchTrue
+		return true
+	}
+	matches[i] = dictMatchFalse
+	return false
+}
@@ -11,9 +11,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package query
+package profilefilter
 
 import (
+	"regexp"
 	"testing"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
@@ -58,6 +59,22 @@ func BenchmarkStringMatching(b *testing.B) {
 			_ = matchesStringCondition(testValue, condition)
 		}
 	})
+
+	b.Run("matchesRegex", func(b *testing.B) {
+		condition := &pb.StringCondition{
+			Condition: &pb.StringCondition_MatchesRegex{
+				MatchesRegex: `^runtime\.(goexit|mallocgc)$`,
+			},
+		}
+		m := &Matcher{regexps: map[*pb.StringCondition]*regexp.Regexp{}}
+		if err := m.compile(condition); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.matchesString(testValue, condition)
+		}
+	})
 }
 
 func BenchmarkStringMatchingLongStrings(b *testing.B) {
@@ -0,0 +1,226 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// callersCalleesValue accumulates the values of a function or a call. The
+// sample it was last counted for is tracked, so recursive stacks only count
+// once towards the cumulative value.
+type callersCalleesValue struct {
+	name       string
+	filename   string
+	flat       int64
+	cumulative int64
+	diff       int64
+	lastSample int
+}
+
+func (v *callersCalleesValue) add(sample int, value, diff int64, isLeaf bool) {
+	if isLeaf {
+		v.flat += value
+	}
+	if v.lastSample == sample {
+		return
+	}
+	v.lastSample = sample
+	v.cumulative += value
+	v.diff += diff
+}
+
+type callersCalleesFunction struct {
+	callersCalleesValue
+	callers map[string]*callersCalleesValue
+	callees map[string]*callersCalleesValue
+}
+
+// GenerateCallersCallees generates the callers and callees report of the
+// functions matching the regular expression, like pprof's peek command. For
+// each matching function its direct callers and callees are listed with the
+// value of the respective calls.
+func GenerateCallersCallees(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	function string,
+) (*pb.CallersCallees, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateCallersCallees")
+	defer span.End()
+
+	if function == "" {
+		return nil, 0, status.Error(codes.InvalidArgument, "callers and callees report requires a function")
+	}
+	re, err := regexp.Compile(function)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "invalid function regular expression %q: %v", function, err)
+	}
+
+	var (
+		total     int64
+		sample    int
+		frames    []stackFrame
+		functions = map[string]*callersCalleesFunction{}
+		// matches caches the regular expression results by function name.
+		matches = map[string]bool{}
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			value := r.Value.Value(i)
+			total += value
+			if r.Locations.IsNull(i) {
+				continue
+			}
+
+			frames = appendStack(frames[:0], r, i)
+			diff := r.Diff.Value(i)
+			// Samples are numbered from 1, so the zero value of lastSample
+			// never matches.
+			sample++
+
+			for k, f := range frames {
+				matched, ok := matches[string(f.name)]
+				if !ok {
+					matched = re.Match(f.name)
+					matches[string(f.name)] = matched
+				}
+				if !matched {
+					continue
+				}
+
+				key := callersCalleesKey(f)
+				fn, ok := functions[key]
+				if !ok {
+					fn = &callersCalleesFunction{
+						callersCalleesValue: newCallersCalleesValue(f),
+						callers:             map[string]*callersCalleesValue{},
+						callees:             map[string]*callersCalleesValue{},
+					}
+					functions[key] = fn
+				}
+
+				isLeaf := k == len(frames)-1
+				fn.add(sample, value, diff, isLeaf)
+				if k > 0 {
+					callersCalleesCall(fn.callers, frames[k-1]).add(sample, value, diff, isLeaf)
+				}
+				if !isLeaf {
+					callersCalleesCall(fn.callees, frames[k+1]).add(sample, value, diff, k+1 == len(frames)-1)
+				}
+			}
+		}
+	}
+
+	res := &pb.CallersCallees{
+		Functions: make([]*pb.CallersCalleesFunction, 0, len(functions)),
+		Unit:      p.Meta.SampleType.Unit,
+	}
+	for _, fn := range functions {
+		res.Functions = append(res.Functions, &pb.CallersCalleesFunction{
+			Function: fn.entry(total),
+			Callers:  callersCalleesEntries(fn.callers, fn.cumulative),
+			Callees:  callersCalleesEntries(fn.callees, fn.cumulative),
+		})
+	}
+	sortCallersCalleesEntries(res.Functions, func(f *pb.CallersCalleesFunction) *pb.CallersCalleesEntry {
+		return f.Function
+	})
+
+	return res, total, nil
+}
+
+func newCallersCalleesValue(f stackFrame) callersCalleesValue {
+	return callersCalleesValue{
+		name:     chromeTraceFrameName(f),
+		filename: string(f.file),
+	}
+}
+
+// callersCalleesKey identifies the function of a frame, functions of the
+// same name in different files are distinct.
+func callersCalleesKey(f stackFrame) string {
+	return string(f.name) + "\x00" + string(f.file)
+}
+
+func callersCalleesCall(calls map[string]*callersCalleesValue, f stackFrame) *callersCalleesValue {
+	key := callersCalleesKey(f)
+	c, ok := calls[key]
+	if !ok {
+		v := newCallersCalleesValue(f)
+		c = &v
+		calls[key] = c
+	}
+	return c
+}
+
+func (v *callersCalleesValue) entry(total int64) *pb.CallersCalleesEntry {
+	return &pb.CallersCalleesEntry{
+		Name:                 v.name,
+		Filename:             v.filename,
+		Flat:                 v.flat,
+		Cumulative:           v.cumulative,
+		FlatPercentage:       percentage(v.flat, total),
+		CumulativePercentage: percentage(v.cumulative, total),
+		Diff:                 v.diff,
+	}
+}
+
+func callersCalleesEntries(calls map[string]*callersCalleesValue, total int64) []*pb.CallersCalleesEntry {
+	entries := make([]*pb.CallersCalleesEntry, 0, len(calls))
+	for _, c := range calls {
+		entries = append(entries, c.entry(total))
+	}
+	sortCallersCalleesEntries(entries, func(e *pb.CallersCalleesEntry) *pb.CallersCalleesEntry {
+		return e
+	})
+	return entries
+}
+
+// sortCallersCalleesEntries sorts by cumulative value in descending order,
+// ties are broken by name and filename to keep the report deterministic.
+func sortCallersCalleesEntries[T any](s []T, entry func(T) *pb.CallersCalleesEntry) {
+	sort.Slice(s, func(i, j int) bool {
+		a, b := entry(s[i]), entry(s[j])
+		if a.Cumulative != b.Cumulative {
+			return a.Cumulative > b.Cumulative
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Filename < b.Filename
+	})
+}
+
+func percentage(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total) * 100
+}
@@ -0,0 +1,115 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateCallersCallees(t *testing.T) {
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app"}
+	functions := []*pprofprofile.Function{
+		{ID: 1, Name: "main", Filename: "main.go"},
+		{ID: 2, Name: "handler", Filename: "main.go"},
+		{ID: 3, Name: "worker", Filename: "main.go"},
+		{ID: 4, Name: "foo", Filename: "main.go"},
+	}
+	locations := make([]*pprofprofile.Location, 0, len(functions)+1)
+	for _, f := range functions {
+		locations = append(locations, &pprofprofile.Location{
+			ID:      f.ID,
+			Mapping: mapping,
+			Address: 0x1000 * f.ID,
+			Line:    []pprofprofile.Line{{Function: f}},
+		})
+	}
+	locations = append(locations, &pprofprofile.Location{ID: 5, Mapping: mapping, Address: 0x5000})
+	main, handler, worker, foo, unsymbolized := locations[0], locations[1], locations[2], locations[3], locations[4]
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+	}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, handler, main}, Value: []int64{10}},
+			{Location: []*pprofprofile.Location{handler, main}, Value: []int64{5}},
+			{Location: []*pprofprofile.Location{unsymbolized, foo, worker, main}, Value: []int64{3}},
+			// Recursive calls only count once towards the cumulative value.
+			{Location: []*pprofprofile.Location{foo, foo, worker, main}, Value: []int64{2}},
+			{Location: []*pprofprofile.Location{main}, Value: []int64{20}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	res, total, err := GenerateCallersCallees(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		p,
+		"^foo$",
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), total)
+	require.Equal(t, &pb.CallersCallees{
+		Unit: "count",
+		Functions: []*pb.CallersCalleesFunction{{
+			Function: &pb.CallersCalleesEntry{
+				Name: "foo", Filename: "main.go",
+				Flat: 12, Cumulative: 15,
+				FlatPercentage: 30, CumulativePercentage: 37.5,
+			},
+			Callers: []*pb.CallersCalleesEntry{{
+				Name: "handler", Filename: "main.go",
+				Flat: 10, Cumulative: 10,
+				FlatPercentage: 66.66666666666666, CumulativePercentage: 66.66666666666666,
+			}, {
+				Name: "worker", Filename: "main.go",
+				Flat: 0, Cumulative: 5,
+				FlatPercentage: 0, CumulativePercentage: 33.33333333333333,
+			}, {
+				Name: "foo", Filename: "main.go",
+				Flat: 2, Cumulative: 2,
+				FlatPercentage: 13.333333333333334, CumulativePercentage: 13.333333333333334,
+			}},
+			Callees: []*pb.CallersCalleesEntry{{
+				Name: "[app] 0x5000", Filename: "/usr/bin/app",
+				Flat: 3, Cumulative: 3,
+				FlatPercentage: 20, CumulativePercentage: 20,
+			}, {
+				Name: "foo", Filename: "main.go",
+				Flat: 2, Cumulative: 2,
+				FlatPercentage: 13.333333333333334, CumulativePercentage: 13.333333333333334,
+			}},
+		}},
+	}, res)
+}
+
+func TestGenerateCallersCalleesInvalidFunction(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	_, _, err := GenerateCallersCallees(context.Background(), tracer, profile.Profile{}, "")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, _, err = GenerateCallersCallees(context.Background(), tracer, profile.Profile{}, "(")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
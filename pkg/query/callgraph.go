@@ -15,6 +15,7 @@ package query
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
 
@@ -22,6 +23,7 @@ import (
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
 )
 
@@ -29,51 +31,143 @@ const (
 	NodeCutOffFraction = float32(0.005)
 )
 
-func GenerateCallgraph(ctx context.Context, p profile.OldProfile) (*querypb.Callgraph, error) {
+// GenerateCallgraph generates the callgraph of the profile. If nodeCount is
+// greater than zero only the nodeCount nodes with the highest flat values are
+// kept, similar to pprof's nodecount option. Otherwise nodes with a small
+// cumulative value are pruned.
+func GenerateCallgraph(
+	ctx context.Context,
+	p profile.Profile,
+	converter *parcacol.ArrowToProfileConverter,
+	nodeCount int,
+) (*querypb.Callgraph, error) {
+	lr := converter.NewLocationResolver()
+	g, err := buildCallgraph(p, lr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if nodeCount <= 0 {
+		return pruneGraph(g), nil
+	}
+
+	//nolint:staticcheck // SA1019: This needs to be updated in a follow-up PR.
+	if len(g.Nodes) <= nodeCount {
+		return g, nil
+	}
+
+	return buildCallgraph(p, lr, topCallgraphNodes(g.Nodes, nodeCount))
+}
+
+type callgraphEdgeKey struct {
+	source string
+	target string
+}
+
+// buildCallgraph builds the callgraph of the profile. If keep is not nil, only
+// the nodes with the keys in keep are part of the callgraph, and the edges that
+// skip removed nodes are marked as collapsed.
+func buildCallgraph(
+	p profile.Profile,
+	lr *parcacol.LocationResolver,
+	keep map[string]struct{},
+) (*querypb.Callgraph, error) {
 	nodesMap := make(map[string]*querypb.CallgraphNode)
 	nodes := make([]*querypb.CallgraphNode, 0)
 	edges := make([]*querypb.CallgraphEdge, 0)
-	edgesMap := make(map[string]*querypb.CallgraphEdge)
+	edgesMap := make(map[callgraphEdgeKey]*querypb.CallgraphEdge)
 	cummValue := int64(0)
 
-	for _, s := range p.Samples {
-		cummValue += s.Value
-		var prevNode *querypb.CallgraphNode = nil
-		for i, location := range s.Locations {
-			locationNodes := locationToCallgraphNodes(location)
-			for _, n := range locationNodes {
-				key := getNodeKey(n)
-				if _, exists := nodesMap[key]; !exists {
-					nodesMap[key] = n
-					nodes = append(nodes, n)
-				}
-				currentNode := nodesMap[key]
-				currentNode.Cumulative += s.Value
-				currentNodeId := currentNode.Id
-				if i == 0 {
-					currentNode.Flat += s.Value
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create record reader: %w", err)
+		}
+		lr.Reset(r)
+
+		for row := 0; row < int(rec.NumRows()); row++ {
+			value := r.Value.Value(row)
+			cummValue += value
+			var (
+				prevNode     *querypb.CallgraphNode = nil
+				prevLocation string
+				collapsed    bool
+				leaf         = true
+			)
+			beg, end := r.Locations.ValueOffsets(row)
+			for j := int(beg); j < int(end); j++ {
+				if r.Locations.ListValues().IsNull(j) {
+					continue // The location has been filtered out.
 				}
 
-				if prevNode != nil {
-					key := currentNodeId + " -> " + prevNode.Id
-					if _, exists := edgesMap[key]; !exists {
-						edge := &querypb.CallgraphEdge{
-							Id:         uuid.New().String(),
-							Source:     currentNodeId,
-							Target:     prevNode.Id,
-							Cumulative: s.Value,
+				location := lr.Location(j)
+				// Inlined functions are inserted from the outermost to the
+				// innermost.
+				for k := max(len(location.Lines), 1) - 1; k >= 0; k-- {
+					key := callgraphNodeKey(location, k)
+					if keep != nil {
+						if _, ok := keep[key]; !ok {
+							collapsed = prevNode != nil
+							continue
+						}
+					}
+					currentNode, exists := nodesMap[key]
+					if !exists {
+						currentNode = newCallgraphNode(location, k)
+						nodesMap[key] = currentNode
+						nodes = append(nodes, currentNode)
+					}
+					currentNode.Cumulative += value
+					if leaf {
+						currentNode.Flat += value
+					}
+
+					if prevNode != nil {
+						key := callgraphEdgeKey{source: currentNode.Id, target: prevNode.Id}
+						if edge, exists := edgesMap[key]; !exists {
+							edge := &querypb.CallgraphEdge{
+								Id:          uuid.New().String(),
+								Source:      currentNode.Id,
+								Target:      prevNode.Id,
+								Cumulative:  value,
+								IsCollapsed: collapsed,
+								IsInlined:   !collapsed && prevLocation == location.ID,
+							}
+							edges = append(edges, edge)
+							edgesMap[key] = edge
+						} else {
+							edge.Cumulative += value
 						}
-						edges = append(edges, edge)
-						edgesMap[key] = edge
-					} else {
-						edgesMap[key].Cumulative += s.Value
 					}
+					prevNode = currentNode
+					prevLocation = location.ID
+					collapsed = false
 				}
-				prevNode = currentNode
+				leaf = false
 			}
 		}
 	}
-	return pruneGraph(&querypb.Callgraph{Nodes: nodes, Edges: edges, Cumulative: cummValue}), nil
+
+	//nolint:staticcheck // SA1019: Fow now we want to support these APIs
+	return &querypb.Callgraph{Nodes: nodes, Edges: edges, Cumulative: cummValue}, nil
+}
+
+// topCallgraphNodes returns the keys of the n nodes with the highest flat
+// values, using the cumulative value to break ties.
+func topCallgraphNodes(nodes []*querypb.CallgraphNode, n int) map[string]struct{} {
+	sorted := make([]*querypb.CallgraphNode, len(nodes))
+	copy(sorted, nodes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Flat != sorted[j].Flat {
+			return sorted[i].Flat > sorted[j].Flat
+		}
+		return sorted[i].Cumulative > sorted[j].Cumulative
+	})
+
+	keep := make(map[string]struct{}, n)
+	for _, node := range sorted[:n] {
+		keep[getNodeKey(node)] = struct{}{}
+	}
+	return keep
 }
 
 func getNodeKey(node *querypb.CallgraphNode) string {
@@ -84,23 +178,28 @@ func getNodeKey(node *querypb.CallgraphNode) string {
 	return node.Meta.Function.Name
 }
 
-// locationToCallgraphNodes converts a location to its tree nodes, if the location
-// has multiple inlined functions it creates multiple nodes for each inlined
-// function.
-func locationToCallgraphNodes(location *profile.Location) []*querypb.CallgraphNode {
-	if len(location.Lines) > 0 {
-		return linesToCallgraphNodes(
-			location,
-			location.Mapping,
-			location.Lines,
-		)
+// callgraphNodeKey returns the key of the node for the k-th line of the
+// location. Functions are merged by their name, unsymbolized locations by
+// their ID.
+func callgraphNodeKey(location *profile.Location, k int) string {
+	if k < len(location.Lines) && location.Lines[k].Function != nil {
+		return location.Lines[k].Function.Name
+	}
+	return location.ID
+}
+
+// newCallgraphNode creates the node for the k-th line of the location. If the
+// location has no lines, the node represents the location itself.
+func newCallgraphNode(location *profile.Location, k int) *querypb.CallgraphNode {
+	if k < len(location.Lines) {
+		return lineToGraphNode(location, location.Mapping, location.Lines[k])
 	}
 
 	var mappingID string
 	if location.Mapping != nil {
 		mappingID = location.Mapping.Id
 	}
-	return []*querypb.CallgraphNode{{
+	return &querypb.CallgraphNode{
 		Id: location.ID,
 		Meta: &querypb.CallgraphNodeMeta{
 			Location: &pb.Location{
@@ -111,37 +210,7 @@ func locationToCallgraphNodes(location *profile.Location) []*querypb.CallgraphNo
 			},
 			Mapping: location.Mapping,
 		},
-	}}
-}
-
-// linesToCallgraphNodes turns inlined `lines` into a stack of TreeNode items and
-// returns the slice of items in order from outer-most to inner-most.
-func linesToCallgraphNodes(
-	location *profile.Location,
-	mapping *pb.Mapping,
-	lines []profile.LocationLine,
-) []*querypb.CallgraphNode {
-	if len(lines) == 0 {
-		return nil
-	}
-
-	res := make([]*querypb.CallgraphNode, len(lines))
-
-	// Same as locations, lines are in order from deepest to highest in the
-	// stack. Therefore we start with the innermost, and work ourselves
-	// outwards. We want the result to be from highest to deepest to be inserted
-	// into our callgraph at our "current" position that's calling
-	// linesToTreeNodes.
-	for i := 0; i < len(lines); i++ {
-		node := lineToGraphNode(
-			location,
-			mapping,
-			lines[i],
-		)
-		res[len(lines)-1-i] = node
 	}
-
-	return res
 }
 
 func lineToGraphNode(
@@ -166,7 +235,7 @@ func lineToGraphNode(
 			},
 			Function: line.Function,
 			Line: &pb.Line{
-				FunctionId: line.Function.Id,
+				FunctionId: line.Function.GetId(),
 				Line:       line.Line,
 			},
 			Mapping: mapping,
@@ -0,0 +1,194 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateCallgraphDOT renders the callgraph in the Graphviz DOT format,
+// following the conventions of pprof's dot output: nodes are scaled by their
+// flat value and colored by their cumulative value, edges are weighted by
+// their value, collapsed edges are dotted and inlined calls are dashed.
+func GenerateCallgraphDOT(cg *querypb.Callgraph, meta profile.Meta) []byte {
+	//nolint:staticcheck // SA1019: Fow now we want to support these APIs
+	total := cg.Cumulative
+
+	var maxFlat, shown int64
+	for _, n := range cg.Nodes {
+		maxFlat = max(maxFlat, abs64(n.Flat))
+		shown += n.Flat
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "digraph \"%s\" {\n", dotEscape(meta.Name))
+	buf.WriteString("node [style=filled fillcolor=\"#f8f8f8\"]\n")
+
+	legend := []string{
+		"Type: " + meta.SampleType.Type,
+		fmt.Sprintf("Showing nodes accounting for %d, %s of %d total", shown, dotPercentage(shown, total), total),
+	}
+	fmt.Fprintf(buf,
+		"subgraph cluster_L { \"%s\" [shape=box fontsize=16 label=\"%s\\l\"] }\n",
+		dotEscape(legend[0]),
+		dotEscape(strings.Join(legend, "\n")),
+	)
+
+	ids := make(map[string]int, len(cg.Nodes))
+	for i, n := range cg.Nodes {
+		id := i + 1
+		ids[n.Id] = id
+
+		name := callgraphNodeName(n)
+		label := dotEscape(name) + `\n`
+		if n.Flat != 0 {
+			label += fmt.Sprintf("%d (%s)", n.Flat, dotPercentage(n.Flat, total))
+		} else {
+			label += "0"
+		}
+		if n.Cumulative != n.Flat {
+			if n.Flat != 0 {
+				label += `\n`
+			} else {
+				label += " "
+			}
+			label += fmt.Sprintf("of %d (%s)", n.Cumulative, dotPercentage(n.Cumulative, total))
+		}
+
+		// Scale the font size from 8 to 24 based on the flat value, using
+		// non linear growth to emphasize the difference.
+		fontSize := 8
+		if maxFlat != 0 && n.Flat != 0 {
+			fontSize += int(math.Ceil(16 * math.Sqrt(float64(abs64(n.Flat))/float64(maxFlat))))
+		}
+
+		score := dotScore(n.Cumulative, total)
+		fmt.Fprintf(buf,
+			"N%d [label=\"%s\" id=\"node%d\" fontsize=%d shape=box tooltip=\"%s (%d)\" color=\"%s\" fillcolor=\"%s\"]\n",
+			id, label, id, fontSize, dotEscape(name), n.Cumulative, dotColor(score, false), dotColor(score, true),
+		)
+	}
+
+	for _, e := range cg.Edges {
+		source, target := ids[e.Source], ids[e.Target]
+		if source == 0 || target == 0 {
+			continue
+		}
+
+		attr := fmt.Sprintf("label=\" %d\"", e.Cumulative)
+		if total != 0 {
+			if weight := 1 + int(min(abs64(e.Cumulative*100/total), 100)); weight > 1 {
+				attr += fmt.Sprintf(" weight=%d", weight)
+			}
+			if width := 1 + int(min(abs64(e.Cumulative*5/total), 5)); width > 1 {
+				attr += fmt.Sprintf(" penwidth=%d", width)
+			}
+			attr += fmt.Sprintf(" color=\"%s\"", dotColor(dotScore(e.Cumulative, total), false))
+		}
+		switch {
+		case e.IsCollapsed:
+			attr += " style=\"dotted\""
+		case e.IsInlined:
+			attr += " style=\"dashed\""
+		}
+		fmt.Fprintf(buf, "N%d -> N%d [%s]\n", source, target, attr)
+	}
+
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func callgraphNodeName(n *querypb.CallgraphNode) string {
+	if n.Meta.GetFunction() != nil {
+		return n.Meta.Function.Name
+	}
+
+	name := fmt.Sprintf("0x%x", n.Meta.GetLocation().GetAddress())
+	if file := n.Meta.GetMapping().GetFile(); file != "" {
+		name = "[" + filepath.Base(file) + "] " + name
+	}
+	return name
+}
+
+func dotScore(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(abs64(total))
+}
+
+func dotPercentage(value, total int64) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.2f%%", float64(value)/float64(total)*100)
+}
+
+// dotColor returns a color for the given score between -1.0 and 1.0, with
+// -1.0 colored green, 0.0 colored grey and 1.0 colored red. Background colors
+// are less saturated and lighter than foreground colors.
+func dotColor(score float64, isBackground bool) string {
+	// A float between 0.0 and 1.0, indicating the extent to which colors
+	// should be shifted away from grey, to make positive and negative values
+	// easier to distinguish.
+	const shift = 0.7
+
+	saturation, value := 1.0, 0.7
+	if isBackground {
+		saturation, value = 0.1, 0.93
+	}
+
+	score = math.Max(-1.0, math.Min(1.0, score))
+
+	// Reduce saturation near score=0, so that it is grey.
+	if math.Abs(score) < 0.2 {
+		saturation *= math.Abs(score) / 0.2
+	}
+
+	if score > 0.0 {
+		score = math.Pow(score, 1.0-shift)
+	}
+	if score < 0.0 {
+		score = -math.Pow(-score, 1.0-shift)
+	}
+
+	var r, g, b float64
+	if score < 0.0 {
+		g = value
+		r = value * (1 + saturation*score)
+	} else {
+		r = value
+		g = value * (1 - saturation*score)
+	}
+	b = value * (1 - saturation)
+	return fmt.Sprintf("#%02x%02x%02x", uint8(r*255.0), uint8(g*255.0), uint8(b*255.0))
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\l`).Replace(s)
+}
+
+func abs64(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
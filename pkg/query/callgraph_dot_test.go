@@ -0,0 +1,75 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/kv"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateCallgraphDOT(t *testing.T) {
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar"}
+	bazFunction := &pprofprofile.Function{ID: 4, Name: "baz"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	foo := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction}}}
+	bar := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction}}}
+	baz := &pprofprofile.Location{ID: 4, Address: 0x4000, Line: []pprofprofile.Line{{Function: bazFunction}}}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{
+		Name:       "memory",
+		SampleType: profile.ValueType{Type: "alloc_objects", Unit: "count"},
+	}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{75}},
+			{Location: []*pprofprofile.Location{bar, baz, main}, Value: []int64{25}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	cg, err := GenerateCallgraph(context.Background(), p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()), 10)
+	require.NoError(t, err)
+
+	require.Equal(t, `digraph "memory" {
+node [style=filled fillcolor="#f8f8f8"]
+subgraph cluster_L { "Type: alloc_objects" [shape=box fontsize=16 label="Type: alloc_objects\lShowing nodes accounting for 100, 100.00% of 100 total\l"] }
+N1 [label="foo\n75 (75.00%)" id="node1" fontsize=24 shape=box tooltip="foo (75)" color="#b20e00" fillcolor="#edd7d5"]
+N2 [label="main\n0 of 100 (100.00%)" id="node2" fontsize=8 shape=box tooltip="main (100)" color="#b20000" fillcolor="#edd5d5"]
+N3 [label="bar\n25 (25.00%)" id="node3" fontsize=18 shape=box tooltip="bar (25)" color="#b23c00" fillcolor="#edddd5"]
+N4 [label="baz\n0 of 25 (25.00%)" id="node4" fontsize=8 shape=box tooltip="baz (25)" color="#b23c00" fillcolor="#edddd5"]
+N2 -> N1 [label=" 75" weight=76 penwidth=4 color="#b20e00"]
+N4 -> N3 [label=" 25" weight=26 penwidth=2 color="#b23c00"]
+N2 -> N4 [label=" 25" weight=26 penwidth=2 color="#b23c00"]
+}
+`, string(GenerateCallgraphDOT(cg, p.Meta)))
+
+	// Inlined calls are dashed, collapsed edges dotted.
+	for _, e := range cg.Edges {
+		e.IsInlined = true
+	}
+	cg.Edges[0].IsCollapsed = true
+	dot := string(GenerateCallgraphDOT(cg, p.Meta))
+	require.Contains(t, dot, `N2 -> N1 [label=" 75" weight=76 penwidth=4 color="#b20e00" style="dotted"]`)
+	require.Contains(t, dot, `N4 -> N3 [label=" 25" weight=26 penwidth=2 color="#b23c00" style="dashed"]`)
+}
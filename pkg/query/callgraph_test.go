@@ -52,10 +52,7 @@ func TestGenerateCallgraph(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	op, err := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()).Convert(ctx, p)
-	require.NoError(t, err)
-
-	res, err := GenerateCallgraph(ctx, op)
+	res, err := GenerateCallgraph(ctx, p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()), 0)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 
@@ -216,3 +213,109 @@ func TestPruneCallgraph(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateCallgraphNodeCount(t *testing.T) {
+	functions := []*pprofprofile.Function{
+		{ID: 1, Name: "main"},
+		{ID: 2, Name: "foo"},
+		{ID: 3, Name: "bar"},
+		{ID: 4, Name: "baz"},
+		{ID: 5, Name: "qux"},
+	}
+	locations := make([]*pprofprofile.Location, len(functions))
+	for i, f := range functions {
+		locations[i] = &pprofprofile.Location{
+			ID:      f.ID,
+			Address: 0x1000 * f.ID,
+			Line:    []pprofprofile.Line{{Function: f}},
+		}
+	}
+	main, foo, bar, baz, qux := locations[0], locations[1], locations[2], locations[3], locations[4]
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{bar, foo, main}, Value: []int64{10}},
+			{Location: []*pprofprofile.Location{baz, main}, Value: []int64{5}},
+			{Location: []*pprofprofile.Location{main}, Value: []int64{3}},
+			{Location: []*pprofprofile.Location{qux, foo, main}, Value: []int64{1}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	res, err := GenerateCallgraph(
+		context.Background(),
+		p,
+		parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()),
+		3,
+	)
+	require.NoError(t, err)
+
+	nodes := map[string]*pb.CallgraphNode{}
+	for _, n := range res.GetNodes() {
+		nodes[n.Meta.Function.Name] = n
+	}
+	require.Len(t, nodes, 3)
+	require.Equal(t, int64(10), nodes["bar"].Flat)
+	require.Equal(t, int64(5), nodes["baz"].Flat)
+	require.Equal(t, int64(3), nodes["main"].Flat)
+	require.Equal(t, int64(19), nodes["main"].Cumulative)
+
+	require.Len(t, res.GetEdges(), 2)
+	for _, e := range res.GetEdges() {
+		require.Equal(t, nodes["main"].Id, e.Source)
+		switch e.Target {
+		case nodes["bar"].Id:
+			require.Equal(t, int64(10), e.Cumulative)
+			require.True(t, e.IsCollapsed, "Edge main -> bar skips foo and is not marked as collapsed")
+		case nodes["baz"].Id:
+			require.Equal(t, int64(5), e.Cumulative)
+			require.False(t, e.IsCollapsed, "Edge main -> baz is marked as collapsed")
+		default:
+			require.Fail(t, "Unexpected edge target", e.Target)
+		}
+	}
+}
+
+func TestGenerateCallgraphFrameFilter(t *testing.T) {
+	p := frameFilteredTestProfile(t)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	res, err := GenerateCallgraph(context.Background(), p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()), 0)
+	require.NoError(t, err)
+
+	nodes := map[string]*pb.CallgraphNode{}
+	for _, n := range res.GetNodes() {
+		nodes[n.Meta.Function.Name] = n
+	}
+	require.Len(t, nodes, 2)
+	require.Equal(t, int64(10), nodes["foo"].Flat)
+	require.Equal(t, int64(10), nodes["main"].Cumulative)
+
+	require.Len(t, res.GetEdges(), 1)
+	require.Equal(t, nodes["main"].Id, res.GetEdges()[0].Source)
+	require.Equal(t, nodes["foo"].Id, res.GetEdges()[0].Target)
+}
+
+func BenchmarkGenerateCallgraph(b *testing.B) {
+	ctx := context.Background()
+
+	pp, err := pprofprofile.ParseData(MustReadAllGzip(b, "testdata/alloc_objects.pb.gz"))
+	require.NoError(b, err)
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, pp, 0, []string{})
+	require.NoError(b, err)
+
+	converter := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := GenerateCallgraph(ctx, p, converter, 0)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// The types below implement the subset of the Chrome trace event format that
+// is understood by ui.perfetto.dev and chrome://tracing, see
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+
+type chromeTrace struct {
+	TraceEvents     []chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string             `json:"displayTimeUnit"`
+}
+
+type chromeTraceEvent struct {
+	Name  string `json:"name"`
+	Phase string `json:"ph"`
+	// Ts and Dur are in microseconds.
+	Ts   float64        `json:"ts"`
+	Dur  float64        `json:"dur,omitempty"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// chromeTracePid is the process all tracks belong to, the tracks themselves
+// are the threads of this process.
+const chromeTracePid = 1
+
+type chromeTraceSample struct {
+	r   *profile.RecordReader
+	row int
+}
+
+type chromeTraceTrack struct {
+	name    string
+	samples []chromeTraceSample
+}
+
+type chromeTraceOpenFrame struct {
+	name  string
+	file  string
+	line  int64
+	start int64
+}
+
+// GenerateChromeTrace generates a Chrome trace event JSON document of a flame
+// chart profile, that can be loaded into ui.perfetto.dev.
+//
+// Each distinct label set of the samples becomes its own track, so grouping the
+// query by labels like the thread ID results in one track per thread. Within a
+// track the samples are laid out at their timestamps and the frames of
+// consecutive samples are merged into a single slice, just like the flame
+// chart does.
+func GenerateChromeTrace(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+) ([]byte, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateChromeTrace")
+	defer span.End()
+
+	var (
+		total      int64
+		tracks     []*chromeTraceTrack
+		trackIndex = map[string]*chromeTraceTrack{}
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			if r.Locations.IsNull(i) {
+				continue
+			}
+
+			name := chromeTraceTrackName(r, i)
+			t, ok := trackIndex[name]
+			if !ok {
+				t = &chromeTraceTrack{name: name}
+				trackIndex[name] = t
+				tracks = append(tracks, t)
+			}
+			t.samples = append(t.samples, chromeTraceSample{r: r, row: i})
+			total += r.Value.Value(i)
+		}
+	}
+
+	sort.Slice(tracks, func(i, j int) bool {
+		return tracks[i].name < tracks[j].name
+	})
+
+	events := []chromeTraceEvent{{
+		Name:  "process_name",
+		Phase: "M",
+		Pid:   chromeTracePid,
+		Args:  map[string]any{"name": profileTypeName(p.Meta)},
+	}}
+	var frames []stackFrame
+	for i, t := range tracks {
+		tid := i + 1
+		events = append(events, chromeTraceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			Pid:   chromeTracePid,
+			Tid:   tid,
+			Args:  map[string]any{"name": t.name},
+		})
+
+		sort.SliceStable(t.samples, func(i, j int) bool {
+			return t.samples[i].r.Timestamp.Value(t.samples[i].row) < t.samples[j].r.Timestamp.Value(t.samples[j].row)
+		})
+
+		var (
+			open []chromeTraceOpenFrame
+			end  int64
+		)
+		closeFrames := func(n int) {
+			for k := len(open) - 1; k >= n; k-- {
+				f := open[k]
+				e := chromeTraceEvent{
+					Name:  f.name,
+					Phase: "X",
+					Ts:    float64(f.start) / 1e3,
+					Dur:   float64(end-f.start) / 1e3,
+					Pid:   chromeTracePid,
+					Tid:   tid,
+				}
+				if f.file != "" {
+					e.Args = map[string]any{"file": f.file, "line": f.line}
+				}
+				events = append(events, e)
+			}
+			open = open[:n]
+		}
+
+		for _, s := range t.samples {
+			frames = appendStack(frames[:0], s.r, s.row)
+			if len(frames) == 0 {
+				continue // All frames have been filtered out.
+			}
+
+			start := s.r.Timestamp.Value(s.row)
+			period := s.r.Period.Value(s.row)
+
+			// Samples that start within the jitter of the previous sample's
+			// end continue its frames, see matchRowsByTimestamp.
+			common := 0
+			if len(open) > 0 && start-end-period/10 <= 0 {
+				start = max(start, end)
+				for common < len(open) && common < len(frames) && open[common].matches(frames[common]) {
+					common++
+				}
+			}
+			closeFrames(common)
+
+			for _, f := range frames[common:] {
+				open = append(open, chromeTraceOpenFrame{
+					name:  chromeTraceFrameName(f),
+					file:  string(f.file),
+					line:  f.line,
+					start: start,
+				})
+			}
+			end = start + chromeTraceSampleDuration(p.Meta, s.r.Value.Value(s.row), period)
+		}
+		closeFrames(0)
+	}
+
+	res, err := json.Marshal(chromeTrace{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ns",
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("marshal chrome trace: %w", err)
+	}
+
+	return res, total, nil
+}
+
+func (f chromeTraceOpenFrame) matches(frame stackFrame) bool {
+	return f.line == frame.line &&
+		f.name == chromeTraceFrameName(frame) &&
+		f.file == unsafeString(frame.file)
+}
+
+func chromeTraceFrameName(f stackFrame) string {
+	if f.unsymbolized && len(f.file) > 0 {
+		return fmt.Sprintf("[%s] %s", filepath.Base(string(f.file)), f.name)
+	}
+	return string(f.name)
+}
+
+// chromeTraceTrackName returns the label set of the sample, which identifies
+// the track the sample belongs to.
+func chromeTraceTrackName(r *profile.RecordReader, row int) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	first := true
+	for i, c := range r.LabelColumns {
+		if c.Col.IsNull(row) {
+			continue
+		}
+		value := c.Dict.Value(int(c.Col.Value(row)))
+		if len(value) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		b.WriteString(strings.TrimPrefix(r.LabelFields[i].Name, profile.ColumnLabelsPrefix))
+		b.WriteString(`="`)
+		b.Write(bytes.ReplaceAll(value, []byte(`"`), []byte(`\"`)))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// chromeTraceSampleDuration returns the duration of a sample in nanoseconds.
+// Wall-clock profiles record the duration as value, for sampled profiles it is
+// the number of samples times the sampling period.
+func chromeTraceSampleDuration(m profile.Meta, value, period int64) int64 {
+	switch {
+	case m.SampleType.Unit == "nanoseconds":
+		return value
+	case m.PeriodType.Unit == "nanoseconds":
+		return value * period
+	default:
+		return value
+	}
+}
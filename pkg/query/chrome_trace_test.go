@@ -0,0 +1,96 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateChromeTrace(t *testing.T) {
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+
+	mainLocation := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction, Line: 1}}}
+	fooLocation := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction, Line: 1}}}
+	barLocation := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction, Line: 1}}}
+
+	var samples []arrow.RecordBatch
+	for _, s := range []struct {
+		timestamp int64
+		thread    string
+		locations []*pprofprofile.Location
+		value     int64
+	}{
+		// Starts right after the previous sample, so main and foo continue.
+		{timestamp: 1100, thread: "1", locations: []*pprofprofile.Location{barLocation, fooLocation, mainLocation}, value: 100},
+		{timestamp: 1000, thread: "1", locations: []*pprofprofile.Location{fooLocation, mainLocation}, value: 100},
+		{timestamp: 2000, thread: "2", locations: []*pprofprofile.Location{mainLocation}, value: 50},
+		// There is a gap to the previous sample, so main starts again.
+		{timestamp: 5000, thread: "1", locations: []*pprofprofile.Location{mainLocation}, value: 100},
+	} {
+		p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+			TimeNanos: s.timestamp,
+			Period:    100,
+			Sample: []*pprofprofile.Sample{{
+				Location: s.locations,
+				Value:    []int64{s.value},
+				Label:    map[string][]string{"thread": {s.thread}},
+			}},
+		}, 0, []string{})
+		require.NoError(t, err)
+		defer p.Samples[0].Release()
+		samples = append(samples, p.Samples...)
+	}
+
+	buf, total, err := GenerateChromeTrace(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		profile.Profile{
+			Meta: profile.Meta{
+				Name:       "parca_agent",
+				SampleType: profile.ValueType{Type: "wallclock", Unit: "nanoseconds"},
+				PeriodType: profile.ValueType{Type: "samples", Unit: "count"},
+			},
+			Samples: samples,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(350), total)
+
+	var trace chromeTrace
+	require.NoError(t, json.Unmarshal(buf, &trace))
+	require.Equal(t, "ns", trace.DisplayTimeUnit)
+
+	args := map[string]any{"file": "main.go", "line": float64(1)}
+	require.Equal(t, []chromeTraceEvent{
+		{Name: "process_name", Phase: "M", Pid: 1, Args: map[string]any{"name": "parca_agent:wallclock:nanoseconds:samples:count"}},
+		{Name: "thread_name", Phase: "M", Pid: 1, Tid: 1, Args: map[string]any{"name": `{thread="1"}`}},
+		{Name: "bar", Phase: "X", Ts: 1.1, Dur: 0.1, Pid: 1, Tid: 1, Args: args},
+		{Name: "foo", Phase: "X", Ts: 1, Dur: 0.2, Pid: 1, Tid: 1, Args: args},
+		{Name: "main", Phase: "X", Ts: 1, Dur: 0.2, Pid: 1, Tid: 1, Args: args},
+		{Name: "main", Phase: "X", Ts: 5, Dur: 0.1, Pid: 1, Tid: 1, Args: args},
+		{Name: "thread_name", Phase: "M", Pid: 1, Tid: 2, Args: map[string]any{"name": `{thread="2"}`}},
+		{Name: "main", Phase: "X", Ts: 2, Dur: 0.05, Pid: 1, Tid: 2, Args: args},
+	}, trace.TraceEvents)
+}
@@ -14,10 +14,11 @@
 package query
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/math"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
@@ -36,11 +38,14 @@ import (
 
 	"github.com/polarsignals/frostdb/pqarrow/arrowutils"
 
+	annotationpb "github.com/parca-dev/parca/gen/proto/go/parca/annotation/v1alpha1"
+	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
 	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	sharepb "github.com/parca-dev/parca/gen/proto/go/parca/share/v1alpha1"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
 )
 
 type Querier interface {
@@ -56,8 +61,10 @@ type Querier interface {
 		step time.Duration,
 		limit uint32,
 		sumBy []string,
+		filters []*pb.Filter,
 	) ([]*pb.MetricsSeries, error)
 	ProfileTypes(ctx context.Context, startTime, endTime time.Time) ([]*pb.ProfileType, error)
+	Series(ctx context.Context, match []string, startTime, endTime time.Time) ([]*pb.Series, error)
 	QuerySingle(ctx context.Context, query string, time time.Time, invertCallStacks bool) (profile.Profile, error)
 	QueryMerge(
 		ctx context.Context,
@@ -75,6 +82,7 @@ type Querier interface {
 var (
 	ErrSourceNotFound     = errors.New("source file not found; either profiling metadata is wrong, or the referenced file was not included in the uploaded sources")
 	ErrNoSourceForBuildID = errors.New("no sources for this build id have been uploaded")
+	ErrDebuginfoNotFound  = errors.New("no debuginfo for this build id has been uploaded")
 )
 
 type SourceFinder interface {
@@ -82,6 +90,16 @@ type SourceFinder interface {
 	SourceExists(ctx context.Context, ref *pb.SourceReference) (bool, error)
 }
 
+type DebuginfoFinder interface {
+	FindDebuginfo(ctx context.Context, buildID string, typ debuginfopb.DebuginfoType) (*os.File, error)
+}
+
+// AnnotationFinder finds the annotations overlapping a time window that apply
+// to the series selected by a query.
+type AnnotationFinder interface {
+	FindAnnotations(ctx context.Context, start, end time.Time, query string) ([]*annotationpb.Annotation, error)
+}
+
 // ColumnQueryAPI is the read api interface for parca
 // It implements the proto/query/query.proto APIServer interface.
 type ColumnQueryAPI struct {
@@ -96,7 +114,12 @@ type ColumnQueryAPI struct {
 	mem                memory.Allocator
 	converter          *parcacol.ArrowToProfileConverter
 
-	sourceFinder SourceFinder
+	sourceFinder    SourceFinder
+	debuginfoFinder DebuginfoFinder
+
+	derivedTypes map[string]profile.DerivedType
+
+	annotationFinder AnnotationFinder
 }
 
 func NewColumnQueryAPI(
@@ -107,7 +130,15 @@ func NewColumnQueryAPI(
 	mem memory.Allocator,
 	converter *parcacol.ArrowToProfileConverter,
 	sourceFinder SourceFinder,
+	debuginfoFinder DebuginfoFinder,
+	derivedTypes []profile.DerivedType,
+	annotationFinder AnnotationFinder,
 ) *ColumnQueryAPI {
+	derived := make(map[string]profile.DerivedType, len(derivedTypes))
+	for _, d := range derivedTypes {
+		derived[d.Name] = d
+	}
+
 	return &ColumnQueryAPI{
 		logger:             logger,
 		tracer:             tracer,
@@ -117,6 +148,9 @@ func NewColumnQueryAPI(
 		mem:                mem,
 		converter:          converter,
 		sourceFinder:       sourceFinder,
+		debuginfoFinder:    debuginfoFinder,
+		derivedTypes:       derived,
+		annotationFinder:   annotationFinder,
 	}
 }
 
@@ -183,13 +217,41 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		req.Step.AsDuration(),
 		req.Limit,
 		req.SumBy,
+		ConvertDeprecatedFilters(req.GetFilter()),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.QueryRangeResponse{
+	resp := &pb.QueryRangeResponse{
 		Series: res,
+	}
+	if q.annotationFinder != nil && len(res) > 0 {
+		// The annotations are matched against the query rather than the
+		// series, which lose the labels that aren't summed by.
+		// Annotations only add context to the series, so failing to find them doesn't fail the query.
+		resp.Annotations, err = q.annotationFinder.FindAnnotations(ctx, req.Start.AsTime(), req.End.AsTime(), req.Query)
+		if err != nil {
+			level.Warn(q.logger).Log("msg", "failed to find annotations", "err", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// Series returns the series matching the selectors within the time window.
+func (q *ColumnQueryAPI) Series(ctx context.Context, req *pb.SeriesRequest) (*pb.SeriesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	series, err := q.querier.Series(ctx, req.Match, req.Start.AsTime(), req.End.AsTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SeriesResponse{
+		Series: series,
 	}, nil
 }
 
@@ -271,6 +333,8 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 	var (
 		profileMetadata *pb.ProfileMetadata
 		p               profile.Profile
+		derived         *derivedQuery
+		significance    DiffSignificance
 		filtered        int64
 		isDiff          bool
 		isInvert        bool
@@ -280,37 +344,80 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		isInvert = *req.InvertCallStack
 	}
 
+	// Convert deprecated filters to new format for backward compatibility
+	filters := ConvertDeprecatedFilters(req.GetFilter())
+	pprofOpts := profilefilter.PprofOptions{
+		Focus:     req.GetFocus(),
+		Ignore:    req.GetIgnore(),
+		Hide:      req.GetHide(),
+		Show:      req.GetShow(),
+		ShowFrom:  req.GetShowFrom(),
+		PruneFrom: req.GetPruneFrom(),
+	}
+	var sandwichFilters []*pb.Filter
+	if sandwichByFunction := req.GetSandwichByFunction(); sandwichByFunction != "" {
+		// Create a stack filter for sandwich view
+		sandwichFilter := &pb.Filter{
+			Filter: &pb.Filter_StackFilter{
+				StackFilter: &pb.StackFilter{
+					Filter: &pb.StackFilter_Criteria{
+						Criteria: &pb.FilterCriteria{
+							FunctionName: &pb.StringCondition{
+								Condition: &pb.StringCondition_Contains{
+									Contains: sandwichByFunction,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		// Combine existing filters with the sandwich filter
+		sandwichFilters = make([]*pb.Filter, 0, len(filters)+1)
+		sandwichFilters = append(sandwichFilters, filters...)
+		sandwichFilters = append(sandwichFilters, sandwichFilter)
+	}
+
 	groupBy := req.GetGroupBy().GetFields()
-	allowedGroupBy := map[string]struct{}{
-		FlamegraphFieldFunctionFileName: {},
-		FlamegraphFieldFunctionName:     {},
-		FlamegraphFieldLocationAddress:  {},
-		FlamegraphFieldMappingFile:      {},
-		FlamegraphFieldTimestamp:        {},
+	if err := ValidateGroupBy(groupBy); err != nil {
+		return nil, err
 	}
 
-	if req.GetReportType() == pb.QueryRequest_REPORT_TYPE_FLAMECHART {
+	if req.GetReportType() == pb.QueryRequest_REPORT_TYPE_FLAMECHART ||
+		req.GetReportType() == pb.QueryRequest_REPORT_TYPE_CHROME_TRACE ||
+		req.GetReportType() == pb.QueryRequest_REPORT_TYPE_HEATMAP {
 		groupBy = append(groupBy, FlamegraphFieldTimestamp)
 	}
 
-	groupByLabels := make([]string, 0, len(groupBy))
-	for _, f := range groupBy {
-		if strings.HasPrefix(f, FlamegraphFieldLabels+".") {
-			// Add label to the groupByLabels passed to FrostDB
-			groupByLabels = append(groupByLabels, f)
-			continue
+	var heatmap HeatmapOptions
+	if req.GetReportType() == pb.QueryRequest_REPORT_TYPE_HEATMAP {
+		if req.Mode != pb.QueryRequest_MODE_MERGE {
+			return nil, status.Error(codes.InvalidArgument, "heatmap report is only supported for merge queries")
 		}
-		if _, allowed := allowedGroupBy[f]; allowed {
-			groupByLabels = append(groupByLabels, f)
-			continue
+		heatmap = HeatmapOptions{
+			Start:         req.GetMerge().GetStart().AsTime(),
+			End:           req.GetMerge().GetEnd().AsTime(),
+			BucketCount:   int(req.GetHeatmapBucketCount()),
+			FunctionCount: int(req.GetHeatmapFunctionCount()),
+			ByRoot:        req.GetHeatmapByRoot(),
 		}
-		return nil, status.Errorf(codes.InvalidArgument, "invalid group by field: %s", f)
 	}
 
+	groupByLabels := append(make([]string, 0, len(groupBy)), groupBy...)
+
 	switch req.Mode {
 	case pb.QueryRequest_MODE_SINGLE_UNSPECIFIED:
 		p, err = q.selectSingle(ctx, req.GetSingle(), isInvert)
 	case pb.QueryRequest_MODE_MERGE:
+		derived, err = q.derivedQuery(req.GetMerge().GetQuery())
+		if err != nil {
+			return nil, err
+		}
+		if derived != nil {
+			p, err = q.selectDerived(ctx, derived, req.GetMerge(), req.GetReportType(), groupByLabels, isInvert)
+			break
+		}
+
 		switch req.GetReportType() {
 		case pb.QueryRequest_REPORT_TYPE_PROFILE_METADATA:
 			mappingFiles, labels, err := getMappingFilesAndLabels(
@@ -329,6 +436,35 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 				Labels:       labels,
 			}
 
+		case pb.QueryRequest_REPORT_TYPE_LABEL_BREAKDOWN:
+			// Merging drops the label columns that aren't grouped by.
+			var labels []string
+			if label := req.GetLabelBreakdownLabel(); label != "" {
+				labels = []string{label}
+			} else {
+				labels, err = q.querier.GetProfileMetadataLabels(
+					ctx,
+					req.GetMerge().Query,
+					req.GetMerge().Start.AsTime(),
+					req.GetMerge().End.AsTime(),
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get labels: %w", err)
+				}
+			}
+			for _, l := range labels {
+				if f := FlamegraphFieldLabels + "." + l; !slices.Contains(groupByLabels, f) {
+					groupByLabels = append(groupByLabels, f)
+				}
+			}
+
+			p, err = q.selectMerge(
+				ctx,
+				req.GetMerge(),
+				groupByLabels,
+				isInvert,
+				req.GetSandwichByFunction(),
+			)
 		default:
 			p, err = q.selectMerge(
 				ctx,
@@ -359,13 +495,28 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 				Labels:       labels,
 			}
 		default:
-			p, err = q.selectDiff(
+			var matchers []*profilefilter.Matcher
+			if req.GetDiff().GetSignificance() {
+				// The significance is computed before the diff profile is
+				// filtered, so its samples are filtered the same way.
+				matchers, err = significanceMatchers(filters, pprofOpts, sandwichFilters)
+				if err != nil {
+					return nil, err
+				}
+			}
+			p, significance, err = q.selectDiff(
 				ctx,
 				req.GetDiff(),
 				false,
 				isInvert,
+				matchers,
 			)
 		}
+	case pb.QueryRequest_MODE_MULTI_METRIC:
+		if req.GetReportType() != pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW {
+			return nil, status.Error(codes.InvalidArgument, "multi metric queries only support the arrow flame graph report")
+		}
+		p, err = q.selectMultiMetric(ctx, req.GetMultiMetric(), groupByLabels, isInvert)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "unknown query mode")
 	}
@@ -385,44 +536,20 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		}
 	}()
 
-	// Convert deprecated filters to new format for backward compatibility
-	filters := ConvertDeprecatedFilters(req.GetFilter())
-
-	p.Samples, filtered, err = FilterProfileData(
+	p.Samples, filtered, err = FilterProfileDataWithPprofOptions(
 		ctx,
 		q.tracer,
 		q.mem,
 		p.Samples,
 		filters,
+		pprofOpts,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("filtering profile: %w", err)
 	}
 
 	// Apply sandwich view filtering if specified
-	sandwichByFunction := req.GetSandwichByFunction()
-	if sandwichByFunction != "" {
-		// Create a stack filter for sandwich view
-		sandwichFilter := &pb.Filter{
-			Filter: &pb.Filter_StackFilter{
-				StackFilter: &pb.StackFilter{
-					Filter: &pb.StackFilter_Criteria{
-						Criteria: &pb.FilterCriteria{
-							FunctionName: &pb.StringCondition{
-								Condition: &pb.StringCondition_Contains{
-									Contains: sandwichByFunction,
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-		// Combine existing filters with the sandwich filter
-		sandwichFilters := make([]*pb.Filter, 0, len(filters)+1)
-		sandwichFilters = append(sandwichFilters, filters...)
-		sandwichFilters = append(sandwichFilters, sandwichFilter)
-
+	if sandwichFilters != nil {
 		var sandwichFiltered int64
 		p.Samples, sandwichFiltered, err = FilterProfileData(
 			ctx,
@@ -437,6 +564,13 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		filtered += sandwichFiltered
 	}
 
+	if req.Mode == pb.QueryRequest_MODE_MULTI_METRIC {
+		return q.renderMultiMetric(ctx, p, req.GetNodeTrimThreshold(), filtered, groupByLabels)
+	}
+	if derived != nil {
+		return q.renderDerived(ctx, p, req.GetReportType(), req.GetNodeTrimThreshold(), filtered, groupByLabels)
+	}
+
 	return q.renderReport(
 		ctx,
 		p,
@@ -446,25 +580,97 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 		groupByLabels,
 		req.GetSourceReference(),
 		source,
+		req.GetDisassemblyReference(),
 		isDiff,
+		RenderOptions{
+			NodeCount:              int(req.GetNodeCount()),
+			CallersCalleesFunction: req.GetCallersCalleesFunction(),
+			LabelBreakdownFunction: req.GetLabelBreakdownFunction(),
+			LabelBreakdownLabel:    req.GetLabelBreakdownLabel(),
+			Heatmap:                heatmap,
+			Significance:           significance,
+		},
 	)
 }
 
+var allowedGroupBy = map[string]struct{}{
+	FlamegraphFieldFunctionFileName: {},
+	FlamegraphFieldFunctionName:     {},
+	FlamegraphFieldLocationAddress:  {},
+	FlamegraphFieldMappingFile:      {},
+	FlamegraphFieldTimestamp:        {},
+}
+
+// ValidateGroupBy returns an InvalidArgument error if any of the fields can't
+// be grouped by.
+func ValidateGroupBy(groupBy []string) error {
+	for _, f := range groupBy {
+		if strings.HasPrefix(f, FlamegraphFieldLabels+".") {
+			continue
+		}
+		if _, allowed := allowedGroupBy[f]; allowed {
+			continue
+		}
+		return status.Errorf(codes.InvalidArgument, "invalid group by field: %s", f)
+	}
+	return nil
+}
+
+// significanceMatchers returns the matchers of the filters a profile is
+// filtered by before rendering a report, in the order they are applied.
+func significanceMatchers(filters []*pb.Filter, opts profilefilter.PprofOptions, sandwichFilters []*pb.Filter) ([]*profilefilter.Matcher, error) {
+	var matchers []*profilefilter.Matcher
+	if len(filters) != 0 || !opts.IsEmpty() {
+		m, err := profilefilter.NewMatcherWithPprofOptions(filters, opts)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	if len(sandwichFilters) != 0 {
+		m, err := profilefilter.NewMatcherWithPprofOptions(sandwichFilters, profilefilter.PprofOptions{})
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
 func FilterProfileData(
 	ctx context.Context,
 	tracer trace.Tracer,
 	pool memory.Allocator,
 	records []arrow.RecordBatch,
 	filters []*pb.Filter,
+) ([]arrow.RecordBatch, int64, error) {
+	return FilterProfileDataWithPprofOptions(ctx, tracer, pool, records, filters, profilefilter.PprofOptions{})
+}
+
+// FilterProfileDataWithPprofOptions filters the records like
+// FilterProfileData and additionally applies the pprof style focus, ignore,
+// hide, show, show_from and prune_from options.
+func FilterProfileDataWithPprofOptions(
+	ctx context.Context,
+	tracer trace.Tracer,
+	pool memory.Allocator,
+	records []arrow.RecordBatch,
+	filters []*pb.Filter,
+	opts profilefilter.PprofOptions,
 ) ([]arrow.RecordBatch, int64, error) {
 	_, span := tracer.Start(ctx, "filterByFunction")
 	defer span.End()
 
-	if len(filters) == 0 {
+	if len(filters) == 0 && opts.IsEmpty() {
 		// No filtering means all values are kept, so filtered count = 0
 		return records, 0, nil
 	}
 
+	matcher, err := profilefilter.NewMatcherWithPprofOptions(filters, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	defer func() {
 		for _, r := range records {
 			r.Release()
@@ -481,7 +687,7 @@ func FilterProfileData(
 			tracer,
 			pool,
 			r,
-			filters,
+			matcher,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("filter record: %w", err)
@@ -527,88 +733,49 @@ func filterRecord(
 	tracer trace.Tracer,
 	pool memory.Allocator,
 	rec arrow.RecordBatch,
-	filters []*pb.Filter,
+	matcher *profilefilter.Matcher,
 ) ([]arrow.RecordBatch, int64, int64, error) {
 	_, span := tracer.Start(ctx, "filterRecord")
 	defer span.End()
 
+	// Dictionaries are per record, so cached dictionary matches must not
+	// outlive the record they were computed for.
+	matcher.Reset()
+
 	r, err := profile.NewRecordReader(rec)
 	if err != nil {
 		return nil, 0, 0, fmt.Errorf("failed to create record reader: %w", err)
 	}
 
-	// If no filters, return all records
-	if len(filters) == 0 {
-		valueSum := math.Int64.Sum(r.Value)
-		return []arrow.RecordBatch{rec}, valueSum, valueSum, nil
-	}
-
-	stackFilters := make([]*pb.FilterCriteria, 0)
-	frameFilters := make([]*pb.FilterCriteria, 0)
-
-	for _, filter := range filters {
-		if stackFilter := filter.GetStackFilter(); stackFilter != nil {
-			if criteria := stackFilter.GetCriteria(); criteria != nil {
-				stackFilters = append(stackFilters, criteria)
-			}
-		}
-		if frameFilter := filter.GetFrameFilter(); frameFilter != nil {
-			if criteria := frameFilter.GetCriteria(); criteria != nil {
-				frameFilters = append(frameFilters, criteria)
-			}
-		}
-	}
-
 	originalValueSum := math.Int64.Sum(r.Value)
 
 	rowsToKeep := make([]int64, 0, int(rec.NumRows()))
 	for i := 0; i < int(rec.NumRows()); i++ {
-		lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
-
 		// Check stack filters first
-		keepRow := true
-		if len(stackFilters) > 0 {
-			stackMatches := true
-			if lOffsetStart < lOffsetEnd {
-				firstStart, _ := r.Lines.ValueOffsets(int(lOffsetStart))
-				_, lastEnd := r.Lines.ValueOffsets(int(lOffsetEnd - 1))
-
-				for _, filter := range stackFilters {
-					if !stackMatchesFilter(
-						r,
-						int(firstStart),
-						int(lastEnd-1),
-						int(lOffsetStart),
-						int(lOffsetEnd),
-						filter,
-					) {
-						stackMatches = false
-						break
-					}
-				}
-			}
-			keepRow = stackMatches
+		if !matcher.StackMatches(r, i) {
+			continue
 		}
-
-		if !keepRow {
+		stackRange, ok := matcher.StackRange(r, i)
+		if !ok {
 			continue
 		}
 		rowsToKeep = append(rowsToKeep, int64(i))
 
 		// Apply frame filters - determine which frames to keep
+		lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
 		if lOffsetEnd-lOffsetStart > 0 {
 			for j := int(lOffsetStart); j < int(lOffsetEnd); j++ {
 				keepLocation := false
 				lineStart, lineEnd := r.Lines.ValueOffsets(j)
 				if lineStart >= lineEnd {
 					// For Unsymbolized location, check at location level only
-					if matchesAllFrameFilters(r, j, -1, frameFilters) {
+					if stackRange.Contains(j, -1) && matcher.FrameMatches(r, j, -1) {
 						keepLocation = true
 					}
 				} else {
 					// For Symbolized location, check each line/frame
 					for lineIdx := int(lineStart); lineIdx < int(lineEnd); lineIdx++ {
-						if matchesAllFrameFilters(r, j, lineIdx, frameFilters) {
+						if stackRange.Contains(j, lineIdx) && matcher.FrameMatches(r, j, lineIdx) {
 							keepLocation = true
 						} else {
 							setArrayElementToNull(r.Line, lineIdx, pool)
@@ -637,459 +804,6 @@ func filterRecord(
 	return recs, originalValueSum, filtered, nil
 }
 
-// stackMatchesFilter checks if a stack matches the given filter criteria.
-func stackMatchesFilter(
-	r *profile.RecordReader,
-	firstStart, lastEnd, locStart, locEnd int,
-	filter *pb.FilterCriteria,
-) bool {
-	if fnCond := filter.GetFunctionName(); fnCond != nil {
-		if r.LineFunctionNameIndices.Len() == 0 {
-			return handleUnsymbolizedFunctionCondition(fnCond)
-		}
-		return matchesFunctionNameInRange(r, firstStart, lastEnd, fnCond)
-	}
-
-	if binCond := filter.GetBinary(); binCond != nil {
-		found := false
-		for locIdx := locStart; locIdx < locEnd; locIdx++ {
-			if r.MappingStart.IsValid(locIdx) {
-				mappingFile := r.MappingFileDict.Value(int(r.MappingFileIndices.Value(locIdx)))
-				lastSlash := bytes.LastIndex(mappingFile, []byte("/"))
-				mappingFileBase := mappingFile
-				if lastSlash >= 0 {
-					mappingFileBase = mappingFile[lastSlash+1:]
-				}
-				if matchesStringCondition(mappingFileBase, binCond) {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	if sysCond := filter.GetSystemName(); sysCond != nil {
-		if r.LineFunctionSystemNameIndices.Len() == 0 {
-			return handleUnsymbolizedFunctionCondition(sysCond)
-		}
-		if !matchesSystemNameInRange(r, firstStart, lastEnd, sysCond) {
-			return false
-		}
-	}
-
-	if fileCond := filter.GetFilename(); fileCond != nil {
-		if r.LineFunctionFilenameIndices.Len() == 0 {
-			return handleUnsymbolizedFunctionCondition(fileCond)
-		}
-		if !matchesFilenameInRange(r, firstStart, lastEnd, fileCond) {
-			return false
-		}
-	}
-
-	if addrCond := filter.GetAddress(); addrCond != nil {
-		found := false
-		for locIdx := locStart; locIdx < locEnd; locIdx++ {
-			if r.Address.IsValid(locIdx) {
-				address := r.Address.Value(locIdx)
-				if matchesNumberCondition(address, addrCond) {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	if lineCond := filter.GetLineNumber(); lineCond != nil {
-		if !matchesLineNumberInRange(r, firstStart, lastEnd, lineCond) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func handleUnsymbolizedFunctionCondition(fnCond *pb.StringCondition) bool {
-	switch fnCond.GetCondition().(type) {
-	case *pb.StringCondition_Contains, *pb.StringCondition_Equal, *pb.StringCondition_StartsWith:
-		// For Contains/Equal/StartsWith: no function names means no matches
-		return false
-	case *pb.StringCondition_NotContains, *pb.StringCondition_NotEqual, *pb.StringCondition_NotStartsWith:
-		// For NotContains/NotEqual/NotStartsWith: no function names means condition is satisfied
-		return true
-	}
-	return false
-}
-
-func matchesFunctionNameInRange(r *profile.RecordReader, firstStart, lastEnd int, fnCond *pb.StringCondition) bool {
-	// For NotContains/NotEqual/NotStartsWith, we need ALL functions to not contain/equal/start-with the target (AND logic)
-	// For Contains/Equal/StartsWith, we need ANY function to contain/equal/start-with the target (OR logic)
-	isNegativeCondition := false
-	switch fnCond.GetCondition().(type) {
-	case *pb.StringCondition_NotContains, *pb.StringCondition_NotEqual, *pb.StringCondition_NotStartsWith:
-		isNegativeCondition = true
-	}
-
-	// Iterate through all line indices in the range
-	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
-		if lineIndex >= r.LineFunctionNameIndices.Len() {
-			break
-		}
-
-		// Check if this line has a valid function name
-		if r.LineFunctionNameIndices.IsValid(lineIndex) {
-			fnIndex := r.LineFunctionNameIndices.Value(lineIndex)
-			functionName := r.LineFunctionNameDict.Value(int(fnIndex))
-
-			if isNegativeCondition {
-				// For negative conditions (NotContains/NotEqual), if ANY function matches the negative condition, return false
-				if !matchesStringCondition(functionName, fnCond) {
-					return false
-				}
-			} else {
-				// For positive conditions (Contains/Equal), if ANY function matches, return true
-				if matchesStringCondition(functionName, fnCond) {
-					return true
-				}
-			}
-		}
-	}
-
-	if isNegativeCondition {
-		// For negative conditions, if we got here, ALL functions passed the negative condition
-		return true
-	} else {
-		// For positive conditions, if we got here, NO function matched
-		return false
-	}
-}
-
-func matchesSystemNameInRange(r *profile.RecordReader, firstStart, lastEnd int, sysCond *pb.StringCondition) bool {
-	isNegativeCondition := false
-	switch sysCond.GetCondition().(type) {
-	case *pb.StringCondition_NotContains, *pb.StringCondition_NotEqual, *pb.StringCondition_NotStartsWith:
-		isNegativeCondition = true
-	}
-
-	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
-		if lineIndex >= r.LineFunctionSystemNameIndices.Len() {
-			break
-		}
-
-		if r.LineFunctionSystemNameIndices.IsValid(lineIndex) {
-			sysIndex := r.LineFunctionSystemNameIndices.Value(lineIndex)
-			systemName := r.LineFunctionSystemNameDict.Value(int(sysIndex))
-
-			if isNegativeCondition {
-				if !matchesStringCondition(systemName, sysCond) {
-					return false
-				}
-			} else {
-				if matchesStringCondition(systemName, sysCond) {
-					return true
-				}
-			}
-		}
-	}
-
-	return isNegativeCondition
-}
-
-func matchesFilenameInRange(r *profile.RecordReader, firstStart, lastEnd int, fileCond *pb.StringCondition) bool {
-	isNegativeCondition := false
-	switch fileCond.GetCondition().(type) {
-	case *pb.StringCondition_NotContains, *pb.StringCondition_NotEqual, *pb.StringCondition_NotStartsWith:
-		isNegativeCondition = true
-	}
-
-	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
-		if lineIndex >= r.LineFunctionFilenameIndices.Len() {
-			break
-		}
-
-		if r.LineFunctionFilenameIndices.IsValid(lineIndex) {
-			fileIndex := r.LineFunctionFilenameIndices.Value(lineIndex)
-			filename := r.LineFunctionFilenameDict.Value(int(fileIndex))
-
-			if isNegativeCondition {
-				if !matchesStringCondition(filename, fileCond) {
-					return false
-				}
-			} else {
-				if matchesStringCondition(filename, fileCond) {
-					return true
-				}
-			}
-		}
-	}
-
-	return isNegativeCondition
-}
-
-func matchesLineNumberInRange(r *profile.RecordReader, firstStart, lastEnd int, lineCond *pb.NumberCondition) bool {
-	isNegativeCondition := false
-	switch lineCond.GetCondition().(type) {
-	case *pb.NumberCondition_NotEqual:
-		isNegativeCondition = true
-	}
-
-	for lineIndex := firstStart; lineIndex <= lastEnd; lineIndex++ {
-		if lineIndex >= r.LineNumber.Len() {
-			break
-		}
-
-		if r.LineNumber.IsValid(lineIndex) {
-			lineNumber := uint64(r.LineNumber.Value(lineIndex))
-
-			if isNegativeCondition {
-				if !matchesNumberCondition(lineNumber, lineCond) {
-					return false
-				}
-			} else {
-				if matchesNumberCondition(lineNumber, lineCond) {
-					return true
-				}
-			}
-		}
-	}
-
-	return isNegativeCondition
-}
-
-func matchesAllFrameFilters(
-	r *profile.RecordReader,
-	locationIndex, lineIndex int,
-	frameFilters []*pb.FilterCriteria,
-) bool {
-	// If no frame filters are provided, keep all frames
-	if len(frameFilters) == 0 {
-		return true
-	}
-
-	for _, filter := range frameFilters {
-		if !matchesFrameFilter(r, locationIndex, lineIndex, filter) {
-			return false
-		}
-	}
-	return true
-}
-
-// matchesFrameFilter checks if a single frame matches the filter criteria.
-func matchesFrameFilter(r *profile.RecordReader, locationIndex, lineIndex int, filter *pb.FilterCriteria) bool {
-	if fnCond := filter.GetFunctionName(); fnCond != nil {
-		// If lineIndex is -1, skip function name check
-		if lineIndex >= 0 {
-			// If unsymbolized, always return false
-			if r.LineFunctionNameIndices.Len() == 0 {
-				return false
-			}
-			if r.LineFunctionNameIndices.IsValid(lineIndex) {
-				fnIndex := r.LineFunctionNameIndices.Value(lineIndex)
-				functionName := r.LineFunctionNameDict.Value(int(fnIndex))
-				if !matchesStringCondition(functionName, fnCond) {
-					return false
-				}
-			} else {
-				// Frame has no function name, so function name filter doesn't match
-				return false
-			}
-		}
-	}
-
-	if binCond := filter.GetBinary(); binCond != nil {
-		if r.MappingStart.IsValid(locationIndex) {
-			mappingFile := r.MappingFileDict.Value(int(r.MappingFileIndices.Value(locationIndex)))
-			lastSlash := bytes.LastIndex(mappingFile, []byte("/"))
-			mappingFileBase := mappingFile
-			if lastSlash >= 0 {
-				mappingFileBase = mappingFile[lastSlash+1:]
-			}
-			if !matchesStringCondition(mappingFileBase, binCond) {
-				return false
-			}
-		}
-	}
-
-	if sysCond := filter.GetSystemName(); sysCond != nil {
-		// If lineIndex is -1, skip system name check
-		if lineIndex >= 0 {
-			if r.LineFunctionSystemNameIndices.Len() == 0 {
-				return false
-			}
-			if r.LineFunctionSystemNameIndices.IsValid(lineIndex) {
-				sysIndex := r.LineFunctionSystemNameIndices.Value(lineIndex)
-				systemName := r.LineFunctionSystemNameDict.Value(int(sysIndex))
-				if !matchesStringCondition(systemName, sysCond) {
-					return false
-				}
-			} else {
-				// Frame has no system name, so system name filter doesn't match
-				return false
-			}
-		}
-	}
-
-	if fileCond := filter.GetFilename(); fileCond != nil {
-		// If lineIndex is -1, skip filename check
-		if lineIndex >= 0 {
-			if r.LineFunctionFilenameIndices.Len() == 0 {
-				return false
-			}
-			if r.LineFunctionFilenameIndices.IsValid(lineIndex) {
-				fileIndex := r.LineFunctionFilenameIndices.Value(lineIndex)
-				filename := r.LineFunctionFilenameDict.Value(int(fileIndex))
-				if !matchesStringCondition(filename, fileCond) {
-					return false
-				}
-			} else {
-				// Frame has no filename, so filename filter doesn't match
-				return false
-			}
-		}
-	}
-
-	if addrCond := filter.GetAddress(); addrCond != nil {
-		if r.Address.IsValid(locationIndex) {
-			address := r.Address.Value(locationIndex)
-			if !matchesNumberCondition(address, addrCond) {
-				return false
-			}
-		} else {
-			// Frame has no address, so address filter doesn't match
-			return false
-		}
-	}
-
-	if lineCond := filter.GetLineNumber(); lineCond != nil {
-		// If lineIndex is -1, skip line number check
-		if lineIndex >= 0 {
-			if r.LineNumber.IsValid(lineIndex) {
-				lineNumber := uint64(r.LineNumber.Value(lineIndex))
-				if !matchesNumberCondition(lineNumber, lineCond) {
-					return false
-				}
-			} else {
-				// Frame has no line number, so line number filter doesn't match
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
-// toLower converts ASCII byte to lowercase without allocation.
-// Non-ASCII bytes are left unchanged.
-func toLower(b byte) byte {
-	if b >= 'A' && b <= 'Z' {
-		return b + ('a' - 'A')
-	}
-	return b
-}
-
-// equalFoldBytes performs case-insensitive comparison without allocation.
-func equalFoldBytes(s, t []byte) bool {
-	if len(s) != len(t) {
-		return false
-	}
-	for i := 0; i < len(s); i++ {
-		if toLower(s[i]) != toLower(t[i]) {
-			return false
-		}
-	}
-	return true
-}
-
-// containsFoldBytes performs case-insensitive contains check without allocation.
-func containsFoldBytes(s, substr []byte) bool {
-	if len(substr) == 0 {
-		return true
-	}
-	if len(substr) > len(s) {
-		return false
-	}
-
-	// Search for substring
-	for i := 0; i <= len(s)-len(substr); i++ {
-		// Check if substring matches at position i
-		match := true
-		for j := 0; j < len(substr); j++ {
-			if toLower(s[i+j]) != toLower(substr[j]) {
-				match = false
-				break
-			}
-		}
-		if match {
-			return true
-		}
-	}
-	return false
-}
-
-// hasPrefixFoldBytes performs case-insensitive prefix check without allocation.
-func hasPrefixFoldBytes(s, prefix []byte) bool {
-	if len(prefix) > len(s) {
-		return false
-	}
-	for i := 0; i < len(prefix); i++ {
-		if toLower(s[i]) != toLower(prefix[i]) {
-			return false
-		}
-	}
-	return true
-}
-
-// matchesStringCondition checks if a value matches a string condition.
-func matchesStringCondition(value []byte, condition *pb.StringCondition) bool {
-	if condition == nil {
-		return true
-	}
-
-	switch condition.GetCondition().(type) {
-	case *pb.StringCondition_Equal:
-		target := []byte(condition.GetEqual())
-		return equalFoldBytes(value, target)
-	case *pb.StringCondition_NotEqual:
-		target := []byte(condition.GetNotEqual())
-		return !equalFoldBytes(value, target)
-	case *pb.StringCondition_Contains:
-		target := []byte(condition.GetContains())
-		return containsFoldBytes(value, target)
-	case *pb.StringCondition_NotContains:
-		target := []byte(condition.GetNotContains())
-		return !containsFoldBytes(value, target)
-	case *pb.StringCondition_StartsWith:
-		target := []byte(condition.GetStartsWith())
-		return hasPrefixFoldBytes(value, target)
-	case *pb.StringCondition_NotStartsWith:
-		target := []byte(condition.GetNotStartsWith())
-		return !hasPrefixFoldBytes(value, target)
-	default:
-		return true
-	}
-}
-
-// matchesNumberCondition checks if a numeric value matches a number condition.
-func matchesNumberCondition(value uint64, condition *pb.NumberCondition) bool {
-	if condition == nil {
-		return true
-	}
-
-	switch condition.GetCondition().(type) {
-	case *pb.NumberCondition_Equal:
-		return value == condition.GetEqual()
-	case *pb.NumberCondition_NotEqual:
-		return value != condition.GetNotEqual()
-	default:
-		return true
-	}
-}
-
 func (q *ColumnQueryAPI) renderReport(
 	ctx context.Context,
 	p profile.Profile,
@@ -1099,8 +813,14 @@ func (q *ColumnQueryAPI) renderReport(
 	groupBy []string,
 	sourceReference *pb.SourceReference,
 	source string,
+	disassemblyReference *pb.DisassemblyReference,
 	isDiff bool,
+	opts RenderOptions,
 ) (*pb.QueryResponse, error) {
+	if typ == pb.QueryRequest_REPORT_TYPE_DISASSEMBLY {
+		return q.renderDisassembly(ctx, p, filtered, disassemblyReference)
+	}
+
 	return RenderReport(
 		ctx,
 		q.tracer,
@@ -1115,9 +835,30 @@ func (q *ColumnQueryAPI) renderReport(
 		sourceReference,
 		source,
 		isDiff,
+		opts,
 	)
 }
 
+// RenderOptions holds the options of the reports that only some report
+// types use. The zero value renders these reports with their defaults.
+type RenderOptions struct {
+	// NodeCount is the maximum number of nodes of the callgraph reports,
+	// zero prunes nodes by their cumulative value instead.
+	NodeCount int
+	// CallersCalleesFunction is the pattern of the functions of the callers
+	// and callees report.
+	CallersCalleesFunction string
+	// LabelBreakdownFunction and LabelBreakdownLabel select the function
+	// and the label of the label breakdown report.
+	LabelBreakdownFunction string
+	LabelBreakdownLabel    string
+	// Heatmap configures the heatmap report.
+	Heatmap HeatmapOptions
+	// Significance holds the p-values of a diff, which are added to the
+	// flamegraph and table reports.
+	Significance DiffSignificance
+}
+
 func RenderReport(
 	ctx context.Context,
 	tracer trace.Tracer,
@@ -1132,6 +873,7 @@ func RenderReport(
 	sourceReference *pb.SourceReference,
 	source string,
 	isDiff bool,
+	opts RenderOptions,
 ) (*pb.QueryResponse, error) {
 	ctx, span := tracer.Start(ctx, "renderReport")
 	span.SetAttributes(attribute.String("reportType", typ.String()))
@@ -1233,7 +975,7 @@ func RenderReport(
 			}
 		}
 
-		fa, total, err := GenerateFlamegraphArrow(ctx, mem, tracer, p, groupBy, nodeTrimFraction)
+		fa, total, err := GenerateFlamegraphArrow(ctx, mem, tracer, p, groupBy, nodeTrimFraction, opts.Significance)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate arrow flamegraph: %v", err.Error())
 		}
@@ -1253,6 +995,7 @@ func RenderReport(
 			p,
 			sourceReference,
 			source,
+			isDiff,
 		)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate arrow flamegraph: %v", err.Error())
@@ -1281,13 +1024,53 @@ func RenderReport(
 			Filtered: filtered,
 			Report:   &pb.QueryResponse_Pprof{Pprof: buf},
 		}, nil
-	case pb.QueryRequest_REPORT_TYPE_TOP:
-		op, err := converter.Convert(ctx, p)
+	case pb.QueryRequest_REPORT_TYPE_FOLDED:
+		folded, total, err := GenerateFoldedStacks(ctx, tracer, p, isDiff)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate folded stacks: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_Folded{Folded: folded},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_SPEEDSCOPE:
+		if isDiff {
+			return nil, status.Error(codes.InvalidArgument, "speedscope report is not supported for diff queries")
+		}
+
+		// Unlike for the flame chart the timestamp isn't added to the
+		// group by fields, so merges are exported as sampled profiles
+		// unless grouping by timestamp is requested, see query.proto.
+		evented := slices.Contains(groupBy, FlamegraphFieldTimestamp)
+		buf, total, err := GenerateSpeedscope(ctx, tracer, p, evented)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate speedscope profile: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_Speedscope{Speedscope: buf},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_CHROME_TRACE:
+		if isDiff {
+			return nil, status.Error(codes.InvalidArgument, "chrome trace report is not supported for diff queries")
+		}
+
+		buf, total, err := GenerateChromeTrace(ctx, tracer, p)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to convert profile: %v", err.Error())
+			return nil, status.Errorf(codes.Internal, "failed to generate chrome trace: %v", err.Error())
 		}
 
-		top, cumulative, err := GenerateTopTable(ctx, op)
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_ChromeTrace{ChromeTrace: buf},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_TOP:
+		top, cumulative, err := GenerateTopTable(ctx, p, converter)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate pprof: %v", err.Error())
 		}
@@ -1299,7 +1082,7 @@ func RenderReport(
 			Report:   &pb.QueryResponse_Top{Top: top},
 		}, nil
 	case pb.QueryRequest_REPORT_TYPE_TABLE_ARROW:
-		table, cumulative, err := GenerateTable(ctx, mem, tracer, p)
+		table, cumulative, err := GenerateTable(ctx, mem, tracer, p, opts.Significance)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate table: %v", err.Error())
 		}
@@ -1311,20 +1094,69 @@ func RenderReport(
 		}, nil
 
 	case pb.QueryRequest_REPORT_TYPE_CALLGRAPH:
-		op, err := converter.Convert(ctx, p)
+		callgraph, err := GenerateCallgraph(ctx, p, converter, opts.NodeCount)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to convert profile: %v", err.Error())
+			return nil, status.Errorf(codes.Internal, "failed to generate callgraph: %v", err.Error())
+		}
+		return &pb.QueryResponse{
+			//nolint:staticcheck // SA1019: TODO: The cumulative should be passed differently in the future.
+			Total:    callgraph.Cumulative,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_Callgraph{Callgraph: callgraph},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_CALLERS_CALLEES:
+		callersCallees, total, err := GenerateCallersCallees(ctx, tracer, p, opts.CallersCalleesFunction)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to generate callers and callees: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_CallersCallees{CallersCallees: callersCallees},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_LABEL_BREAKDOWN:
+		labelBreakdown, total, err := GenerateLabelBreakdown(ctx, tracer, p, opts.LabelBreakdownFunction, opts.LabelBreakdownLabel)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to generate label breakdown: %v", err.Error())
 		}
 
-		callgraph, err := GenerateCallgraph(ctx, op)
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_LabelBreakdown{LabelBreakdown: labelBreakdown},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_HEATMAP:
+		res, total, err := GenerateHeatmap(ctx, tracer, p, opts.Heatmap)
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to generate heatmap: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Total:    total,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_Heatmap{Heatmap: res},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_CALLGRAPH_DOT:
+		callgraph, err := GenerateCallgraph(ctx, p, converter, opts.NodeCount)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate callgraph: %v", err.Error())
 		}
+
 		return &pb.QueryResponse{
 			//nolint:staticcheck // SA1019: TODO: The cumulative should be passed differently in the future.
 			Total:    callgraph.Cumulative,
 			Filtered: filtered,
-			Report:   &pb.QueryResponse_Callgraph{Callgraph: callgraph},
+			Report:   &pb.QueryResponse_CallgraphDot{CallgraphDot: GenerateCallgraphDOT(callgraph, p.Meta)},
 		}, nil
 	default:
 		return nil, status.Error(codes.InvalidArgument, "requested report type does not exist")
@@ -1371,20 +1203,30 @@ func (q *ColumnQueryAPI) selectMerge(
 	return p, nil
 }
 
+// selectDiff returns the diff of the two selected profiles and, if requested,
+// the significance of the differences of the samples kept by the matchers.
 func (q *ColumnQueryAPI) selectDiff(
 	ctx context.Context,
 	d *pb.DiffProfile,
 	aggregateByLabels, isInverted bool,
-) (profile.Profile, error) {
+	matchers []*profilefilter.Matcher,
+) (profile.Profile, DiffSignificance, error) {
 	ctx, span := q.tracer.Start(ctx, "diffRequest")
 	defer span.End()
 
 	if d == nil {
-		return profile.Profile{}, status.Error(
+		return profile.Profile{}, nil, status.Error(
 			codes.InvalidArgument,
 			"requested diff mode, but did not provide parameters for diff",
 		)
 	}
+	if d.GetSignificance() && (d.GetA().GetMode() != pb.ProfileDiffSelection_MODE_MERGE ||
+		d.GetB().GetMode() != pb.ProfileDiffSelection_MODE_MERGE) {
+		return profile.Profile{}, nil, status.Error(
+			codes.InvalidArgument,
+			"significance of a diff requires both profiles to be merges",
+		)
+	}
 
 	g, ctx := errgroup.WithContext(ctx)
 	var base profile.Profile
@@ -1396,7 +1238,7 @@ func (q *ColumnQueryAPI) selectDiff(
 	g.Go(
 		func() error {
 			var err error
-			base, err = q.selectProfileForDiff(ctx, d.A, aggregateByLabels, isInverted)
+			base, err = q.selectProfileForDiff(ctx, d.A, aggregateByLabels, isInverted, d.GetSignificance())
 			if err != nil {
 				return fmt.Errorf("reading base profile: %w", err)
 			}
@@ -1413,7 +1255,7 @@ func (q *ColumnQueryAPI) selectDiff(
 	g.Go(
 		func() error {
 			var err error
-			compare, err = q.selectProfileForDiff(ctx, d.B, aggregateByLabels, isInverted)
+			compare, err = q.selectProfileForDiff(ctx, d.B, aggregateByLabels, isInverted, d.GetSignificance())
 			if err != nil {
 				return fmt.Errorf("reading compared profile: %w", err)
 			}
@@ -1422,16 +1264,47 @@ func (q *ColumnQueryAPI) selectDiff(
 	)
 
 	if err := g.Wait(); err != nil {
-		return profile.Profile{}, err
+		return profile.Profile{}, nil, err
 	}
 
-	return ComputeDiff(ctx, q.tracer, q.mem, base, compare, d.GetAbsolute())
+	var significance DiffSignificance
+	if d.GetSignificance() {
+		var err error
+		significance, err = ComputeDiffSignificance(ctx, q.tracer, base, compare, matchers...)
+		if err != nil {
+			return profile.Profile{}, nil, fmt.Errorf("computing diff significance: %w", err)
+		}
+	}
+
+	p, err := ComputeDiff(ctx, q.tracer, q.mem, base, compare, d.GetAbsolute())
+	if err != nil {
+		return profile.Profile{}, nil, err
+	}
+	return p, significance, nil
 }
 
 type Releasable interface {
 	Release()
 }
 
+// diffSideMetadataKey is the schema metadata key ComputeDiff uses to mark the
+// records it creates from the samples of the base profile.
+const diffSideMetadataKey = "parca_diff_side"
+
+func diffBaseSchema(schema *arrow.Schema) *arrow.Schema {
+	md := schema.Metadata().ToMap()
+	md[diffSideMetadataKey] = "base"
+	meta := arrow.MetadataFrom(md)
+	return arrow.NewSchema(schema.Fields(), &meta)
+}
+
+// isDiffBaseRecord returns whether the record holds samples of the base
+// profile of a diff, as opposed to the samples of the compare profile.
+func isDiffBaseRecord(rec arrow.RecordBatch) bool {
+	side, ok := rec.Schema().Metadata().GetValue(diffSideMetadataKey)
+	return ok && side == "base"
+}
+
 func ComputeDiff(
 	ctx context.Context,
 	tracer trace.Tracer,
@@ -1509,6 +1382,7 @@ func ComputeDiff(
 
 	for _, r := range base.Samples {
 		func() {
+			schema := diffBaseSchema(r.Schema())
 			columns := r.Columns()
 
 			cols := make([]arrow.Array, len(columns))
@@ -1523,7 +1397,7 @@ func ComputeDiff(
 			defer duration.Release()
 			records = append(
 				records, array.NewRecordBatch(
-					r.Schema(),
+					schema,
 					append(
 						cols[:len(cols)-4], // all other columns like locations
 						value,
@@ -1577,12 +1451,18 @@ func (q *ColumnQueryAPI) selectProfileForDiff(
 	ctx context.Context,
 	s *pb.ProfileDiffSelection,
 	_, isInverted bool,
+	byTimestamp bool,
 ) (profile.Profile, error) {
 	switch s.Mode {
 	case pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED:
 		return q.selectSingle(ctx, s.GetSingle(), isInverted)
 	case pb.ProfileDiffSelection_MODE_MERGE:
-		return q.selectMerge(ctx, s.GetMerge(), []string{}, isInverted, "")
+		groupBy := []string{}
+		if byTimestamp {
+			// Keep the profiles apart, to compare their values.
+			groupBy = append(groupBy, profile.ColumnTimestamp)
+		}
+		return q.selectMerge(ctx, s.GetMerge(), groupBy, isInverted, "")
 	default:
 		return profile.Profile{}, status.Error(codes.InvalidArgument, "unknown mode for diff profile selection")
 	}
@@ -91,10 +91,14 @@ func TestColumnQueryAPIQueryRangeEmpty(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	_, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
 		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
@@ -217,10 +221,14 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
 		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
@@ -231,6 +239,44 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 	require.Equal(t, 1, len(res.Series))
 	require.Equal(t, 1, len(res.Series[0].Labelset.Labels))
 	require.Equal(t, 10, len(res.Series[0].Samples))
+
+	stackFilter := func(cond *pb.StringCondition) []*pb.Filter {
+		return []*pb.Filter{{
+			Filter: &pb.Filter_StackFilter{
+				StackFilter: &pb.StackFilter{
+					Filter: &pb.StackFilter_Criteria{
+						Criteria: &pb.FilterCriteria{FunctionName: cond},
+					},
+				},
+			},
+		}}
+	}
+
+	// Only the values of stacks matching the filters are included.
+	filtered, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query:  `memory:alloc_objects:count:space:bytes{job="default"}`,
+		Start:  timestamppb.New(time.Unix(0, 0)),
+		End:    timestamppb.New(time.Unix(0, 9223372036854775807)),
+		Filter: stackFilter(&pb.StringCondition{Condition: &pb.StringCondition_NotContains{NotContains: "does-not-exist"}}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filtered.Series))
+	for i, sample := range filtered.Series[0].Samples {
+		require.Equal(t, res.Series[0].Samples[i].Value, sample.Value)
+	}
+
+	filtered, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query:  `memory:alloc_objects:count:space:bytes{job="default"}`,
+		Start:  timestamppb.New(time.Unix(0, 0)),
+		End:    timestamppb.New(time.Unix(0, 9223372036854775807)),
+		Filter: stackFilter(&pb.StringCondition{Condition: &pb.StringCondition_Equal{Equal: "does-not-exist"}}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filtered.Series))
+	require.Equal(t, 10, len(filtered.Series[0].Samples))
+	for _, sample := range filtered.Series[0].Samples {
+		require.Equal(t, int64(0), sample.Value)
+	}
 }
 
 func TestColumnQueryAPIQuerySingle(t *testing.T) {
@@ -310,10 +356,14 @@ func TestColumnQueryAPIQuerySingle(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	ts := timestamppb.New(timestamp.Time(p.TimeNanos / time.Millisecond.Nanoseconds()))
 	res, err := api.Query(ctx, &pb.QueryRequest{
@@ -459,10 +509,14 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	res, err := api.QueryRange(ctx, &pb.QueryRangeRequest{
@@ -566,10 +620,14 @@ func TestColumnQueryAPIQueryCumulative(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// These have been extracted from the profiles above.
@@ -772,10 +830,14 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	res, err := api.Query(ctx, &pb.QueryRequest{
@@ -998,10 +1060,14 @@ func TestColumnQueryAPITypes(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	res, err := api.ProfileTypes(ctx, &pb.ProfileTypesRequest{})
 	require.NoError(t, err)
@@ -1094,10 +1160,14 @@ func TestColumnQueryAPILabelNames(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	res, err := api.Labels(ctx, &pb.LabelsRequest{})
 	require.NoError(t, err)
@@ -1182,10 +1252,14 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	res, err := api.Values(ctx, &pb.ValuesRequest{
 		LabelName: "job",
@@ -1197,6 +1271,125 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 	}, res.LabelValues)
 }
 
+func TestColumnQueryAPISeries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := noop.NewTracerProvider().Tracer("")
+	col, err := columnstore.New()
+	require.NoError(t, err)
+	colDB, err := col.DB(context.Background(), "parca")
+	require.NoError(t, err)
+
+	schema, err := profile.Schema()
+	require.NoError(t, err)
+
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(profile.SchemaDefinition()),
+	)
+	require.NoError(t, err)
+
+	fileContent, err := os.ReadFile("testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	ingester := ingester.NewIngester(
+		logger,
+		table,
+	)
+	store := profilestore.NewProfileColumnStore(
+		reg,
+		logger,
+		tracer,
+		ingester,
+		schema,
+		memory.DefaultAllocator,
+	)
+
+	_, err = store.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{
+					{
+						Name:  "__name__",
+						Value: "memory",
+					},
+					{
+						Name:  "job",
+						Value: "default",
+					},
+				},
+			},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: fileContent,
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		getShareServerConn(t),
+		parcacol.NewQuerier(
+			logger,
+			tracer,
+			query.NewEngine(
+				mem,
+				colDB.TableProvider(),
+			),
+			"stacktraces",
+			nil,
+			nil,
+			mem,
+			nil,
+		),
+		mem,
+		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	start, end := timestamppb.New(time.Unix(0, 0)), timestamppb.New(time.Unix(0, 9223372036854775807))
+	res, err := api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`memory:alloc_objects:count:space:bytes{job="default"}`},
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Series, 1)
+	require.Equal(t, []*profilestorepb.Label{{Name: "job", Value: "default"}}, res.Series[0].Labelset.Labels)
+	require.Equal(t, "alloc_objects", res.Series[0].ProfileType.SampleType)
+	require.False(t, res.Series[0].ProfileType.Delta)
+	require.False(t, res.Series[0].LastSeen.AsTime().Before(res.Series[0].FirstSeen.AsTime()))
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{Match: []string{`{job="default"}`}, Start: start, End: end})
+	require.NoError(t, err)
+	require.Greater(t, len(res.Series), 1)
+	for _, s := range res.Series {
+		require.Equal(t, "memory", s.ProfileType.Name)
+	}
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{Match: []string{`{job="other"}`}, Start: start, End: end})
+	require.NoError(t, err)
+	require.Empty(t, res.Series)
+
+	for _, req := range []*pb.SeriesRequest{
+		{End: end},
+		{Start: start},
+		{Start: end, End: start},
+	} {
+		_, err = api.Series(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
 func BenchmarkQuery(b *testing.B) {
 	ctx := context.Background()
 	tracer := noop.NewTracerProvider().Tracer("")
@@ -1230,99 +1423,13 @@ func BenchmarkQuery(b *testing.B) {
 			nil,
 			"",
 			false,
+			RenderOptions{},
 		)
 	}
 }
 
 func PprofToSymbolizedProfile(meta profile.Meta, prof *pprofprofile.Profile, index int, groupBy []string) (profile.Profile, error) {
-	labelNameSet := make(map[string]struct{})
-	for _, s := range prof.Sample {
-		for k := range s.Label {
-			labelNameSet[k] = struct{}{}
-		}
-	}
-	labelNames := make([]string, 0, len(labelNameSet))
-	for l := range labelNameSet {
-		labelNames = append(labelNames, l)
-	}
-
-	groupBySet := make(map[string]struct{}, len(groupBy))
-	for _, g := range groupBy {
-		groupBySet[g] = struct{}{}
-	}
-
-	w := profile.NewWriter(memory.DefaultAllocator, labelNames)
-	defer w.RecordBuilder.Release()
-	for i := range prof.Sample {
-		if len(prof.Sample[i].Value) <= index {
-			return profile.Profile{}, status.Errorf(codes.InvalidArgument, "failed to find samples for profile type")
-		}
-
-		w.Value.Append(prof.Sample[i].Value[index])
-		w.Diff.Append(0)
-		w.TimeNanos.Append(prof.TimeNanos)
-		w.Period.Append(prof.Period)
-
-		for labelName, labelBuilder := range w.LabelBuildersMap {
-			if prof.Sample[i].Label == nil {
-				labelBuilder.AppendNull()
-				continue
-			}
-
-			if labelValues, ok := prof.Sample[i].Label[labelName]; ok && len(labelValues) > 0 {
-				labelBuilder.Append([]byte(labelValues[0]))
-			} else {
-				labelBuilder.AppendNull()
-			}
-		}
-
-		w.LocationsList.Append(len(prof.Sample[i].Location) > 0)
-		if len(prof.Sample[i].Location) > 0 {
-			for _, loc := range prof.Sample[i].Location {
-				w.Locations.Append(true)
-				w.Addresses.Append(loc.Address)
-
-				if loc.Mapping != nil {
-					w.MappingStart.Append(loc.Mapping.Start)
-					w.MappingLimit.Append(loc.Mapping.Limit)
-					w.MappingOffset.Append(loc.Mapping.Offset)
-					w.MappingFile.Append([]byte(loc.Mapping.File))
-					w.MappingBuildID.Append([]byte(loc.Mapping.BuildID))
-				} else {
-					w.MappingStart.AppendNull()
-					w.MappingLimit.AppendNull()
-					w.MappingOffset.AppendNull()
-					w.MappingFile.AppendNull()
-					w.MappingBuildID.AppendNull()
-				}
-
-				w.Lines.Append(len(loc.Line) > 0)
-				if len(loc.Line) > 0 {
-					for _, line := range loc.Line {
-						w.Line.Append(true)
-						w.LineNumber.Append(line.Line)
-						w.ColumnNumber.Append(uint64(line.Column))
-						if line.Function != nil {
-							w.FunctionName.Append([]byte(line.Function.Name))
-							w.FunctionSystemName.Append([]byte(line.Function.SystemName))
-							w.FunctionFilename.Append([]byte(line.Function.Filename))
-							w.FunctionStartLine.Append(line.Function.StartLine)
-						} else {
-							w.FunctionName.AppendNull()
-							w.FunctionSystemName.AppendNull()
-							w.FunctionFilename.AppendNull()
-							w.FunctionStartLine.AppendNull()
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return profile.Profile{
-		Meta:    meta,
-		Samples: []arrow.RecordBatch{w.RecordBuilder.NewRecordBatch()},
-	}, nil
+	return ProfileFromPprof(memory.DefaultAllocator, meta, prof, index)
 }
 
 func TestFilterData(t *testing.T) {
@@ -0,0 +1,131 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
+	"github.com/parca-dev/parca/pkg/debuginfo"
+)
+
+type DebuginfoMetadata interface {
+	Fetch(ctx context.Context, buildID string, typ debuginfopb.DebuginfoType) (*debuginfopb.Debuginfo, error)
+}
+
+type DebuginfoFetcher interface {
+	FetchDebuginfo(ctx context.Context, dbginfo *debuginfopb.Debuginfo) (io.ReadCloser, error)
+}
+
+// BucketDebuginfoFinder finds the debuginfo files in the debuginfo bucket and
+// caches them on disk by build ID.
+type BucketDebuginfoFinder struct {
+	metadata DebuginfoMetadata
+	fetcher  DebuginfoFetcher
+	cacheDir string
+}
+
+func NewBucketDebuginfoFinder(
+	metadata DebuginfoMetadata,
+	fetcher DebuginfoFetcher,
+	cacheDir string,
+) *BucketDebuginfoFinder {
+	return &BucketDebuginfoFinder{
+		metadata: metadata,
+		fetcher:  fetcher,
+		cacheDir: cacheDir,
+	}
+}
+
+// FindDebuginfo opens the debuginfo file of the given type. The file is
+// fetched into the cache directory the first time, the debuginfo files are
+// cached at the same path as by the symbolizer so they are only fetched once.
+func (f *BucketDebuginfoFinder) FindDebuginfo(ctx context.Context, buildID string, typ debuginfopb.DebuginfoType) (*os.File, error) {
+	if buildID == "" || filepath.Base(buildID) != buildID {
+		// The build ID is used as file name, no such build ID can have been
+		// uploaded.
+		return nil, ErrDebuginfoNotFound
+	}
+
+	dbginfo, err := f.metadata.Fetch(ctx, buildID, typ)
+	if err != nil {
+		if errors.Is(err, debuginfo.ErrMetadataNotFound) {
+			return nil, ErrDebuginfoNotFound
+		}
+		return nil, err
+	}
+	if dbginfo.Source == debuginfopb.Debuginfo_SOURCE_UPLOAD &&
+		dbginfo.GetUpload().GetState() != debuginfopb.DebuginfoUpload_STATE_UPLOADED {
+		return nil, ErrDebuginfoNotFound
+	}
+
+	targetPath := filepath.Join(f.cacheDir, cachedDebuginfoName(buildID, typ))
+	if _, err := os.Stat(targetPath); errors.Is(err, os.ErrNotExist) {
+		if err := f.fetch(ctx, dbginfo, targetPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return os.Open(targetPath)
+}
+
+// fetch copies the debuginfo to a temporary file that is then renamed to the
+// target path, so concurrent requests never open a partially written file.
+func (f *BucketDebuginfoFinder) fetch(ctx context.Context, dbginfo *debuginfopb.Debuginfo, targetPath string) error {
+	rc, err := f.fetcher.FetchDebuginfo(ctx, dbginfo)
+	if err != nil {
+		return fmt.Errorf("fetch debuginfo: %w", err)
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp(f.cacheDir, "parca-debuginfo-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	if _, err := io.Copy(tmp, rc); err != nil {
+		return fmt.Errorf("copy debuginfo to temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), targetPath); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
+
+// cachedDebuginfoName returns the file name of the cached debuginfo file of
+// the given type.
+func cachedDebuginfoName(buildID string, typ debuginfopb.DebuginfoType) string {
+	switch typ {
+	case debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE:
+		return buildID + "-executable"
+	case debuginfopb.DebuginfoType_DEBUGINFO_TYPE_SOURCES:
+		return buildID + "-sources"
+	case debuginfopb.DebuginfoType_DEBUGINFO_TYPE_SOURCE_MAP:
+		return buildID + "-sourcemap"
+	default:
+		return buildID
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
+	"github.com/parca-dev/parca/pkg/debuginfo"
+)
+
+type fakeDebuginfoMetadata map[debuginfopb.DebuginfoType]*debuginfopb.Debuginfo
+
+func (m fakeDebuginfoMetadata) Fetch(_ context.Context, buildID string, typ debuginfopb.DebuginfoType) (*debuginfopb.Debuginfo, error) {
+	if dbginfo, ok := m[typ]; ok && dbginfo.BuildId == buildID {
+		return dbginfo, nil
+	}
+	return nil, debuginfo.ErrMetadataNotFound
+}
+
+type fakeDebuginfoFetcher struct {
+	fetches int
+}
+
+func (f *fakeDebuginfoFetcher) FetchDebuginfo(_ context.Context, dbginfo *debuginfopb.Debuginfo) (io.ReadCloser, error) {
+	f.fetches++
+	return io.NopCloser(strings.NewReader(dbginfo.Type.String())), nil
+}
+
+func TestBucketDebuginfoFinderCachesByBuildID(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	uploaded := &debuginfopb.DebuginfoUpload{State: debuginfopb.DebuginfoUpload_STATE_UPLOADED}
+	metadata := fakeDebuginfoMetadata{
+		debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE: {
+			BuildId: "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085",
+			Type:    debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE,
+			Source:  debuginfopb.Debuginfo_SOURCE_UPLOAD,
+			Upload:  uploaded,
+		},
+		debuginfopb.DebuginfoType_DEBUGINFO_TYPE_DEBUGINFO_UNSPECIFIED: {
+			BuildId: "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085",
+			Type:    debuginfopb.DebuginfoType_DEBUGINFO_TYPE_DEBUGINFO_UNSPECIFIED,
+			Source:  debuginfopb.Debuginfo_SOURCE_UPLOAD,
+			Upload:  uploaded,
+		},
+	}
+	fetcher := &fakeDebuginfoFetcher{}
+	finder := NewBucketDebuginfoFinder(metadata, fetcher, dir)
+
+	read := func(typ debuginfopb.DebuginfoType) string {
+		f, err := finder.FindDebuginfo(ctx, "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085", typ)
+		require.NoError(t, err)
+		defer f.Close()
+
+		content, err := io.ReadAll(f)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, "DEBUGINFO_TYPE_EXECUTABLE", read(debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE))
+		require.Equal(t, "DEBUGINFO_TYPE_DEBUGINFO_UNSPECIFIED", read(debuginfopb.DebuginfoType_DEBUGINFO_TYPE_DEBUGINFO_UNSPECIFIED))
+	}
+	require.Equal(t, 2, fetcher.fetches)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.ElementsMatch(t, []string{
+		"2d6912fd3dd64542f6f6294f4bf9cb6c265b3085",
+		"2d6912fd3dd64542f6f6294f4bf9cb6c265b3085-executable",
+	}, names)
+
+	_, err = finder.FindDebuginfo(ctx, filepath.Join("..", "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"), debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE)
+	require.ErrorIs(t, err, ErrDebuginfoNotFound)
+}
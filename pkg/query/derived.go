@@ -0,0 +1,369 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const (
+	// FlamegraphFieldCumulativeNumerator and FlamegraphFieldFlatNumerator
+	// replace the secondary columns in flame graphs of derived profile types,
+	// the ratio columns hold their ratio to the cumulative and flat values.
+	FlamegraphFieldCumulativeNumerator = "cumulative_numerator"
+	FlamegraphFieldFlatNumerator       = "flat_numerator"
+	FlamegraphFieldRatio               = "ratio"
+	FlamegraphFieldFlatRatio           = "flat_ratio"
+
+	// TableFieldCumulativeNumerator and TableFieldFlatNumerator replace the
+	// secondary columns in tables of derived profile types, the ratio columns
+	// hold their ratio to the cumulative and flat values.
+	TableFieldCumulativeNumerator = "cumulative_numerator"
+	TableFieldFlatNumerator       = "flat_numerator"
+	TableFieldCumulativeRatio     = "cumulative_ratio"
+	TableFieldFlatRatio           = "flat_ratio"
+)
+
+// derivedQuery is a query of a derived profile type, translated to queries of
+// its numerator and denominator.
+type derivedQuery struct {
+	profile.DerivedType
+	numerator, denominator string
+}
+
+// derivedQuery returns nil if the query is not of a derived profile type.
+func (q *ColumnQueryAPI) derivedQuery(query string) (*derivedQuery, error) {
+	qp, err := profile.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if qp.Derived == "" {
+		return nil, nil
+	}
+
+	d, ok := q.derivedTypes[qp.Derived]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown derived profile type %q", qp.Derived)
+	}
+	numerator, denominator := d.Queries(qp.Matchers)
+	return &derivedQuery{
+		DerivedType: d,
+		numerator:   numerator,
+		denominator: denominator,
+	}, nil
+}
+
+func (q *ColumnQueryAPI) selectDerived(
+	ctx context.Context,
+	d *derivedQuery,
+	m *pb.MergeProfile,
+	reportType pb.QueryRequest_ReportType,
+	groupByLabels []string,
+	isInverted bool,
+) (profile.Profile, error) {
+	ctx, span := q.tracer.Start(ctx, "derivedRequest")
+	defer span.End()
+
+	if reportType != pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW && reportType != pb.QueryRequest_REPORT_TYPE_TABLE_ARROW {
+		return profile.Profile{}, status.Error(
+			codes.InvalidArgument,
+			"derived profile types only support the arrow flame graph and table reports",
+		)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	var numerator profile.Profile
+	defer func() {
+		for _, r := range numerator.Samples {
+			r.Release()
+		}
+	}()
+	g.Go(func() error {
+		var err error
+		numerator, err = q.selectMerge(ctx, &pb.MergeProfile{
+			Query: d.numerator,
+			Start: m.GetStart(),
+			End:   m.GetEnd(),
+		}, groupByLabels, isInverted, "")
+		if err != nil {
+			return fmt.Errorf("reading numerator profile: %w", err)
+		}
+		return nil
+	})
+
+	var denominator profile.Profile
+	defer func() {
+		for _, r := range denominator.Samples {
+			r.Release()
+		}
+	}()
+	g.Go(func() error {
+		var err error
+		denominator, err = q.selectMerge(ctx, &pb.MergeProfile{
+			Query: d.denominator,
+			Start: m.GetStart(),
+			End:   m.GetEnd(),
+		}, groupByLabels, isInverted, "")
+		if err != nil {
+			return fmt.Errorf("reading denominator profile: %w", err)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return profile.Profile{}, err
+	}
+
+	return DeriveProfile(ctx, q.tracer, q.mem, d.Name, numerator, denominator)
+}
+
+// DeriveProfile combines the numerator and denominator of a derived profile
+// type into a single profile, see CombineProfiles. The values of the derived
+// profile are the ones of the denominator, its secondary values are the ones
+// of the numerator.
+func DeriveProfile(
+	ctx context.Context,
+	tracer trace.Tracer,
+	mem memory.Allocator,
+	name string,
+	numerator, denominator profile.Profile,
+) (profile.Profile, error) {
+	_, span := tracer.Start(ctx, "DeriveProfile")
+	defer span.End()
+
+	meta := denominator.Meta
+	meta.Name = name
+	return profile.Profile{
+		Meta:    meta,
+		Samples: combineSamples(mem, denominator, numerator),
+	}, nil
+}
+
+// renderDerived renders the report of a derived profile, see DeriveProfile.
+func (q *ColumnQueryAPI) renderDerived(
+	ctx context.Context,
+	p profile.Profile,
+	reportType pb.QueryRequest_ReportType,
+	nodeTrimThreshold float32,
+	filtered int64,
+	groupBy []string,
+) (*pb.QueryResponse, error) {
+	if reportType == pb.QueryRequest_REPORT_TYPE_TABLE_ARROW {
+		table, cumulative, err := GenerateDerivedTable(ctx, q.mem, q.tracer, p)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate derived table: %v", err.Error())
+		}
+		return &pb.QueryResponse{
+			Total:    cumulative,
+			Filtered: filtered,
+			Report:   &pb.QueryResponse_TableArrow{TableArrow: table},
+		}, nil
+	}
+
+	nodeTrimFraction := float32(0)
+	if nodeTrimThreshold != 0 {
+		nodeTrimFraction = nodeTrimThreshold / 100
+	}
+
+	fa, total, err := GenerateDerivedFlamegraphArrow(ctx, q.mem, q.tracer, p, groupBy, nodeTrimFraction)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate derived flamegraph: %v", err.Error())
+	}
+
+	return &pb.QueryResponse{
+		Total:    total,
+		Filtered: filtered,
+		Report:   &pb.QueryResponse_FlamegraphArrow{FlamegraphArrow: fa},
+	}, nil
+}
+
+// GenerateDerivedFlamegraphArrow generates the arrow flame graph of a derived
+// profile, see DeriveProfile. Its secondary columns are renamed to
+// cumulative_numerator and flat_numerator and the ratio columns are added.
+func GenerateDerivedFlamegraphArrow(
+	ctx context.Context,
+	mem memory.Allocator,
+	tracer trace.Tracer,
+	p profile.Profile,
+	groupBy []string,
+	trimFraction float32,
+) (*pb.FlamegraphArrow, int64, error) {
+	ctx, span := tracer.Start(ctx, "GenerateDerivedFlamegraphArrow")
+	defer span.End()
+
+	record, cumulative, height, trimmed, err := generateFlamegraphArrowRecord(ctx, mem, tracer, p, groupBy, trimFraction)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer record.Release()
+
+	derived, err := withRatioColumns(mem, record, ratioColumn{
+		numerator:     FlamegraphFieldCumulativeSecondary,
+		numeratorName: FlamegraphFieldCumulativeNumerator,
+		denominator:   FlamegraphFieldCumulative,
+		name:          FlamegraphFieldRatio,
+	}, ratioColumn{
+		numerator:     FlamegraphFieldFlatSecondary,
+		numeratorName: FlamegraphFieldFlatNumerator,
+		denominator:   FlamegraphFieldFlat,
+		name:          FlamegraphFieldFlatRatio,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer derived.Release()
+
+	fg, err := newFlamegraphArrow(span, mem, derived, p.Meta.SampleType.Unit, height, trimmed)
+	if err != nil {
+		return nil, 0, err
+	}
+	return fg, cumulative, nil
+}
+
+// GenerateDerivedTable generates the arrow table of a derived profile, see
+// DeriveProfile. Its secondary columns are renamed to cumulative_numerator and
+// flat_numerator and the ratio columns are added.
+func GenerateDerivedTable(
+	ctx context.Context,
+	mem memory.Allocator,
+	tracer trace.Tracer,
+	p profile.Profile,
+) (*pb.TableArrow, int64, error) {
+	ctx, span := tracer.Start(ctx, "GenerateDerivedTable")
+	defer span.End()
+
+	record, cumulative, err := generateTableArrowRecord(ctx, mem, tracer, p)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer record.Release()
+
+	derived, err := withRatioColumns(mem, record, ratioColumn{
+		numerator:     TableFieldCumulativeSecondary,
+		numeratorName: TableFieldCumulativeNumerator,
+		denominator:   TableFieldCumulative,
+		name:          TableFieldCumulativeRatio,
+	}, ratioColumn{
+		numerator:     TableFieldFlatSecondary,
+		numeratorName: TableFieldFlatNumerator,
+		denominator:   TableFieldFlat,
+		name:          TableFieldFlatRatio,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer derived.Release()
+
+	table, err := newTableArrow(span, mem, derived, p.Meta.SampleType.Unit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return table, cumulative, nil
+}
+
+// ratioColumn is a column holding the ratio of two integer columns.
+type ratioColumn struct {
+	numerator, numeratorName string
+	denominator              string
+	name                     string
+}
+
+// withRatioColumns returns the record with the numerator columns renamed and
+// the ratio columns appended. The ratio is null where the denominator is null
+// or zero.
+func withRatioColumns(mem memory.Allocator, rec arrow.RecordBatch, ratios ...ratioColumn) (arrow.RecordBatch, error) {
+	fields := make([]arrow.Field, 0, int(rec.NumCols())+len(ratios))
+	fields = append(fields, rec.Schema().Fields()...)
+	columns := make([]arrow.Array, 0, int(rec.NumCols())+len(ratios))
+	columns = append(columns, rec.Columns()...)
+
+	for _, r := range ratios {
+		numIndices := rec.Schema().FieldIndices(r.numerator)
+		if len(numIndices) != 1 {
+			return nil, fmt.Errorf("invalid %s indices: %v", r.numerator, numIndices)
+		}
+		denIndices := rec.Schema().FieldIndices(r.denominator)
+		if len(denIndices) != 1 {
+			return nil, fmt.Errorf("invalid %s indices: %v", r.denominator, denIndices)
+		}
+		numerator, err := intValues(rec.Column(numIndices[0]))
+		if err != nil {
+			return nil, err
+		}
+		denominator, err := intValues(rec.Column(denIndices[0]))
+		if err != nil {
+			return nil, err
+		}
+
+		b := array.NewFloat64Builder(mem)
+		b.Reserve(int(rec.NumRows()))
+		for i := 0; i < int(rec.NumRows()); i++ {
+			// Null numerators are zero.
+			num, _ := numerator(i)
+			den, ok := denominator(i)
+			if !ok || den == 0 {
+				b.AppendNull()
+				continue
+			}
+			b.Append(float64(num) / float64(den))
+		}
+		ratio := b.NewFloat64Array()
+		b.Release()
+		defer ratio.Release()
+
+		fields[numIndices[0]].Name = r.numeratorName
+		fields = append(fields, arrow.Field{Name: r.name, Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+		columns = append(columns, ratio)
+	}
+
+	return array.NewRecordBatch(arrow.NewSchema(fields, nil), columns, rec.NumRows()), nil
+}
+
+// intValues returns an accessor for the values of an integer column, which
+// reports false for nulls.
+func intValues(arr arrow.Array) (func(i int) (int64, bool), error) {
+	valid := func(i int) bool { return arr.IsValid(i) }
+	switch a := arr.(type) {
+	case *array.Int8:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Int16:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Int32:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Int64:
+		return func(i int) (int64, bool) { return a.Value(i), valid(i) }, nil
+	case *array.Uint8:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Uint16:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Uint32:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	case *array.Uint64:
+		return func(i int) (int64, bool) { return int64(a.Value(i)), valid(i) }, nil
+	default:
+		return nil, fmt.Errorf("unexpected integer column type %s", arr.DataType())
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func derivedTestProfile(t *testing.T, mem memory.Allocator) profile.Profile {
+	t.Helper()
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	computeFunction := &pprofprofile.Function{ID: 2, Name: "compute"}
+	readFunction := &pprofprofile.Function{ID: 3, Name: "read"}
+	writeFunction := &pprofprofile.Function{ID: 4, Name: "write"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	compute := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: computeFunction}}}
+	read := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: readFunction}}}
+	write := &pprofprofile.Location{ID: 4, Address: 0x4000, Line: []pprofprofile.Line{{Function: writeFunction}}}
+
+	count := profile.Meta{SampleType: profile.ValueType{Type: "cycles", Unit: "count"}}
+	instructions, err := PprofToSymbolizedProfile(count, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{compute, main}, Value: []int64{300}},
+			{Location: []*pprofprofile.Location{read, main}, Value: []int64{20}},
+			// Stacks without any cycles have no ratio.
+			{Location: []*pprofprofile.Location{write, main}, Value: []int64{40}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer instructions.Samples[0].Release()
+
+	cycles, err := PprofToSymbolizedProfile(count, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{compute, main}, Value: []int64{100}},
+			{Location: []*pprofprofile.Location{read, main}, Value: []int64{80}},
+			{Location: []*pprofprofile.Location{main}, Value: []int64{20}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer cycles.Samples[0].Release()
+
+	p, err := DeriveProfile(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		"ipc",
+		instructions,
+		cycles,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "ipc", p.Meta.Name)
+	return p
+}
+
+func derivedTestColumns(t *testing.T, mem memory.Allocator, record []byte, name, cumulative, numerator, ratio string) map[string][3]float64 {
+	t.Helper()
+
+	r, err := ipc.NewReader(bytes.NewReader(record), ipc.WithAllocator(mem))
+	require.NoError(t, err)
+	defer r.Release()
+	require.True(t, r.Next())
+	rec := r.RecordBatch()
+
+	names := rec.Column(rec.Schema().FieldIndices(name)[0]).(*array.Dictionary)
+	cumulativeValues, err := intValues(rec.Column(rec.Schema().FieldIndices(cumulative)[0]))
+	require.NoError(t, err)
+	numeratorValues, err := intValues(rec.Column(rec.Schema().FieldIndices(numerator)[0]))
+	require.NoError(t, err)
+	ratios := rec.Column(rec.Schema().FieldIndices(ratio)[0]).(*array.Float64)
+
+	res := map[string][3]float64{}
+	for i := 0; i < int(rec.NumRows()); i++ {
+		n := "root"
+		if names.IsValid(i) {
+			switch dict := names.Dictionary().(type) {
+			case *array.Binary:
+				n = string(dict.Value(names.GetValueIndex(i)))
+			case *array.String:
+				n = dict.Value(names.GetValueIndex(i))
+			}
+		}
+		c, _ := cumulativeValues(i)
+		num, _ := numeratorValues(i)
+		res[n] = [3]float64{float64(c), float64(num), ratios.Value(i)}
+	}
+	return res
+}
+
+func TestGenerateDerivedFlamegraphArrow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	p := derivedTestProfile(t, mem)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	fa, total, err := GenerateDerivedFlamegraphArrow(context.Background(), mem, tracer, p, []string{FlamegraphFieldFunctionName}, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), total)
+	require.Equal(t, "count", fa.Unit)
+
+	require.Equal(t, map[string][3]float64{
+		"root":    {200, 360, 1.8},
+		"main":    {200, 360, 1.8},
+		"compute": {100, 300, 3},
+		"read":    {80, 20, 0.25},
+		"write":   {0, 40, 0},
+	}, derivedTestColumns(t, mem, fa.Record, FlamegraphFieldFunctionName, FlamegraphFieldCumulative, FlamegraphFieldCumulativeNumerator, FlamegraphFieldRatio))
+	require.Equal(t, map[string][3]float64{
+		"root":    {0, 0, 0},
+		"main":    {20, 0, 0},
+		"compute": {100, 300, 3},
+		"read":    {80, 20, 0.25},
+		"write":   {0, 40, 0},
+	}, derivedTestColumns(t, mem, fa.Record, FlamegraphFieldFunctionName, FlamegraphFieldFlat, FlamegraphFieldFlatNumerator, FlamegraphFieldFlatRatio))
+}
+
+func TestGenerateDerivedTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	p := derivedTestProfile(t, mem)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	table, total, err := GenerateDerivedTable(context.Background(), mem, tracer, p)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), total)
+
+	require.Equal(t, map[string][3]float64{
+		"main":    {200, 360, 1.8},
+		"compute": {100, 300, 3},
+		"read":    {80, 20, 0.25},
+		"write":   {0, 40, 0},
+	}, derivedTestColumns(t, mem, table.Record, TableFieldFunctionName, TableFieldCumulative, TableFieldCumulativeNumerator, TableFieldCumulativeRatio))
+	require.Equal(t, map[string][3]float64{
+		"main":    {20, 0, 0},
+		"compute": {100, 300, 3},
+		"read":    {80, 20, 0.25},
+		"write":   {0, 40, 0},
+	}, derivedTestColumns(t, mem, table.Record, TableFieldFunctionName, TableFieldFlat, TableFieldFlatNumerator, TableFieldFlatRatio))
+}
+
+func TestDerivedQuery(t *testing.T) {
+	api := NewColumnQueryAPI(nil, noop.NewTracerProvider().Tracer(""), nil, nil, nil, nil, nil, nil, []profile.DerivedType{{
+		Name:        "ipc",
+		Numerator:   "perf:instructions:count:instructions:count",
+		Denominator: "perf:cycles:count:cycles:count",
+	}}, nil)
+
+	d, err := api.derivedQuery(`derived:ipc{job="app"}`)
+	require.NoError(t, err)
+	require.Equal(t, `perf:instructions:count:instructions:count{job="app"}`, d.numerator)
+	require.Equal(t, `perf:cycles:count:cycles:count{job="app"}`, d.denominator)
+
+	d, err = api.derivedQuery(`perf:cycles:count:cycles:count{job="app"}`)
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	_, err = api.derivedQuery(`derived:cpi{}`)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = api.Query(context.Background(), &pb.QueryRequest{
+		Mode:       pb.QueryRequest_MODE_MERGE,
+		Options:    &pb.QueryRequest_Merge{Merge: &pb.MergeProfile{Query: `derived:ipc{}`}},
+		ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,412 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/arch/arm64/arm64asm"
+	"golang.org/x/arch/x86/x86asm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/symbol/addr2line"
+	"github.com/parca-dev/parca/pkg/symbol/demangle"
+	"github.com/parca-dev/parca/pkg/symbol/elfutils"
+	"github.com/parca-dev/parca/pkg/symbolizer"
+)
+
+// disassemblyLiner resolves the source line of an instruction.
+type disassemblyLiner interface {
+	PCToLines(ctx context.Context, pc uint64) ([]profile.LocationLine, error)
+}
+
+type disassemblyValue struct {
+	flat       int64
+	cumulative int64
+	lastSample int
+}
+
+type disassembledInstruction struct {
+	address uint64
+	size    int
+	text    string
+}
+
+// renderDisassembly fetches the executable of the referenced build ID, and its
+// separate debuginfo if the executable was stripped, to generate the
+// disassembly report.
+func (q *ColumnQueryAPI) renderDisassembly(
+	ctx context.Context,
+	p profile.Profile,
+	filtered int64,
+	ref *pb.DisassemblyReference,
+) (*pb.QueryResponse, error) {
+	if ref.GetBuildId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "disassembly report requires a build ID")
+	}
+	if q.debuginfoFinder == nil {
+		return nil, status.Error(codes.FailedPrecondition, "disassembly report requires access to the uploaded debuginfo")
+	}
+
+	exeFile, err := q.debuginfoFinder.FindDebuginfo(ctx, ref.GetBuildId(), debuginfopb.DebuginfoType_DEBUGINFO_TYPE_EXECUTABLE)
+	if err != nil {
+		if errors.Is(err, ErrDebuginfoNotFound) {
+			return nil, status.Error(codes.NotFound, "no executable for this build id has been uploaded")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to fetch executable: %v", err)
+	}
+	defer exeFile.Close()
+
+	exe, err := elf.NewFile(exeFile)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to open executable: %v", err)
+	}
+
+	var dbg *elf.File
+	if !elfutils.HasDWARF(exe) {
+		// Source lines are optional, so failing to find them is not an error.
+		dbgFile, err := q.debuginfoFinder.FindDebuginfo(ctx, ref.GetBuildId(), debuginfopb.DebuginfoType_DEBUGINFO_TYPE_DEBUGINFO_UNSPECIFIED)
+		switch {
+		case err == nil:
+			defer dbgFile.Close()
+			if dbg, err = elf.NewFile(dbgFile); err != nil {
+				level.Debug(q.logger).Log("msg", "failed to open debuginfo", "build_id", ref.GetBuildId(), "err", err)
+			}
+		case !errors.Is(err, ErrDebuginfoNotFound):
+			level.Debug(q.logger).Log("msg", "failed to fetch debuginfo", "build_id", ref.GetBuildId(), "err", err)
+		}
+	}
+
+	disassembly, total, err := GenerateDisassembly(ctx, q.tracer, p, ref, exe, newDisassemblyLiner(q.logger, exe, dbg))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to generate disassembly: %v", err.Error())
+	}
+
+	return &pb.QueryResponse{
+		Total:    total,
+		Filtered: filtered,
+		Report:   &pb.QueryResponse_Disassembly{Disassembly: disassembly},
+	}, nil
+}
+
+// GenerateDisassembly disassembles the function of the executable containing
+// the referenced address and annotates each instruction with the values of
+// the samples at its address. The address is resolved using the mappings of
+// the build ID in the profile, it doesn't need to be sampled itself. The source lines of the instructions are
+// resolved using the liner, which may be nil if no debug information is
+// available.
+func GenerateDisassembly(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	ref *pb.DisassemblyReference,
+	exe *elf.File,
+	liner disassemblyLiner,
+) (*pb.Disassembly, int64, error) {
+	ctx, span := tracer.Start(ctx, "GenerateDisassembly")
+	defer span.End()
+
+	ei, err := profile.ExecutableInfoFromELF(exe)
+	if err != nil {
+		return nil, 0, fmt.Errorf("executable info from ELF: %w", err)
+	}
+
+	var (
+		total   int64
+		sample  int
+		target  uint64
+		found   bool
+		buildID = []byte(ref.GetBuildId())
+		// values are keyed by the addresses within the executable.
+		values = map[uint64]*disassemblyValue{}
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			value := r.Value.Value(i)
+			total += value
+			if r.Locations.IsNull(i) {
+				continue
+			}
+			// Samples are numbered from 1, so the zero value of lastSample
+			// never matches.
+			sample++
+
+			isLeaf := true
+			beg, end := r.Locations.ValueOffsets(i)
+			for j := int(beg); j < int(end); j++ {
+				if r.Locations.ListValues().IsNull(j) {
+					continue // The location has been filtered out.
+				}
+				leaf := isLeaf
+				isLeaf = false
+
+				if !r.MappingBuildIDIndices.IsValid(j) ||
+					!bytes.Equal(r.MappingBuildIDDict.Value(int(r.MappingBuildIDIndices.Value(j))), buildID) {
+					continue
+				}
+
+				m := profile.Mapping{
+					StartAddr: r.MappingStart.Value(j),
+					EndAddr:   r.MappingLimit.Value(j),
+					Offset:    r.MappingOffset.Value(j),
+				}
+				if !found && ref.GetAddress() >= m.StartAddr && ref.GetAddress() < m.EndAddr {
+					// The referenced address doesn't need to be sampled, it
+					// only needs to be mapped to be resolved to its function.
+					if addr, err := symbolizer.NormalizeAddress(ref.GetAddress(), ei, m); err == nil {
+						target, found = addr, true
+					}
+				}
+
+				addr, err := symbolizer.NormalizeAddress(r.Address.Value(j), ei, m)
+				if err != nil {
+					continue
+				}
+
+				v, ok := values[addr]
+				if !ok {
+					v = &disassemblyValue{}
+					values[addr] = v
+				}
+				if leaf {
+					v.flat += value
+				}
+				// Recursive stacks only count once towards the cumulative
+				// value.
+				if v.lastSample != sample {
+					v.lastSample = sample
+					v.cumulative += value
+				}
+			}
+		}
+	}
+
+	if !found {
+		return nil, 0, status.Errorf(codes.NotFound, "address 0x%x is not mapped by build ID %q in the profile", ref.GetAddress(), ref.GetBuildId())
+	}
+
+	symbols := elfFunctionSymbols(exe)
+	fn, ok := symbols.find(target)
+	if !ok {
+		return nil, 0, status.Errorf(codes.NotFound, "no function found for address 0x%x", target)
+	}
+
+	code, err := elfFunctionCode(exe, fn)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	instructions, err := disassemble(exe.Machine, code, fn.Value, symbols.lookup)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := &pb.Disassembly{
+		Function:     symbols.name(fn),
+		Start:        fn.Value,
+		End:          fn.Value + fn.Size,
+		Instructions: make([]*pb.DisassemblyInstruction, 0, len(instructions)),
+		Unit:         p.Meta.SampleType.Unit,
+	}
+	for _, inst := range instructions {
+		in := &pb.DisassemblyInstruction{
+			Address: inst.address,
+			Text:    inst.text,
+		}
+		if liner != nil {
+			if lines, err := liner.PCToLines(ctx, inst.address); err == nil && len(lines) > 0 {
+				in.Filename = lines[0].Function.GetFilename()
+				in.Line = lines[0].Line
+			}
+		}
+		res.Instructions = append(res.Instructions, in)
+	}
+
+	for addr, v := range values {
+		if addr < res.Start || addr >= res.End {
+			continue
+		}
+		// Find the instruction containing the address.
+		i := sort.Search(len(instructions), func(i int) bool {
+			return instructions[i].address > addr
+		}) - 1
+		if i < 0 {
+			continue
+		}
+		res.Instructions[i].Flat += v.flat
+		res.Instructions[i].Cumulative += v.cumulative
+	}
+
+	return res, total, nil
+}
+
+// disassemble decodes the machine code of the given architecture, pc is the
+// address of the first instruction.
+func disassemble(
+	machine elf.Machine,
+	code []byte,
+	pc uint64,
+	lookup func(uint64) (string, uint64),
+) ([]disassembledInstruction, error) {
+	var res []disassembledInstruction
+	switch machine {
+	case elf.EM_X86_64:
+		for len(code) > 0 {
+			inst := disassembledInstruction{address: pc, size: 1, text: "(bad)"}
+			if in, err := x86asm.Decode(code, 64); err == nil && in.Len > 0 {
+				inst.size = in.Len
+				inst.text = x86asm.GNUSyntax(in, pc, lookup)
+			}
+			res = append(res, inst)
+			code = code[inst.size:]
+			pc += uint64(inst.size)
+		}
+	case elf.EM_AARCH64:
+		// Instructions are of fixed size, trailing bytes are padding.
+		for len(code) >= 4 {
+			inst := disassembledInstruction{address: pc, size: 4, text: "(bad)"}
+			if in, err := arm64asm.Decode(code); err == nil {
+				inst.text = strings.TrimSpace(arm64asm.GNUSyntax(in))
+			}
+			res = append(res, inst)
+			code = code[inst.size:]
+			pc += uint64(inst.size)
+		}
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "disassembling %s executables is not supported", machine)
+	}
+	return res, nil
+}
+
+// elfSymbols are the function symbols of an executable sorted by address.
+type elfSymbols struct {
+	symbols   []elf.Symbol
+	demangler *demangle.Demangler
+}
+
+func elfFunctionSymbols(f *elf.File) elfSymbols {
+	// Either of the symbol tables may be missing, so errors are ignored.
+	syms, _ := f.Symbols()
+	dynSyms, _ := f.DynamicSymbols()
+
+	res := elfSymbols{
+		symbols:   make([]elf.Symbol, 0, len(syms)+len(dynSyms)),
+		demangler: demangle.NewDemangler("simple", false),
+	}
+	for _, s := range append(syms, dynSyms...) {
+		if elf.ST_TYPE(s.Info) == elf.STT_FUNC && s.Value != 0 && s.Size != 0 {
+			res.symbols = append(res.symbols, s)
+		}
+	}
+	sort.Slice(res.symbols, func(i, j int) bool {
+		return res.symbols[i].Value < res.symbols[j].Value
+	})
+	return res
+}
+
+// find returns the function symbol containing the address.
+func (s elfSymbols) find(addr uint64) (elf.Symbol, bool) {
+	i := sort.Search(len(s.symbols), func(i int) bool {
+		return s.symbols[i].Value > addr
+	})
+	if i == 0 {
+		return elf.Symbol{}, false
+	}
+	// The closest symbol starting at or before the address.
+	sym := s.symbols[i-1]
+	if addr >= sym.Value+sym.Size {
+		return elf.Symbol{}, false
+	}
+	return sym, true
+}
+
+// lookup resolves addresses to symbols for the operands of instructions.
+func (s elfSymbols) lookup(addr uint64) (string, uint64) {
+	sym, ok := s.find(addr)
+	if !ok {
+		return "", 0
+	}
+	return s.name(sym), sym.Value
+}
+
+func (s elfSymbols) name(sym elf.Symbol) string {
+	return s.demangler.Demangle(&metastorev1alpha1.Function{SystemName: sym.Name}).Name
+}
+
+// elfFunctionCode reads the machine code of the function symbol.
+func elfFunctionCode(f *elf.File, sym elf.Symbol) ([]byte, error) {
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_PROGBITS || s.Flags&elf.SHF_EXECINSTR == 0 {
+			continue
+		}
+		if sym.Value < s.Addr || sym.Value+sym.Size > s.Addr+s.Size {
+			continue
+		}
+
+		code := make([]byte, sym.Size)
+		if _, err := s.ReadAt(code, int64(sym.Value-s.Addr)); err != nil {
+			return nil, fmt.Errorf("read section %s: %w", s.Name, err)
+		}
+		return code, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "no code found for function %s", sym.Name)
+}
+
+// newDisassemblyLiner returns the most precise liner available for the
+// executable. The separate debuginfo file, which may be nil, is only used if
+// the executable itself has no DWARF data. Nil is returned if neither of them
+// contain line information.
+func newDisassemblyLiner(logger log.Logger, exe, dbg *elf.File) disassemblyLiner {
+	var (
+		liner disassemblyLiner
+		err   error
+	)
+	switch {
+	case elfutils.HasDWARF(exe):
+		liner, err = addr2line.LineTable(logger, "", exe)
+	case dbg != nil && elfutils.HasDWARF(dbg):
+		liner, err = addr2line.LineTable(logger, "", dbg)
+	case elfutils.HasGoPclntab(exe):
+		liner, err = addr2line.Go(logger, "", exe)
+	default:
+		return nil
+	}
+	if err != nil {
+		level.Debug(logger).Log("msg", "failed to create liner for disassembly", "err", err)
+		return nil
+	}
+	return liner
+}
@@ -0,0 +1,180 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"debug/elf"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// disassemblyTestRecord writes unsymbolized stacks of the addresses (leaf
+// first), mapped like the text segment of the test binary.
+func disassemblyTestRecord(mem memory.Allocator, stacks [][]uint64, values []int64) arrow.RecordBatch {
+	w := profile.NewWriter(mem, nil)
+	defer w.Release()
+
+	for i, stack := range stacks {
+		w.LocationsList.Append(true)
+		for _, addr := range stack {
+			w.Locations.Append(true)
+			w.Addresses.Append(addr)
+			w.MappingStart.Append(0x401000)
+			w.MappingLimit.Append(0x402000)
+			w.MappingOffset.Append(0x1000)
+			w.MappingFile.Append([]byte("/usr/bin/basic-cpp"))
+			w.MappingBuildID.Append([]byte("abc"))
+			w.Lines.AppendNull()
+		}
+		w.Value.Append(values[i])
+		w.Diff.Append(0)
+		w.TimeNanos.Append(1)
+		w.Period.Append(1)
+	}
+
+	return w.RecordBuilder.NewRecordBatch()
+}
+
+func TestGenerateDisassembly(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	exe, err := elf.Open("../symbol/addr2line/testdata/basic-cpp-no-fp-with-debuginfo")
+	require.NoError(t, err)
+	t.Cleanup(func() { exe.Close() })
+
+	rec := disassemblyTestRecord(mem, [][]uint64{
+		{0x401110, 0x401200},
+		{0x401110, 0x401200},
+		{0x40111f, 0x401200},
+		// Addresses outside of the function are ignored, callers only count
+		// towards the cumulative value.
+		{0x401300, 0x401110, 0x401200},
+	}, []int64{3, 2, 4, 1})
+	defer rec.Release()
+
+	res, total, err := GenerateDisassembly(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		profile.Profile{
+			Meta:    profile.Meta{SampleType: profile.ValueType{Type: "samples", Unit: "count"}},
+			Samples: []arrow.RecordBatch{rec},
+		},
+		&pb.DisassemblyReference{BuildId: "abc", Address: 0x40111f},
+		exe,
+		newDisassemblyLiner(log.NewNopLogger(), exe, nil),
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), total)
+	require.Equal(t, "top1", res.Function)
+	require.Equal(t, uint64(0x401106), res.Start)
+	require.Equal(t, uint64(0x401125), res.End)
+	require.Equal(t, "count", res.Unit)
+
+	type instruction struct {
+		address    uint64
+		line       int64
+		flat       int64
+		cumulative int64
+	}
+	instructions := make([]instruction, 0, len(res.Instructions))
+	for _, in := range res.Instructions {
+		require.NotEmpty(t, in.Text)
+		require.Equal(t, "src/basic-cpp.cpp", in.Filename)
+		instructions = append(instructions, instruction{in.Address, in.Line, in.Flat, in.Cumulative})
+	}
+	require.Equal(t, []instruction{
+		{address: 0x401106, line: 2},
+		{address: 0x40110e, line: 2},
+		{address: 0x401110, line: 2, flat: 5, cumulative: 6},
+		{address: 0x401115, line: 2},
+		{address: 0x40111d, line: 2},
+		{address: 0x40111f, line: 5, flat: 4, cumulative: 4},
+		{address: 0x401124, line: 6},
+	}, instructions)
+	require.Equal(t, "retq", res.Instructions[6].Text)
+
+	// Any address within the function resolves to it, whether it was
+	// sampled or not.
+	for _, address := range []uint64{0x401106, 0x401115, 0x401124} {
+		res, _, err := GenerateDisassembly(
+			context.Background(),
+			noop.NewTracerProvider().Tracer(""),
+			profile.Profile{Samples: []arrow.RecordBatch{rec}},
+			&pb.DisassemblyReference{BuildId: "abc", Address: address},
+			exe,
+			nil,
+		)
+		require.NoError(t, err)
+		require.Equal(t, "top1", res.Function)
+		require.Len(t, res.Instructions, 7)
+		require.Equal(t, int64(5), res.Instructions[2].Flat)
+	}
+}
+
+func TestGenerateDisassemblyAddressNotFound(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	exe, err := elf.Open("../symbol/addr2line/testdata/basic-cpp-no-fp-with-debuginfo")
+	require.NoError(t, err)
+	t.Cleanup(func() { exe.Close() })
+
+	rec := disassemblyTestRecord(mem, [][]uint64{{0x401110}}, []int64{1})
+	defer rec.Release()
+
+	for _, ref := range []*pb.DisassemblyReference{
+		// Outside of the mapping.
+		{BuildId: "abc", Address: 0x403000},
+		// Not mapped by this build ID.
+		{BuildId: "def", Address: 0x401110},
+	} {
+		_, _, err = GenerateDisassembly(
+			context.Background(),
+			noop.NewTracerProvider().Tracer(""),
+			profile.Profile{Samples: []arrow.RecordBatch{rec}},
+			ref,
+			exe,
+			nil,
+		)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	}
+}
+
+func TestDisassembleARM64(t *testing.T) {
+	instructions, err := disassemble(elf.EM_AARCH64, []byte{
+		0x1f, 0x20, 0x03, 0xd5, // nop
+		0xc0, 0x03, 0x5f, 0xd6, // ret
+		0x00, 0x00, // padding
+	}, 0x1000, nil)
+	require.NoError(t, err)
+	require.Equal(t, []disassembledInstruction{
+		{address: 0x1000, size: 4, text: "nop"},
+		{address: 0x1004, size: 4, text: "ret"},
+	}, instructions)
+
+	_, err = disassemble(elf.EM_RISCV, []byte{0x13, 0x00, 0x00, 0x00}, 0x1000, nil)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
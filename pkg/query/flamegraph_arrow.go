@@ -62,6 +62,12 @@ const (
 	FlamegraphFieldFlat       = "flat"
 	FlamegraphFieldDiff       = "diff"
 
+	// FlamegraphFieldCumulativeSecondary and FlamegraphFieldFlatSecondary
+	// hold the values of the secondary profile type of combined profiles,
+	// see CombineProfiles.
+	FlamegraphFieldCumulativeSecondary = "cumulative_secondary"
+	FlamegraphFieldFlatSecondary       = "flat_secondary"
+
 	FlamegraphFieldTimestamp   = "timestamp"
 	FlamegraphFieldDepth       = "depth"
 	FlamegraphFieldValueOffset = "value_offset"
@@ -74,6 +80,7 @@ func GenerateFlamegraphArrow(
 	p profile.Profile,
 	groupBy []string,
 	trimFraction float32,
+	significance DiffSignificance,
 ) (*queryv1alpha1.FlamegraphArrow, int64, error) {
 	ctx, span := tracer.Start(ctx, "GenerateFlamegraphArrow")
 	defer span.End()
@@ -84,6 +91,31 @@ func GenerateFlamegraphArrow(
 	}
 	defer record.Release()
 
+	if significance != nil {
+		withPValues, err := withSignificanceColumn(mem, record, FlamegraphFieldFunctionName, FlamegraphFieldFunctionFileName, FlamegraphFieldPValue, significance)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer withPValues.Release()
+		record = withPValues
+	}
+
+	fg, err := newFlamegraphArrow(span, mem, record, p.Meta.SampleType.Unit, height, trimmed)
+	if err != nil {
+		return nil, 0, err
+	}
+	return fg, cumulative, nil
+}
+
+// newFlamegraphArrow serializes the flame graph record.
+func newFlamegraphArrow(
+	span trace.Span,
+	mem memory.Allocator,
+	record arrow.RecordBatch,
+	unit string,
+	height int32,
+	trimmed int64,
+) (*queryv1alpha1.FlamegraphArrow, error) {
 	// TODO: Reuse buffer and potentially writers
 	var buf bytes.Buffer
 	w := ipc.NewWriter(&buf,
@@ -93,8 +125,8 @@ func GenerateFlamegraphArrow(
 	)
 	defer w.Close()
 
-	if err = w.Write(record); err != nil {
-		return nil, 0, err
+	if err := w.Write(record); err != nil {
+		return nil, err
 	}
 
 	span.SetAttributes(attribute.Int("record_size", buf.Len()))
@@ -104,10 +136,10 @@ func GenerateFlamegraphArrow(
 
 	return &queryv1alpha1.FlamegraphArrow{
 		Record:  buf.Bytes(),
-		Unit:    p.Meta.SampleType.Unit,
+		Unit:    unit,
 		Height:  height, // add one for the root
 		Trimmed: trimmed,
-	}, cumulative, nil
+	}, nil
 }
 
 func generateFlamegraphArrowRecord(ctx context.Context, mem memory.Allocator, tracer trace.Tracer, p profile.Profile, groupBy []string, trimFraction float32) (arrow.RecordBatch, int64, int32, int64, error) {
@@ -119,7 +151,7 @@ func generateFlamegraphArrowRecord(ctx context.Context, mem memory.Allocator, tr
 		totalRows += r.NumRows()
 	}
 
-	fb, err := newFlamegraphBuilder(mem, totalRows, groupBy)
+	fb, err := newFlamegraphBuilder(mem, totalRows, groupBy, hasSecondaryValues(p))
 	if err != nil {
 		return nil, 0, 0, 0, fmt.Errorf("create flamegraph builder: %w", err)
 	}
@@ -136,6 +168,9 @@ func generateFlamegraphArrowRecord(ctx context.Context, mem memory.Allocator, tr
 	for _, r := range profileReader.RecordReaders {
 		fb.cumulative += math.Int64.Sum(r.Value)
 		fb.diff += math.Int64.Sum(r.Diff)
+		if r.ValueSecondary != nil {
+			fb.cumulativeSecondary += math.Int64.Sum(r.ValueSecondary)
+		}
 
 		if err := fb.ensureLabelColumns(r.LabelFields); err != nil {
 			return nil, 0, 0, 0, fmt.Errorf("ensure label columns: %w", err)
@@ -372,6 +407,12 @@ func generateFlamegraphArrowRecord(ctx context.Context, mem memory.Allocator, tr
 		fb.trimmedFlat.AppendNull()
 		fb.trimmedDiff = array.NewUint8Builder(fb.pool)
 		fb.trimmedDiff.AppendNull()
+		if fb.secondary {
+			fb.trimmedCumulativeSecondary = array.NewUint8Builder(fb.pool)
+			fb.trimmedCumulativeSecondary.AppendNull()
+			fb.trimmedFlatSecondary = array.NewUint8Builder(fb.pool)
+			fb.trimmedFlatSecondary.AppendNull()
+		}
 		fb.trimmedTimestamp = builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64)
 		fb.trimmedTimestamp.AppendNull()
 		fb.trimmedDepth = array.NewUint8Builder(fb.pool)
@@ -773,6 +814,7 @@ func (fb *flamegraphBuilder) mergeUnsymbolizedRows(
 		if leaf {
 			fb.builderFlat.Add(cr, value)
 		}
+		fb.addSecondaryValues(r, cr, sampleIndex, leaf)
 
 		fb.parent.Set(cr)
 		fb.compareRows = fb.children[cr]
@@ -858,6 +900,11 @@ type flamegraphBuilder struct {
 	cumulative int64
 	// This keeps track of the total diff values so that we can set the first row's diff value at the end.
 	diff int64
+	// secondary is true if the samples carry the values of a secondary profile type, whose cumulative and flat
+	// values are kept in their own columns.
+	secondary bool
+	// This keeps track of the total cumulative value of the secondary profile type.
+	cumulativeSecondary int64
 	// This keeps track of the max height of the flame graph.
 	maxHeight int32
 	// trimmed keeps track of the values that were trimmed from the flame graph.
@@ -904,6 +951,8 @@ type flamegraphBuilder struct {
 	builderCumulative                    *builder.OptInt64Builder
 	builderFlat                          *builder.OptInt64Builder
 	builderDiff                          *builder.OptInt64Builder
+	builderCumulativeSecondary           *builder.OptInt64Builder
+	builderFlatSecondary                 *builder.OptInt64Builder
 	builderTimestamp                     *builder.OptInt64Builder
 	builderDepth                         *array.Uint32Builder
 
@@ -931,6 +980,9 @@ type flamegraphBuilder struct {
 	trimmedFlat              array.Builder
 	trimmedDiff              array.Builder
 
+	trimmedCumulativeSecondary array.Builder
+	trimmedFlatSecondary       array.Builder
+
 	trimmedTimestamp *builder.OptInt64Builder
 	trimmedDepth     array.Builder
 	trimmedParent    *array.Int32Builder
@@ -956,6 +1008,7 @@ func newFlamegraphBuilder(
 	pool memory.Allocator,
 	rows int64,
 	groupBy []string,
+	secondary bool,
 ) (*flamegraphBuilder, error) {
 	builderChildren := builder.NewListBuilder(pool, arrow.PrimitiveTypes.Uint32)
 
@@ -1001,6 +1054,12 @@ func newFlamegraphBuilder(
 		builderDiff:           builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64),
 	}
 
+	if secondary {
+		fb.secondary = true
+		fb.builderCumulativeSecondary = builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64)
+		fb.builderFlatSecondary = builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64)
+	}
+
 	fb.aggregationConfig = aggregationConfig{aggregateByLabels: map[string]struct{}{}}
 
 	for _, f := range groupBy {
@@ -1046,6 +1105,10 @@ func newFlamegraphBuilder(
 	fb.builderDiff.Append(0)
 	// the root will never have a flat value
 	fb.builderFlat.Append(0)
+	if fb.secondary {
+		fb.builderCumulativeSecondary.Append(0)
+		fb.builderFlatSecondary.Append(0)
+	}
 
 	fb.builderTimestamp.Append(0)
 	fb.builderDepth.Append(0)
@@ -1070,6 +1133,9 @@ func (fb *flamegraphBuilder) prepareNewRecord() error {
 	// We don't care about a global flat value, therefore it's omitted here.
 	fb.builderCumulative.Set(0, fb.cumulative)
 	fb.builderDiff.Set(0, fb.diff)
+	if fb.secondary {
+		fb.builderCumulativeSecondary.Set(0, fb.cumulativeSecondary)
+	}
 
 	// We want to unify the dictionaries after having created the flame graph now.
 	// They are going to be trimmed and compacted in the next step.
@@ -1189,6 +1255,12 @@ func (fb *flamegraphBuilder) NewRecord() (arrow.RecordBatch, error) {
 		{Name: FlamegraphFieldDepth, Type: fb.trimmedDepth.Type()},
 		{Name: FlamegraphFieldValueOffset, Type: fb.valueOffset.Type()},
 	}
+	if fb.secondary {
+		fields = append(fields,
+			arrow.Field{Name: FlamegraphFieldCumulativeSecondary, Type: fb.trimmedCumulativeSecondary.Type()},
+			arrow.Field{Name: FlamegraphFieldFlatSecondary, Type: fb.trimmedFlatSecondary.Type()},
+		)
+	}
 
 	numCols := len(fields)
 
@@ -1255,6 +1327,12 @@ func (fb *flamegraphBuilder) NewRecord() (arrow.RecordBatch, error) {
 	cleanupArrs = append(cleanupArrs, arrays[16])
 	arrays[17] = fb.valueOffset.NewArray()
 	cleanupArrs = append(cleanupArrs, arrays[17])
+	if fb.secondary {
+		arrays[18] = fb.trimmedCumulativeSecondary.NewArray()
+		cleanupArrs = append(cleanupArrs, arrays[18])
+		arrays[19] = fb.trimmedFlatSecondary.NewArray()
+		cleanupArrs = append(cleanupArrs, arrays[19])
+	}
 
 	for i, field := range fb.builderLabelFields {
 		field.Type = fb.labels[i].DataType() // overwrite for variable length uint types
@@ -1298,6 +1376,10 @@ func (fb *flamegraphBuilder) Release() {
 	fb.builderCumulative.Release()
 	fb.builderFlat.Release()
 	fb.builderDiff.Release()
+	if fb.secondary {
+		fb.builderCumulativeSecondary.Release()
+		fb.builderFlatSecondary.Release()
+	}
 
 	if fb.trimmedLocationLine != nil {
 		fb.trimmedLocationLine.Release()
@@ -1319,6 +1401,14 @@ func (fb *flamegraphBuilder) Release() {
 		fb.trimmedDiff.Release()
 	}
 
+	if fb.trimmedCumulativeSecondary != nil {
+		fb.trimmedCumulativeSecondary.Release()
+	}
+
+	if fb.trimmedFlatSecondary != nil {
+		fb.trimmedFlatSecondary.Release()
+	}
+
 	if fb.trimmedDepth != nil {
 		fb.trimmedDepth.Release()
 	}
@@ -1507,6 +1597,12 @@ func (fb *flamegraphBuilder) appendRow(
 		fb.builderDiff.AppendNull()
 	}
 
+	if fb.secondary {
+		fb.builderCumulativeSecondary.Append(0)
+		fb.builderFlatSecondary.Append(0)
+		fb.addSecondaryValues(r, row, sampleRow, leaf)
+	}
+
 	return nil
 }
 
@@ -1583,6 +1679,10 @@ func (fb *flamegraphBuilder) AppendLabelRow(
 	fb.builderCumulative.Append(0)
 	fb.builderDiff.Append(0)
 	fb.builderFlat.Append(0)
+	if fb.secondary {
+		fb.builderCumulativeSecondary.Append(0)
+		fb.builderFlatSecondary.Append(0)
+	}
 	fb.addRowValues(r, row, sampleRow, leaf)
 
 	return nil
@@ -1598,6 +1698,41 @@ func (fb *flamegraphBuilder) addRowValues(r *profile.RecordReader, row, sampleRo
 	if leaf {
 		fb.builderFlat.Add(row, value)
 	}
+	fb.addSecondaryValues(r, row, sampleRow, leaf)
+}
+
+// addSecondaryValues adds the value of the secondary profile type to the
+// existing row, if the flame graph has secondary values.
+func (fb *flamegraphBuilder) addSecondaryValues(r *profile.RecordReader, row, sampleRow int, leaf bool) {
+	if !fb.secondary || r.ValueSecondary == nil {
+		return
+	}
+	value := r.ValueSecondary.Value(sampleRow)
+	fb.builderCumulativeSecondary.Add(row, value)
+	if leaf {
+		fb.builderFlatSecondary.Add(row, value)
+	}
+}
+
+// keepRow returns whether the child row is above the threshold of its parent
+// row. Rows of combined profiles are kept if either of their values is.
+func (fb *flamegraphBuilder) keepRow(parent, child int, threshold float32) bool {
+	if fb.builderCumulative.Value(child) > int64(float32(fb.builderCumulative.Value(parent))*threshold) {
+		return true
+	}
+	return fb.secondary &&
+		fb.builderCumulativeSecondary.Value(child) > int64(float32(fb.builderCumulativeSecondary.Value(parent))*threshold)
+}
+
+// hasSecondaryValues returns whether the samples of the profile carry the
+// values of a secondary profile type, see CombineProfiles.
+func hasSecondaryValues(p profile.Profile) bool {
+	for _, r := range p.Samples {
+		if len(r.Schema().FieldIndices(profile.ColumnValueSecondary)) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 type childBuf struct {
@@ -1664,6 +1799,7 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 	largestFlatValue := uint64(0)
 	largestDiffValue := int64(0)
 	smallestDiffValue := int64(0)
+	largestFlatSecondaryValue := uint64(0)
 	largestDepth := uint64(0)
 	b := newChildBuf()
 	for trimmingQueue.len() > 0 {
@@ -1687,17 +1823,18 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 		diff := fb.builderDiff.Value(te.row)
 		largestDiffValue = max(largestDiffValue, diff)
 		smallestDiffValue = max(smallestDiffValue, diff)
+		if fb.secondary {
+			largestFlatSecondaryValue = max(largestFlatSecondaryValue, uint64(fb.builderFlatSecondary.Value(te.row)))
+		}
 		largestDepth = max(largestDepth, uint64(fb.builderDepth.Value(te.row)))
 
-		cumThreshold := float32(cum) * threshold
-
 		b.reset()
 		b.reserve(len(fb.childrenList[te.row]))
 		for _, cr := range fb.childrenList[te.row] {
-			if v := fb.builderCumulative.Value(cr); v > int64(cumThreshold) {
+			if fb.keepRow(te.row, cr, threshold) {
 				// this row is above the threshold, so we need to keep it
 				// add this row to the queue to check its children.
-				b.append(v, cr)
+				b.append(fb.builderCumulative.Value(cr), cr)
 			}
 		}
 		sort.Sort(b)
@@ -1724,6 +1861,16 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 	trimmedFlat := array.NewBuilder(fb.pool, trimmedFlatType)
 	trimmedDiffType := smallestSignedTypeFor(smallestDiffValue, largestDiffValue)
 	trimmedDiff := array.NewBuilder(fb.pool, trimmedDiffType)
+	var (
+		trimmedCumulativeSecondary *builder.OptInt64Builder
+		trimmedFlatSecondary       array.Builder
+	)
+	if fb.secondary {
+		trimmedCumulativeSecondary = builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64)
+		trimmedFlatSecondary = array.NewBuilder(fb.pool, smallestUnsignedTypeFor(largestFlatSecondaryValue))
+		trimmedCumulativeSecondary.Reserve(row)
+		trimmedFlatSecondary.Reserve(row)
+	}
 
 	trimmedTimestamps := builder.NewOptInt64Builder(arrow.PrimitiveTypes.Int64)
 	trimmedDepthType := smallestUnsignedTypeFor(largestDepth)
@@ -1779,6 +1926,7 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 	trimmingQueue.push(trimmingElement{row: 0})
 
 	rootCumulativeTrimmedValue := int64(0)
+	rootCumulativeSecondaryTrimmedValue := int64(0)
 	// keep processing new elements until the queue is empty
 	for trimmingQueue.len() > 0 {
 		// pop the first item from the queue
@@ -1830,6 +1978,11 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 			panic(fmt.Errorf("unsupported type %T", b))
 		}
 
+		if fb.secondary {
+			trimmedCumulativeSecondary.Append(fb.builderCumulativeSecondary.Value(te.row))
+			copyInt64BuilderValueToUnknownUnsigned(fb.builderFlatSecondary, trimmedFlatSecondary, te.row)
+		}
+
 		switch b := trimmedDepth.(type) {
 		case *array.Uint64Builder:
 			b.Append(uint64(fb.builderDepth.Value(te.row)))
@@ -1878,14 +2031,15 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 			// Only accumulate direct children of root (parent row 0) for root's cumulative value
 			if te.parent == 0 {
 				rootCumulativeTrimmedValue += cum
+				if fb.secondary {
+					rootCumulativeSecondaryTrimmedValue += fb.builderCumulativeSecondary.Value(te.row)
+				}
 			}
 		}
 
-		cumThreshold := float32(cum) * threshold
-
 		valueOffset := te.valueOffset
 		for _, cr := range fb.childrenList[te.row] {
-			if v := fb.builderCumulative.Value(cr); v > int64(cumThreshold) {
+			if v := fb.builderCumulative.Value(cr); fb.keepRow(te.row, cr, threshold) {
 				// this row is above the threshold, so we need to keep it
 				// add this row to the queue to check its children.
 				trimmingQueue.push(trimmingElement{
@@ -1996,6 +2150,16 @@ func (fb *flamegraphBuilder) trim(ctx context.Context, tracer trace.Tracer, thre
 
 	trimmedCumulative.Release()
 
+	if fb.secondary {
+		trimmedCumulativeSecondary.Set(0, rootCumulativeSecondaryTrimmedValue)
+		fb.trimmedCumulativeSecondary = array.NewBuilder(fb.pool, smallestUnsignedTypeFor(uint64(rootCumulativeSecondaryTrimmedValue)))
+		for i := 0; i < trimmedCumulativeSecondary.Len(); i++ {
+			copyInt64BuilderValueToUnknownUnsigned(trimmedCumulativeSecondary, fb.trimmedCumulativeSecondary, i)
+		}
+		trimmedCumulativeSecondary.Release()
+		fb.trimmedFlatSecondary = trimmedFlatSecondary
+	}
+
 	release(
 		fb.builderLabelsOnly,
 		fb.builderLabelsExist,
@@ -1093,6 +1093,7 @@ func BenchmarkArrowFlamegraph(b *testing.B) {
 			np,
 			nil,
 			0,
+			nil,
 		)
 		require.NoError(b, err)
 	}
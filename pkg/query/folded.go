@@ -0,0 +1,196 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+type foldedValue struct {
+	value int64
+	// base and compare are only used for diff reports.
+	base    int64
+	compare int64
+}
+
+// GenerateFoldedStacks generates the folded stacks representation of the
+// profile, as consumed by flamegraph.pl and inferno. Identical stacks are
+// merged and the lines are sorted by stack.
+//
+// For diff profiles each line contains the base and the compare value, which
+// is the format expected by difffolded.pl.
+func GenerateFoldedStacks(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	isDiff bool,
+) ([]byte, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateFoldedStacks")
+	defer span.End()
+
+	stacks := map[string]*foldedValue{}
+	total := int64(0)
+
+	var (
+		frames []stackFrame
+		stack  []byte
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+		isBase := isDiffBaseRecord(rec)
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			if r.Locations.IsNull(i) {
+				continue
+			}
+
+			frames = appendStack(frames[:0], r, i)
+
+			// All frames of the stack have been filtered out.
+			if len(frames) == 0 {
+				continue
+			}
+
+			stack = stack[:0]
+			for k, frame := range frames {
+				if k > 0 {
+					stack = append(stack, ';')
+				}
+				if frame.unsymbolized && len(frame.file) > 0 {
+					stack = append(stack, '[')
+					stack = append(stack, filepath.Base(string(frame.file))...)
+					stack = append(stack, "] "...)
+				}
+				stack = append(stack, frame.name...)
+			}
+
+			v, ok := stacks[unsafeString(stack)]
+			if !ok {
+				v = &foldedValue{}
+				stacks[string(stack)] = v
+			}
+
+			value := r.Value.Value(i)
+			v.value += value
+			total += value
+			if isDiff {
+				// The samples of the base profile have the negated base
+				// value as diff, the samples of the compare profile the
+				// compare value.
+				if isBase {
+					v.base -= r.Diff.Value(i)
+				} else {
+					v.compare += r.Diff.Value(i)
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(stacks))
+	for k := range stacks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(nil)
+	for _, k := range keys {
+		v := stacks[k]
+		buf.WriteString(k)
+		buf.WriteByte(' ')
+		if isDiff {
+			buf.WriteString(strconv.FormatInt(v.base, 10))
+			buf.WriteByte(' ')
+			buf.WriteString(strconv.FormatInt(v.compare, 10))
+		} else {
+			buf.WriteString(strconv.FormatInt(v.value, 10))
+		}
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), total, nil
+}
+
+// stackFrame is a single frame of a stack, inlined functions each have their
+// own frame.
+type stackFrame struct {
+	name         []byte
+	file         []byte
+	line         int64
+	unsymbolized bool
+}
+
+// appendStackFrames appends the frames of the location at index j, from the
+// outermost to the innermost inlined function. Unsymbolized locations are
+// named by their address and use the mapping file as file.
+func appendStackFrames(frames []stackFrame, r *profile.RecordReader, j int) []stackFrame {
+	if r.Locations.ListValues().IsNull(j) {
+		return frames // The location has been filtered out.
+	}
+
+	lOffsetStart, lOffsetEnd := r.Lines.ValueOffsets(j)
+	if !r.Lines.IsValid(j) || lOffsetEnd-lOffsetStart <= 0 {
+		// The location is not symbolized, so we work with the address.
+		var mappingFile []byte
+		if r.MappingFileIndices.IsValid(j) {
+			mappingFile = r.MappingFileDict.Value(int(r.MappingFileIndices.Value(j)))
+		}
+		return append(frames, stackFrame{
+			name:         []byte(fmt.Sprintf("0x%x", r.Address.Value(j))),
+			file:         mappingFile,
+			unsymbolized: true,
+		})
+	}
+
+	// Just like locations, pprof stores lines in reverse order.
+	for k := int(lOffsetEnd - 1); k >= int(lOffsetStart); k-- {
+		if !r.Line.IsValid(k) {
+			continue // The line has been filtered out.
+		}
+
+		fn := r.LineFunctionNameDict.Value(int(r.LineFunctionNameIndices.Value(k)))
+		if len(fn) == 0 {
+			fn = []byte(fmt.Sprintf("0x%x", r.Address.Value(j)))
+		}
+		frames = append(frames, stackFrame{
+			name: fn,
+			file: r.LineFunctionFilenameDict.Value(int(r.LineFunctionFilenameIndices.Value(k))),
+			line: r.LineNumber.Value(k),
+		})
+	}
+
+	return frames
+}
+
+// appendStack appends all frames of the stack at the given row from the root
+// to the leaf.
+func appendStack(frames []stackFrame, r *profile.RecordReader, row int) []stackFrame {
+	beg, end := r.Locations.ValueOffsets(row)
+	// pprof stores locations in reverse order.
+	for j := int(end - 1); j >= int(beg); j-- {
+		frames = appendStackFrames(frames, r, j)
+	}
+	return frames
+}
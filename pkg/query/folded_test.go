@@ -0,0 +1,235 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateFoldedStacks(t *testing.T) {
+	mappings := []*pprofprofile.Mapping{{
+		ID:      1,
+		Start:   0x1000,
+		Limit:   0x9000,
+		File:    "/usr/bin/app",
+		BuildID: "abc",
+	}}
+
+	functions := []*pprofprofile.Function{{
+		ID:       1,
+		Name:     "main",
+		Filename: "main.go",
+	}, {
+		ID:       2,
+		Name:     "foo",
+		Filename: "main.go",
+	}, {
+		ID:       3,
+		Name:     "bar",
+		Filename: "main.go",
+	}}
+
+	locations := []*pprofprofile.Location{{
+		ID:      1,
+		Mapping: mappings[0],
+		Address: 0x1000,
+		Line:    []pprofprofile.Line{{Function: functions[0]}},
+	}, {
+		ID:      2,
+		Mapping: mappings[0],
+		Address: 0x3000,
+		// bar is inlined into foo.
+		Line: []pprofprofile.Line{{Function: functions[2]}, {Function: functions[1]}},
+	}, {
+		ID:      3,
+		Mapping: mappings[0],
+		Address: 0x4000,
+	}}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{locations[1], locations[0]},
+			Value:    []int64{10},
+		}, {
+			Location: []*pprofprofile.Location{locations[2], locations[0]},
+			Value:    []int64{5},
+		}, {
+			Location: []*pprofprofile.Location{locations[1], locations[0]},
+			Value:    []int64{7},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	folded, total, err := GenerateFoldedStacks(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		p,
+		false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(22), total)
+	require.Equal(t, "main;[app] 0x4000 5\nmain;foo;bar 17\n", string(folded))
+}
+
+func TestGenerateFoldedStacksDiff(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	functions := []*pprofprofile.Function{{
+		ID:   1,
+		Name: "main",
+	}, {
+		ID:   2,
+		Name: "foo",
+	}, {
+		ID:   3,
+		Name: "bar",
+	}}
+
+	locations := []*pprofprofile.Location{{
+		ID:      1,
+		Address: 0x1000,
+		Line:    []pprofprofile.Line{{Function: functions[0]}},
+	}, {
+		ID:      2,
+		Address: 0x2000,
+		Line:    []pprofprofile.Line{{Function: functions[1]}},
+	}, {
+		ID:      3,
+		Address: 0x3000,
+		Line:    []pprofprofile.Line{{Function: functions[2]}},
+	}}
+
+	base, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{locations[1], locations[0]},
+			Value:    []int64{10},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer base.Samples[0].Release()
+
+	compare, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{locations[1], locations[0]},
+			Value:    []int64{30},
+		}, {
+			Location: []*pprofprofile.Location{locations[0]},
+			Value:    []int64{5},
+		}, {
+			// Negative values of the compare profile are not mistaken
+			// for values of the base profile.
+			Location: []*pprofprofile.Location{locations[2], locations[0]},
+			Value:    []int64{-3},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer compare.Samples[0].Release()
+
+	p, err := ComputeDiff(context.Background(), tracer, mem, base, compare, true)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	folded, _, err := GenerateFoldedStacks(context.Background(), tracer, p, true)
+	require.NoError(t, err)
+	require.Equal(t, "main 0 5\nmain;bar 0 -3\nmain;foo 10 30\n", string(folded))
+}
+
+func TestGenerateFoldedStacksFrameFilter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	tracer := noop.NewTracerProvider().Tracer("")
+	functions := []*pprofprofile.Function{{
+		ID:   1,
+		Name: "main",
+	}, {
+		ID:   2,
+		Name: "foo",
+	}, {
+		ID:   3,
+		Name: "runtime.mallocgc",
+	}}
+
+	locations := []*pprofprofile.Location{{
+		ID:      1,
+		Address: 0x1000,
+		Line:    []pprofprofile.Line{{Function: functions[0]}},
+	}, {
+		ID:      2,
+		Address: 0x2000,
+		Line:    []pprofprofile.Line{{Function: functions[1]}},
+	}, {
+		ID:      3,
+		Address: 0x3000,
+		Line:    []pprofprofile.Line{{Function: functions[2]}},
+	}}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{locations[2], locations[1], locations[0]},
+			Value:    []int64{10},
+		}, {
+			Location: []*pprofprofile.Location{locations[2]},
+			Value:    []int64{3},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		tracer,
+		mem,
+		p.Samples,
+		[]*pb.Filter{{
+			Filter: &pb.Filter_FrameFilter{
+				FrameFilter: &pb.FrameFilter{
+					Filter: &pb.FrameFilter_Criteria{
+						Criteria: &pb.FilterCriteria{
+							FunctionName: &pb.StringCondition{
+								Condition: &pb.StringCondition_NotStartsWith{NotStartsWith: "runtime."},
+							},
+						},
+					},
+				},
+			},
+		}},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	folded, _, err := GenerateFoldedStacks(context.Background(), tracer, profile.Profile{Samples: recs}, false)
+	require.NoError(t, err)
+	require.Equal(t, "main;foo 10\n", string(folded))
+}
@@ -0,0 +1,172 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const (
+	defaultHeatmapBucketCount   = 60
+	defaultHeatmapFunctionCount = 20
+	maxHeatmapBucketCount       = 1000
+)
+
+// HeatmapOptions configures the heatmap report.
+type HeatmapOptions struct {
+	// Start and End are the time range that is divided into buckets.
+	Start, End time.Time
+	// BucketCount is the number of time buckets, FunctionCount the number
+	// of rows. Zero means their default.
+	BucketCount   int
+	FunctionCount int
+	// ByRoot groups the rows by the roots of the stacks instead of by all
+	// of their functions.
+	ByRoot bool
+}
+
+type heatmapRow struct {
+	name       string
+	total      int64
+	values     []int64
+	lastSample int
+}
+
+// GenerateHeatmap sums the cumulative values of the functions per time bucket
+// and returns the functions with the largest total value. The samples have to
+// keep their timestamps, for example by being merged by timestamp. Samples
+// outside of the time range are added to the first or last bucket.
+func GenerateHeatmap(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	opts HeatmapOptions,
+) (*pb.Heatmap, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateHeatmap")
+	defer span.End()
+
+	bucketCount := opts.BucketCount
+	if bucketCount == 0 {
+		bucketCount = defaultHeatmapBucketCount
+	}
+	if bucketCount < 0 || bucketCount > maxHeatmapBucketCount {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "heatmap bucket count must be between 1 and %d", maxHeatmapBucketCount)
+	}
+	functionCount := opts.FunctionCount
+	if functionCount <= 0 {
+		functionCount = defaultHeatmapFunctionCount
+	}
+	rangeNanos := opts.End.Sub(opts.Start).Nanoseconds()
+	if rangeNanos <= 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "heatmap end must be after its start")
+	}
+	start := opts.Start.UnixNano()
+
+	var (
+		total int64
+		// Samples are numbered from 1, so the zero value of lastSample
+		// never matches.
+		sample int
+		frames []stackFrame
+		totals = make([]int64, bucketCount)
+		rows   = map[string]*heatmapRow{}
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			value := r.Value.Value(i)
+			total += value
+
+			bucket := int((r.Timestamp.Value(i) - start) * int64(bucketCount) / rangeNanos)
+			bucket = min(max(bucket, 0), bucketCount-1)
+			totals[bucket] += value
+
+			if r.Locations.IsNull(i) {
+				continue
+			}
+			frames = appendStack(frames[:0], r, i)
+			if len(frames) == 0 {
+				continue // All frames have been filtered out.
+			}
+			if opts.ByRoot {
+				frames = frames[:1]
+			}
+			sample++
+
+			for _, f := range frames {
+				name := chromeTraceFrameName(f)
+				row, ok := rows[name]
+				if !ok {
+					row = &heatmapRow{name: name, values: make([]int64, bucketCount)}
+					rows[name] = row
+				}
+				// Recursive stacks only count once towards the cumulative
+				// value.
+				if row.lastSample == sample {
+					continue
+				}
+				row.lastSample = sample
+				row.total += value
+				row.values[bucket] += value
+			}
+		}
+	}
+
+	sorted := make([]*heatmapRow, 0, len(rows))
+	for _, row := range rows {
+		sorted = append(sorted, row)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].total != sorted[j].total {
+			return sorted[i].total > sorted[j].total
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	if len(sorted) > functionCount {
+		sorted = sorted[:functionCount]
+	}
+
+	res := &pb.Heatmap{
+		Start:          timestamppb.New(opts.Start),
+		BucketDuration: durationpb.New(time.Duration(rangeNanos / int64(bucketCount))),
+		Totals:         totals,
+		Rows:           make([]*pb.HeatmapRow, 0, len(sorted)),
+		Unit:           p.Meta.SampleType.Unit,
+	}
+	for _, row := range sorted {
+		res.Rows = append(res.Rows, &pb.HeatmapRow{
+			Name:   row.name,
+			Total:  row.total,
+			Values: row.values,
+		})
+	}
+
+	return res, total, nil
+}
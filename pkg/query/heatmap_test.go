@@ -0,0 +1,129 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateHeatmap(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app"}
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	gcFunction := &pprofprofile.Function{ID: 2, Name: "gc"}
+	fooFunction := &pprofprofile.Function{ID: 3, Name: "foo"}
+
+	main := &pprofprofile.Location{ID: 1, Mapping: mapping, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	gc := &pprofprofile.Location{ID: 2, Mapping: mapping, Address: 0x2000, Line: []pprofprofile.Line{{Function: gcFunction}}}
+	foo := &pprofprofile.Location{ID: 3, Mapping: mapping, Address: 0x3000, Line: []pprofprofile.Line{{Function: fooFunction}}}
+	worker := &pprofprofile.Location{ID: 4, Mapping: mapping, Address: 0x4000}
+
+	p := profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "samples", Unit: "count"}},
+	}
+	for _, prof := range []*pprofprofile.Profile{{
+		TimeNanos: 0,
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10}},
+		},
+	}, {
+		TimeNanos: int64(time.Second),
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10}},
+			// A GC spike in the second bucket.
+			{Location: []*pprofprofile.Location{gc, gc, main}, Value: []int64{50}},
+		},
+	}, {
+		TimeNanos: int64(2 * time.Second),
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10}},
+		},
+	}, {
+		TimeNanos: int64(3 * time.Second),
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{worker}, Value: []int64{5}},
+		},
+	}, {
+		// Samples after the end end up in the last bucket.
+		TimeNanos: int64(10 * time.Second),
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{worker}, Value: []int64{1}},
+		},
+	}} {
+		sp, err := PprofToSymbolizedProfile(p.Meta, prof, 0, []string{})
+		require.NoError(t, err)
+		defer sp.Samples[0].Release()
+		p.Samples = append(p.Samples, sp.Samples...)
+	}
+
+	opts := HeatmapOptions{
+		Start:         time.Unix(0, 0),
+		End:           time.Unix(4, 0),
+		BucketCount:   4,
+		FunctionCount: 3,
+	}
+
+	res, total, err := GenerateHeatmap(context.Background(), tracer, p, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(86), total)
+	require.Equal(t, "count", res.Unit)
+	require.True(t, res.Start.AsTime().Equal(opts.Start))
+	require.Equal(t, time.Second, res.BucketDuration.AsDuration())
+	require.Equal(t, []int64{10, 60, 10, 6}, res.Totals)
+	require.Equal(t, []*pb.HeatmapRow{
+		{Name: "main", Total: 80, Values: []int64{10, 60, 10, 0}},
+		{Name: "gc", Total: 50, Values: []int64{0, 50, 0, 0}},
+		{Name: "foo", Total: 30, Values: []int64{10, 10, 10, 0}},
+	}, res.Rows)
+
+	opts.ByRoot = true
+	opts.FunctionCount = 0
+	res, _, err = GenerateHeatmap(context.Background(), tracer, p, opts)
+	require.NoError(t, err)
+	require.Equal(t, []*pb.HeatmapRow{
+		{Name: "main", Total: 80, Values: []int64{10, 60, 10, 0}},
+		{Name: "[app] 0x4000", Total: 6, Values: []int64{0, 0, 0, 6}},
+	}, res.Rows)
+
+	opts.BucketCount = maxHeatmapBucketCount + 1
+	_, _, err = GenerateHeatmap(context.Background(), tracer, p, opts)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	opts.BucketCount = 0
+	opts.End = opts.Start
+	_, _, err = GenerateHeatmap(context.Background(), tracer, p, opts)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHeatmapRequiresMerge(t *testing.T) {
+	api := &ColumnQueryAPI{tracer: noop.NewTracerProvider().Tracer("")}
+
+	_, err := api.Query(context.Background(), &pb.QueryRequest{
+		Mode:       pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+		ReportType: pb.QueryRequest_REPORT_TYPE_HEATMAP,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,158 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateLabelBreakdown sums the values of the samples containing a function
+// matching the regular expression by the values of each label, or only of
+// the given label. All samples are summed if the function is empty. Samples
+// without a label are summed as its empty value.
+func GenerateLabelBreakdown(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	function, label string,
+) (*pb.LabelBreakdown, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateLabelBreakdown")
+	defer span.End()
+
+	var re *regexp.Regexp
+	if function != "" {
+		var err error
+		re, err = regexp.Compile(function)
+		if err != nil {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "invalid function regular expression %q: %v", function, err)
+		}
+	}
+
+	var (
+		total      int64
+		cumulative int64
+		frames     []stackFrame
+		// labels holds the values of the samples per label value, the
+		// samples without the label are added in the end.
+		labels = map[string]map[string]int64{}
+		// matches caches the regular expression results by function name.
+		matches = map[string]bool{}
+	)
+	if label != "" {
+		labels[label] = map[string]int64{}
+	}
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+
+		for i := 0; i < int(rec.NumRows()); i++ {
+			value := r.Value.Value(i)
+			total += value
+			if r.Locations.IsNull(i) {
+				continue
+			}
+
+			if re != nil {
+				frames = appendStack(frames[:0], r, i)
+				matched := false
+				for _, f := range frames {
+					m, ok := matches[string(f.name)]
+					if !ok {
+						m = re.Match(f.name)
+						matches[string(f.name)] = m
+					}
+					if m {
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					continue
+				}
+			}
+			cumulative += value
+
+			for k, c := range r.LabelColumns {
+				name := strings.TrimPrefix(r.LabelFields[k].Name, profile.ColumnLabelsPrefix)
+				if label != "" && name != label {
+					continue
+				}
+				values, ok := labels[name]
+				if !ok {
+					values = map[string]int64{}
+					labels[name] = values
+				}
+				if c.Col.IsNull(i) {
+					continue
+				}
+				if v := c.Dict.Value(int(c.Col.Value(i))); len(v) > 0 {
+					values[string(v)] += value
+				}
+			}
+		}
+	}
+
+	res := &pb.LabelBreakdown{
+		Labels:     make([]*pb.LabelBreakdownLabel, 0, len(labels)),
+		Cumulative: cumulative,
+		Unit:       p.Meta.SampleType.Unit,
+	}
+	for name, values := range labels {
+		l := &pb.LabelBreakdownLabel{
+			Name:   name,
+			Values: make([]*pb.LabelBreakdownValue, 0, len(values)+1),
+		}
+		withLabel := int64(0)
+		for v, c := range values {
+			withLabel += c
+			l.Values = append(l.Values, &pb.LabelBreakdownValue{
+				Value:      v,
+				Cumulative: c,
+				Percentage: percentage(c, cumulative),
+			})
+		}
+		if withoutLabel := cumulative - withLabel; withoutLabel != 0 {
+			l.Values = append(l.Values, &pb.LabelBreakdownValue{
+				Cumulative: withoutLabel,
+				Percentage: percentage(withoutLabel, cumulative),
+			})
+		}
+		sort.Slice(l.Values, func(i, j int) bool {
+			a, b := l.Values[i], l.Values[j]
+			if a.Cumulative != b.Cumulative {
+				return a.Cumulative > b.Cumulative
+			}
+			return a.Value < b.Value
+		})
+		res.Labels = append(res.Labels, l)
+	}
+	sort.Slice(res.Labels, func(i, j int) bool {
+		return res.Labels[i].Name < res.Labels[j].Name
+	})
+
+	return res, total, nil
+}
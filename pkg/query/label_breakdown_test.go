@@ -0,0 +1,112 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateLabelBreakdown(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	foo := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction}}}
+	bar := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction}}}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+	}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{foo, main},
+			Value:    []int64{10},
+			Label:    map[string][]string{"pod": {"a"}, "version": {"v1"}},
+		}, {
+			Location: []*pprofprofile.Location{foo, main},
+			Value:    []int64{30},
+			Label:    map[string][]string{"pod": {"b"}, "version": {"v1"}},
+		}, {
+			Location: []*pprofprofile.Location{bar, main},
+			Value:    []int64{20},
+			Label:    map[string][]string{"pod": {"a"}, "version": {"v2"}},
+		}, {
+			// Recursion only counts once.
+			Location: []*pprofprofile.Location{foo, foo, main},
+			Value:    []int64{5},
+			Label:    map[string][]string{"pod": {"c"}},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	res, total, err := GenerateLabelBreakdown(context.Background(), tracer, p, "^foo$", "")
+	require.NoError(t, err)
+	require.Equal(t, int64(65), total)
+	require.Equal(t, int64(45), res.Cumulative)
+	require.Equal(t, "count", res.Unit)
+	require.Equal(t, []*pb.LabelBreakdownLabel{{
+		Name: "pod",
+		Values: []*pb.LabelBreakdownValue{
+			{Value: "b", Cumulative: 30, Percentage: percentage(30, 45)},
+			{Value: "a", Cumulative: 10, Percentage: percentage(10, 45)},
+			{Value: "c", Cumulative: 5, Percentage: percentage(5, 45)},
+		},
+	}, {
+		Name: "version",
+		Values: []*pb.LabelBreakdownValue{
+			{Value: "v1", Cumulative: 40, Percentage: percentage(40, 45)},
+			// Samples without the label.
+			{Value: "", Cumulative: 5, Percentage: percentage(5, 45)},
+		},
+	}}, res.Labels)
+
+	// All samples by a chosen label.
+	res, _, err = GenerateLabelBreakdown(context.Background(), tracer, p, "", "version")
+	require.NoError(t, err)
+	require.Equal(t, int64(65), res.Cumulative)
+	require.Equal(t, []*pb.LabelBreakdownLabel{{
+		Name: "version",
+		Values: []*pb.LabelBreakdownValue{
+			{Value: "v1", Cumulative: 40, Percentage: percentage(40, 65)},
+			{Value: "v2", Cumulative: 20, Percentage: percentage(20, 65)},
+			{Value: "", Cumulative: 5, Percentage: percentage(5, 65)},
+		},
+	}}, res.Labels)
+
+	// A label that isn't in the profile.
+	res, _, err = GenerateLabelBreakdown(context.Background(), tracer, p, "bar", "node")
+	require.NoError(t, err)
+	require.Equal(t, []*pb.LabelBreakdownLabel{{
+		Name: "node",
+		Values: []*pb.LabelBreakdownValue{
+			{Value: "", Cumulative: 20, Percentage: 100},
+		},
+	}}, res.Labels)
+
+	_, _, err = GenerateLabelBreakdown(context.Background(), tracer, p, "(", "")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
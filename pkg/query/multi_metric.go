@@ -0,0 +1,183 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func (q *ColumnQueryAPI) selectMultiMetric(
+	ctx context.Context,
+	m *pb.MultiMetricProfile,
+	groupByLabels []string,
+	isInverted bool,
+) (profile.Profile, error) {
+	ctx, span := q.tracer.Start(ctx, "multiMetricRequest")
+	defer span.End()
+
+	if m == nil {
+		return profile.Profile{}, status.Error(
+			codes.InvalidArgument,
+			"requested multi metric mode, but did not provide parameters for multi metric",
+		)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	var primary profile.Profile
+	defer func() {
+		for _, r := range primary.Samples {
+			r.Release()
+		}
+	}()
+	g.Go(func() error {
+		var err error
+		primary, err = q.selectMerge(ctx, &pb.MergeProfile{
+			Query: m.GetQuery(),
+			Start: m.GetStart(),
+			End:   m.GetEnd(),
+		}, groupByLabels, isInverted, "")
+		if err != nil {
+			return fmt.Errorf("reading primary profile: %w", err)
+		}
+		return nil
+	})
+
+	var secondary profile.Profile
+	defer func() {
+		for _, r := range secondary.Samples {
+			r.Release()
+		}
+	}()
+	g.Go(func() error {
+		var err error
+		secondary, err = q.selectMerge(ctx, &pb.MergeProfile{
+			Query: m.GetSecondaryQuery(),
+			Start: m.GetStart(),
+			End:   m.GetEnd(),
+		}, groupByLabels, isInverted, "")
+		if err != nil {
+			return fmt.Errorf("reading secondary profile: %w", err)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return profile.Profile{}, err
+	}
+
+	return CombineProfiles(ctx, q.tracer, q.mem, primary, secondary)
+}
+
+// CombineProfiles combines the samples of two profile types with the same
+// unit into a single profile. The samples of the primary profile keep their
+// values, the values of the secondary profile are moved to the
+// value_secondary column.
+func CombineProfiles(
+	ctx context.Context,
+	tracer trace.Tracer,
+	mem memory.Allocator,
+	primary, secondary profile.Profile,
+) (profile.Profile, error) {
+	_, span := tracer.Start(ctx, "CombineProfiles")
+	defer span.End()
+
+	if primary.Meta.SampleType.Unit != secondary.Meta.SampleType.Unit {
+		return profile.Profile{}, status.Errorf(
+			codes.InvalidArgument,
+			"cannot combine profiles with different units %q and %q",
+			primary.Meta.SampleType.Unit,
+			secondary.Meta.SampleType.Unit,
+		)
+	}
+
+	return profile.Profile{
+		Meta:    primary.Meta,
+		Samples: combineSamples(mem, primary, secondary),
+	}, nil
+}
+
+// combineSamples returns the samples of both profiles with the
+// value_secondary column appended. It holds the values of the secondary
+// samples, whose value is zero, and is zero for the primary samples.
+func combineSamples(mem memory.Allocator, primary, secondary profile.Profile) []arrow.RecordBatch {
+	records := make([]arrow.RecordBatch, 0, len(primary.Samples)+len(secondary.Samples))
+	for _, r := range primary.Samples {
+		records = append(records, withSecondaryValues(mem, r, false))
+	}
+	for _, r := range secondary.Samples {
+		records = append(records, withSecondaryValues(mem, r, true))
+	}
+	return records
+}
+
+func withSecondaryValues(mem memory.Allocator, r arrow.RecordBatch, isSecondary bool) arrow.RecordBatch {
+	zeros := zeroInt64Array(mem, int(r.NumRows()))
+	defer zeros.Release()
+
+	schema := r.Schema()
+	fields := make([]arrow.Field, 0, r.NumCols()+1)
+	fields = append(fields, schema.Fields()...)
+	fields = append(fields, arrow.Field{Name: profile.ColumnValueSecondary, Type: arrow.PrimitiveTypes.Int64})
+	cols := make([]arrow.Array, 0, r.NumCols()+1)
+	cols = append(cols, r.Columns()...)
+	if isSecondary {
+		value := schema.FieldIndices(profile.ColumnValue)[0]
+		cols = append(cols, cols[value])
+		cols[value] = zeros
+	} else {
+		cols = append(cols, zeros)
+	}
+
+	md := schema.Metadata()
+	return array.NewRecordBatch(arrow.NewSchema(fields, &md), cols, r.NumRows())
+}
+
+// renderMultiMetric renders the arrow flame graph of a combined profile, see
+// CombineProfiles. It has the cumulative_secondary and flat_secondary columns
+// of the secondary profile type.
+func (q *ColumnQueryAPI) renderMultiMetric(
+	ctx context.Context,
+	p profile.Profile,
+	nodeTrimThreshold float32,
+	filtered int64,
+	groupBy []string,
+) (*pb.QueryResponse, error) {
+	nodeTrimFraction := float32(0)
+	if nodeTrimThreshold != 0 {
+		nodeTrimFraction = nodeTrimThreshold / 100
+	}
+
+	fa, total, err := GenerateFlamegraphArrow(ctx, q.mem, q.tracer, p, groupBy, nodeTrimFraction, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate multi metric flamegraph: %v", err.Error())
+	}
+
+	return &pb.QueryResponse{
+		Total:    total,
+		Filtered: filtered,
+		Report:   &pb.QueryResponse_FlamegraphArrow{FlamegraphArrow: fa},
+	}, nil
+}
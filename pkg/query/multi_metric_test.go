@@ -0,0 +1,155 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateMultiMetricFlamegraphArrow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	computeFunction := &pprofprofile.Function{ID: 2, Name: "compute"}
+	readFunction := &pprofprofile.Function{ID: 3, Name: "read"}
+	sleepFunction := &pprofprofile.Function{ID: 4, Name: "sleep"}
+	tinyFunction := &pprofprofile.Function{ID: 5, Name: "tiny"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	compute := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: computeFunction}}}
+	read := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: readFunction}}}
+	sleep := &pprofprofile.Location{ID: 4, Address: 0x4000, Line: []pprofprofile.Line{{Function: sleepFunction}}}
+	tiny := &pprofprofile.Location{ID: 5, Address: 0x5000, Line: []pprofprofile.Line{{Function: tinyFunction}}}
+
+	nanoseconds := profile.Meta{SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"}}
+	onCPU, err := PprofToSymbolizedProfile(nanoseconds, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{compute, main}, Value: []int64{70}},
+			{Location: []*pprofprofile.Location{read, main}, Value: []int64{5}},
+			{Location: []*pprofprofile.Location{tiny, main}, Value: []int64{1}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer onCPU.Samples[0].Release()
+
+	offCPU, err := PprofToSymbolizedProfile(nanoseconds, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{read, main}, Value: []int64{100}},
+			{Location: []*pprofprofile.Location{sleep, main}, Value: []int64{50}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer offCPU.Samples[0].Release()
+
+	p, err := CombineProfiles(
+		context.Background(),
+		tracer,
+		mem,
+		onCPU,
+		offCPU,
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	// Nodes are kept if either of their values is above the threshold.
+	fa, total, err := GenerateFlamegraphArrow(context.Background(), mem, tracer, p, []string{FlamegraphFieldFunctionName}, 0.1, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(76), total)
+	require.Equal(t, int64(1), fa.Trimmed)
+	require.Equal(t, "nanoseconds", fa.Unit)
+
+	r, err := ipc.NewReader(bytes.NewReader(fa.Record), ipc.WithAllocator(mem))
+	require.NoError(t, err)
+	defer r.Release()
+	require.True(t, r.Next())
+	rec := r.RecordBatch()
+
+	column := func(name string) arrow.Array {
+		indices := rec.Schema().FieldIndices(name)
+		require.Len(t, indices, 1, name)
+		return rec.Column(indices[0])
+	}
+	names := column(FlamegraphFieldFunctionName).(*array.Dictionary)
+	cumulative := column(FlamegraphFieldCumulative)
+	flat := column(FlamegraphFieldFlat)
+	cumulativeSecondary := column(FlamegraphFieldCumulativeSecondary)
+	flatSecondary := column(FlamegraphFieldFlatSecondary)
+	value := func(arr arrow.Array, i int) int64 {
+		if arr.IsNull(i) {
+			return 0
+		}
+		switch a := arr.(type) {
+		case *array.Uint8:
+			return int64(a.Value(i))
+		case *array.Uint16:
+			return int64(a.Value(i))
+		default:
+			t.Fatalf("unexpected type %T", arr)
+			return 0
+		}
+	}
+
+	type values struct{ cumulative, flat, cumulativeSecondary, flatSecondary int64 }
+	res := map[string]values{}
+	for i := 0; i < int(rec.NumRows()); i++ {
+		name := "root"
+		if names.IsValid(i) {
+			name = string(names.Dictionary().(*array.Binary).Value(names.GetValueIndex(i)))
+		}
+		res[name] = values{
+			cumulative:          value(cumulative, i),
+			flat:                value(flat, i),
+			cumulativeSecondary: value(cumulativeSecondary, i),
+			flatSecondary:       value(flatSecondary, i),
+		}
+	}
+	require.Equal(t, map[string]values{
+		"root":    {cumulative: 76, cumulativeSecondary: 150},
+		"main":    {cumulative: 76, cumulativeSecondary: 150},
+		"compute": {cumulative: 70, flat: 70},
+		"read":    {cumulative: 5, flat: 5, cumulativeSecondary: 100, flatSecondary: 100},
+		"sleep":   {cumulativeSecondary: 50, flatSecondary: 50},
+	}, res)
+}
+
+func TestCombineProfilesDifferentUnits(t *testing.T) {
+	_, err := CombineProfiles(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		memory.DefaultAllocator,
+		profile.Profile{Meta: profile.Meta{SampleType: profile.ValueType{Unit: "nanoseconds"}}},
+		profile.Profile{Meta: profile.Meta{SampleType: profile.ValueType{Unit: "bytes"}}},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/profile"
@@ -1720,3 +1722,407 @@ func TestFrameFilterFunctionNameNotStartsWith(t *testing.T) {
 		}
 	}
 }
+
+func TestStackFilterFunctionNameMatchesRegex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+
+	// Test stack filter: keep only stacks containing database.query or database.execute
+	// Expected: 2 samples (database.connect does not match the anchored expression),
+	// regular expressions are case-insensitive like the other conditions.
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_StackFilter{
+					StackFilter: &pb.StackFilter{
+						Filter: &pb.StackFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								FunctionName: &pb.StringCondition{
+									Condition: &pb.StringCondition_MatchesRegex{
+										MatchesRegex: `^Database\.(query|EXECUTE)$`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	totalSamples := int64(0)
+	totalValue := int64(0)
+	for _, rec := range recs {
+		totalSamples += rec.NumRows()
+		r, err := profile.NewRecordReader(rec)
+		require.NoError(t, err)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			totalValue += r.Value.Value(i)
+		}
+	}
+	require.Equal(t, int64(2), totalSamples, "Should have 2 samples matching the regex")
+	require.Equal(t, int64(145), totalValue, "Should keep the database.query and database.execute samples")
+}
+
+func TestStackFilterFunctionNameNotMatchesRegex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+
+	// Test stack filter: drop stacks containing any runtime.* function
+	// Expected: 4 out of 5 samples remain
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_StackFilter{
+					StackFilter: &pb.StackFilter{
+						Filter: &pb.StackFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								FunctionName: &pb.StringCondition{
+									Condition: &pb.StringCondition_NotMatchesRegex{
+										NotMatchesRegex: `^runtime\.`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	totalSamples := int64(0)
+	for _, rec := range recs {
+		totalSamples += rec.NumRows()
+	}
+	require.Equal(t, int64(4), totalSamples, "Should have 4 samples NOT containing runtime.* functions")
+
+	for _, rec := range recs {
+		r, err := profile.NewRecordReader(rec)
+		require.NoError(t, err)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
+			firstStart, _ := r.Lines.ValueOffsets(int(lOffsetStart))
+			_, lastEnd := r.Lines.ValueOffsets(int(lOffsetEnd - 1))
+
+			for k := int(firstStart); k < int(lastEnd); k++ {
+				fnIndex := r.LineFunctionNameIndices.Value(k)
+				functionName := string(r.LineFunctionNameDict.Value(int(fnIndex)))
+				require.False(t, strings.HasPrefix(functionName, "runtime."),
+					"Sample should NOT contain runtime.* functions, found: %s", functionName)
+			}
+		}
+	}
+}
+
+func TestFrameFilterMatchesRegex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+
+	// Test frame filter: keep only frames from files matching the expression
+	// in binaries matching the expression.
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_FrameFilter{
+					FrameFilter: &pb.FrameFilter{
+						Filter: &pb.FrameFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								Filename: &pb.StringCondition{
+									Condition: &pb.StringCondition_MatchesRegex{
+										MatchesRegex: `^(gc|malloc|main)\.go$`,
+									},
+								},
+								Binary: &pb.StringCondition{
+									Condition: &pb.StringCondition_MatchesRegex{
+										MatchesRegex: `^run`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	totalSamples := int64(0)
+	for _, rec := range recs {
+		totalSamples += rec.NumRows()
+	}
+	require.Equal(t, int64(5), totalSamples, "Frame filters should keep all samples")
+
+	functionNames := []string{}
+	for _, rec := range recs {
+		r, err := profile.NewRecordReader(rec)
+		require.NoError(t, err)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
+			firstStart, _ := r.Lines.ValueOffsets(int(lOffsetStart))
+			_, lastEnd := r.Lines.ValueOffsets(int(lOffsetEnd - 1))
+
+			for k := int(firstStart); k < int(lastEnd); k++ {
+				if r.Line.IsValid(k) {
+					fnIndex := r.LineFunctionNameIndices.Value(k)
+					functionNames = append(functionNames, string(r.LineFunctionNameDict.Value(int(fnIndex))))
+				}
+			}
+		}
+	}
+	require.Equal(t, []string{"runtime.gc", "runtime.malloc"}, functionNames)
+}
+
+func TestFilterInvalidRegex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+	defer func() {
+		for _, r := range originalRecords {
+			r.Release()
+		}
+	}()
+
+	_, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_StackFilter{
+					StackFilter: &pb.StackFilter{
+						Filter: &pb.StackFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								FunctionName: &pb.StringCondition{
+									Condition: &pb.StringCondition_MatchesRegex{
+										MatchesRegex: `runtime\.(`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStackFilterLineNumberComparisons(t *testing.T) {
+	testCases := []struct {
+		name      string
+		condition *pb.NumberCondition
+		expected  int64
+	}{
+		{
+			name: "range",
+			condition: &pb.NumberCondition{
+				Condition: &pb.NumberCondition_Range{
+					Range: &pb.NumberRange{Start: 90, End: 100},
+				},
+			},
+			// runtime.gc:100, app.process:90 and database.execute:100
+			expected: 3,
+		},
+		{
+			name: "greater_than",
+			condition: &pb.NumberCondition{
+				Condition: &pb.NumberCondition_GreaterThan{
+					GreaterThan: 100,
+				},
+			},
+			// runtime.malloc:200
+			expected: 1,
+		},
+		{
+			name: "less_than",
+			condition: &pb.NumberCondition{
+				Condition: &pb.NumberCondition_LessThan{
+					LessThan: 15,
+				},
+			},
+			// main:10
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+			defer mem.AssertSize(t, 0)
+
+			originalRecords, cleanup := createTestProfileData(mem)
+			defer cleanup()
+
+			recs, _, err := FilterProfileData(
+				context.Background(),
+				noop.NewTracerProvider().Tracer(""),
+				mem,
+				originalRecords,
+				[]*pb.Filter{
+					{
+						Filter: &pb.Filter_StackFilter{
+							StackFilter: &pb.StackFilter{
+								Filter: &pb.StackFilter_Criteria{
+									Criteria: &pb.FilterCriteria{
+										LineNumber: tc.condition,
+									},
+								},
+							},
+						},
+					},
+				},
+			)
+			require.NoError(t, err)
+			defer func() {
+				for _, r := range recs {
+					r.Release()
+				}
+			}()
+
+			totalSamples := int64(0)
+			for _, rec := range recs {
+				totalSamples += rec.NumRows()
+			}
+			require.Equal(t, tc.expected, totalSamples)
+		})
+	}
+}
+
+func TestFrameFilterAddressRange(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+
+	// Test frame filtering by the address range [0x4000, 0x5000] (runtime.gc and runtime.malloc frames)
+	// Expected: all samples remain, but only frames within the range are kept
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_FrameFilter{
+					FrameFilter: &pb.FrameFilter{
+						Filter: &pb.FrameFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								Address: &pb.NumberCondition{
+									Condition: &pb.NumberCondition_Range{
+										Range: &pb.NumberRange{Start: 0x4000, End: 0x5000},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	totalSamples := int64(0)
+	addresses := []uint64{}
+	for _, rec := range recs {
+		totalSamples += rec.NumRows()
+		r, err := profile.NewRecordReader(rec)
+		require.NoError(t, err)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
+			for j := int(lOffsetStart); j < int(lOffsetEnd); j++ {
+				if r.Locations.ListValues().IsValid(j) {
+					addresses = append(addresses, r.Address.Value(j))
+				}
+			}
+		}
+	}
+	require.Equal(t, int64(5), totalSamples, "Frame filters should keep all samples")
+	require.Equal(t, []uint64{0x4000, 0x5000}, addresses)
+}
+
+func TestFilterInvalidNumberRange(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	originalRecords, cleanup := createTestProfileData(mem)
+	defer cleanup()
+	defer func() {
+		for _, r := range originalRecords {
+			r.Release()
+		}
+	}()
+
+	_, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		mem,
+		originalRecords,
+		[]*pb.Filter{
+			{
+				Filter: &pb.Filter_FrameFilter{
+					FrameFilter: &pb.FrameFilter{
+						Filter: &pb.FrameFilter_Criteria{
+							Criteria: &pb.FilterCriteria{
+								LineNumber: &pb.NumberCondition{
+									Condition: &pb.NumberCondition_Range{
+										Range: &pb.NumberRange{Start: 180, End: 120},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
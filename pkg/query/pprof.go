@@ -23,6 +23,10 @@ import (
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	parcaprofile "github.com/parca-dev/parca/pkg/profile"
@@ -56,6 +60,94 @@ func Gzip(data []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// ProfileFromPprof converts the samples of the sample type at index of a
+// symbolized pprof profile to a profile, the labels of the samples are kept.
+func ProfileFromPprof(mem memory.Allocator, meta parcaprofile.Meta, prof *pprofprofile.Profile, index int) (parcaprofile.Profile, error) {
+	labelNameSet := make(map[string]struct{})
+	for _, s := range prof.Sample {
+		for k := range s.Label {
+			labelNameSet[k] = struct{}{}
+		}
+	}
+	labelNames := make([]string, 0, len(labelNameSet))
+	for l := range labelNameSet {
+		labelNames = append(labelNames, l)
+	}
+
+	w := parcaprofile.NewWriter(mem, labelNames)
+	defer w.RecordBuilder.Release()
+	for i := range prof.Sample {
+		if len(prof.Sample[i].Value) <= index {
+			return parcaprofile.Profile{}, status.Errorf(codes.InvalidArgument, "failed to find samples for profile type")
+		}
+
+		w.Value.Append(prof.Sample[i].Value[index])
+		w.Diff.Append(0)
+		w.TimeNanos.Append(prof.TimeNanos)
+		w.Period.Append(prof.Period)
+
+		for labelName, labelBuilder := range w.LabelBuildersMap {
+			if prof.Sample[i].Label == nil {
+				labelBuilder.AppendNull()
+				continue
+			}
+
+			if labelValues, ok := prof.Sample[i].Label[labelName]; ok && len(labelValues) > 0 {
+				labelBuilder.Append([]byte(labelValues[0]))
+			} else {
+				labelBuilder.AppendNull()
+			}
+		}
+
+		w.LocationsList.Append(len(prof.Sample[i].Location) > 0)
+		if len(prof.Sample[i].Location) > 0 {
+			for _, loc := range prof.Sample[i].Location {
+				w.Locations.Append(true)
+				w.Addresses.Append(loc.Address)
+
+				if loc.Mapping != nil {
+					w.MappingStart.Append(loc.Mapping.Start)
+					w.MappingLimit.Append(loc.Mapping.Limit)
+					w.MappingOffset.Append(loc.Mapping.Offset)
+					w.MappingFile.Append([]byte(loc.Mapping.File))
+					w.MappingBuildID.Append([]byte(loc.Mapping.BuildID))
+				} else {
+					w.MappingStart.AppendNull()
+					w.MappingLimit.AppendNull()
+					w.MappingOffset.AppendNull()
+					w.MappingFile.AppendNull()
+					w.MappingBuildID.AppendNull()
+				}
+
+				w.Lines.Append(len(loc.Line) > 0)
+				if len(loc.Line) > 0 {
+					for _, line := range loc.Line {
+						w.Line.Append(true)
+						w.LineNumber.Append(line.Line)
+						w.ColumnNumber.Append(uint64(line.Column))
+						if line.Function != nil {
+							w.FunctionName.Append([]byte(line.Function.Name))
+							w.FunctionSystemName.Append([]byte(line.Function.SystemName))
+							w.FunctionFilename.Append([]byte(line.Function.Filename))
+							w.FunctionStartLine.Append(line.Function.StartLine)
+						} else {
+							w.FunctionName.AppendNull()
+							w.FunctionSystemName.AppendNull()
+							w.FunctionFilename.AppendNull()
+							w.FunctionStartLine.AppendNull()
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return parcaprofile.Profile{
+		Meta:    meta,
+		Samples: []arrow.RecordBatch{w.RecordBuilder.NewRecordBatch()},
+	}, nil
+}
+
 func GenerateFlatPprof(
 	ctx context.Context,
 	isDiff bool,
@@ -0,0 +1,217 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/parca-dev/parca/pkg/kv"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
+)
+
+func pprofOptionsTestProfile(t *testing.T) profile.Profile {
+	t.Helper()
+
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app"}
+	functions := []*pprofprofile.Function{
+		{ID: 1, Name: "main"},
+		{ID: 2, Name: "handler"},
+		{ID: 3, Name: "foo"},
+		{ID: 4, Name: "bar"},
+		{ID: 5, Name: "runtime.mallocgc"},
+		{ID: 6, Name: "baz"},
+	}
+	locations := []*pprofprofile.Location{
+		{ID: 1, Mapping: mapping, Address: 0x1000, Line: []pprofprofile.Line{{Function: functions[0]}}},
+		{ID: 2, Mapping: mapping, Address: 0x2000, Line: []pprofprofile.Line{{Function: functions[1]}}},
+		// bar is inlined into foo.
+		{ID: 3, Mapping: mapping, Address: 0x3000, Line: []pprofprofile.Line{{Function: functions[3]}, {Function: functions[2]}}},
+		{ID: 4, Mapping: mapping, Address: 0x4000, Line: []pprofprofile.Line{{Function: functions[4]}}},
+		{ID: 5, Mapping: mapping, Address: 0x5000, Line: []pprofprofile.Line{{Function: functions[5]}}},
+		{ID: 6, Mapping: mapping, Address: 0x6000},
+	}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{locations[3], locations[2], locations[1], locations[0]},
+			Value:    []int64{10},
+		}, {
+			Location: []*pprofprofile.Location{locations[4], locations[1], locations[0]},
+			Value:    []int64{5},
+		}, {
+			Location: []*pprofprofile.Location{locations[5], locations[0]},
+			Value:    []int64{2},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	t.Cleanup(func() { p.Samples[0].Release() })
+
+	return p
+}
+
+func TestFilterProfileDataPprofOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     profilefilter.PprofOptions
+		expected string
+		filtered int64
+	}{{
+		name:     "focus",
+		opts:     profilefilter.PprofOptions{Focus: "^foo$"},
+		expected: "main;handler;foo;bar;runtime.mallocgc 10\n",
+		filtered: 7,
+	}, {
+		name:     "focus is case-sensitive",
+		opts:     profilefilter.PprofOptions{Focus: "^FOO$"},
+		expected: "",
+		filtered: 17,
+	}, {
+		name:     "focus on mapping file",
+		opts:     profilefilter.PprofOptions{Focus: "/usr/bin/app"},
+		expected: "main;[app] 0x6000 2\nmain;handler;baz 5\nmain;handler;foo;bar;runtime.mallocgc 10\n",
+		filtered: 0,
+	}, {
+		name:     "ignore",
+		opts:     profilefilter.PprofOptions{Ignore: "^bar$"},
+		expected: "main;[app] 0x6000 2\nmain;handler;baz 5\n",
+		filtered: 10,
+	}, {
+		name:     "hide",
+		opts:     profilefilter.PprofOptions{Hide: "^(runtime\\.|bar$)"},
+		expected: "main;[app] 0x6000 2\nmain;handler;baz 5\nmain;handler;foo 10\n",
+		filtered: 0,
+	}, {
+		name:     "show",
+		opts:     profilefilter.PprofOptions{Show: "^(main|foo|baz)$"},
+		expected: "main 2\nmain;baz 5\nmain;foo 10\n",
+		filtered: 0,
+	}, {
+		name:     "show_from",
+		opts:     profilefilter.PprofOptions{ShowFrom: "^(handler|bar)$"},
+		expected: "handler;baz 5\nhandler;foo;bar;runtime.mallocgc 10\n",
+		filtered: 2,
+	}, {
+		name:     "prune_from",
+		opts:     profilefilter.PprofOptions{PruneFrom: "^(foo|handler)$"},
+		expected: "main;[app] 0x6000 2\nmain;handler 15\n",
+		filtered: 0,
+	}, {
+		name:     "show_from and prune_from",
+		opts:     profilefilter.PprofOptions{ShowFrom: "^handler$", PruneFrom: "^foo$"},
+		expected: "handler;baz 5\nhandler;foo 10\n",
+		filtered: 2,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+			defer mem.AssertSize(t, 0)
+
+			tracer := noop.NewTracerProvider().Tracer("")
+			p := pprofOptionsTestProfile(t)
+
+			recs, filtered, err := FilterProfileDataWithPprofOptions(
+				context.Background(),
+				tracer,
+				mem,
+				p.Samples,
+				nil,
+				tc.opts,
+			)
+			require.NoError(t, err)
+			defer func() {
+				for _, r := range recs {
+					r.Release()
+				}
+			}()
+			require.Equal(t, tc.filtered, filtered)
+
+			folded, _, err := GenerateFoldedStacks(context.Background(), tracer, profile.Profile{Samples: recs}, false)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(folded))
+		})
+	}
+}
+
+func TestFilterProfileDataPprofOptionsTop(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	tracer := noop.NewTracerProvider().Tracer("")
+	p := pprofOptionsTestProfile(t)
+
+	recs, _, err := FilterProfileDataWithPprofOptions(
+		context.Background(),
+		tracer,
+		mem,
+		p.Samples,
+		nil,
+		profilefilter.PprofOptions{Hide: "^(runtime\\.|bar$)"},
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	top, _, err := GenerateTopTable(
+		context.Background(),
+		profile.Profile{Samples: recs},
+		parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()),
+	)
+	require.NoError(t, err)
+
+	// The hidden frames are gone, so their values are attributed to their
+	// callers just like in the other reports.
+	flat := map[string]int64{}
+	for _, n := range top.List {
+		name := n.Meta.GetFunction().GetName()
+		if name == "" {
+			continue
+		}
+		flat[name] = n.Flat
+	}
+	names := make([]string, 0, len(flat))
+	for name := range flat {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{"baz", "foo", "handler", "main"}, names)
+	require.Equal(t, int64(10), flat["foo"])
+	require.Equal(t, int64(5), flat["baz"])
+}
+
+func TestFilterProfileDataPprofOptionsInvalid(t *testing.T) {
+	_, _, err := FilterProfileDataWithPprofOptions(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		memory.DefaultAllocator,
+		nil,
+		nil,
+		profilefilter.PprofOptions{Focus: "("},
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -120,10 +120,14 @@ func Benchmark_Query_Merge(b *testing.B) {
 					nil,
 					nil,
 					mem,
+					nil,
 				),
 				mem,
 				parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 				nil,
+				nil,
+				nil,
+				nil,
 			)
 			b.ResetTimer()
 
@@ -187,10 +191,14 @@ func Benchmark_ProfileTypes(b *testing.B) {
 			nil,
 			nil,
 			mem,
+			nil,
 		),
 		mem,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	b.ResetTimer()
 
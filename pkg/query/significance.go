@@ -0,0 +1,348 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
+)
+
+const (
+	// FlamegraphFieldPValue and TableFieldPValue are the columns holding the
+	// p-value of the difference of a function between the two sides of a
+	// diff, if its significance was requested.
+	FlamegraphFieldPValue = "p_value"
+	TableFieldPValue      = "p_value"
+)
+
+// DiffSignificanceKey identifies a function by its name and the file it is
+// defined in, so functions of the same name in different files are tested
+// separately.
+type DiffSignificanceKey struct {
+	FunctionName string
+	FileName     string
+}
+
+// DiffSignificance maps functions to the two-sided p-value of the difference
+// of their cumulative values between the profiles of the two sides of a diff.
+type DiffSignificance map[DiffSignificanceKey]float64
+
+// functionTimeline holds the cumulative value of a function per profile,
+// identified by the profile's timestamp.
+type functionTimeline struct {
+	values     map[int64]int64
+	lastSample int
+}
+
+// functionTimelines returns the timelines of all symbolized functions of the
+// profile and the timestamps of its profiles. The profile has to keep the
+// timestamps of its samples, for example by being merged by timestamp. The
+// samples and frames are filtered by the matchers one after the other, like
+// the profile is filtered before rendering a report.
+func functionTimelines(p profile.Profile, matchers []*profilefilter.Matcher) (map[DiffSignificanceKey]*functionTimeline, map[int64]struct{}, error) {
+	var (
+		sample     int
+		keys       []DiffSignificanceKey
+		ranges     = make([]profilefilter.StackRange, len(matchers))
+		timelines  = map[DiffSignificanceKey]*functionTimeline{}
+		timestamps = map[int64]struct{}{}
+	)
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create record reader: %w", err)
+		}
+		for _, m := range matchers {
+			m.Reset()
+		}
+
+	rows:
+		for i := 0; i < int(rec.NumRows()); i++ {
+			ts := r.Timestamp.Value(i)
+			timestamps[ts] = struct{}{}
+			if r.Locations.IsNull(i) {
+				continue
+			}
+			for mi, m := range matchers {
+				if !m.StackMatches(r, i) {
+					continue rows
+				}
+				var ok bool
+				if ranges[mi], ok = m.StackRange(r, i); !ok {
+					continue rows
+				}
+			}
+
+			keys = appendSignificanceKeys(keys[:0], r, i, matchers, ranges)
+			value := r.Value.Value(i)
+			// Samples are numbered from 1, so the zero value of lastSample
+			// never matches.
+			sample++
+
+			for _, key := range keys {
+				t, ok := timelines[key]
+				if !ok {
+					t = &functionTimeline{values: map[int64]int64{}}
+					timelines[key] = t
+				}
+				// Recursive stacks only count once towards the cumulative
+				// value.
+				if t.lastSample == sample {
+					continue
+				}
+				t.lastSample = sample
+				t.values[ts] += value
+			}
+		}
+	}
+	return timelines, timestamps, nil
+}
+
+// appendSignificanceKeys appends the keys of the symbolized functions of the
+// stack at the given row that are kept by all matchers.
+func appendSignificanceKeys(
+	keys []DiffSignificanceKey,
+	r *profile.RecordReader,
+	row int,
+	matchers []*profilefilter.Matcher,
+	ranges []profilefilter.StackRange,
+) []DiffSignificanceKey {
+	kept := func(j, k int) bool {
+		for mi, m := range matchers {
+			if !ranges[mi].Contains(j, k) || !m.FrameMatches(r, j, k) {
+				return false
+			}
+		}
+		return true
+	}
+
+	beg, end := r.Locations.ValueOffsets(row)
+	for j := int(beg); j < int(end); j++ {
+		if r.Locations.ListValues().IsNull(j) || !r.Lines.IsValid(j) {
+			continue // The location has been filtered out or is unsymbolized.
+		}
+		lOffsetStart, lOffsetEnd := r.Lines.ValueOffsets(j)
+		for k := int(lOffsetStart); k < int(lOffsetEnd); k++ {
+			if !r.Line.IsValid(k) || !kept(j, k) {
+				continue
+			}
+			fn := r.LineFunctionNameDict.Value(int(r.LineFunctionNameIndices.Value(k)))
+			if len(fn) == 0 {
+				continue // The function is unsymbolized.
+			}
+			keys = append(keys, DiffSignificanceKey{
+				FunctionName: string(fn),
+				FileName:     string(r.LineFunctionFilenameDict.Value(int(r.LineFunctionFilenameIndices.Value(k)))),
+			})
+		}
+	}
+	return keys
+}
+
+// ComputeDiffSignificance tests per function whether its cumulative values
+// in the individual profiles of base and compare differ significantly, using
+// the Mann-Whitney U test. Unlike a diff of the merged profiles, a single
+// noisy profile on either side does not make a difference significant. A
+// function missing from a profile has the value zero in it. The samples are
+// filtered by the matchers, so the significance matches the filtered diff.
+func ComputeDiffSignificance(
+	ctx context.Context,
+	tracer trace.Tracer,
+	base, compare profile.Profile,
+	matchers ...*profilefilter.Matcher,
+) (DiffSignificance, error) {
+	_, span := tracer.Start(ctx, "ComputeDiffSignificance")
+	defer span.End()
+
+	baseTimelines, baseTimestamps, err := functionTimelines(base, matchers)
+	if err != nil {
+		return nil, err
+	}
+	compareTimelines, compareTimestamps, err := functionTimelines(compare, matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	values := func(t *functionTimeline, timestamps map[int64]struct{}, buf []float64) []float64 {
+		buf = buf[:0]
+		for ts := range timestamps {
+			var v int64
+			if t != nil {
+				v = t.values[ts]
+			}
+			buf = append(buf, float64(v))
+		}
+		return buf
+	}
+
+	var (
+		xs, ys []float64
+		res    = make(DiffSignificance, len(baseTimelines)+len(compareTimelines))
+	)
+	for _, timelines := range []map[DiffSignificanceKey]*functionTimeline{baseTimelines, compareTimelines} {
+		for key := range timelines {
+			if _, ok := res[key]; ok {
+				continue
+			}
+			xs = values(baseTimelines[key], baseTimestamps, xs)
+			ys = values(compareTimelines[key], compareTimestamps, ys)
+			res[key] = mannWhitneyU(xs, ys)
+		}
+	}
+	return res, nil
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U test of
+// the two samples. It uses the normal approximation with tie and continuity
+// correction, which is reasonably accurate from about eight observations per
+// sample on.
+func mannWhitneyU(xs, ys []float64) float64 {
+	n1, n2 := len(xs), len(ys)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type observation struct {
+		value float64
+		x     bool
+	}
+	obs := make([]observation, 0, n1+n2)
+	for _, v := range xs {
+		obs = append(obs, observation{value: v, x: true})
+	}
+	for _, v := range ys {
+		obs = append(obs, observation{value: v})
+	}
+	sort.Slice(obs, func(i, j int) bool {
+		return obs[i].value < obs[j].value
+	})
+
+	// Tied observations get the average of their ranks.
+	var rankSumX, tieCorrection float64
+	for i := 0; i < len(obs); {
+		j := i
+		for j < len(obs) && obs[j].value == obs[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if obs[k].x {
+				rankSumX += rank
+			}
+		}
+		t := float64(j - i)
+		tieCorrection += t*t*t - t
+		i = j
+	}
+
+	var (
+		fn1, fn2 = float64(n1), float64(n2)
+		n        = fn1 + fn2
+		u        = rankSumX - fn1*(fn1+1)/2
+		mean     = fn1 * fn2 / 2
+		variance = fn1 * fn2 / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	)
+	if n < 2 || variance <= 0 {
+		// All observations are equal.
+		return 1
+	}
+
+	z := math.Abs(u-mean) - 0.5
+	if z < 0 {
+		z = 0
+	}
+	z /= math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// withSignificanceColumn returns the record with a column of the p-values of
+// the functions named in its function name and file name columns appended.
+// Rows of other functions are null.
+func withSignificanceColumn(
+	mem memory.Allocator,
+	rec arrow.RecordBatch,
+	functionNameField, fileNameField, field string,
+	significance DiffSignificance,
+) (arrow.RecordBatch, error) {
+	names, name, err := dictionaryColumn(rec, functionNameField)
+	if err != nil {
+		return nil, err
+	}
+	fileNames, fileName, err := dictionaryColumn(rec, fileNameField)
+	if err != nil {
+		return nil, err
+	}
+
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+	b.Reserve(int(rec.NumRows()))
+	for i := 0; i < int(rec.NumRows()); i++ {
+		if names.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		key := DiffSignificanceKey{FunctionName: name(i)}
+		if fileNames.IsValid(i) {
+			key.FileName = fileName(i)
+		}
+		if p, ok := significance[key]; ok {
+			b.Append(p)
+		} else {
+			b.AppendNull()
+		}
+	}
+	pValues := b.NewFloat64Array()
+	defer pValues.Release()
+
+	fields := make([]arrow.Field, 0, rec.NumCols()+1)
+	fields = append(fields, rec.Schema().Fields()...)
+	fields = append(fields, arrow.Field{Name: field, Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+	columns := make([]arrow.Array, 0, rec.NumCols()+1)
+	columns = append(columns, rec.Columns()...)
+	columns = append(columns, pValues)
+	metadata := rec.Schema().Metadata()
+	return array.NewRecordBatch(arrow.NewSchema(fields, &metadata), columns, rec.NumRows()), nil
+}
+
+// dictionaryColumn returns the dictionary column of the field and a function
+// returning the string value of a valid row.
+func dictionaryColumn(rec arrow.RecordBatch, field string) (*array.Dictionary, func(i int) string, error) {
+	indices := rec.Schema().FieldIndices(field)
+	if len(indices) != 1 {
+		return nil, nil, fmt.Errorf("invalid %s indices: %v", field, indices)
+	}
+	col, ok := rec.Column(indices[0]).(*array.Dictionary)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected %s column type %s", field, rec.Column(indices[0]).DataType())
+	}
+
+	switch dict := col.Dictionary().(type) {
+	case *array.String:
+		return col, func(i int) string { return dict.Value(col.GetValueIndex(i)) }, nil
+	case *array.Binary:
+		return col, func(i int) string { return unsafeString(dict.Value(col.GetValueIndex(i))) }, nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected %s dictionary type %s", field, dict.DataType())
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/profilefilter"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	// Completely separated samples.
+	require.InDelta(t, 0.000939, mannWhitneyU(
+		[]float64{1, 2, 3, 4, 5, 6, 7, 8},
+		[]float64{11, 12, 13, 14, 15, 16, 17, 18},
+	), 1e-6)
+	// Identical samples.
+	require.Equal(t, 1.0, mannWhitneyU(
+		[]float64{1, 2, 3, 4},
+		[]float64{1, 2, 3, 4},
+	))
+	require.Equal(t, 1.0, mannWhitneyU([]float64{0, 0}, []float64{0, 0}))
+	require.Equal(t, 1.0, mannWhitneyU(nil, []float64{1}))
+}
+
+func TestComputeDiffSignificance(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+	// Another function named foo, which didn't change.
+	otherFooFunction := &pprofprofile.Function{ID: 4, Name: "foo", Filename: "other.go"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	foo := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction}}}
+	bar := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction}}}
+	otherFoo := &pprofprofile.Location{ID: 5, Address: 0x5000, Line: []pprofprofile.Line{{Function: otherFooFunction}}}
+	// An unsymbolized frame isn't a function.
+	unsymbolized := &pprofprofile.Location{ID: 4, Address: 0x4000}
+
+	// Every profile of a side is written at its own timestamp.
+	var base, compare profile.Profile
+	appendProfile := func(p *profile.Profile, prof *pprofprofile.Profile) {
+		sp, err := PprofToSymbolizedProfile(profile.Meta{}, prof, 0, []string{})
+		require.NoError(t, err)
+		t.Cleanup(func() { sp.Samples[0].Release() })
+		p.Samples = append(p.Samples, sp.Samples...)
+	}
+	for ts := int64(1); ts <= 8; ts++ {
+		appendProfile(&base, &pprofprofile.Profile{
+			TimeNanos: ts,
+			Sample: []*pprofprofile.Sample{
+				{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10 + ts%2}},
+				{Location: []*pprofprofile.Location{bar, main}, Value: []int64{10 + ts%3}},
+				{Location: []*pprofprofile.Location{otherFoo, main}, Value: []int64{10 + ts%2}},
+			},
+		})
+
+		// foo got consistently slower, bar only in a single noisy profile.
+		barValue := 10 + ts%3
+		if ts == 8 {
+			barValue = 1000
+		}
+		samples := []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{20 + ts%2}},
+			{Location: []*pprofprofile.Location{bar, main}, Value: []int64{barValue}},
+			{Location: []*pprofprofile.Location{otherFoo, main}, Value: []int64{10 + ts%2}},
+		}
+		if ts == 1 {
+			samples = append(samples, &pprofprofile.Sample{Location: []*pprofprofile.Location{unsymbolized, main}, Value: []int64{1}})
+		}
+		appendProfile(&compare, &pprofprofile.Profile{TimeNanos: 100 + ts, Sample: samples})
+	}
+
+	significance, err := ComputeDiffSignificance(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		base,
+		compare,
+	)
+	require.NoError(t, err)
+	require.Len(t, significance, 4)
+	require.Less(t, significance[DiffSignificanceKey{FunctionName: "foo", FileName: "main.go"}], 0.01)
+	require.Less(t, significance[DiffSignificanceKey{FunctionName: "main", FileName: "main.go"}], 0.01)
+	require.Greater(t, significance[DiffSignificanceKey{FunctionName: "bar", FileName: "main.go"}], 0.5)
+	require.Greater(t, significance[DiffSignificanceKey{FunctionName: "foo", FileName: "other.go"}], 0.5)
+
+	// Without the samples of foo, main only changed in the noisy profile.
+	matcher, err := profilefilter.NewMatcherWithPprofOptions(nil, profilefilter.PprofOptions{Ignore: "^foo$"})
+	require.NoError(t, err)
+	significance, err = ComputeDiffSignificance(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		base,
+		compare,
+		matcher,
+	)
+	require.NoError(t, err)
+	require.Len(t, significance, 2)
+	require.Greater(t, significance[DiffSignificanceKey{FunctionName: "main", FileName: "main.go"}], 0.5)
+	require.Greater(t, significance[DiffSignificanceKey{FunctionName: "bar", FileName: "main.go"}], 0.5)
+}
+
+func TestWithSignificanceColumn(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	otherFooFunction := &pprofprofile.Function{ID: 3, Name: "foo", Filename: "other.go"}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{{
+			Location: []*pprofprofile.Location{
+				{ID: 3, Address: 0x3000},
+				{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction}}},
+				{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}},
+			},
+			Value: []int64{1},
+		}, {
+			Location: []*pprofprofile.Location{
+				{ID: 4, Address: 0x4000, Line: []pprofprofile.Line{{Function: otherFooFunction}}},
+				{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}},
+			},
+			Value: []int64{1},
+		}},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+	significance := DiffSignificance{{FunctionName: "foo", FileName: "main.go"}: 0.01}
+
+	pValues := func(rec arrow.RecordBatch, nameField, fileNameField, field string) map[string]any {
+		withPValues, err := withSignificanceColumn(mem, rec, nameField, fileNameField, field, significance)
+		require.NoError(t, err)
+		defer withPValues.Release()
+
+		indices := withPValues.Schema().FieldIndices(field)
+		require.Len(t, indices, 1)
+		require.Equal(t, int(rec.NumCols()), indices[0])
+
+		names, name, err := dictionaryColumn(withPValues, nameField)
+		require.NoError(t, err)
+		fileNames, fileName, err := dictionaryColumn(withPValues, fileNameField)
+		require.NoError(t, err)
+		col := withPValues.Column(indices[0]).(*array.Float64)
+		res := map[string]any{}
+		for i := 0; i < col.Len(); i++ {
+			key := "<null>"
+			if names.IsValid(i) {
+				key = name(i)
+				if fileNames.IsValid(i) {
+					key += " " + fileName(i)
+				}
+			}
+			if col.IsNull(i) {
+				res[key] = nil
+			} else {
+				res[key] = col.Value(i)
+			}
+		}
+		return res
+	}
+
+	table, _, err := generateTableArrowRecord(context.Background(), mem, tracer, p)
+	require.NoError(t, err)
+	defer table.Release()
+	require.Equal(t, map[string]any{
+		// The table merges functions of the same name, they keep the file
+		// of the first one.
+		"<null>":       nil,
+		"foo main.go":  0.01,
+		"main main.go": nil,
+	}, pValues(table, TableFieldFunctionName, TableFieldFunctionFileName, TableFieldPValue))
+
+	// The schema metadata, like the marker of the base records of a diff, is
+	// kept.
+	marked := array.NewRecordBatch(diffBaseSchema(table.Schema()), table.Columns(), table.NumRows())
+	defer marked.Release()
+	withPValues, err := withSignificanceColumn(mem, marked, TableFieldFunctionName, TableFieldFunctionFileName, TableFieldPValue, significance)
+	require.NoError(t, err)
+	defer withPValues.Release()
+	require.True(t, isDiffBaseRecord(withPValues))
+
+	fg, _, _, _, err := generateFlamegraphArrowRecord(context.Background(), mem, tracer, p, []string{FlamegraphFieldFunctionFileName}, 0)
+	require.NoError(t, err)
+	defer fg.Release()
+	require.Equal(t, map[string]any{
+		"<null>":       nil,
+		"foo main.go":  0.01,
+		"foo other.go": nil,
+		"main main.go": nil,
+	}, pValues(fg, FlamegraphFieldFunctionName, FlamegraphFieldFunctionFileName, FlamegraphFieldPValue))
+
+	fg, _, _, _, err = generateFlamegraphArrowRecord(context.Background(), mem, tracer, p, nil, 0)
+	require.NoError(t, err)
+	defer fg.Release()
+	require.Equal(t, map[string]any{
+		// The flamegraph merges both foo functions into a node without a
+		// file, which can't be attributed to either of them.
+		"<null>":       nil,
+		"foo":          nil,
+		"main main.go": nil,
+	}, pValues(fg, FlamegraphFieldFunctionName, FlamegraphFieldFunctionFileName, FlamegraphFieldPValue))
+}
@@ -38,6 +38,7 @@ func GenerateSourceReport(
 	p profile.Profile,
 	ref *pb.SourceReference,
 	source string,
+	isDiff bool,
 ) (*pb.Source, int64, error) {
 	record, cumulative, err := generateSourceReportRecord(
 		ctx,
@@ -46,6 +47,7 @@ func GenerateSourceReport(
 		p,
 		ref,
 		source,
+		isDiff,
 	)
 	if err != nil {
 		return nil, 0, err
@@ -78,8 +80,10 @@ func generateSourceReportRecord(
 	p profile.Profile,
 	ref *pb.SourceReference,
 	_ string,
+	isDiff bool,
 ) (arrow.RecordBatch, int64, error) {
 	b := newSourceReportBuilder(pool, ref)
+	b.isDiff = isDiff
 	for _, record := range p.Samples {
 		if err := b.addRecord(record); err != nil {
 			return nil, 0, err
@@ -94,6 +98,25 @@ type lineMetrics struct {
 	lineNumber int64
 	cumulative int64
 	flat       int64
+
+	// The values of the base profile and the difference to it, only set
+	// for diff profiles. Like the diff, the base values are scaled to the
+	// total of the larger profile unless the diff is absolute.
+	cumulativeBase int64
+	flatBase       int64
+	cumulativeDiff int64
+	flatDiff       int64
+}
+
+func (m *lineMetrics) add(value, diff, base int64, isLeaf bool) {
+	m.cumulative += value
+	m.cumulativeDiff += diff
+	m.cumulativeBase += base
+	if isLeaf {
+		m.flat += value
+		m.flatDiff += diff
+		m.flatBase += base
+	}
 }
 
 type sourceReportBuilder struct {
@@ -104,6 +127,10 @@ type sourceReportBuilder struct {
 
 	lineData   map[string][]lineMetrics
 	cumulative int64
+
+	// isDiff adds the columns of the base profile values and the
+	// differences to the record.
+	isDiff bool
 }
 
 // filenameMatches checks if profileFilename matches queryFilename using suffix matching.
@@ -166,12 +193,45 @@ func (b *sourceReportBuilder) finish() (arrow.RecordBatch, int64) {
 	cumuBuilder.Reserve(totalRows)
 	flatBuilder.Reserve(totalRows)
 
+	fields := []arrow.Field{
+		{Name: "filename", Type: filenameDictType},
+		{Name: "line_number", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "cumulative", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "flat", Type: arrow.PrimitiveTypes.Int64},
+	}
+	valueBuilders := []*array.Int64Builder{cumuBuilder, flatBuilder}
+	values := []func(lineMetrics) int64{
+		func(m lineMetrics) int64 { return m.cumulative },
+		func(m lineMetrics) int64 { return m.flat },
+	}
+	if b.isDiff {
+		fields = append(fields,
+			arrow.Field{Name: "cumulative_base_scaled", Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: "flat_base_scaled", Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: "cumulative_diff", Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: "flat_diff", Type: arrow.PrimitiveTypes.Int64},
+		)
+		for range 4 {
+			vb := array.NewInt64Builder(b.pool)
+			defer vb.Release()
+			vb.Reserve(totalRows)
+			valueBuilders = append(valueBuilders, vb)
+		}
+		values = append(values,
+			func(m lineMetrics) int64 { return m.cumulativeBase },
+			func(m lineMetrics) int64 { return m.flatBase },
+			func(m lineMetrics) int64 { return m.cumulativeDiff },
+			func(m lineMetrics) int64 { return m.flatDiff },
+		)
+	}
+
 	for _, filename := range filenames {
 		for _, metrics := range b.lineData[filename] {
 			_ = filenameBuilder.AppendString(filename)
 			lineNumBuilder.Append(metrics.lineNumber)
-			cumuBuilder.Append(metrics.cumulative)
-			flatBuilder.Append(metrics.flat)
+			for i, vb := range valueBuilders {
+				vb.Append(values[i](metrics))
+			}
 		}
 	}
 
@@ -179,27 +239,18 @@ func (b *sourceReportBuilder) finish() (arrow.RecordBatch, int64) {
 	defer filenameArr.Release()
 	lineNumArr := lineNumBuilder.NewInt64Array()
 	defer lineNumArr.Release()
-	cumuArr := cumuBuilder.NewInt64Array()
-	defer cumuArr.Release()
-	flatArr := flatBuilder.NewInt64Array()
-	defer flatArr.Release()
+
+	columns := make([]arrow.Array, 0, len(fields))
+	columns = append(columns, filenameArr, lineNumArr)
+	for _, vb := range valueBuilders {
+		arr := vb.NewInt64Array()
+		defer arr.Release()
+		columns = append(columns, arr)
+	}
 
 	return array.NewRecordBatch(
-		arrow.NewSchema(
-			[]arrow.Field{
-				{Name: "filename", Type: filenameDictType},
-				{Name: "line_number", Type: arrow.PrimitiveTypes.Int64},
-				{Name: "cumulative", Type: arrow.PrimitiveTypes.Int64},
-				{Name: "flat", Type: arrow.PrimitiveTypes.Int64},
-			},
-			nil,
-		),
-		[]arrow.Array{
-			filenameArr,
-			lineNumArr,
-			cumuArr,
-			flatArr,
-		},
+		arrow.NewSchema(fields, nil),
+		columns,
 		int64(totalRows),
 	), b.cumulative
 }
@@ -210,6 +261,7 @@ func (b *sourceReportBuilder) addRecord(rec arrow.RecordBatch) error {
 		return fmt.Errorf("failed to create record reader: %w", err)
 	}
 	b.cumulative += math.Int64.Sum(r.Value)
+	isBase := isDiffBaseRecord(rec)
 
 	for i := 0; i < int(rec.NumRows()); i++ {
 		lOffsetStart, lOffsetEnd := r.Locations.ValueOffsets(i)
@@ -228,6 +280,15 @@ func (b *sourceReportBuilder) addRecord(rec arrow.RecordBatch) error {
 							lineNum := r.LineNumber.Value(k)
 							filename := string(profileFilename)
 							value := r.Value.Value(i)
+							diff := r.Diff.Value(i)
+							// The samples of the base profile have no value
+							// and the negated base value as diff, which is
+							// scaled like the rest of the diff, so the
+							// reported base values are scaled too.
+							base := int64(0)
+							if isBase {
+								base = -diff
+							}
 
 							isLeaf := isFirstNonNil(i, j, r.Locations) && isFirstNonNil(j, k, r.Lines)
 
@@ -235,24 +296,15 @@ func (b *sourceReportBuilder) addRecord(rec arrow.RecordBatch) error {
 							found := false
 							for idx := range metrics {
 								if metrics[idx].lineNumber == lineNum {
-									metrics[idx].cumulative += value
-									if isLeaf {
-										metrics[idx].flat += value
-									}
+									metrics[idx].add(value, diff, base, isLeaf)
 									found = true
 									break
 								}
 							}
 							if !found {
-								flat := int64(0)
-								if isLeaf {
-									flat = value
-								}
-								b.lineData[filename] = append(metrics, lineMetrics{
-									lineNumber: lineNum,
-									cumulative: value,
-									flat:       flat,
-								})
+								m := lineMetrics{lineNumber: lineNum}
+								m.add(value, diff, base, isLeaf)
+								b.lineData[filename] = append(metrics, m)
 							}
 						}
 					}
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/go-kit/log"
+	pprofprofile "github.com/google/pprof/profile"
 	"github.com/stretchr/testify/require"
 	"github.com/thanos-io/objstore"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -31,6 +32,7 @@ import (
 	"github.com/parca-dev/parca/pkg/debuginfo"
 	"github.com/parca-dev/parca/pkg/kv"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
 )
 
 func TestSourcesOnlyRequest(t *testing.T) {
@@ -59,10 +61,14 @@ func TestSourcesOnlyRequest(t *testing.T) {
 			nil,
 			nil,
 			allocator,
+			nil,
 		),
 		allocator,
 		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
 		NewBucketSourceFinder(bucket, &debuginfo.NopDebuginfodClients{}),
+		nil,
+		nil,
+		nil,
 	)
 
 	resp, err := api.Query(ctx, &pb.QueryRequest{
@@ -259,3 +265,98 @@ func TestSourceReportBuilderEmptyBuildID(t *testing.T) {
 	require.NotNil(t, builder.lineData)
 	require.Len(t, builder.lineData, 0)
 }
+
+func TestSourceReportDiff(t *testing.T) {
+	allocator := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer allocator.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app", BuildID: "abc"}
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+
+	main := &pprofprofile.Location{ID: 1, Mapping: mapping, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction, Line: 1}}}
+	foo := &pprofprofile.Location{ID: 2, Mapping: mapping, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction, Line: 10}}}
+	bar := &pprofprofile.Location{ID: 3, Mapping: mapping, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction, Line: 20}}}
+
+	base, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer base.Samples[0].Release()
+
+	compare, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{4}},
+			{Location: []*pprofprofile.Location{bar, main}, Value: []int64{6}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer compare.Samples[0].Release()
+
+	p, err := ComputeDiff(
+		context.Background(),
+		tracer,
+		allocator,
+		base,
+		compare,
+		true,
+	)
+	require.NoError(t, err)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+	// The records of the base profile are marked, so their samples are
+	// attributed to the base even when a compare sample has no value.
+	require.Len(t, p.Samples, 2)
+	require.False(t, isDiffBaseRecord(p.Samples[0]))
+	require.True(t, isDiffBaseRecord(p.Samples[1]))
+
+	record, cumulative, err := generateSourceReportRecord(
+		context.Background(),
+		allocator,
+		tracer,
+		p,
+		&pb.SourceReference{BuildId: "abc", Filename: "main.go"},
+		"",
+		true,
+	)
+	require.NoError(t, err)
+	defer record.Release()
+
+	require.Equal(t, int64(10), cumulative)
+
+	schema := record.Schema()
+	names := make([]string, 0, schema.NumFields())
+	for _, f := range schema.Fields() {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{
+		"filename",
+		"line_number",
+		"cumulative",
+		"flat",
+		"cumulative_base_scaled",
+		"flat_base_scaled",
+		"cumulative_diff",
+		"flat_diff",
+	}, names)
+
+	rows := make([][]int64, record.NumRows())
+	for i := range rows {
+		for c := 1; c < int(record.NumCols()); c++ {
+			rows[i] = append(rows[i], record.Column(c).(*array.Int64).Value(i))
+		}
+	}
+	require.Equal(t, [][]int64{
+		// line, cumulative, flat, cumulative_base_scaled, flat_base_scaled, cumulative_diff, flat_diff
+		{1, 10, 0, 10, 0, 0, 0},
+		{10, 4, 4, 10, 10, -6, -6},
+		{20, 6, 6, 0, 0, 6, 6},
+	}, rows)
+}
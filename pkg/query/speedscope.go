@@ -0,0 +1,276 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const speedscopeSchema = "https://www.speedscope.app/file-format-schema.json"
+
+// The types below implement the subset of the speedscope file format that is
+// needed to export sampled and evented profiles, see
+// https://github.com/jlfwong/speedscope/blob/main/src/lib/file-format-spec.ts.
+
+type speedscopeFile struct {
+	Schema             string              `json:"$schema"`
+	Shared             speedscopeShared    `json:"shared"`
+	Profiles           []speedscopeProfile `json:"profiles"`
+	Name               string              `json:"name,omitempty"`
+	ActiveProfileIndex int                 `json:"activeProfileIndex"`
+	Exporter           string              `json:"exporter"`
+}
+
+type speedscopeShared struct {
+	Frames []speedscopeFrame `json:"frames"`
+}
+
+type speedscopeFrame struct {
+	Name string `json:"name"`
+	File string `json:"file,omitempty"`
+	Line int64  `json:"line,omitempty"`
+}
+
+type speedscopeProfile struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Unit       string `json:"unit"`
+	StartValue int64  `json:"startValue"`
+	EndValue   int64  `json:"endValue"`
+
+	// Sampled profiles.
+	Samples [][]int `json:"samples,omitempty"`
+	Weights []int64 `json:"weights,omitempty"`
+
+	// Evented profiles.
+	Events []speedscopeEvent `json:"events,omitempty"`
+}
+
+type speedscopeEvent struct {
+	Type  string `json:"type"`
+	Frame int    `json:"frame"`
+	At    int64  `json:"at"`
+}
+
+type speedscopeFrameKey struct {
+	name string
+	file string
+	line int64
+}
+
+type speedscopeBuilder struct {
+	frames     []speedscopeFrame
+	frameIndex map[speedscopeFrameKey]int
+	stackBuf   []stackFrame
+}
+
+func (b *speedscopeBuilder) frame(f stackFrame) int {
+	key := speedscopeFrameKey{name: unsafeString(f.name), file: unsafeString(f.file), line: f.line}
+	if idx, ok := b.frameIndex[key]; ok {
+		return idx
+	}
+
+	key.name, key.file = string(f.name), string(f.file)
+	b.frames = append(b.frames, speedscopeFrame{Name: key.name, File: key.file, Line: key.line})
+	b.frameIndex[key] = len(b.frames) - 1
+	return len(b.frames) - 1
+}
+
+// stack returns the frame indices of the stack at the given row, from the
+// root to the leaf.
+func (b *speedscopeBuilder) stack(r *profile.RecordReader, row int) []int {
+	b.stackBuf = appendStack(b.stackBuf[:0], r, row)
+
+	stack := make([]int, 0, len(b.stackBuf))
+	for _, f := range b.stackBuf {
+		stack = append(stack, b.frame(f))
+	}
+	return stack
+}
+
+// GenerateSpeedscope generates a speedscope JSON document of the profile.
+//
+// If evented is false a sampled profile is generated, with identical stacks
+// merged into a single weighted sample. Otherwise the samples are ordered by
+// their timestamp and laid out one after another, as done by the flame chart,
+// resulting in an evented profile.
+func GenerateSpeedscope(
+	ctx context.Context,
+	tracer trace.Tracer,
+	p profile.Profile,
+	evented bool,
+) ([]byte, int64, error) {
+	_, span := tracer.Start(ctx, "GenerateSpeedscope")
+	defer span.End()
+
+	b := &speedscopeBuilder{
+		frameIndex: map[speedscopeFrameKey]int{},
+	}
+
+	readers := make([]*profile.RecordReader, 0, len(p.Samples))
+	for _, rec := range p.Samples {
+		r, err := profile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+		readers = append(readers, r)
+	}
+
+	name := profileTypeName(p.Meta)
+	sp := speedscopeProfile{
+		Name: name,
+		Unit: speedscopeUnit(p.Meta.SampleType.Unit),
+	}
+
+	var total int64
+	if evented {
+		sp.Type = "evented"
+		sp.Events, total = b.events(readers)
+	} else {
+		sp.Type = "sampled"
+		sp.Samples, sp.Weights, total = b.samples(readers)
+	}
+	sp.EndValue = total
+
+	res, err := json.Marshal(speedscopeFile{
+		Schema:   speedscopeSchema,
+		Shared:   speedscopeShared{Frames: b.frames},
+		Profiles: []speedscopeProfile{sp},
+		Name:     name,
+		Exporter: "parca",
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("marshal speedscope profile: %w", err)
+	}
+
+	return res, total, nil
+}
+
+func (b *speedscopeBuilder) samples(readers []*profile.RecordReader) ([][]int, []int64, int64) {
+	var (
+		samples     [][]int
+		weights     []int64
+		total       int64
+		sampleIndex = map[string]int{}
+		key         strings.Builder
+	)
+	for _, r := range readers {
+		for i := 0; i < int(r.Record.NumRows()); i++ {
+			if r.Locations.IsNull(i) {
+				continue
+			}
+
+			stack := b.stack(r, i)
+			if len(stack) == 0 {
+				continue // All frames have been filtered out.
+			}
+
+			value := r.Value.Value(i)
+			total += value
+
+			key.Reset()
+			for _, f := range stack {
+				fmt.Fprintf(&key, "%d;", f)
+			}
+			if idx, ok := sampleIndex[key.String()]; ok {
+				weights[idx] += value
+				continue
+			}
+
+			sampleIndex[key.String()] = len(samples)
+			samples = append(samples, stack)
+			weights = append(weights, value)
+		}
+	}
+
+	return samples, weights, total
+}
+
+type speedscopeRow struct {
+	r         *profile.RecordReader
+	row       int
+	timestamp int64
+}
+
+func (b *speedscopeBuilder) events(readers []*profile.RecordReader) ([]speedscopeEvent, int64) {
+	rows := []speedscopeRow{}
+	for _, r := range readers {
+		for i := 0; i < int(r.Record.NumRows()); i++ {
+			if r.Locations.IsNull(i) {
+				continue
+			}
+			rows = append(rows, speedscopeRow{r: r, row: i, timestamp: r.Timestamp.Value(i)})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].timestamp < rows[j].timestamp
+	})
+
+	var (
+		events []speedscopeEvent
+		open   []int
+		at     int64
+	)
+	for _, row := range rows {
+		stack := b.stack(row.r, row.row)
+		if len(stack) == 0 {
+			continue // All frames have been filtered out.
+		}
+
+		// Frames shared with the previous stack stay open, so that
+		// consecutive samples of the same call are merged.
+		common := 0
+		for common < len(open) && common < len(stack) && open[common] == stack[common] {
+			common++
+		}
+		for k := len(open) - 1; k >= common; k-- {
+			events = append(events, speedscopeEvent{Type: "C", Frame: open[k], At: at})
+		}
+		open = open[:common]
+		for _, f := range stack[common:] {
+			events = append(events, speedscopeEvent{Type: "O", Frame: f, At: at})
+			open = append(open, f)
+		}
+
+		at += row.r.Value.Value(row.row)
+	}
+	for k := len(open) - 1; k >= 0; k-- {
+		events = append(events, speedscopeEvent{Type: "C", Frame: open[k], At: at})
+	}
+
+	return events, at
+}
+
+func profileTypeName(m profile.Meta) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", m.Name, m.SampleType.Type, m.SampleType.Unit, m.PeriodType.Type, m.PeriodType.Unit)
+}
+
+// speedscopeUnit maps the sample unit to one of the units known to
+// speedscope, falling back to none.
+func speedscopeUnit(unit string) string {
+	switch unit {
+	case "nanoseconds", "microseconds", "milliseconds", "seconds", "bytes":
+		return unit
+	default:
+		return "none"
+	}
+}
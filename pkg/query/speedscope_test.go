@@ -0,0 +1,154 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateSpeedscopeSampled(t *testing.T) {
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app"}
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+
+	mainLocation := &pprofprofile.Location{ID: 1, Mapping: mapping, Address: 0x1000, Line: []pprofprofile.Line{
+		{Function: mainFunction, Line: 1},
+	}}
+	// bar is inlined into foo.
+	barLocation := &pprofprofile.Location{ID: 2, Mapping: mapping, Address: 0x3000, Line: []pprofprofile.Line{
+		{Function: barFunction, Line: 1},
+		{Function: fooFunction, Line: 2},
+	}}
+	unsymbolizedLocation := &pprofprofile.Location{ID: 3, Mapping: mapping, Address: 0x4000}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{
+		Name:       "parca_agent",
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+		PeriodType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+	}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{barLocation, mainLocation}, Value: []int64{10}},
+			{Location: []*pprofprofile.Location{unsymbolizedLocation, mainLocation}, Value: []int64{5}},
+			{Location: []*pprofprofile.Location{barLocation, mainLocation}, Value: []int64{7}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	buf, total, err := GenerateSpeedscope(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		p,
+		false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(22), total)
+
+	var f speedscopeFile
+	require.NoError(t, json.Unmarshal(buf, &f))
+	require.Equal(t, speedscopeSchema, f.Schema)
+	require.Equal(t, []speedscopeFrame{
+		{Name: "main", File: "main.go", Line: 1},
+		{Name: "foo", File: "main.go", Line: 2},
+		{Name: "bar", File: "main.go", Line: 1},
+		{Name: "0x4000", File: "/usr/bin/app"},
+	}, f.Shared.Frames)
+	require.Len(t, f.Profiles, 1)
+
+	sp := f.Profiles[0]
+	require.Equal(t, "sampled", sp.Type)
+	require.Equal(t, "none", sp.Unit)
+	require.Equal(t, "parca_agent:samples:count:cpu:nanoseconds", sp.Name)
+	require.Equal(t, int64(22), sp.EndValue)
+	require.Equal(t, [][]int{{0, 1, 2}, {0, 3}}, sp.Samples)
+	require.Equal(t, []int64{17, 5}, sp.Weights)
+}
+
+func TestGenerateSpeedscopeEvented(t *testing.T) {
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+
+	mainLocation := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction, Line: 1}}}
+	fooLocation := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction, Line: 1}}}
+	barLocation := &pprofprofile.Location{ID: 3, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction, Line: 1}}}
+
+	// Each sample is taken at a different time, the samples are ordered by
+	// their timestamp rather than the order they are stored in.
+	var samples []arrow.RecordBatch
+	for _, s := range []struct {
+		timestamp int64
+		locations []*pprofprofile.Location
+		value     int64
+	}{
+		{timestamp: 3, locations: []*pprofprofile.Location{barLocation, mainLocation}, value: 5},
+		{timestamp: 1, locations: []*pprofprofile.Location{fooLocation, mainLocation}, value: 10},
+		{timestamp: 2, locations: []*pprofprofile.Location{fooLocation, mainLocation}, value: 2},
+	} {
+		p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+			TimeNanos: s.timestamp,
+			Period:    1,
+			Sample:    []*pprofprofile.Sample{{Location: s.locations, Value: []int64{s.value}}},
+		}, 0, []string{})
+		require.NoError(t, err)
+		defer p.Samples[0].Release()
+		samples = append(samples, p.Samples...)
+	}
+
+	buf, total, err := GenerateSpeedscope(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		profile.Profile{
+			Meta: profile.Meta{
+				SampleType: profile.ValueType{Type: "samples", Unit: "nanoseconds"},
+			},
+			Samples: samples,
+		},
+		true,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(17), total)
+
+	var f speedscopeFile
+	require.NoError(t, json.Unmarshal(buf, &f))
+	require.Equal(t, []speedscopeFrame{
+		{Name: "main", File: "main.go", Line: 1},
+		{Name: "foo", File: "main.go", Line: 1},
+		{Name: "bar", File: "main.go", Line: 1},
+	}, f.Shared.Frames)
+	require.Len(t, f.Profiles, 1)
+
+	sp := f.Profiles[0]
+	require.Equal(t, "evented", sp.Type)
+	require.Equal(t, "nanoseconds", sp.Unit)
+	require.Equal(t, int64(17), sp.EndValue)
+	require.Equal(t, []speedscopeEvent{
+		{Type: "O", Frame: 0, At: 0},
+		{Type: "O", Frame: 1, At: 0},
+		{Type: "C", Frame: 1, At: 12},
+		{Type: "O", Frame: 2, At: 12},
+		{Type: "C", Frame: 2, At: 17},
+		{Type: "C", Frame: 0, At: 17},
+	}, sp.Events)
+}
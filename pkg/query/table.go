@@ -49,6 +49,12 @@ const (
 	TableFieldFlat           = "flat"
 	TableFieldFlatDiff       = "flat_diff"
 
+	// TableFieldCumulativeSecondary and TableFieldFlatSecondary hold the
+	// values of the secondary profile type of combined profiles, see
+	// CombineProfiles.
+	TableFieldCumulativeSecondary = "cumulative_secondary"
+	TableFieldFlatSecondary       = "flat_secondary"
+
 	TableFieldCallers = "callers"
 	TableFieldCallees = "callees"
 )
@@ -58,6 +64,7 @@ func GenerateTable(
 	mem memory.Allocator,
 	tracer trace.Tracer,
 	p profile.Profile,
+	significance DiffSignificance,
 ) (*queryv1alpha1.TableArrow, int64, error) {
 	ctx, span := tracer.Start(ctx, "GenerateTable")
 	defer span.End()
@@ -68,6 +75,28 @@ func GenerateTable(
 	}
 	defer record.Release()
 
+	if significance != nil {
+		withPValues, err := withSignificanceColumn(mem, record, TableFieldFunctionName, TableFieldFunctionFileName, TableFieldPValue, significance)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer withPValues.Release()
+		record = withPValues
+	}
+
+	table, err := newTableArrow(span, mem, record, p.Meta.SampleType.Unit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return table, cumulative, nil
+}
+
+func newTableArrow(
+	span trace.Span,
+	mem memory.Allocator,
+	record arrow.RecordBatch,
+	unit string,
+) (*queryv1alpha1.TableArrow, error) {
 	// TODO: Reuse buffer and potentially writers
 	var buf bytes.Buffer
 	w := ipc.NewWriter(&buf,
@@ -77,8 +106,8 @@ func GenerateTable(
 	)
 	defer w.Close()
 
-	if err = w.Write(record); err != nil {
-		return nil, 0, err
+	if err := w.Write(record); err != nil {
+		return nil, err
 	}
 
 	span.SetAttributes(attribute.Int("record_size", buf.Len()))
@@ -88,8 +117,8 @@ func GenerateTable(
 
 	return &queryv1alpha1.TableArrow{
 		Record: buf.Bytes(),
-		Unit:   p.Meta.SampleType.Unit,
-	}, cumulative, nil
+		Unit:   unit,
+	}, nil
 }
 
 // isFirstNonNil returns true if the row is the first non-nil value in the list found at the given row.
@@ -126,7 +155,7 @@ func generateTableArrowRecord(
 		return nil, 0, fmt.Errorf("failed to create profile reader: %w", err)
 	}
 
-	tb := newTableBuilder(mem, estimateTableRows(profileReader))
+	tb := newTableBuilder(mem, estimateTableRows(profileReader), hasSecondaryValues(p))
 	defer tb.Release()
 
 	tableRow := 0
@@ -227,10 +256,14 @@ type tableBuilder struct {
 	builderFlatDiff           *builder.OptInt64Builder
 	builderCallers            *builder.ListBuilder
 	builderCallees            *builder.ListBuilder
+
+	// Only set if the samples carry the values of a secondary profile type.
+	builderCumulativeSecondary *builder.OptInt64Builder
+	builderFlatSecondary       *builder.OptInt64Builder
 }
 
-func newTableBuilder(mem memory.Allocator, rowCountEstimate int) *tableBuilder {
-	schema := arrow.NewSchema([]arrow.Field{
+func newTableBuilder(mem memory.Allocator, rowCountEstimate int, secondary bool) *tableBuilder {
+	fields := []arrow.Field{
 		{Name: TableFieldMappingFile, Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint16, ValueType: arrow.BinaryTypes.String}},
 		{Name: TableFieldMappingBuildID, Type: &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Uint16, ValueType: arrow.BinaryTypes.String}},
 		// Location
@@ -250,7 +283,14 @@ func newTableBuilder(mem memory.Allocator, rowCountEstimate int) *tableBuilder {
 		// Call View
 		{Name: TableFieldCallers, Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)},
 		{Name: TableFieldCallees, Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)},
-	}, nil)
+	}
+	if secondary {
+		fields = append(fields,
+			arrow.Field{Name: TableFieldCumulativeSecondary, Type: arrow.PrimitiveTypes.Int64},
+			arrow.Field{Name: TableFieldFlatSecondary, Type: arrow.PrimitiveTypes.Int64},
+		)
+	}
+	schema := arrow.NewSchema(fields, nil)
 
 	rb := builder.NewRecordBuilder(mem, schema)
 
@@ -278,6 +318,10 @@ func newTableBuilder(mem memory.Allocator, rowCountEstimate int) *tableBuilder {
 		builderCallers:            rb.Field(schema.FieldIndices(TableFieldCallers)[0]).(*builder.ListBuilder),
 		builderCallees:            rb.Field(schema.FieldIndices(TableFieldCallees)[0]).(*builder.ListBuilder),
 	}
+	if secondary {
+		tb.builderCumulativeSecondary = rb.Field(schema.FieldIndices(TableFieldCumulativeSecondary)[0]).(*builder.OptInt64Builder)
+		tb.builderFlatSecondary = rb.Field(schema.FieldIndices(TableFieldFlatSecondary)[0]).(*builder.OptInt64Builder)
+	}
 
 	return tb
 }
@@ -422,6 +466,14 @@ func (tb *tableBuilder) appendRow(
 				// don't set null as it might also just be merged into a bigger number.
 				tb.builderFlatDiff.Append(0)
 			}
+		case TableFieldCumulativeSecondary:
+			tb.builderCumulativeSecondary.Append(secondaryValue(r, sampleRow))
+		case TableFieldFlatSecondary:
+			if leaf {
+				tb.builderFlatSecondary.Append(secondaryValue(r, sampleRow))
+			} else {
+				tb.builderFlatSecondary.Append(0)
+			}
 		case TableFieldCallers:
 			tb.addCaller(previousTableRow, int64(currentTableRow))
 		case TableFieldCallees:
@@ -446,10 +498,26 @@ func (tb *tableBuilder) mergeRow(r *profile.RecordReader, mergeRow, sampleRow, _
 		}
 	}
 
+	if tb.builderCumulativeSecondary != nil {
+		tb.builderCumulativeSecondary.Add(mergeRow, secondaryValue(r, sampleRow))
+		if isLeaf {
+			tb.builderFlatSecondary.Add(mergeRow, secondaryValue(r, sampleRow))
+		}
+	}
+
 	tb.addCaller(previousTableRow, int64(currentTableRow))
 	tb.addCallee(currentTableRow, int64(previousTableRow))
 }
 
+// secondaryValue returns the value of the secondary profile type of the
+// sample, which is zero if the record doesn't have any.
+func secondaryValue(r *profile.RecordReader, sampleRow int) int64 {
+	if r.ValueSecondary == nil {
+		return 0
+	}
+	return r.ValueSecondary.Value(sampleRow)
+}
+
 func (tb *tableBuilder) addCaller(idx int, caller int64) {
 	if caller == -1 || idx == -1 {
 		return
@@ -15,65 +15,92 @@ package query
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/parcacol"
 	parcaprofile "github.com/parca-dev/parca/pkg/profile"
 )
 
-func GenerateTopTable(ctx context.Context, p parcaprofile.OldProfile) (*pb.Top, int64, error) {
+// GenerateTopTable generates the top table of the profile. The values are
+// first collected per location ID, the location nodes are then merged by the
+// name of their first function, or by mapping and address for unsymbolized
+// locations.
+func GenerateTopTable(
+	ctx context.Context,
+	p parcaprofile.Profile,
+	converter *parcacol.ArrowToProfileConverter,
+) (*pb.Top, int64, error) {
 	// Iterate over all samples and their locations.
 	// Calculate the cumulative value of all locations of all samples.
 	// In the end return a *pb.TopNode for each location including all the metadata we have.
 	locationsTopNodes := map[string]*pb.TopNode{}
+	list := []*pb.TopNode{}
 	cumulative := int64(0)
-	for _, sample := range p.Samples {
-		cumulative += sample.Value
-		for i, location := range sample.Locations {
-			if node, found := locationsTopNodes[location.ID]; found {
-				node.Cumulative += sample.Value
-				node.Diff += sample.DiffValue
-
-				if i == 0 {
-					node.Flat += sample.Value
+
+	lr := converter.NewLocationResolver()
+	for _, rec := range p.Samples {
+		r, err := parcaprofile.NewRecordReader(rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create record reader: %w", err)
+		}
+		lr.Reset(r)
+
+		for row := 0; row < int(rec.NumRows()); row++ {
+			value := r.Value.Value(row)
+			diff := r.Diff.Value(row)
+			cumulative += value
+
+			beg, end := r.Locations.ValueOffsets(row)
+			leaf := true
+			for j := int(beg); j < int(end); j++ {
+				if r.Locations.ListValues().IsNull(j) {
+					continue // The location has been filtered out.
 				}
-			} else {
-				node := &pb.TopNode{
-					Cumulative: sample.Value,
-					Diff:       sample.DiffValue,
-					Meta: &pb.TopNodeMeta{
-						Mapping: location.Mapping,
-						Location: &metastorev1alpha1.Location{
-							Id:        location.ID,
-							Address:   location.Address,
-							MappingId: location.Mapping.GetId(),
-							IsFolded:  location.IsFolded,
+
+				location := lr.Location(j)
+				if node, found := locationsTopNodes[location.ID]; found {
+					node.Cumulative += value
+					node.Diff += diff
+
+					if leaf {
+						node.Flat += value
+					}
+				} else {
+					node := &pb.TopNode{
+						Cumulative: value,
+						Diff:       diff,
+						Meta: &pb.TopNodeMeta{
+							Mapping: location.Mapping,
+							Location: &metastorev1alpha1.Location{
+								Id:        location.ID,
+								Address:   location.Address,
+								MappingId: location.Mapping.GetId(),
+								IsFolded:  location.IsFolded,
+							},
 						},
-					},
-				}
-				if len(location.Lines) > 0 {
-					// TODO: Return or merge multiple lines for samples
-					node.Meta.Function = location.Lines[0].Function
-					node.Meta.Line = &metastorev1alpha1.Line{
-						FunctionId: location.Lines[0].Function.Id,
-						Line:       location.Lines[0].Line,
 					}
+					if len(location.Lines) > 0 {
+						// TODO: Return or merge multiple lines for samples
+						node.Meta.Function = location.Lines[0].Function
+						node.Meta.Line = &metastorev1alpha1.Line{
+							FunctionId: location.Lines[0].Function.GetId(),
+							Line:       location.Lines[0].Line,
+						}
+					}
+					if leaf {
+						node.Flat = value
+					}
+					locationsTopNodes[location.ID] = node
+					list = append(list, node)
 				}
-				if i == 0 {
-					node.Flat = sample.Value
-					node.Diff = sample.DiffValue
-				}
-				locationsTopNodes[location.ID] = node
+				leaf = false
 			}
 		}
 	}
 
-	list := make([]*pb.TopNode, 0, len(locationsTopNodes))
-	for _, f := range locationsTopNodes {
-		list = append(list, f)
-	}
-
 	top := &pb.Top{
 		List:     list,
 		Reported: int32(len(list)),
@@ -0,0 +1,323 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// defaultTopRegressionsMinSignificance is the minimum share increase in
+// percentage points for a function to be reported, smaller changes are
+// usually noise.
+const defaultTopRegressionsMinSignificance = 0.1
+
+// TopRegressions compares the merged profiles of the baseline and candidate
+// time windows and returns the functions whose share of the profile grew the
+// most.
+func (q *ColumnQueryAPI) TopRegressions(ctx context.Context, req *pb.TopRegressionsRequest) (*pb.TopRegressionsResponse, error) {
+	ctx, span := q.tracer.Start(ctx, "TopRegressions")
+	defer span.End()
+
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	baselineDuration, err := topRegressionsWindow("baseline", req.GetBaselineStart(), req.GetBaselineEnd())
+	if err != nil {
+		return nil, err
+	}
+	candidateDuration, err := topRegressionsWindow("candidate", req.GetCandidateStart(), req.GetCandidateEnd())
+	if err != nil {
+		return nil, err
+	}
+	minSignificance := defaultTopRegressionsMinSignificance
+	if req.MinSignificance != nil {
+		minSignificance = req.GetMinSignificance()
+	}
+
+	// The windows are merged separately instead of through selectDiff and
+	// ComputeDiff: the diff profile's values are scaled by the ratio of the
+	// totals while the windows are normalized by their duration here, and
+	// the table report drops the cumulative diff of the first sample of a row
+	// if it is not positive, so the baseline values could not be recovered
+	// from the diff. Both windows still go through the table report
+	// aggregation.
+	g, gctx := errgroup.WithContext(ctx)
+	var baseline, candidate profile.Profile
+	defer func() {
+		for _, r := range baseline.Samples {
+			r.Release()
+		}
+		for _, r := range candidate.Samples {
+			r.Release()
+		}
+	}()
+	g.Go(func() error {
+		var err error
+		baseline, err = q.selectMerge(gctx, &pb.MergeProfile{
+			Query: req.GetQuery(),
+			Start: req.GetBaselineStart(),
+			End:   req.GetBaselineEnd(),
+		}, []string{}, false, "")
+		if err != nil {
+			return fmt.Errorf("reading baseline profile: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		candidate, err = q.selectMerge(gctx, &pb.MergeProfile{
+			Query: req.GetQuery(),
+			Start: req.GetCandidateStart(),
+			End:   req.GetCandidateEnd(),
+		}, []string{}, false, "")
+		if err != nil {
+			return fmt.Errorf("reading candidate profile: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return GenerateTopRegressions(
+		ctx,
+		q.tracer,
+		q.mem,
+		baseline,
+		candidate,
+		baselineDuration,
+		candidateDuration,
+		minSignificance,
+		int(req.GetLimit()),
+	)
+}
+
+func topRegressionsWindow(name string, start, end *timestamppb.Timestamp) (time.Duration, error) {
+	if start == nil || end == nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s start and end are required", name)
+	}
+	d := end.AsTime().Sub(start.AsTime())
+	if d <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s end must be after its start", name)
+	}
+	return d, nil
+}
+
+// GenerateTopRegressions ranks the functions of the candidate profile by the
+// increase of their flat and cumulative share compared to the baseline
+// profile. The values of both profiles are normalized by the duration of their
+// time window. Functions whose shares increased by less than minSignificance
+// percentage points are omitted, as are all but the first limit functions
+// unless limit is zero.
+func GenerateTopRegressions(
+	ctx context.Context,
+	tracer trace.Tracer,
+	mem memory.Allocator,
+	baseline, candidate profile.Profile,
+	baselineDuration, candidateDuration time.Duration,
+	minSignificance float64,
+	limit int,
+) (*pb.TopRegressionsResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenerateTopRegressions")
+	defer span.End()
+
+	b := &topRegressionsBuilder{index: map[topRegressionsFunctionKey]int{}}
+	baselineTotal, err := b.add(ctx, mem, tracer, baseline, false)
+	if err != nil {
+		return nil, err
+	}
+	candidateTotal, err := b.add(ctx, mem, tracer, candidate, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		baselineSeconds  = baselineDuration.Seconds()
+		candidateSeconds = candidateDuration.Seconds()
+		res              = &pb.TopRegressionsResponse{
+			Unit:                    candidate.Meta.SampleType.Unit,
+			BaselineTotalPerSecond:  float64(baselineTotal) / baselineSeconds,
+			CandidateTotalPerSecond: float64(candidateTotal) / candidateSeconds,
+		}
+	)
+
+	for _, f := range b.functions {
+		reg := &pb.TopRegression{
+			FunctionName:                 f.name,
+			FunctionFileName:             f.fileName,
+			BaselineFlatPerSecond:        float64(f.baseline.flat) / baselineSeconds,
+			BaselineCumulativePerSecond:  float64(f.baseline.cumulative) / baselineSeconds,
+			CandidateFlatPerSecond:       float64(f.candidate.flat) / candidateSeconds,
+			CandidateCumulativePerSecond: float64(f.candidate.cumulative) / candidateSeconds,
+		}
+		reg.FlatShareChange = shareChange(
+			reg.BaselineFlatPerSecond, res.BaselineTotalPerSecond,
+			reg.CandidateFlatPerSecond, res.CandidateTotalPerSecond,
+		)
+		reg.CumulativeShareChange = shareChange(
+			reg.BaselineCumulativePerSecond, res.BaselineTotalPerSecond,
+			reg.CandidateCumulativePerSecond, res.CandidateTotalPerSecond,
+		)
+		if reg.FlatShareChange < minSignificance && reg.CumulativeShareChange < minSignificance {
+			continue
+		}
+		reg.Score = reg.FlatShareChange + reg.CumulativeShareChange
+		res.Regressions = append(res.Regressions, reg)
+	}
+
+	sort.Slice(res.Regressions, func(i, j int) bool {
+		a, b := res.Regressions[i], res.Regressions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.FunctionName != b.FunctionName {
+			return a.FunctionName < b.FunctionName
+		}
+		return a.FunctionFileName < b.FunctionFileName
+	})
+	if limit > 0 && len(res.Regressions) > limit {
+		res.Regressions = res.Regressions[:limit]
+	}
+
+	return res, nil
+}
+
+type topRegressionsFunctionKey struct {
+	name     string
+	fileName string
+}
+
+type topRegressionsValues struct {
+	flat       int64
+	cumulative int64
+}
+
+type topRegressionsFunction struct {
+	topRegressionsFunctionKey
+	baseline  topRegressionsValues
+	candidate topRegressionsValues
+}
+
+// topRegressionsBuilder sums the values of the table rows of the baseline and
+// candidate profile by function.
+type topRegressionsBuilder struct {
+	functions []*topRegressionsFunction
+	index     map[topRegressionsFunctionKey]int
+}
+
+// add adds the flat and cumulative values of the functions of the profile to
+// the baseline or candidate values and returns the total of the profile.
+func (b *topRegressionsBuilder) add(
+	ctx context.Context,
+	mem memory.Allocator,
+	tracer trace.Tracer,
+	p profile.Profile,
+	isCandidate bool,
+) (int64, error) {
+	record, total, err := generateTableArrowRecord(ctx, mem, tracer, p)
+	if err != nil {
+		return 0, err
+	}
+	defer record.Release()
+
+	schema := record.Schema()
+	columns := make(map[string]arrow.Array, 6)
+	for _, name := range []string{
+		TableFieldMappingFile,
+		TableFieldLocationAddress,
+		TableFieldFunctionName,
+		TableFieldFunctionFileName,
+		TableFieldCumulative,
+		TableFieldFlat,
+	} {
+		indices := schema.FieldIndices(name)
+		if len(indices) != 1 {
+			return 0, fmt.Errorf("invalid %s indices: %v", name, indices)
+		}
+		columns[name] = record.Column(indices[0])
+	}
+	var (
+		mappingFile  = columns[TableFieldMappingFile].(*array.Dictionary)
+		address      = columns[TableFieldLocationAddress].(*array.Uint64)
+		functionName = columns[TableFieldFunctionName].(*array.Dictionary)
+		fileName     = columns[TableFieldFunctionFileName].(*array.Dictionary)
+		cumulative   = columns[TableFieldCumulative].(*array.Int64)
+		flat         = columns[TableFieldFlat].(*array.Int64)
+	)
+	dictValue := func(d *array.Dictionary, i int) []byte {
+		if d.IsNull(i) {
+			return nil
+		}
+		return []byte(d.Dictionary().(*array.String).Value(d.GetValueIndex(i)))
+	}
+
+	for i := 0; i < int(record.NumRows()); i++ {
+		var key topRegressionsFunctionKey
+		if name := dictValue(functionName, i); name != nil {
+			key.name = string(name)
+			key.fileName = string(dictValue(fileName, i))
+		} else {
+			file := dictValue(mappingFile, i)
+			key.name = chromeTraceFrameName(stackFrame{
+				name:         []byte(fmt.Sprintf("0x%x", address.Value(i))),
+				file:         file,
+				unsymbolized: true,
+			})
+			key.fileName = string(file)
+		}
+
+		idx, ok := b.index[key]
+		if !ok {
+			idx = len(b.functions)
+			b.index[key] = idx
+			b.functions = append(b.functions, &topRegressionsFunction{topRegressionsFunctionKey: key})
+		}
+		v := &b.functions[idx].baseline
+		if isCandidate {
+			v = &b.functions[idx].candidate
+		}
+		v.flat += flat.Value(i)
+		v.cumulative += cumulative.Value(i)
+	}
+
+	return total, nil
+}
+
+// shareChange returns the change of the share of the value in its total in
+// percentage points.
+func shareChange(baseline, baselineTotal, candidate, candidateTotal float64) float64 {
+	var baselineShare, candidateShare float64
+	if baselineTotal != 0 {
+		baselineShare = baseline / baselineTotal * 100
+	}
+	if candidateTotal != 0 {
+		candidateShare = candidate / candidateTotal * 100
+	}
+	return candidateShare - baselineShare
+}
@@ -0,0 +1,144 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateTopRegressions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	mapping := &pprofprofile.Mapping{ID: 1, Start: 0x1000, Limit: 0x9000, File: "/usr/bin/app"}
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main", Filename: "main.go"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo", Filename: "main.go"}
+	barFunction := &pprofprofile.Function{ID: 3, Name: "bar", Filename: "main.go"}
+
+	mainLocation := &pprofprofile.Location{ID: 1, Mapping: mapping, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction}}}
+	fooLocation := &pprofprofile.Location{ID: 2, Mapping: mapping, Address: 0x2000, Line: []pprofprofile.Line{{Function: fooFunction}}}
+	barLocation := &pprofprofile.Location{ID: 3, Mapping: mapping, Address: 0x3000, Line: []pprofprofile.Line{{Function: barFunction}}}
+	bazLocation := &pprofprofile.Location{ID: 4, Mapping: mapping, Address: 0x4000}
+
+	baseline, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{fooLocation, mainLocation}, Value: []int64{50}},
+			{Location: []*pprofprofile.Location{barLocation, mainLocation}, Value: []int64{50}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer baseline.Samples[0].Release()
+
+	candidate, err := PprofToSymbolizedProfile(profile.Meta{
+		SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+	}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{fooLocation, mainLocation}, Value: []int64{30}},
+			{Location: []*pprofprofile.Location{barLocation, mainLocation}, Value: []int64{10}},
+			{Location: []*pprofprofile.Location{bazLocation, mainLocation}, Value: []int64{10}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer candidate.Samples[0].Release()
+
+	// The baseline window is twice as long, so both have 10 samples per
+	// second.
+	res, err := GenerateTopRegressions(context.Background(), tracer, mem, baseline, candidate, 10*time.Second, 5*time.Second, 0.1, 0)
+	require.NoError(t, err)
+	require.Equal(t, "count", res.Unit)
+	require.InDelta(t, 10, res.BaselineTotalPerSecond, 1e-9)
+	require.InDelta(t, 10, res.CandidateTotalPerSecond, 1e-9)
+
+	// bar's share decreased and main's did not change, so neither of them
+	// are reported.
+	require.Len(t, res.Regressions, 2)
+
+	baz := res.Regressions[0]
+	require.Equal(t, "[app] 0x4000", baz.FunctionName)
+	require.Equal(t, "/usr/bin/app", baz.FunctionFileName)
+	require.InDelta(t, 0, baz.BaselineFlatPerSecond, 1e-9)
+	require.InDelta(t, 2, baz.CandidateFlatPerSecond, 1e-9)
+	require.InDelta(t, 2, baz.CandidateCumulativePerSecond, 1e-9)
+	require.InDelta(t, 20, baz.FlatShareChange, 1e-9)
+	require.InDelta(t, 20, baz.CumulativeShareChange, 1e-9)
+	require.InDelta(t, 40, baz.Score, 1e-9)
+
+	foo := res.Regressions[1]
+	require.Equal(t, "foo", foo.FunctionName)
+	require.Equal(t, "main.go", foo.FunctionFileName)
+	require.InDelta(t, 5, foo.BaselineFlatPerSecond, 1e-9)
+	require.InDelta(t, 5, foo.BaselineCumulativePerSecond, 1e-9)
+	require.InDelta(t, 6, foo.CandidateFlatPerSecond, 1e-9)
+	require.InDelta(t, 6, foo.CandidateCumulativePerSecond, 1e-9)
+	require.InDelta(t, 10, foo.FlatShareChange, 1e-9)
+	require.InDelta(t, 10, foo.CumulativeShareChange, 1e-9)
+	require.InDelta(t, 20, foo.Score, 1e-9)
+
+	// Changes below the significance threshold are omitted.
+	res, err = GenerateTopRegressions(context.Background(), tracer, mem, baseline, candidate, 10*time.Second, 5*time.Second, 15, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Regressions, 1)
+	require.Equal(t, "[app] 0x4000", res.Regressions[0].FunctionName)
+
+	res, err = GenerateTopRegressions(context.Background(), tracer, mem, baseline, candidate, 10*time.Second, 5*time.Second, 0.1, 1)
+	require.NoError(t, err)
+	require.Len(t, res.Regressions, 1)
+	require.Equal(t, "[app] 0x4000", res.Regressions[0].FunctionName)
+
+	// Functions that decreased keep their baseline values.
+	res, err = GenerateTopRegressions(context.Background(), tracer, mem, candidate, baseline, 5*time.Second, 10*time.Second, -100, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Regressions, 4)
+	baz = res.Regressions[3]
+	require.Equal(t, "[app] 0x4000", baz.FunctionName)
+	require.InDelta(t, 2, baz.BaselineFlatPerSecond, 1e-9)
+	require.InDelta(t, 2, baz.BaselineCumulativePerSecond, 1e-9)
+	require.InDelta(t, 0, baz.CandidateFlatPerSecond, 1e-9)
+	require.InDelta(t, -20, baz.FlatShareChange, 1e-9)
+}
+
+func TestTopRegressionsInvalidWindow(t *testing.T) {
+	api := &ColumnQueryAPI{tracer: noop.NewTracerProvider().Tracer("")}
+	now := time.Now()
+
+	_, err := api.TopRegressions(context.Background(), &pb.TopRegressionsRequest{
+		Query:          "parca_agent:samples:count:cpu:nanoseconds:delta",
+		BaselineStart:  timestamppb.New(now),
+		BaselineEnd:    timestamppb.New(now.Add(-time.Minute)),
+		CandidateStart: timestamppb.New(now),
+		CandidateEnd:   timestamppb.New(now.Add(time.Minute)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = api.TopRegressions(context.Background(), &pb.TopRegressionsRequest{
+		Query:         "parca_agent:samples:count:cpu:nanoseconds:delta",
+		BaselineStart: timestamppb.New(now),
+		BaselineEnd:   timestamppb.New(now.Add(time.Minute)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -17,8 +17,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	pprofprofile "github.com/google/pprof/profile"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
@@ -42,10 +44,7 @@ func TestGenerateTopTable(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	op, err := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()).Convert(ctx, p)
-	require.NoError(t, err)
-
-	res, cummulative, err := GenerateTopTable(ctx, op)
+	res, cummulative, err := GenerateTopTable(ctx, p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()))
 	require.NoError(t, err)
 
 	//nolint:staticcheck // SA1019: Fow now we want to support these APIs
@@ -118,10 +117,7 @@ func TestGenerateTopTableAggregateFlat(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	op, err := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()).Convert(ctx, p)
-	require.NoError(t, err)
-
-	top, _, err := GenerateTopTable(ctx, op)
+	top, _, err := GenerateTopTable(ctx, p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()))
 	require.NoError(t, err)
 
 	require.Equal(t, 4, len(top.List))
@@ -353,3 +349,115 @@ func TestAggregateTopByFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateTopTableFrameFilter(t *testing.T) {
+	ctx := context.Background()
+
+	p := frameFilteredTestProfile(t)
+	defer func() {
+		for _, r := range p.Samples {
+			r.Release()
+		}
+	}()
+
+	top, cumulative, err := GenerateTopTable(ctx, p, parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker()))
+	require.NoError(t, err)
+	require.Equal(t, int64(10), cumulative)
+
+	// The filtered runtime frame inlined into foo is gone, foo is now the
+	// first function of its location.
+	flat := map[string]int64{}
+	for _, n := range top.List {
+		flat[n.Meta.GetFunction().GetName()] = n.Flat
+	}
+	require.Equal(t, map[string]int64{"foo": 10, "main": 0}, flat)
+}
+
+// frameFilteredTestProfile returns a profile whose leaf location has a
+// runtime function inlined into foo, with the runtime frames filtered out,
+// which leaves a null line in the location.
+func frameFilteredTestProfile(t *testing.T) profile.Profile {
+	t.Helper()
+
+	mainFunction := &pprofprofile.Function{ID: 1, Name: "main"}
+	fooFunction := &pprofprofile.Function{ID: 2, Name: "foo"}
+	runtimeFunction := &pprofprofile.Function{ID: 3, Name: "runtime.memmove"}
+
+	main := &pprofprofile.Location{ID: 1, Address: 0x1000, Line: []pprofprofile.Line{{Function: mainFunction, Line: 1}}}
+	foo := &pprofprofile.Location{ID: 2, Address: 0x2000, Line: []pprofprofile.Line{
+		{Function: runtimeFunction, Line: 2},
+		{Function: fooFunction, Line: 3},
+	}}
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, &pprofprofile.Profile{
+		Sample: []*pprofprofile.Sample{
+			{Location: []*pprofprofile.Location{foo, main}, Value: []int64{10}},
+		},
+	}, 0, []string{})
+	require.NoError(t, err)
+	defer p.Samples[0].Release()
+
+	recs, _, err := FilterProfileData(
+		context.Background(),
+		noop.NewTracerProvider().Tracer(""),
+		memory.DefaultAllocator,
+		p.Samples,
+		[]*pb.Filter{{
+			Filter: &pb.Filter_FrameFilter{
+				FrameFilter: &pb.FrameFilter{
+					Filter: &pb.FrameFilter_Criteria{
+						Criteria: &pb.FilterCriteria{
+							FunctionName: &pb.StringCondition{
+								Condition: &pb.StringCondition_NotStartsWith{NotStartsWith: "runtime."},
+							},
+						},
+					},
+				},
+			},
+		}},
+	)
+	require.NoError(t, err)
+
+	return profile.Profile{Samples: recs}
+}
+
+// BenchmarkConvertProfile is the baseline for BenchmarkGenerateTopTable and
+// BenchmarkGenerateCallgraph. Before they read the Arrow records directly,
+// both reports converted the whole profile like this first.
+func BenchmarkConvertProfile(b *testing.B) {
+	ctx := context.Background()
+
+	pp, err := pprofprofile.ParseData(MustReadAllGzip(b, "testdata/alloc_objects.pb.gz"))
+	require.NoError(b, err)
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, pp, 0, []string{})
+	require.NoError(b, err)
+
+	converter := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := converter.Convert(ctx, p)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkGenerateTopTable(b *testing.B) {
+	ctx := context.Background()
+
+	pp, err := pprofprofile.ParseData(MustReadAllGzip(b, "testdata/alloc_objects.pb.gz"))
+	require.NoError(b, err)
+
+	p, err := PprofToSymbolizedProfile(profile.Meta{}, pp, 0, []string{})
+	require.NoError(b, err)
+
+	converter := parcacol.NewArrowToProfileConverter(nil, kv.NewKeyMaker())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := GenerateTopTable(ctx, p, converter)
+		require.NoError(b, err)
+	}
+}
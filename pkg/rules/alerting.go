@@ -0,0 +1,273 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+type alertState int
+
+const (
+	alertStatePending alertState = iota
+	alertStateFiring
+)
+
+type alertingRule struct {
+	cfg     *config.AlertingRule
+	filters []*pb.Filter
+	notify  func(ctx context.Context, alert string, alerts []webhookAlert)
+
+	mtx            sync.Mutex // Guards the fields below.
+	lastEvaluation time.Time
+	lastError      error
+	// active holds the pending and firing alerts by their labels.
+	active map[string]*alert
+}
+
+type alert struct {
+	state       alertState
+	labels      labels.Labels
+	annotations map[string]string
+	value       float64
+	activeAt    time.Time
+	firedAt     time.Time
+}
+
+func newAlertingRule(
+	cfg *config.AlertingRule,
+	notify func(ctx context.Context, alert string, alerts []webhookAlert),
+) *alertingRule {
+	return &alertingRule{
+		cfg:     cfg,
+		filters: stackFilters(cfg.StackFilters),
+		notify:  notify,
+		active:  map[string]*alert{},
+	}
+}
+
+func (r *alertingRule) evaluate(ctx context.Context, querier Querier, ts time.Time) error {
+	samples, err := r.query(ctx, querier, ts)
+
+	r.mtx.Lock()
+	r.lastEvaluation = ts
+	r.lastError = err
+	if err != nil {
+		// Keep the alerts as they are until the next evaluation succeeds.
+		r.mtx.Unlock()
+		return err
+	}
+
+	var notifications []webhookAlert
+	seen := make(map[string]struct{}, len(samples))
+	for _, s := range samples {
+		if s.value <= r.cfg.Threshold {
+			continue
+		}
+
+		lbls := r.alertLabels(s.labelValues)
+		key := lbls.String()
+		seen[key] = struct{}{}
+
+		a, ok := r.active[key]
+		if !ok {
+			a = &alert{
+				state:    alertStatePending,
+				labels:   lbls,
+				activeAt: ts,
+			}
+			r.active[key] = a
+		}
+		a.value = s.value
+		a.annotations = expandAnnotations(r.cfg.Annotations, lbls, s.value)
+
+		if a.state == alertStatePending && ts.Sub(a.activeAt) >= time.Duration(r.cfg.For) {
+			a.state = alertStateFiring
+			a.firedAt = ts
+			notifications = append(notifications, a.webhookAlert(time.Time{}))
+		}
+	}
+
+	for key, a := range r.active {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		delete(r.active, key)
+		if a.state == alertStateFiring {
+			notifications = append(notifications, a.webhookAlert(ts))
+		}
+	}
+	r.mtx.Unlock()
+
+	r.notify(ctx, r.cfg.Alert, notifications)
+	return nil
+}
+
+// query returns the values of the rule, the share of the filtered stacks if
+// configured.
+func (r *alertingRule) query(ctx context.Context, querier Querier, ts time.Time) ([]sample, error) {
+	interval := time.Duration(r.cfg.Interval)
+	samples, err := queryLatest(ctx, querier, r.cfg.Query, r.filters, r.cfg.SumBy, interval, ts)
+	if err != nil {
+		return nil, err
+	}
+	if !r.cfg.Share {
+		return samples, nil
+	}
+
+	totals, err := queryLatest(ctx, querier, r.cfg.Query, nil, r.cfg.SumBy, interval, ts)
+	if err != nil {
+		return nil, err
+	}
+	total := make(map[string]float64, len(totals))
+	for _, t := range totals {
+		total[labelValuesKey(t.labelValues)] = t.value
+	}
+
+	shares := make([]sample, 0, len(samples))
+	for _, s := range samples {
+		if t := total[labelValuesKey(s.labelValues)]; t > 0 {
+			shares = append(shares, sample{labelValues: s.labelValues, value: s.value / t})
+		}
+	}
+	return shares, nil
+}
+
+func (r *alertingRule) alertLabels(labelValues []string) labels.Labels {
+	b := labels.NewBuilder(labels.EmptyLabels())
+	for i, name := range r.cfg.SumBy {
+		b.Set(name, labelValues[i])
+	}
+	for name, value := range r.cfg.Labels {
+		b.Set(name, value)
+	}
+	b.Set(labels.AlertName, r.cfg.Alert)
+	return b.Labels()
+}
+
+// resolveAll resolves the firing alerts of a removed rule.
+func (r *alertingRule) resolveAll(ts time.Time) []webhookAlert {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var resolved []webhookAlert
+	for key, a := range r.active {
+		delete(r.active, key)
+		if a.state == alertStateFiring {
+			resolved = append(resolved, a.webhookAlert(ts))
+		}
+	}
+	return resolved
+}
+
+// sameAlerts returns whether the rule results in the same alerts as the other
+// one, only their threshold, timing or annotations may differ.
+func (r *alertingRule) sameAlerts(o *alertingRule) bool {
+	return r.cfg.Query == o.cfg.Query &&
+		reflect.DeepEqual(r.cfg.StackFilters, o.cfg.StackFilters) &&
+		r.cfg.Share == o.cfg.Share &&
+		slices.Equal(r.cfg.SumBy, o.cfg.SumBy) &&
+		maps.Equal(r.cfg.Labels, o.cfg.Labels)
+}
+
+// takeOver moves the active alerts and the last evaluation of the stopped rule
+// it replaces to the rule.
+func (r *alertingRule) takeOver(old *alertingRule) {
+	old.mtx.Lock()
+	defer old.mtx.Unlock()
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.active, old.active = old.active, map[string]*alert{}
+	r.lastEvaluation = old.lastEvaluation
+	r.lastError = old.lastError
+}
+
+// alerts returns a copy of the active alerts sorted by their labels.
+func (r *alertingRule) alerts() ([]alert, time.Time, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	alerts := make([]alert, 0, len(r.active))
+	for _, a := range r.active {
+		alerts = append(alerts, *a)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return labels.Compare(alerts[i].labels, alerts[j].labels) < 0
+	})
+	return alerts, r.lastEvaluation, r.lastError
+}
+
+// webhookAlert returns the alert as sent to webhooks, it is resolved if
+// resolvedAt is set.
+func (a *alert) webhookAlert(resolvedAt time.Time) webhookAlert {
+	status := statusFiring
+	if !resolvedAt.IsZero() {
+		status = statusResolved
+	}
+	return webhookAlert{
+		Status:      status,
+		Labels:      a.labels.Map(),
+		Annotations: a.annotations,
+		StartsAt:    a.firedAt,
+		EndsAt:      resolvedAt,
+		Fingerprint: fmt.Sprintf("%016x", a.labels.Hash()),
+	}
+}
+
+// expandAnnotations expands the annotation templates with the $labels and
+// $value of an alert.
+func expandAnnotations(annotations map[string]string, lbls labels.Labels, value float64) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	data := struct {
+		Labels map[string]string
+		Value  float64
+	}{
+		Labels: lbls.Map(),
+		Value:  value,
+	}
+
+	res := make(map[string]string, len(annotations))
+	for name, text := range annotations {
+		t, err := template.New(name).Option("missingkey=zero").Parse("{{$labels := .Labels}}{{$value := .Value}}" + text)
+		if err != nil {
+			res[name] = fmt.Sprintf("<error expanding template: %v>", err)
+			continue
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			res[name] = fmt.Sprintf("<error expanding template: %v>", err)
+			continue
+		}
+		res[name] = buf.String()
+	}
+	return res
+}
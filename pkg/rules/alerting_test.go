@@ -0,0 +1,201 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	alertspb "github.com/parca-dev/parca/gen/proto/go/parca/alerts/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+// shareQuerier returns the filtered value for queries with filters and the
+// total value otherwise.
+type shareQuerier struct {
+	mtx             sync.Mutex
+	filtered, total float64
+}
+
+func (q *shareQuerier) QueryRange(
+	_ context.Context,
+	_ string,
+	_, end time.Time,
+	_ time.Duration,
+	_ uint32,
+	_ []string,
+	filters []*pb.Filter,
+) ([]*pb.MetricsSeries, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	value := q.total
+	if len(filters) > 0 {
+		value = q.filtered
+	}
+	return []*pb.MetricsSeries{
+		series("api", "a", &pb.MetricsSample{Timestamp: timestamppb.New(end), ValuePerSecond: value}),
+	}, nil
+}
+
+func (q *shareQuerier) set(filtered float64) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.filtered = filtered
+}
+
+type webhookReceiver struct {
+	mtx      sync.Mutex
+	messages []webhookMessage
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var msg webhookMessage
+	if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mtx.Lock()
+	r.messages = append(r.messages, msg)
+	r.mtx.Unlock()
+}
+
+func (r *webhookReceiver) received() []webhookMessage {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]webhookMessage(nil), r.messages...)
+}
+
+func TestAlertingRule(t *testing.T) {
+	receiver := &webhookReceiver{}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	querier := &shareQuerier{filtered: 20, total: 100}
+	cfg := &config.AlertingRule{
+		Alert:        "FlateCPUHigh",
+		Query:        `parca_agent:samples:count:cpu:nanoseconds:delta{}`,
+		StackFilters: []*config.StackFilter{{FunctionName: "compress/flate"}},
+		Share:        true,
+		SumBy:        []string{"job"},
+		Threshold:    0.15,
+		For:          model.Duration(10 * time.Minute),
+		Interval:     model.Duration(time.Minute),
+		Labels:       map[string]string{"severity": "warning"},
+		Annotations:  map[string]string{"summary": `{{ $labels.job }} spends {{ $value }} of CPU in flate`},
+	}
+
+	m := NewManager(nil, prometheus.NewRegistry(), querier)
+	require.NoError(t, m.ApplyConfig(&config.Config{
+		AlertingRules: []*config.AlertingRule{cfg},
+		Alerting: &config.AlertingConfig{Webhooks: []*config.WebhookConfig{{
+			URL: srv.URL,
+		}}},
+	}))
+	r := m.rules[kindAlerting+"/"+cfg.Alert]
+
+	ctx := context.Background()
+	start := time.Now()
+
+	// Alerts are pending until the value exceeds the threshold for the duration.
+	m.evaluate(ctx, r, start)
+	resp, err := m.Rules(ctx, &alertspb.RulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Rules, 1)
+	require.Empty(t, resp.Rules[0].LastError)
+	require.Len(t, resp.Rules[0].Alerts, 1)
+	alert := resp.Rules[0].Alerts[0]
+	require.Equal(t, alertspb.Alert_STATE_PENDING, alert.State)
+	require.Equal(t, 0.2, alert.Value)
+	require.Equal(t, "api spends 0.2 of CPU in flate", alert.Annotations["summary"])
+	require.Empty(t, receiver.received())
+
+	m.evaluate(ctx, r, start.Add(10*time.Minute))
+	resp, err = m.Rules(ctx, &alertspb.RulesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, alertspb.Alert_STATE_FIRING, resp.Rules[0].Alerts[0].State)
+
+	messages := receiver.received()
+	require.Len(t, messages, 1)
+	require.Equal(t, "4", messages[0].Version)
+	require.Equal(t, statusFiring, messages[0].Status)
+	require.Equal(t, map[string]string{"alertname": "FlateCPUHigh"}, messages[0].GroupLabels)
+	require.Len(t, messages[0].Alerts, 1)
+	require.Equal(t, map[string]string{
+		"alertname": "FlateCPUHigh",
+		"job":       "api",
+		"severity":  "warning",
+	}, messages[0].Alerts[0].Labels)
+	require.True(t, messages[0].Alerts[0].EndsAt.IsZero())
+
+	// Firing alerts don't notify again.
+	m.evaluate(ctx, r, start.Add(11*time.Minute))
+	require.Len(t, receiver.received(), 1)
+
+	// Alerts below the threshold are resolved.
+	querier.set(10)
+	m.evaluate(ctx, r, start.Add(12*time.Minute))
+	resp, err = m.Rules(ctx, &alertspb.RulesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Rules[0].Alerts)
+
+	messages = receiver.received()
+	require.Len(t, messages, 2)
+	require.Equal(t, statusResolved, messages[1].Status)
+	require.Equal(t, statusResolved, messages[1].Alerts[0].Status)
+	require.True(t, messages[1].Alerts[0].EndsAt.Equal(start.Add(12*time.Minute)))
+}
+
+func TestAlertingRuleRemoved(t *testing.T) {
+	receiver := &webhookReceiver{}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	alerting := &config.AlertingConfig{Webhooks: []*config.WebhookConfig{{URL: srv.URL}}}
+	cfg := &config.AlertingRule{
+		Alert:     "CPUHigh",
+		Query:     `parca_agent:samples:count:cpu:nanoseconds:delta{}`,
+		Threshold: 10,
+		Interval:  model.Duration(time.Minute),
+	}
+
+	m := NewManager(nil, prometheus.NewRegistry(), &shareQuerier{total: 100})
+	require.NoError(t, m.ApplyConfig(&config.Config{AlertingRules: []*config.AlertingRule{cfg}, Alerting: alerting}))
+	m.evaluate(context.Background(), m.rules[kindAlerting+"/"+cfg.Alert], time.Now())
+
+	messages := receiver.received()
+	require.Len(t, messages, 1)
+	require.Equal(t, statusFiring, messages[0].Status)
+
+	// Firing alerts of removed rules are resolved.
+	require.NoError(t, m.ApplyConfig(&config.Config{Alerting: alerting}))
+	messages = receiver.received()
+	require.Len(t, messages, 2)
+	require.Equal(t, statusResolved, messages[1].Status)
+
+	resp, err := m.Rules(context.Background(), &alertspb.RulesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Rules)
+}
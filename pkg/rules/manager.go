@@ -0,0 +1,347 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+
+	alertspb "github.com/parca-dev/parca/gen/proto/go/parca/alerts/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+const (
+	kindRecording = "recording"
+	kindAlerting  = "alerting"
+)
+
+// Querier evaluates the queries of rules.
+type Querier interface {
+	QueryRange(
+		ctx context.Context,
+		query string,
+		startTime, endTime time.Time,
+		step time.Duration,
+		limit uint32,
+		sumBy []string,
+		filters []*pb.Filter,
+	) ([]*pb.MetricsSeries, error)
+}
+
+// Manager periodically evaluates recording and alerting rules. The latest
+// results of recording rules are exported as Prometheus gauges, alerts are
+// sent to the configured webhooks.
+type Manager struct {
+	alertspb.UnimplementedAlertsServiceServer
+
+	logger  log.Logger
+	querier Querier
+	// notifier is read by evaluations, which must never take mtx.
+	notifier atomic.Pointer[notifier]
+
+	// applyMtx serializes ApplyConfig, which releases mtx while it stops
+	// rules.
+	applyMtx sync.Mutex
+
+	mtx      sync.Mutex // Guards the fields below.
+	ctx      context.Context
+	rules    map[string]*runner
+	alerting *config.AlertingConfig
+
+	evaluations          *prometheus.CounterVec
+	evaluationFailures   *prometheus.CounterVec
+	evaluationDuration   *prometheus.SummaryVec
+	notifications        prometheus.Counter
+	notificationFailures prometheus.Counter
+}
+
+// runner runs the evaluations of a rule.
+type runner struct {
+	kind, name string
+	cfg        any
+	interval   time.Duration
+	rule       interface {
+		evaluate(ctx context.Context, querier Querier, ts time.Time) error
+	}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManager is the Manager constructor. The recorded metrics are registered
+// with reg, the rules are set by ApplyConfig.
+func NewManager(
+	logger log.Logger,
+	reg prometheus.Registerer,
+	querier Querier,
+) *Manager {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+
+	m := &Manager{
+		logger:  logger,
+		querier: querier,
+		rules:   map[string]*runner{},
+
+		evaluations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_rule_evaluations_total",
+				Help: "Total number of rule evaluations.",
+			}, []string{"kind", "rule"}),
+		evaluationFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "parca_rule_evaluation_failures_total",
+				Help: "Total number of rule evaluations that failed.",
+			}, []string{"kind", "rule"}),
+		evaluationDuration: prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Name:       "parca_rule_evaluation_duration_seconds",
+				Help:       "The duration of rule evaluations.",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			}, []string{"kind", "rule"}),
+		notifications: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "parca_alert_notifications_total",
+				Help: "Total number of alert notifications sent to webhooks.",
+			}),
+		notificationFailures: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "parca_alert_notification_failures_total",
+				Help: "Total number of alert notifications that failed to be sent to webhooks.",
+			}),
+	}
+
+	m.notifier.Store(&notifier{})
+
+	reg.MustRegister(
+		m,
+		m.evaluations,
+		m.evaluationFailures,
+		m.evaluationDuration,
+		m.notifications,
+		m.notificationFailures,
+	)
+
+	return m
+}
+
+// Run evaluates the rules until the context is canceled.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mtx.Lock()
+	m.ctx = ctx
+	for _, r := range m.rules {
+		m.start(r)
+	}
+	m.mtx.Unlock()
+
+	<-ctx.Done()
+
+	// Rules are stopped without holding the lock, their evaluations might
+	// still be sending notifications.
+	m.mtx.Lock()
+	m.ctx = nil
+	runners := make([]*runner, 0, len(m.rules))
+	for _, r := range m.rules {
+		runners = append(runners, r)
+	}
+	m.mtx.Unlock()
+
+	for _, r := range runners {
+		r.stop()
+	}
+	return nil
+}
+
+// ApplyConfig replaces the rules and webhooks, rules whose config changed are
+// restarted. Alerting rules whose alerts are still the same keep their state,
+// firing alerts of removed alerting rules are resolved.
+func (m *Manager) ApplyConfig(cfg *config.Config) error {
+	m.applyMtx.Lock()
+	defer m.applyMtx.Unlock()
+
+	m.mtx.Lock()
+	if !reflect.DeepEqual(m.alerting, cfg.Alerting) {
+		n, err := newNotifier(cfg.Alerting)
+		if err != nil {
+			m.mtx.Unlock()
+			return err
+		}
+		m.alerting = cfg.Alerting
+		m.notifier.Store(n)
+	}
+
+	runners := make(map[string]*runner, len(cfg.RecordingRules)+len(cfg.AlertingRules))
+	for _, rc := range cfg.RecordingRules {
+		r := &runner{
+			kind:     kindRecording,
+			name:     rc.Record,
+			cfg:      rc,
+			interval: time.Duration(rc.Interval),
+			rule:     newRecordingRule(rc),
+		}
+		runners[r.key()] = r
+	}
+	for _, ac := range cfg.AlertingRules {
+		r := &runner{
+			kind:     kindAlerting,
+			name:     ac.Alert,
+			cfg:      ac,
+			interval: time.Duration(ac.Interval),
+			rule:     newAlertingRule(ac, m.notify),
+		}
+		runners[r.key()] = r
+	}
+
+	stale := map[string]*runner{}
+	for key, r := range m.rules {
+		if n, ok := runners[key]; ok && reflect.DeepEqual(r.cfg, n.cfg) {
+			delete(runners, key)
+			continue
+		}
+		stale[key] = r
+		delete(m.rules, key)
+	}
+	m.mtx.Unlock()
+
+	// The stale rules have to be stopped before their alerts are handed over
+	// or resolved, which waits for their running evaluations.
+	for key, r := range stale {
+		r.stop()
+
+		old, ok := r.rule.(*alertingRule)
+		if !ok {
+			continue
+		}
+		if n, ok := runners[key]; ok && old.sameAlerts(n.rule.(*alertingRule)) {
+			n.rule.(*alertingRule).takeOver(old)
+			continue
+		}
+		m.notify(context.Background(), old.cfg.Alert, old.resolveAll(time.Now()))
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for key, r := range runners {
+		m.rules[key] = r
+		if m.ctx != nil {
+			m.start(r)
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) key() string {
+	return r.kind + "/" + r.name
+}
+
+// start starts evaluating the rule, m.mtx must be held.
+func (m *Manager) start(r *runner) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			m.evaluate(ctx, r, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stop stops evaluating the rule, if it was started.
+func (r *runner) stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	<-r.done
+}
+
+func (m *Manager) evaluate(ctx context.Context, r *runner, ts time.Time) {
+	start := time.Now()
+	defer func() {
+		m.evaluationDuration.WithLabelValues(r.kind, r.name).Observe(time.Since(start).Seconds())
+	}()
+	m.evaluations.WithLabelValues(r.kind, r.name).Inc()
+
+	if err := r.rule.evaluate(ctx, m.querier, ts); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		m.evaluationFailures.WithLabelValues(r.kind, r.name).Inc()
+		level.Warn(m.logger).Log("msg", "failed to evaluate rule", "kind", r.kind, "rule", r.name, "err", err)
+	}
+}
+
+// notify sends the alerts of an alerting rule to the webhooks.
+func (m *Manager) notify(ctx context.Context, alert string, alerts []webhookAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+	n := m.notifier.Load()
+	for _, err := range n.send(ctx, alert, alerts) {
+		m.notificationFailures.Inc()
+		level.Warn(m.logger).Log("msg", "failed to send alert notification", "alert", alert, "err", err)
+	}
+	m.notifications.Add(float64(len(n.webhooks)))
+}
+
+// runners returns the runners of a kind sorted by name.
+func (m *Manager) runners(kind string) []*runner {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	runners := make([]*runner, 0, len(m.rules))
+	for _, r := range m.rules {
+		if r.kind == kind {
+			runners = append(runners, r)
+		}
+	}
+	sort.Slice(runners, func(i, j int) bool {
+		return runners[i].name < runners[j].name
+	})
+	return runners
+}
+
+// Describe implements the prometheus.Collector interface. The recorded metrics
+// change with the config, so the Manager is an unchecked collector.
+func (m *Manager) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements the prometheus.Collector interface.
+func (m *Manager) Collect(ch chan<- prometheus.Metric) {
+	for _, r := range m.runners(kindRecording) {
+		r.rule.(*recordingRule).collect(ch)
+	}
+}
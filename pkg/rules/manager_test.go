@@ -0,0 +1,239 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+type fakeQuerier struct {
+	mtx     sync.Mutex
+	queries []string
+	filters [][]*pb.Filter
+	series  []*pb.MetricsSeries
+	err     error
+}
+
+func (q *fakeQuerier) QueryRange(
+	_ context.Context,
+	query string,
+	_, _ time.Time,
+	_ time.Duration,
+	_ uint32,
+	_ []string,
+	filters []*pb.Filter,
+) ([]*pb.MetricsSeries, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.queries = append(q.queries, query)
+	q.filters = append(q.filters, filters)
+	return q.series, q.err
+}
+
+func series(job, instance string, samples ...*pb.MetricsSample) *pb.MetricsSeries {
+	return &pb.MetricsSeries{
+		Labelset: &profilestorepb.LabelSet{Labels: []*profilestorepb.Label{
+			{Name: "instance", Value: instance},
+			{Name: "job", Value: job},
+		}},
+		Samples: samples,
+	}
+}
+
+func TestManagerEvaluate(t *testing.T) {
+	now := time.Now()
+	querier := &fakeQuerier{series: []*pb.MetricsSeries{
+		series("api", "a",
+			&pb.MetricsSample{Timestamp: timestamppb.New(now), ValuePerSecond: 2},
+			&pb.MetricsSample{Timestamp: timestamppb.New(now.Add(-time.Second)), ValuePerSecond: 100},
+		),
+		series("api", "b", &pb.MetricsSample{Timestamp: timestamppb.New(now), ValuePerSecond: 3}),
+		series("db", "c", &pb.MetricsSample{Timestamp: timestamppb.New(now), ValuePerSecond: 7}),
+		series("db", "d"),
+	}}
+
+	reg := prometheus.NewRegistry()
+	cfg := &config.RecordingRule{
+		Record:       "parca_flate_cpu_nanoseconds",
+		Query:        `parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		StackFilters: []*config.StackFilter{{FunctionName: "compress/flate"}},
+		SumBy:        []string{"job"},
+		Interval:     model.Duration(time.Minute),
+	}
+	m := NewManager(nil, reg, querier)
+	require.NoError(t, m.ApplyConfig(&config.Config{RecordingRules: []*config.RecordingRule{cfg}}))
+
+	m.evaluate(context.Background(), m.rules[kindRecording+"/"+cfg.Record], now)
+	require.Equal(t, []string{cfg.Query}, querier.queries)
+	require.Equal(t, "compress/flate", querier.filters[0][0].GetStackFilter().GetCriteria().GetFunctionName().GetContains())
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP parca_flate_cpu_nanoseconds Recorded profile query parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}
+# TYPE parca_flate_cpu_nanoseconds gauge
+parca_flate_cpu_nanoseconds{job="api"} 5
+parca_flate_cpu_nanoseconds{job="db"} 7
+`), "parca_flate_cpu_nanoseconds"))
+
+	// Failed evaluations keep the previous values.
+	querier.err = errors.New("unavailable")
+	m.evaluate(context.Background(), m.rules[kindRecording+"/"+cfg.Record], now)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.evaluationFailures.WithLabelValues(kindRecording, cfg.Record)))
+	require.Equal(t, 2, testutil.CollectAndCount(m, "parca_flate_cpu_nanoseconds"))
+
+	// Removed rules are no longer exported.
+	require.NoError(t, m.ApplyConfig(&config.Config{}))
+	require.Equal(t, 0, testutil.CollectAndCount(m))
+}
+
+func TestManagerRun(t *testing.T) {
+	querier := &fakeQuerier{series: []*pb.MetricsSeries{
+		series("api", "a", &pb.MetricsSample{Timestamp: timestamppb.Now(), ValuePerSecond: 1}),
+	}}
+	m := NewManager(nil, prometheus.NewRegistry(), querier)
+	require.NoError(t, m.ApplyConfig(&config.Config{RecordingRules: []*config.RecordingRule{{
+		Record:   "parca_cpu",
+		Query:    `parca_agent:samples:count:cpu:nanoseconds:delta{}`,
+		Interval: model.Duration(time.Hour),
+	}}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	// Rules are evaluated right away.
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(m) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Rules added while running are started.
+	require.NoError(t, m.ApplyConfig(&config.Config{RecordingRules: []*config.RecordingRule{{
+		Record:   "parca_cpu_by_job",
+		Query:    `parca_agent:samples:count:cpu:nanoseconds:delta{}`,
+		SumBy:    []string{"job"},
+		Interval: model.Duration(time.Hour),
+	}}}))
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(m, "parca_cpu_by_job") == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 0, testutil.CollectAndCount(m, "parca_cpu"))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// blockingQuerier blocks the first query until its context is canceled, it
+// then returns the series anyway.
+type blockingQuerier struct {
+	fakeQuerier
+	started chan struct{}
+	once    sync.Once
+}
+
+func (q *blockingQuerier) QueryRange(
+	ctx context.Context,
+	query string,
+	start, end time.Time,
+	step time.Duration,
+	limit uint32,
+	sumBy []string,
+	filters []*pb.Filter,
+) ([]*pb.MetricsSeries, error) {
+	q.once.Do(func() {
+		close(q.started)
+		<-ctx.Done()
+	})
+	return q.fakeQuerier.QueryRange(context.Background(), query, start, end, step, limit, sumBy, filters)
+}
+
+func TestManagerApplyConfigDuringEvaluation(t *testing.T) {
+	querier := &blockingQuerier{
+		fakeQuerier: fakeQuerier{series: []*pb.MetricsSeries{
+			series("api", "a", &pb.MetricsSample{Timestamp: timestamppb.Now(), ValuePerSecond: 10}),
+		}},
+		started: make(chan struct{}),
+	}
+	rule := &config.AlertingRule{
+		Alert:     "HighCPU",
+		Query:     `parca_agent:samples:count:cpu:nanoseconds:delta{}`,
+		SumBy:     []string{"job"},
+		Threshold: 1,
+		Interval:  model.Duration(time.Hour),
+	}
+	m := NewManager(nil, prometheus.NewRegistry(), querier)
+	require.NoError(t, m.ApplyConfig(&config.Config{AlertingRules: []*config.AlertingRule{rule}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+	<-querier.started
+
+	// Reloading stops the rule, whose evaluation then fires the alert.
+	changed := *rule
+	changed.Threshold = 2
+	changed.Annotations = map[string]string{"summary": "{{ $labels.job }} uses a lot of CPU"}
+	reloaded := time.Now()
+	applied := make(chan error)
+	go func() {
+		applied <- m.ApplyConfig(&config.Config{AlertingRules: []*config.AlertingRule{&changed}})
+	}()
+	select {
+	case err := <-applied:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("applying the config during an evaluation deadlocked")
+	}
+
+	// Only the threshold and annotations changed, the alert keeps firing
+	// since the first evaluation.
+	r := m.runners(kindAlerting)[0].rule.(*alertingRule)
+	require.Same(t, &changed, r.cfg)
+	var firing []alert
+	require.Eventually(t, func() bool {
+		firing, _, _ = r.alerts()
+		return len(firing) == 1 && firing[0].annotations != nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, alertStateFiring, firing[0].state)
+	require.True(t, firing[0].firedAt.Before(reloaded))
+	require.Equal(t, "api uses a lot of CPU", firing[0].annotations["summary"])
+
+	// Alerts of rules that changed otherwise are resolved.
+	byInstance := changed
+	byInstance.SumBy = []string{"instance"}
+	require.NoError(t, m.ApplyConfig(&config.Config{AlertingRules: []*config.AlertingRule{&byInstance}}))
+	alerts, _, _ := r.alerts()
+	require.Empty(t, alerts)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stopping the manager deadlocked")
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	commonconfig "github.com/prometheus/common/config"
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+const (
+	statusFiring   = "firing"
+	statusResolved = "resolved"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// webhookMessage is the JSON payload of Alertmanager webhooks.
+type webhookMessage struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []webhookAlert    `json:"alerts"`
+}
+
+type webhookAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// notifier sends alerts to webhooks.
+type notifier struct {
+	webhooks []*webhook
+}
+
+type webhook struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+func newNotifier(cfg *config.AlertingConfig) (*notifier, error) {
+	n := &notifier{}
+	if cfg == nil {
+		return n, nil
+	}
+
+	for _, w := range cfg.Webhooks {
+		client, err := commonconfig.NewClientFromConfig(w.HTTPClientConfig, "alerting")
+		if err != nil {
+			return nil, fmt.Errorf("creating client of webhook %q: %w", w.URL, err)
+		}
+		timeout := time.Duration(w.Timeout)
+		if timeout == 0 {
+			timeout = defaultWebhookTimeout
+		}
+		n.webhooks = append(n.webhooks, &webhook{url: w.URL, client: client, timeout: timeout})
+	}
+	return n, nil
+}
+
+// send sends the firing and resolved alerts of an alerting rule to every
+// webhook, one message per status.
+func (n *notifier) send(ctx context.Context, alert string, alerts []webhookAlert) []error {
+	byStatus := map[string][]webhookAlert{}
+	for _, a := range alerts {
+		byStatus[a.Status] = append(byStatus[a.Status], a)
+	}
+
+	var errs []error
+	for _, status := range []string{statusFiring, statusResolved} {
+		if len(byStatus[status]) == 0 {
+			continue
+		}
+		msg, err := json.Marshal(newWebhookMessage(alert, status, byStatus[status]))
+		if err != nil {
+			return []error{err}
+		}
+		for _, w := range n.webhooks {
+			if err := w.send(ctx, msg); err != nil {
+				errs = append(errs, fmt.Errorf("webhook %q: %w", w.url, err))
+			}
+		}
+	}
+	return errs
+}
+
+func newWebhookMessage(alert, status string, alerts []webhookAlert) *webhookMessage {
+	groupLabels := map[string]string{labels.AlertName: alert}
+	return &webhookMessage{
+		Version:           "4",
+		GroupKey:          fmt.Sprintf("{}:{%s=%q}", labels.AlertName, alert),
+		Status:            status,
+		Receiver:          "parca",
+		GroupLabels:       groupLabels,
+		CommonLabels:      commonValues(alerts, func(a webhookAlert) map[string]string { return a.Labels }),
+		CommonAnnotations: commonValues(alerts, func(a webhookAlert) map[string]string { return a.Annotations }),
+		Alerts:            alerts,
+	}
+}
+
+// commonValues returns the key-value pairs shared by all alerts.
+func commonValues(alerts []webhookAlert, values func(webhookAlert) map[string]string) map[string]string {
+	common := map[string]string{}
+	for k, v := range values(alerts[0]) {
+		common[k] = v
+	}
+	for _, a := range alerts[1:] {
+		vs := values(a)
+		for k, v := range common {
+			if vs[k] != v {
+				delete(common, k)
+			}
+		}
+	}
+	return common
+}
+
+func (w *webhook) send(ctx context.Context, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/config"
+)
+
+type recordingRule struct {
+	cfg     *config.RecordingRule
+	filters []*pb.Filter
+	desc    *prometheus.Desc
+
+	mtx sync.Mutex // Guards the samples.
+	// samples holds the latest value per label values.
+	samples []sample
+}
+
+type sample struct {
+	labelValues []string
+	value       float64
+}
+
+func newRecordingRule(cfg *config.RecordingRule) *recordingRule {
+	return &recordingRule{
+		cfg:     cfg,
+		filters: stackFilters(cfg.StackFilters),
+		desc: prometheus.NewDesc(
+			cfg.Record,
+			fmt.Sprintf("Recorded profile query %s", cfg.Query),
+			cfg.SumBy,
+			nil,
+		),
+	}
+}
+
+func (r *recordingRule) evaluate(ctx context.Context, querier Querier, ts time.Time) error {
+	samples, err := queryLatest(ctx, querier, r.cfg.Query, r.filters, r.cfg.SumBy, time.Duration(r.cfg.Interval), ts)
+	if err != nil {
+		return err
+	}
+
+	r.mtx.Lock()
+	r.samples = samples
+	r.mtx.Unlock()
+	return nil
+}
+
+func (r *recordingRule) collect(ch chan<- prometheus.Metric) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, s := range r.samples {
+		ch <- prometheus.MustNewConstMetric(r.desc, prometheus.GaugeValue, s.value, s.labelValues...)
+	}
+}
+
+// queryLatest queries the profiles of the interval up to ts and returns the
+// latest value of every series, summed by the labels. The values of delta
+// profiles are per second.
+func queryLatest(
+	ctx context.Context,
+	querier Querier,
+	query string,
+	filters []*pb.Filter,
+	sumBy []string,
+	interval time.Duration,
+	ts time.Time,
+) ([]sample, error) {
+	series, err := querier.QueryRange(ctx, query, ts.Add(-interval), ts, interval, 0, sumBy, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Series that only differ in labels that aren't summed by are summed.
+	samples := make([]sample, 0, len(series))
+	index := make(map[string]int, len(series))
+	for _, s := range series {
+		if len(s.Samples) == 0 {
+			continue
+		}
+		latest := s.Samples[0]
+		for _, smpl := range s.Samples[1:] {
+			if smpl.Timestamp.AsTime().After(latest.Timestamp.AsTime()) {
+				latest = smpl
+			}
+		}
+
+		labelValues := make([]string, len(sumBy))
+		for i, name := range sumBy {
+			for _, l := range s.GetLabelset().GetLabels() {
+				if l.Name == name {
+					labelValues[i] = l.Value
+					break
+				}
+			}
+		}
+		key := labelValuesKey(labelValues)
+		if i, ok := index[key]; ok {
+			samples[i].value += latest.ValuePerSecond
+			continue
+		}
+		index[key] = len(samples)
+		samples = append(samples, sample{labelValues: labelValues, value: latest.ValuePerSecond})
+	}
+	return samples, nil
+}
+
+func labelValuesKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// stackFilters returns the query filters of the configured stack filters.
+func stackFilters(filters []*config.StackFilter) []*pb.Filter {
+	res := make([]*pb.Filter, 0, len(filters))
+	for _, f := range filters {
+		criteria := &pb.FilterCriteria{}
+		if f.FunctionName != "" {
+			criteria.FunctionName = &pb.StringCondition{
+				Condition: &pb.StringCondition_Contains{Contains: f.FunctionName},
+			}
+		}
+		if f.Binary != "" {
+			criteria.Binary = &pb.StringCondition{
+				Condition: &pb.StringCondition_Contains{Contains: f.Binary},
+			}
+		}
+		res = append(res, &pb.Filter{
+			Filter: &pb.Filter_StackFilter{
+				StackFilter: &pb.StackFilter{
+					Filter: &pb.StackFilter_Criteria{Criteria: criteria},
+				},
+			},
+		})
+	}
+	return res
+}
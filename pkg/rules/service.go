@@ -0,0 +1,73 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/alerts/v1alpha1"
+	"github.com/parca-dev/parca/pkg/scrape"
+)
+
+// Rules implements the AlertsService.
+func (m *Manager) Rules(ctx context.Context, req *pb.RulesRequest) (*pb.RulesResponse, error) {
+	runners := m.runners(kindAlerting)
+	resp := &pb.RulesResponse{
+		Rules: make([]*pb.AlertingRule, 0, len(runners)),
+	}
+
+	for _, r := range runners {
+		ar := r.rule.(*alertingRule)
+		alerts, lastEvaluation, lastErr := ar.alerts()
+
+		rule := &pb.AlertingRule{
+			Name:        ar.cfg.Alert,
+			Query:       ar.cfg.Query,
+			Threshold:   ar.cfg.Threshold,
+			Duration:    durationpb.New(time.Duration(ar.cfg.For)),
+			Labels:      scrape.ProtoLabelsFromLabels(labels.FromMap(ar.cfg.Labels)),
+			Annotations: ar.cfg.Annotations,
+			Alerts:      make([]*pb.Alert, 0, len(alerts)),
+		}
+		if !lastEvaluation.IsZero() {
+			rule.LastEvaluation = timestamppb.New(lastEvaluation)
+		}
+		if lastErr != nil {
+			rule.LastError = lastErr.Error()
+		}
+
+		for _, a := range alerts {
+			alert := &pb.Alert{
+				State:       pb.Alert_STATE_PENDING,
+				Labels:      scrape.ProtoLabelsFromLabels(a.labels),
+				Annotations: a.annotations,
+				Value:       a.value,
+				ActiveAt:    timestamppb.New(a.activeAt),
+			}
+			if a.state == alertStateFiring {
+				alert.State = pb.Alert_STATE_FIRING
+				alert.FiredAt = timestamppb.New(a.firedAt)
+			}
+			rule.Alerts = append(rule.Alerts, alert)
+		}
+		resp.Rules = append(resp.Rules, rule)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	sharepb "github.com/parca-dev/parca/gen/proto/go/parca/share/v1alpha1"
+)
+
+// localClient calls the Service in process.
+type localClient struct {
+	s *Service
+}
+
+// Client returns a ShareServiceClient calling the Service in process, so
+// profiles shared through the query API are stored locally.
+func (s *Service) Client() sharepb.ShareServiceClient {
+	return &localClient{s: s}
+}
+
+func (c *localClient) Upload(ctx context.Context, req *sharepb.UploadRequest, _ ...grpc.CallOption) (*sharepb.UploadResponse, error) {
+	return c.s.Upload(ctx, req)
+}
+
+func (c *localClient) Query(ctx context.Context, req *sharepb.QueryRequest, _ ...grpc.CallOption) (*sharepb.QueryResponse, error) {
+	return c.s.Query(ctx, req)
+}
+
+func (c *localClient) ProfileTypes(ctx context.Context, req *sharepb.ProfileTypesRequest, _ ...grpc.CallOption) (*sharepb.ProfileTypesResponse, error) {
+	return c.s.ProfileTypes(ctx, req)
+}
@@ -0,0 +1,432 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	pprofprofile "github.com/google/pprof/profile"
+	"github.com/google/uuid"
+	"github.com/thanos-io/objstore"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	sharepb "github.com/parca-dev/parca/gen/proto/go/parca/share/v1alpha1"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/query"
+)
+
+const (
+	profileObject  = "profile.pb.gz"
+	metadataObject = "metadata.json"
+
+	// LinkPath is the path of shared profiles in the UI relative to the external
+	// URL.
+	LinkPath = "/share/"
+)
+
+var errProfileNotFound = errors.New("shared profile not found")
+
+// Service is a self-hosted ShareService, the shared profiles are stored in an
+// object storage bucket.
+type Service struct {
+	sharepb.UnimplementedShareServiceServer
+
+	logger    log.Logger
+	tracer    trace.Tracer
+	bucket    objstore.Bucket
+	mem       memory.Allocator
+	converter *parcacol.ArrowToProfileConverter
+	pool      *sync.Pool
+
+	externalURL string
+	expiry      time.Duration
+	now         func() time.Time
+}
+
+type metadata struct {
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	// ExpiresAt is zero if the profile never expires.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// NewService returns a Service storing profiles in the bucket. Links to shared
+// profiles are relative to the externalURL, profiles expire after expiry
+// unless it is 0.
+func NewService(
+	logger log.Logger,
+	tracer trace.Tracer,
+	bucket objstore.Bucket,
+	mem memory.Allocator,
+	converter *parcacol.ArrowToProfileConverter,
+	externalURL string,
+	expiry time.Duration,
+) *Service {
+	return &Service{
+		logger:      log.With(logger, "component", "share"),
+		tracer:      tracer,
+		bucket:      bucket,
+		mem:         mem,
+		converter:   converter,
+		pool:        query.NewTableConverterPool(),
+		externalURL: strings.TrimSuffix(externalURL, "/"),
+		expiry:      expiry,
+		now:         time.Now,
+	}
+}
+
+// Upload stores the profile and returns the link to it.
+func (s *Service) Upload(ctx context.Context, req *sharepb.UploadRequest) (*sharepb.UploadResponse, error) {
+	if len(req.GetProfile()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "profile is empty")
+	}
+	if _, err := pprofprofile.ParseData(req.GetProfile()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid pprof profile: %v", err)
+	}
+
+	id := uuid.NewString()
+	meta := metadata{
+		Description: req.GetDescription(),
+		CreatedAt:   s.now(),
+	}
+	if s.expiry > 0 {
+		meta.ExpiresAt = meta.CreatedAt.Add(s.expiry)
+	}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal metadata: %v", err)
+	}
+
+	if err := s.bucket.Upload(ctx, path.Join(id, profileObject), bytes.NewReader(req.GetProfile())); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload profile: %v", err)
+	}
+	// The metadata is uploaded last, profiles without metadata aren't found.
+	if err := s.bucket.Upload(ctx, path.Join(id, metadataObject), bytes.NewReader(metaJSON)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upload profile metadata: %v", err)
+	}
+
+	return &sharepb.UploadResponse{
+		Id:   id,
+		Link: s.externalURL + LinkPath + id,
+	}, nil
+}
+
+// ProfileTypes returns the sample types of the shared profile.
+func (s *Service) ProfileTypes(ctx context.Context, req *sharepb.ProfileTypesRequest) (*sharepb.ProfileTypesResponse, error) {
+	p, meta, err := s.fetch(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]*pb.ProfileType, 0, len(p.SampleType))
+	for _, st := range p.SampleType {
+		pt := &pb.ProfileType{
+			SampleType: st.Type,
+			SampleUnit: st.Unit,
+		}
+		if p.PeriodType != nil {
+			pt.PeriodType = p.PeriodType.Type
+			pt.PeriodUnit = p.PeriodType.Unit
+		}
+		types = append(types, pt)
+	}
+
+	return &sharepb.ProfileTypesResponse{
+		Types:       types,
+		Description: meta.Description,
+	}, nil
+}
+
+// Query renders a report of the shared profile.
+func (s *Service) Query(ctx context.Context, req *sharepb.QueryRequest) (*sharepb.QueryResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "share.Query")
+	defer span.End()
+
+	groupBy := req.GetGroupBy().GetFields()
+	if err := query.ValidateGroupBy(groupBy); err != nil {
+		return nil, err
+	}
+
+	p, _, err := s.fetch(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := sampleIndex(p, req.GetProfileType())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetInvertCallStack() {
+		for _, smpl := range p.Sample {
+			for i, j := 0, len(smpl.Location)-1; i < j; i, j = i+1, j-1 {
+				smpl.Location[i], smpl.Location[j] = smpl.Location[j], smpl.Location[i]
+			}
+		}
+	}
+
+	prof, err := query.ProfileFromPprof(s.mem, meta(p, index), p, index)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, r := range prof.Samples {
+			r.Release()
+		}
+	}()
+
+	filters := query.ConvertDeprecatedFilters(req.GetFilter())
+	if fn := req.GetSandwichByFunction(); fn != "" {
+		filters = append(filters, &pb.Filter{
+			Filter: &pb.Filter_StackFilter{
+				StackFilter: &pb.StackFilter{
+					Filter: &pb.StackFilter_Criteria{
+						Criteria: &pb.FilterCriteria{
+							FunctionName: &pb.StringCondition{
+								Condition: &pb.StringCondition_Contains{Contains: fn},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	var filtered int64
+	prof.Samples, filtered, err = query.FilterProfileData(ctx, s.tracer, s.mem, prof.Samples, filters)
+	if err != nil {
+		return nil, fmt.Errorf("filtering profile: %w", err)
+	}
+
+	resp, err := query.RenderReport(
+		ctx,
+		s.tracer,
+		prof,
+		req.GetReportType(),
+		req.GetNodeTrimThreshold(),
+		filtered,
+		groupBy,
+		s.pool,
+		s.mem,
+		s.converter,
+		nil,
+		"",
+		false,
+		query.RenderOptions{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return shareResponse(resp)
+}
+
+// shareResponse converts a query response to the subset of reports supported
+// by the ShareService.
+func shareResponse(resp *pb.QueryResponse) (*sharepb.QueryResponse, error) {
+	res := &sharepb.QueryResponse{
+		Total:    resp.GetTotal(),
+		Filtered: resp.GetFiltered(),
+	}
+
+	switch report := resp.GetReport().(type) {
+	case *pb.QueryResponse_Flamegraph:
+		res.Report = &sharepb.QueryResponse_Flamegraph{Flamegraph: report.Flamegraph}
+	case *pb.QueryResponse_Pprof:
+		res.Report = &sharepb.QueryResponse_Pprof{Pprof: report.Pprof}
+	case *pb.QueryResponse_Top:
+		res.Report = &sharepb.QueryResponse_Top{Top: report.Top}
+	case *pb.QueryResponse_Callgraph:
+		res.Report = &sharepb.QueryResponse_Callgraph{Callgraph: report.Callgraph}
+	case *pb.QueryResponse_FlamegraphArrow:
+		res.Report = &sharepb.QueryResponse_FlamegraphArrow{FlamegraphArrow: report.FlamegraphArrow}
+	case *pb.QueryResponse_Source:
+		res.Report = &sharepb.QueryResponse_Source{Source: report.Source}
+	case *pb.QueryResponse_TableArrow:
+		res.Report = &sharepb.QueryResponse_TableArrow{TableArrow: report.TableArrow}
+	case *pb.QueryResponse_ProfileMetadata:
+		res.Report = &sharepb.QueryResponse_ProfileMetadata{ProfileMetadata: report.ProfileMetadata}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "report type %T is not supported for shared profiles", report)
+	}
+
+	return res, nil
+}
+
+// fetch returns the shared profile and its metadata, expired profiles aren't
+// found.
+func (s *Service) fetch(ctx context.Context, id string) (*pprofprofile.Profile, *metadata, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid id %q", id)
+	}
+
+	meta, err := s.metadata(ctx, id)
+	if err != nil {
+		if errors.Is(err, errProfileNotFound) {
+			return nil, nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	if meta.expired(s.now()) {
+		return nil, nil, status.Error(codes.NotFound, errProfileNotFound.Error())
+	}
+
+	r, err := s.bucket.Get(ctx, path.Join(id, profileObject))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, nil, status.Error(codes.NotFound, errProfileNotFound.Error())
+		}
+		return nil, nil, status.Errorf(codes.Internal, "fetch shared profile: %v", err)
+	}
+	defer r.Close()
+
+	p, err := pprofprofile.Parse(r)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "parse shared profile: %v", err)
+	}
+
+	return p, meta, nil
+}
+
+func (s *Service) metadata(ctx context.Context, id string) (*metadata, error) {
+	r, err := s.bucket.Get(ctx, path.Join(id, metadataObject))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, errProfileNotFound
+		}
+		return nil, fmt.Errorf("fetch shared profile metadata: %w", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read shared profile metadata: %w", err)
+	}
+
+	meta := &metadata{}
+	if err := json.Unmarshal(content, meta); err != nil {
+		return nil, fmt.Errorf("unmarshal shared profile metadata: %w", err)
+	}
+	return meta, nil
+}
+
+func (m *metadata) expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// Run periodically deletes expired profiles until the context is canceled.
+func (s *Service) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+			level.Warn(s.logger).Log("msg", "failed to delete expired shared profiles", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteExpired deletes the expired profiles from the bucket.
+func (s *Service) DeleteExpired(ctx context.Context) error {
+	now := s.now()
+	return s.bucket.Iter(ctx, "", func(dir string) error {
+		id := strings.TrimSuffix(dir, objstore.DirDelim)
+
+		meta, err := s.metadata(ctx, id)
+		if err != nil {
+			if errors.Is(err, errProfileNotFound) {
+				// The profile may still be uploading.
+				return nil
+			}
+			return err
+		}
+		if !meta.expired(now) {
+			return nil
+		}
+
+		// The metadata is deleted first, so partially deleted profiles aren't found.
+		for _, object := range []string{metadataObject, profileObject} {
+			if err := s.bucket.Delete(ctx, path.Join(id, object)); err != nil && !s.bucket.IsObjNotFoundErr(err) {
+				return fmt.Errorf("delete shared profile %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// sampleIndex returns the index of the sample type of the profile type, which
+// has the format name:sample_type:sample_unit:period_type:period_unit. The
+// default sample type is used if the profile type is empty.
+func sampleIndex(p *pprofprofile.Profile, profileType string) (int, error) {
+	if len(p.SampleType) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "profile has no sample types")
+	}
+
+	if profileType == "" {
+		for i, st := range p.SampleType {
+			if st.Type == p.DefaultSampleType {
+				return i, nil
+			}
+		}
+		// Like pprof, the last sample type is the default.
+		return len(p.SampleType) - 1, nil
+	}
+
+	parts := strings.Split(profileType, ":")
+	if len(parts) < 3 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid profile type %q", profileType)
+	}
+	for i, st := range p.SampleType {
+		if st.Type == parts[1] && st.Unit == parts[2] {
+			return i, nil
+		}
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "profile type %q not found in profile", profileType)
+}
+
+func meta(p *pprofprofile.Profile, index int) profile.Meta {
+	m := profile.Meta{
+		Timestamp:  p.TimeNanos / time.Millisecond.Nanoseconds(),
+		TimeNanos:  p.TimeNanos,
+		Duration:   p.DurationNanos,
+		Period:     p.Period,
+		SampleType: profile.ValueType{Type: p.SampleType[index].Type, Unit: p.SampleType[index].Unit},
+	}
+	if p.PeriodType != nil {
+		m.PeriodType = profile.ValueType{Type: p.PeriodType.Type, Unit: p.PeriodType.Unit}
+	}
+	return m
+}
@@ -0,0 +1,145 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package share
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	sharepb "github.com/parca-dev/parca/gen/proto/go/parca/share/v1alpha1"
+	"github.com/parca-dev/parca/pkg/kv"
+	"github.com/parca-dev/parca/pkg/parcacol"
+)
+
+func newTestService(bucket objstore.Bucket, expiry time.Duration) *Service {
+	tracer := noop.NewTracerProvider().Tracer("")
+	return NewService(
+		log.NewNopLogger(),
+		tracer,
+		bucket,
+		memory.DefaultAllocator,
+		parcacol.NewArrowToProfileConverter(tracer, kv.NewKeyMaker()),
+		"http://parca.example.com/",
+		expiry,
+	)
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(objstore.NewInMemBucket(), 0)
+
+	_, err := s.Upload(ctx, &sharepb.UploadRequest{Profile: []byte("not a profile")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	data, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	// Profiles shared through the query API are stored by the service.
+	uploaded, err := s.Client().Upload(ctx, &sharepb.UploadRequest{
+		Profile:     data,
+		Description: "allocations",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "http://parca.example.com/share/"+uploaded.Id, uploaded.Link)
+
+	types, err := s.ProfileTypes(ctx, &sharepb.ProfileTypesRequest{Id: uploaded.Id})
+	require.NoError(t, err)
+	require.Equal(t, "allocations", types.Description)
+	require.NotEmpty(t, types.Types)
+
+	pt := types.Types[0]
+	profileType := ":" + pt.SampleType + ":" + pt.SampleUnit + ":" + pt.PeriodType + ":" + pt.PeriodUnit
+	for _, typ := range []pb.QueryRequest_ReportType{
+		pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW,
+		pb.QueryRequest_REPORT_TYPE_TABLE_ARROW,
+		pb.QueryRequest_REPORT_TYPE_PPROF,
+	} {
+		resp, err := s.Query(ctx, &sharepb.QueryRequest{
+			Id:          uploaded.Id,
+			ProfileType: &profileType,
+			ReportType:  typ,
+		})
+		require.NoError(t, err, typ.String())
+		require.NotNil(t, resp.Report, typ.String())
+		if typ != pb.QueryRequest_REPORT_TYPE_PPROF {
+			require.Positive(t, resp.Total, typ.String())
+		}
+	}
+
+	resp, err := s.Query(ctx, &sharepb.QueryRequest{
+		Id:          uploaded.Id,
+		ProfileType: &profileType,
+		ReportType:  pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW,
+		GroupBy:     &pb.GroupBy{Fields: []string{"labels.thread", "function_name"}},
+	})
+	require.NoError(t, err)
+	require.Positive(t, resp.Total)
+
+	_, err = s.Query(ctx, &sharepb.QueryRequest{
+		Id:          uploaded.Id,
+		ProfileType: &profileType,
+		ReportType:  pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW,
+		GroupBy:     &pb.GroupBy{Fields: []string{"unknown"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	unknown := ":unknown:count:space:bytes"
+	_, err = s.Query(ctx, &sharepb.QueryRequest{Id: uploaded.Id, ProfileType: &unknown})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ProfileTypes(ctx, &sharepb.ProfileTypesRequest{Id: "b9a4c3e2-3f43-4c8a-a5d0-6b8f0f1d5e67"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.ProfileTypes(ctx, &sharepb.ProfileTypesRequest{Id: "../debuginfo"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServiceExpiry(t *testing.T) {
+	ctx := context.Background()
+	bucket := objstore.NewInMemBucket()
+	s := newTestService(bucket, time.Hour)
+
+	data, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	uploaded, err := s.Upload(ctx, &sharepb.UploadRequest{Profile: data})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteExpired(ctx))
+	_, err = s.ProfileTypes(ctx, &sharepb.ProfileTypesRequest{Id: uploaded.Id})
+	require.NoError(t, err)
+
+	now := time.Now()
+	s.now = func() time.Time { return now.Add(time.Hour) }
+
+	// Expired profiles aren't found, even before they are deleted.
+	_, err = s.ProfileTypes(ctx, &sharepb.ProfileTypesRequest{Id: uploaded.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.NoError(t, s.DeleteExpired(ctx))
+	exists, err := bucket.Exists(ctx, path.Join(uploaded.Id, profileObject))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addr2line
+
+import (
+	"context"
+	"debug/dwarf"
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-kit/log"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// LineTableLiner is a liner that resolves addresses to the exact source line
+// of the instruction using the DWARF line tables. Unlike DwarfLiner it does
+// not resolve functions and inlined calls, but the line is that of the
+// instruction rather than of the function containing it.
+type LineTableLiner struct {
+	logger log.Logger
+
+	// rows are sorted by address, rows ending a sequence have no file.
+	rows     []lineTableRow
+	f        *elf.File
+	filename string
+}
+
+type lineTableRow struct {
+	address uint64
+	file    string
+	line    int64
+}
+
+// LineTable creates a new LineTableLiner.
+func LineTable(logger log.Logger, filename string, f *elf.File) (*LineTableLiner, error) {
+	debugData, err := f.DWARF()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DWARF data: %w", err)
+	}
+
+	var rows []lineTableRow
+	r := debugData.Reader()
+	for {
+		cu, err := r.Next()
+		if err != nil {
+			return nil, fmt.Errorf("read compilation unit: %w", err)
+		}
+		if cu == nil {
+			break
+		}
+		if cu.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+
+		lr, err := debugData.LineReader(cu)
+		if err != nil {
+			return nil, fmt.Errorf("read line table: %w", err)
+		}
+		r.SkipChildren()
+		if lr == nil {
+			continue
+		}
+
+		var entry dwarf.LineEntry
+		for {
+			if err := lr.Next(&entry); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("read line table entry: %w", err)
+			}
+
+			row := lineTableRow{address: entry.Address}
+			if !entry.EndSequence && entry.File != nil {
+				row.file = entry.File.Name
+				row.line = int64(entry.Line)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	// A sequence may start at the address another one ends at, the end of
+	// the sequence has to be sorted first to not hide the start.
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].address != rows[j].address {
+			return rows[i].address < rows[j].address
+		}
+		return rows[i].file == "" && rows[j].file != ""
+	})
+
+	return &LineTableLiner{
+		logger:   log.With(logger, "liner", "linetable"),
+		rows:     rows,
+		f:        f,
+		filename: filename,
+	}, nil
+}
+
+func (lt *LineTableLiner) Close() error {
+	return lt.f.Close()
+}
+
+func (lt *LineTableLiner) File() string {
+	return lt.filename
+}
+
+// PCToLines returns the source line of the instruction at the program
+// counter (memory address).
+func (lt *LineTableLiner) PCToLines(ctx context.Context, addr uint64) ([]profile.LocationLine, error) {
+	// Find the last row at or before the address.
+	i := sort.Search(len(lt.rows), func(i int) bool {
+		return lt.rows[i].address > addr
+	}) - 1
+	if i < 0 || lt.rows[i].file == "" {
+		return nil, fmt.Errorf("no line table entry for address 0x%x", addr)
+	}
+
+	return []profile.LocationLine{{
+		Line: lt.rows[i].line,
+		Function: &pb.Function{
+			Filename: lt.rows[i].file,
+		},
+	}}, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addr2line
+
+import (
+	"context"
+	"debug/elf"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineTableLiner(t *testing.T) {
+	f, err := elf.Open("testdata/basic-cpp-no-fp-with-debuginfo")
+	require.NoError(t, err)
+
+	lt, err := LineTable(log.NewNopLogger(), "testdata/basic-cpp-no-fp-with-debuginfo", f)
+	require.NoError(t, err)
+	t.Cleanup(func() { lt.Close() })
+
+	const file = "src/basic-cpp.cpp"
+	for _, tc := range []struct {
+		addr uint64
+		line int64
+	}{
+		// The instructions of top1() are spread across its lines.
+		{addr: 0x401106, line: 2},
+		{addr: 0x401110, line: 2},
+		{addr: 0x40111f, line: 5},
+		{addr: 0x401124, line: 6},
+	} {
+		lines, err := lt.PCToLines(context.Background(), tc.addr)
+		require.NoError(t, err)
+		require.Len(t, lines, 1)
+		require.Equal(t, file, lines[0].Function.Filename)
+		require.Equal(t, tc.line, lines[0].Line)
+	}
+
+	_, err = lt.PCToLines(context.Background(), 0x1000)
+	require.Error(t, err)
+}
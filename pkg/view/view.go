@@ -0,0 +1,287 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/google/uuid"
+	"github.com/thanos-io/objstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/view/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+const objectSuffix = ".json"
+
+var errViewNotFound = errors.New("view not found")
+
+// Service stores views in an object storage bucket.
+type Service struct {
+	pb.UnimplementedViewServiceServer
+
+	logger log.Logger
+	bucket objstore.Bucket
+	now    func() time.Time
+
+	// mtx serializes the updates and deletes of views.
+	mtx sync.Mutex
+}
+
+// NewService returns a Service storing views in the bucket.
+func NewService(logger log.Logger, bucket objstore.Bucket) *Service {
+	return &Service{
+		logger: log.With(logger, "component", "view"),
+		bucket: bucket,
+		now:    time.Now,
+	}
+}
+
+// CreateView stores a new view.
+func (s *Service) CreateView(ctx context.Context, req *pb.CreateViewRequest) (*pb.CreateViewResponse, error) {
+	now := timestamppb.New(s.now())
+	v := &pb.View{
+		Id:          uuid.NewString(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Query:       req.GetQuery(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := validate(v); err != nil {
+		return nil, err
+	}
+
+	if err := s.write(ctx, v); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateViewResponse{View: v}, nil
+}
+
+// GetView returns a view and its query request.
+func (s *Service) GetView(ctx context.Context, req *pb.GetViewRequest) (*pb.GetViewResponse, error) {
+	v, err := s.fetch(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetViewResponse{
+		View:         v,
+		QueryRequest: QueryRequest(v.GetQuery(), s.now()),
+	}, nil
+}
+
+// ListViews returns all views sorted by name.
+func (s *Service) ListViews(ctx context.Context, _ *pb.ListViewsRequest) (*pb.ListViewsResponse, error) {
+	views := []*pb.View{}
+	err := s.bucket.Iter(ctx, "", func(name string) error {
+		id, ok := strings.CutSuffix(name, objectSuffix)
+		if !ok {
+			return nil
+		}
+
+		v, err := s.read(ctx, id)
+		if err != nil {
+			if errors.Is(err, errViewNotFound) {
+				// The view was deleted while listing.
+				return nil
+			}
+			return err
+		}
+		views = append(views, v)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list views: %v", err)
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].Name != views[j].Name {
+			return views[i].Name < views[j].Name
+		}
+		return views[i].Id < views[j].Id
+	})
+
+	return &pb.ListViewsResponse{Views: views}, nil
+}
+
+// UpdateView replaces the name, description and query of a view.
+func (s *Service) UpdateView(ctx context.Context, req *pb.UpdateViewRequest) (*pb.UpdateViewResponse, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	v, err := s.fetch(ctx, req.GetView().GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	v.Name = req.GetView().GetName()
+	v.Description = req.GetView().GetDescription()
+	v.Query = req.GetView().GetQuery()
+	v.UpdatedAt = timestamppb.New(s.now())
+	if err := validate(v); err != nil {
+		return nil, err
+	}
+
+	if err := s.write(ctx, v); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UpdateViewResponse{View: v}, nil
+}
+
+// DeleteView deletes a view.
+func (s *Service) DeleteView(ctx context.Context, req *pb.DeleteViewRequest) (*pb.DeleteViewResponse, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, err := s.fetch(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.bucket.Delete(ctx, objectPath(req.GetId())); err != nil && !s.bucket.IsObjNotFoundErr(err) {
+		return nil, status.Errorf(codes.Internal, "delete view: %v", err)
+	}
+
+	return &pb.DeleteViewResponse{}, nil
+}
+
+// QueryRequest returns the merge query request of the view query, relative
+// time ranges end at now.
+func QueryRequest(q *pb.ViewQuery, now time.Time) *querypb.QueryRequest {
+	var start, end *timestamppb.Timestamp
+	switch r := q.GetTimeRange().GetRange().(type) {
+	case *pb.TimeRange_Absolute:
+		start, end = r.Absolute.GetStart(), r.Absolute.GetEnd()
+	case *pb.TimeRange_Relative:
+		start, end = timestamppb.New(now.Add(-r.Relative.AsDuration())), timestamppb.New(now)
+	}
+
+	return &querypb.QueryRequest{
+		Mode: querypb.QueryRequest_MODE_MERGE,
+		Options: &querypb.QueryRequest_Merge{
+			Merge: &querypb.MergeProfile{
+				Query: q.GetSelector(),
+				Start: start,
+				End:   end,
+			},
+		},
+		ReportType:         q.GetReportType(),
+		Filter:             q.GetFilter(),
+		GroupBy:            q.GetGroupBy(),
+		SandwichByFunction: q.SandwichByFunction,
+	}
+}
+
+func validate(v *pb.View) error {
+	if v.GetName() == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	q := v.GetQuery()
+	if q == nil {
+		return status.Error(codes.InvalidArgument, "query is required")
+	}
+	if _, err := profile.ParseQuery(q.GetSelector()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid selector: %v", err)
+	}
+
+	switch r := q.GetTimeRange().GetRange().(type) {
+	case *pb.TimeRange_Absolute:
+		start, end := r.Absolute.GetStart(), r.Absolute.GetEnd()
+		if start == nil || end == nil {
+			return status.Error(codes.InvalidArgument, "absolute time range requires start and end")
+		}
+		if !start.AsTime().Before(end.AsTime()) {
+			return status.Error(codes.InvalidArgument, "absolute time range start must be before end")
+		}
+	case *pb.TimeRange_Relative:
+		if r.Relative.AsDuration() <= 0 {
+			return status.Error(codes.InvalidArgument, "relative time range must be positive")
+		}
+	default:
+		return status.Error(codes.InvalidArgument, "time range is required")
+	}
+
+	return nil
+}
+
+// fetch returns the view or a gRPC error.
+func (s *Service) fetch(ctx context.Context, id string) (*pb.View, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %q", id)
+	}
+
+	v, err := s.read(ctx, id)
+	if err != nil {
+		if errors.Is(err, errViewNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return v, nil
+}
+
+func (s *Service) read(ctx context.Context, id string) (*pb.View, error) {
+	r, err := s.bucket.Get(ctx, objectPath(id))
+	if err != nil {
+		if s.bucket.IsObjNotFoundErr(err) {
+			return nil, errViewNotFound
+		}
+		return nil, fmt.Errorf("fetch view from object storage: %w", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read view from object storage: %w", err)
+	}
+
+	v := &pb.View{}
+	if err := protojson.Unmarshal(content, v); err != nil {
+		return nil, fmt.Errorf("unmarshal view: %w", err)
+	}
+	return v, nil
+}
+
+func (s *Service) write(ctx context.Context, v *pb.View) error {
+	content, err := protojson.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal view: %w", err)
+	}
+
+	if err := s.bucket.Upload(ctx, objectPath(v.GetId()), bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("write view to object storage: %w", err)
+	}
+	return nil
+}
+
+func objectPath(id string) string {
+	return id + objectSuffix
+}
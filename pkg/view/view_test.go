@@ -0,0 +1,144 @@
+// Copyright 2022-2026 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/view/v1alpha1"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(log.NewNopLogger(), objstore.NewInMemBucket())
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	sandwich := "runtime.mallocgc"
+	query := &pb.ViewQuery{
+		Selector: `parca_agent:samples:count:cpu:nanoseconds:delta{job="api"}`,
+		TimeRange: &pb.TimeRange{
+			Range: &pb.TimeRange_Relative{Relative: durationpb.New(time.Hour)},
+		},
+		GroupBy:            &querypb.GroupBy{Fields: []string{"function_name"}},
+		ReportType:         querypb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_ARROW,
+		SandwichByFunction: &sandwich,
+	}
+
+	created, err := s.CreateView(ctx, &pb.CreateViewRequest{Name: "api hot path", Query: query})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.View.Id)
+
+	// Relative time ranges are resolved when the view is read.
+	now = now.Add(24 * time.Hour)
+	got, err := s.GetView(ctx, &pb.GetViewRequest{Id: created.View.Id})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(created.View, got.View))
+	require.True(t, proto.Equal(&querypb.QueryRequest{
+		Mode: querypb.QueryRequest_MODE_MERGE,
+		Options: &querypb.QueryRequest_Merge{
+			Merge: &querypb.MergeProfile{
+				Query: query.Selector,
+				Start: timestamppb.New(now.Add(-time.Hour)),
+				End:   timestamppb.New(now),
+			},
+		},
+		ReportType:         query.ReportType,
+		GroupBy:            query.GroupBy,
+		SandwichByFunction: &sandwich,
+	}, got.QueryRequest))
+
+	other, err := s.CreateView(ctx, &pb.CreateViewRequest{Name: "a view", Query: query})
+	require.NoError(t, err)
+
+	list, err := s.ListViews(ctx, &pb.ListViewsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Views, 2)
+	require.Equal(t, other.View.Id, list.Views[0].Id)
+
+	start, end := timestamppb.New(now.Add(-2*time.Hour)), timestamppb.New(now.Add(-time.Hour))
+	updated, err := s.UpdateView(ctx, &pb.UpdateViewRequest{View: &pb.View{
+		Id:   created.View.Id,
+		Name: "api hot path yesterday",
+		Query: &pb.ViewQuery{
+			Selector: query.Selector,
+			TimeRange: &pb.TimeRange{
+				Range: &pb.TimeRange_Absolute{Absolute: &pb.AbsoluteTimeRange{Start: start, End: end}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(created.View.CreatedAt, updated.View.CreatedAt))
+	require.True(t, proto.Equal(timestamppb.New(now), updated.View.UpdatedAt))
+
+	got, err = s.GetView(ctx, &pb.GetViewRequest{Id: created.View.Id})
+	require.NoError(t, err)
+	require.Equal(t, "api hot path yesterday", got.View.Name)
+	require.True(t, proto.Equal(start, got.QueryRequest.GetMerge().Start))
+	require.True(t, proto.Equal(end, got.QueryRequest.GetMerge().End))
+
+	_, err = s.DeleteView(ctx, &pb.DeleteViewRequest{Id: created.View.Id})
+	require.NoError(t, err)
+	_, err = s.GetView(ctx, &pb.GetViewRequest{Id: created.View.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.DeleteView(ctx, &pb.DeleteViewRequest{Id: created.View.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err = s.ListViews(ctx, &pb.ListViewsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Views, 1)
+}
+
+func TestServiceInvalid(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(log.NewNopLogger(), objstore.NewInMemBucket())
+
+	relative := &pb.TimeRange{Range: &pb.TimeRange_Relative{Relative: durationpb.New(time.Hour)}}
+	now := timestamppb.Now()
+	for _, req := range []*pb.CreateViewRequest{
+		{Query: &pb.ViewQuery{Selector: "parca_agent:samples:count:cpu:nanoseconds:delta{}", TimeRange: relative}},
+		{Name: "no query"},
+		{Name: "invalid selector", Query: &pb.ViewQuery{Selector: "cpu{", TimeRange: relative}},
+		{Name: "no time range", Query: &pb.ViewQuery{Selector: "parca_agent:samples:count:cpu:nanoseconds:delta{}"}},
+		{Name: "negative time range", Query: &pb.ViewQuery{
+			Selector:  "parca_agent:samples:count:cpu:nanoseconds:delta{}",
+			TimeRange: &pb.TimeRange{Range: &pb.TimeRange_Relative{Relative: durationpb.New(-time.Hour)}},
+		}},
+		{Name: "empty time range", Query: &pb.ViewQuery{
+			Selector: "parca_agent:samples:count:cpu:nanoseconds:delta{}",
+			TimeRange: &pb.TimeRange{Range: &pb.TimeRange_Absolute{Absolute: &pb.AbsoluteTimeRange{
+				Start: now,
+				End:   now,
+			}}},
+		}},
+	} {
+		_, err := s.CreateView(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), req.Name)
+	}
+
+	_, err := s.GetView(ctx, &pb.GetViewRequest{Id: "../share/profile"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -35,6 +35,7 @@ import Component404 from './pages/layouts/Component404';
 import Header from './pages/layouts/Header';
 import ThemeProvider from './pages/layouts/ThemeProvider';
 import SettingsPage from './pages/settings';
+import SharePage from './pages/share';
 import TargetsPage from './pages/targets';
 
 declare global {
@@ -77,6 +78,7 @@ const App = () => {
                   <Route path="/" element={<HomePage />} />
                   <Route path="/targets" element={<TargetsPage />} />
                   <Route path="/settings" element={<SettingsPage />} />
+                  <Route path="/share/:id" element={<SharePage />} />
                   <Route path="/PATH_PREFIX_VAR" element={<Navigate to="/" replace />} />
                   <Route path="*" element={<Component404 />} />
                 </Routes>
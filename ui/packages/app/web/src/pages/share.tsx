@@ -0,0 +1,218 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {useEffect, useMemo, useState} from 'react';
+
+import {GrpcWebFetchTransport} from '@protobuf-ts/grpcweb-transport';
+import {RpcError, RpcOptions, UnaryCall} from '@protobuf-ts/runtime-rpc';
+import {useParams} from 'react-router-dom';
+
+import {
+  ProfileDiffSelection,
+  QueryRequest,
+  QueryRequest_Mode,
+  QueryRequest_ReportType,
+  QueryResponse,
+  QueryServiceClient,
+  ShareProfileTypesResponse,
+  ShareQueryResponse,
+  ShareServiceClient,
+} from '@parca/client';
+import {EmptyState, ParcaContextProvider, Spinner} from '@parca/components';
+import {ProfileType} from '@parca/parser';
+import {ProfileSource, ProfileViewWithData} from '@parca/profile';
+import {selectDarkMode, useAppSelector} from '@parca/store';
+
+const apiEndpoint = import.meta.env.VITE_API_ENDPOINT;
+
+const transport = new GrpcWebFetchTransport({
+  baseUrl: apiEndpoint === undefined ? `${window.PATH_PREFIX}/api` : `${apiEndpoint}/api`,
+});
+
+const shareClient = new ShareServiceClient(transport);
+
+const toQueryResponse = (response: ShareQueryResponse): QueryResponse => ({
+  report: response.report,
+  total: response.total,
+  filtered: response.filtered,
+});
+
+// SharedProfileQueryClient renders the reports of a shared profile through the
+// share service, the other requests still go to the query service.
+class SharedProfileQueryClient extends QueryServiceClient {
+  id: string;
+  profileType: ProfileType;
+
+  constructor(id: string, profileType: ProfileType) {
+    super(transport);
+    this.id = id;
+    this.profileType = profileType;
+  }
+
+  query(input: QueryRequest, options?: RpcOptions): UnaryCall<QueryRequest, QueryResponse> {
+    const call = shareClient.query(
+      {
+        id: this.id,
+        profileType: this.profileType.toString(),
+        reportType: input.reportType,
+        nodeTrimThreshold: input.nodeTrimThreshold,
+        groupBy: input.groupBy,
+        invertCallStack: input.invertCallStack,
+        filter: input.filter,
+        sandwichByFunction: input.sandwichByFunction,
+      },
+      options
+    );
+
+    return new UnaryCall<QueryRequest, QueryResponse>(
+      this.methods[1],
+      call.requestHeaders,
+      input,
+      call.headers,
+      call.response.then(toQueryResponse),
+      call.status,
+      call.trailers
+    );
+  }
+}
+
+class SharedProfileSource implements ProfileSource {
+  id: string;
+  profileType: ProfileType;
+
+  constructor(id: string, profileType: ProfileType) {
+    this.id = id;
+    this.profileType = profileType;
+  }
+
+  QueryRequest(): QueryRequest {
+    return {
+      options: {
+        oneofKind: undefined,
+      },
+      reportType: QueryRequest_ReportType.FLAMEGRAPH_ARROW,
+      mode: QueryRequest_Mode.SINGLE_UNSPECIFIED,
+      filter: [],
+    };
+  }
+
+  ProfileType(): ProfileType {
+    return this.profileType;
+  }
+
+  DiffSelection(): ProfileDiffSelection {
+    throw new Error('Method not implemented.');
+  }
+
+  toString(): string {
+    return `Shared profile ${this.id}`;
+  }
+
+  toKey(): string {
+    return `share-${this.id}-${this.profileType.toString()}`;
+  }
+}
+
+interface IProfileTypesResult {
+  response: ShareProfileTypesResponse | null;
+  error: RpcError | null;
+}
+
+const useSharedProfileTypes = (id: string): IProfileTypesResult => {
+  const [result, setResult] = useState<IProfileTypesResult>({
+    response: null,
+    error: null,
+  });
+
+  useEffect(() => {
+    const call = shareClient.profileTypes({id});
+
+    call.response
+      .then(response => setResult({response, error: null}))
+      .catch(error => setResult({error, response: null}));
+  }, [id]);
+
+  return result;
+};
+
+const SharePage = (): JSX.Element => {
+  const {id = ''} = useParams();
+  const isDarkMode = useAppSelector(selectDarkMode);
+  const {response, error} = useSharedProfileTypes(id);
+  const [selectedType, setSelectedType] = useState(0);
+
+  const profileTypes = useMemo(
+    () =>
+      (response?.types ?? []).map(
+        t =>
+          new ProfileType(t.name, t.sampleType, t.sampleUnit, t.periodType, t.periodUnit, t.delta)
+      ),
+    [response]
+  );
+  const profileType = profileTypes[selectedType] ?? profileTypes[0];
+
+  const [queryClient, profileSource] = useMemo(() => {
+    if (profileType === undefined) {
+      return [undefined, undefined];
+    }
+    return [
+      new SharedProfileQueryClient(id, profileType),
+      new SharedProfileSource(id, profileType),
+    ];
+  }, [id, profileType]);
+
+  if (error != null) {
+    return (
+      <EmptyState isEmpty={true} title="Shared profile not found" body={error.message}>
+        <></>
+      </EmptyState>
+    );
+  }
+
+  if (queryClient === undefined || profileSource === undefined) {
+    return <Spinner />;
+  }
+
+  return (
+    <ParcaContextProvider
+      value={{
+        Spinner,
+        queryServiceClient: queryClient,
+        navigateTo: () => {},
+        isDarkMode,
+      }}
+    >
+      <div className="bg-white dark:bg-gray-900 p-3">
+        {response?.description !== undefined && response.description !== '' && (
+          <p className="mb-2 text-lg">{response.description}</p>
+        )}
+        {profileTypes.length > 1 && (
+          <select
+            className="mb-2 rounded-md border border-gray-200 bg-white p-2 text-sm dark:border-gray-600 dark:bg-gray-900"
+            value={selectedType}
+            onChange={e => setSelectedType(Number(e.target.value))}
+          >
+            {profileTypes.map((t, i) => (
+              <option key={t.toString()} value={i}>
+                {`${t.sampleType} (${t.sampleUnit})`}
+              </option>
+            ))}
+          </select>
+        )}
+        <ProfileViewWithData queryClient={queryClient} profileSource={profileSource} />
+      </div>
+    </ParcaContextProvider>
+  );
+};
+
+export default SharePage;
@@ -10,3 +10,8 @@ export * from './grpc/health/v1/health.client';
 export * from './grpc/health/v1/health';
 export * from './parca/debuginfo/v1alpha1/debuginfo.client';
 export * from './parca/debuginfo/v1alpha1/debuginfo';
+export * from './parca/share/v1alpha1/share.client';
+export type {
+  QueryResponse as ShareQueryResponse,
+  ProfileTypesResponse as ShareProfileTypesResponse,
+} from './parca/share/v1alpha1/share';
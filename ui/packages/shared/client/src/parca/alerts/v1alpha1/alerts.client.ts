@@ -0,0 +1,45 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/alerts/v1alpha1/alerts.proto" (package "parca.alerts.v1alpha1", syntax proto3)
+// tslint:disable
+import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
+import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
+import { AlertsService } from "./alerts";
+import { stackIntercept } from "@protobuf-ts/runtime-rpc";
+import type { RulesResponse } from "./alerts";
+import type { RulesRequest } from "./alerts";
+import type { UnaryCall } from "@protobuf-ts/runtime-rpc";
+import type { RpcOptions } from "@protobuf-ts/runtime-rpc";
+/**
+ * AlertsService exposes the state of the alerting rules
+ *
+ * @generated from protobuf service parca.alerts.v1alpha1.AlertsService
+ */
+export interface IAlertsServiceClient {
+    /**
+     * Rules returns the alerting rules and their active alerts
+     *
+     * @generated from protobuf rpc: Rules
+     */
+    rules(input: RulesRequest, options?: RpcOptions): UnaryCall<RulesRequest, RulesResponse>;
+}
+/**
+ * AlertsService exposes the state of the alerting rules
+ *
+ * @generated from protobuf service parca.alerts.v1alpha1.AlertsService
+ */
+export class AlertsServiceClient implements IAlertsServiceClient, ServiceInfo {
+    typeName = AlertsService.typeName;
+    methods = AlertsService.methods;
+    options = AlertsService.options;
+    constructor(private readonly _transport: RpcTransport) {
+    }
+    /**
+     * Rules returns the alerting rules and their active alerts
+     *
+     * @generated from protobuf rpc: Rules
+     */
+    rules(input: RulesRequest, options?: RpcOptions): UnaryCall<RulesRequest, RulesResponse> {
+        const method = this.methods[0], opt = this._transport.mergeOptions(options);
+        return stackIntercept<RulesRequest, RulesResponse>("unary", this._transport, method, opt, input);
+    }
+}
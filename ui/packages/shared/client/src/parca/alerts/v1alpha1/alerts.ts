@@ -0,0 +1,484 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/alerts/v1alpha1/alerts.proto" (package "parca.alerts.v1alpha1", syntax proto3)
+// tslint:disable
+import { ServiceType } from "@protobuf-ts/runtime-rpc";
+import { WireType } from "@protobuf-ts/runtime";
+import type { BinaryWriteOptions } from "@protobuf-ts/runtime";
+import type { IBinaryWriter } from "@protobuf-ts/runtime";
+import type { BinaryReadOptions } from "@protobuf-ts/runtime";
+import type { IBinaryReader } from "@protobuf-ts/runtime";
+import { UnknownFieldHandler } from "@protobuf-ts/runtime";
+import type { PartialMessage } from "@protobuf-ts/runtime";
+import { reflectionMergePartial } from "@protobuf-ts/runtime";
+import { MessageType } from "@protobuf-ts/runtime";
+import { Timestamp } from "../../../google/protobuf/timestamp";
+import { LabelSet } from "../../profilestore/v1alpha1/profilestore";
+import { Duration } from "../../../google/protobuf/duration";
+/**
+ * RulesRequest is the request for the alerting rules
+ *
+ * @generated from protobuf message parca.alerts.v1alpha1.RulesRequest
+ */
+export interface RulesRequest {
+}
+/**
+ * RulesResponse contains the alerting rules
+ *
+ * @generated from protobuf message parca.alerts.v1alpha1.RulesResponse
+ */
+export interface RulesResponse {
+    /**
+     * rules are the configured alerting rules
+     *
+     * @generated from protobuf field: repeated parca.alerts.v1alpha1.AlertingRule rules = 1
+     */
+    rules: AlertingRule[];
+}
+/**
+ * AlertingRule is the state of an alerting rule
+ *
+ * @generated from protobuf message parca.alerts.v1alpha1.AlertingRule
+ */
+export interface AlertingRule {
+    /**
+     * name is the name of the alert
+     *
+     * @generated from protobuf field: string name = 1
+     */
+    name: string;
+    /**
+     * query is the profile query of the rule
+     *
+     * @generated from protobuf field: string query = 2
+     */
+    query: string;
+    /**
+     * threshold is the value alerts fire above
+     *
+     * @generated from protobuf field: double threshold = 3
+     */
+    threshold: number;
+    /**
+     * duration is how long the value has to be above the threshold before an alert fires
+     *
+     * @generated from protobuf field: google.protobuf.Duration duration = 4
+     */
+    duration?: Duration;
+    /**
+     * labels are the labels added to the alerts
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 5
+     */
+    labels?: LabelSet;
+    /**
+     * annotations are the annotation templates of the alerts
+     *
+     * @generated from protobuf field: map<string, string> annotations = 6
+     */
+    annotations: {
+        [key: string]: string;
+    };
+    /**
+     * last_evaluation is the time stamp of the last evaluation of the rule
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp last_evaluation = 7
+     */
+    lastEvaluation?: Timestamp;
+    /**
+     * last_error is the error of the last evaluation, if it failed
+     *
+     * @generated from protobuf field: string last_error = 8
+     */
+    lastError: string;
+    /**
+     * alerts are the pending and firing alerts of the rule
+     *
+     * @generated from protobuf field: repeated parca.alerts.v1alpha1.Alert alerts = 9
+     */
+    alerts: Alert[];
+}
+/**
+ * Alert is a pending or firing alert
+ *
+ * @generated from protobuf message parca.alerts.v1alpha1.Alert
+ */
+export interface Alert {
+    /**
+     * state is the state of the alert
+     *
+     * @generated from protobuf field: parca.alerts.v1alpha1.Alert.State state = 1
+     */
+    state: Alert_State;
+    /**
+     * labels are the labels of the alert
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 2
+     */
+    labels?: LabelSet;
+    /**
+     * annotations are the expanded annotations of the alert
+     *
+     * @generated from protobuf field: map<string, string> annotations = 3
+     */
+    annotations: {
+        [key: string]: string;
+    };
+    /**
+     * value is the value of the last evaluation
+     *
+     * @generated from protobuf field: double value = 4
+     */
+    value: number;
+    /**
+     * active_at is the time stamp the value went above the threshold
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp active_at = 5
+     */
+    activeAt?: Timestamp;
+    /**
+     * fired_at is the time stamp the alert started firing
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp fired_at = 6
+     */
+    firedAt?: Timestamp;
+}
+/**
+ * State is the state of an alert
+ *
+ * @generated from protobuf enum parca.alerts.v1alpha1.Alert.State
+ */
+export enum Alert_State {
+    /**
+     * STATE_UNKNOWN_UNSPECIFIED unspecified
+     *
+     * @generated from protobuf enum value: STATE_UNKNOWN_UNSPECIFIED = 0;
+     */
+    UNKNOWN_UNSPECIFIED = 0,
+    /**
+     * STATE_PENDING the value is above the threshold, but not for long enough
+     *
+     * @generated from protobuf enum value: STATE_PENDING = 1;
+     */
+    PENDING = 1,
+    /**
+     * STATE_FIRING the value has been above the threshold for long enough
+     *
+     * @generated from protobuf enum value: STATE_FIRING = 2;
+     */
+    FIRING = 2
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class RulesRequest$Type extends MessageType<RulesRequest> {
+    constructor() {
+        super("parca.alerts.v1alpha1.RulesRequest", []);
+    }
+    create(value?: PartialMessage<RulesRequest>): RulesRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<RulesRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RulesRequest): RulesRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RulesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.alerts.v1alpha1.RulesRequest
+ */
+export const RulesRequest = new RulesRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RulesResponse$Type extends MessageType<RulesResponse> {
+    constructor() {
+        super("parca.alerts.v1alpha1.RulesResponse", [
+            { no: 1, name: "rules", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => AlertingRule }
+        ]);
+    }
+    create(value?: PartialMessage<RulesResponse>): RulesResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.rules = [];
+        if (value !== undefined)
+            reflectionMergePartial<RulesResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RulesResponse): RulesResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.alerts.v1alpha1.AlertingRule rules */ 1:
+                    message.rules.push(AlertingRule.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RulesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.alerts.v1alpha1.AlertingRule rules = 1; */
+        for (let i = 0; i < message.rules.length; i++)
+            AlertingRule.internalBinaryWrite(message.rules[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.alerts.v1alpha1.RulesResponse
+ */
+export const RulesResponse = new RulesResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class AlertingRule$Type extends MessageType<AlertingRule> {
+    constructor() {
+        super("parca.alerts.v1alpha1.AlertingRule", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "threshold", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 4, name: "duration", kind: "message", T: () => Duration },
+            { no: 5, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 6, name: "annotations", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 7, name: "last_evaluation", kind: "message", T: () => Timestamp },
+            { no: 8, name: "last_error", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 9, name: "alerts", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Alert }
+        ]);
+    }
+    create(value?: PartialMessage<AlertingRule>): AlertingRule {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.query = "";
+        message.threshold = 0;
+        message.annotations = {};
+        message.lastError = "";
+        message.alerts = [];
+        if (value !== undefined)
+            reflectionMergePartial<AlertingRule>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: AlertingRule): AlertingRule {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string query */ 2:
+                    message.query = reader.string();
+                    break;
+                case /* double threshold */ 3:
+                    message.threshold = reader.double();
+                    break;
+                case /* google.protobuf.Duration duration */ 4:
+                    message.duration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.duration);
+                    break;
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 5:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* map<string, string> annotations */ 6:
+                    this.binaryReadMap6(message.annotations, reader, options);
+                    break;
+                case /* google.protobuf.Timestamp last_evaluation */ 7:
+                    message.lastEvaluation = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.lastEvaluation);
+                    break;
+                case /* string last_error */ 8:
+                    message.lastError = reader.string();
+                    break;
+                case /* repeated parca.alerts.v1alpha1.Alert alerts */ 9:
+                    message.alerts.push(Alert.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap6(map: AlertingRule["annotations"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof AlertingRule["annotations"] | undefined, val: AlertingRule["annotations"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for parca.alerts.v1alpha1.AlertingRule.annotations");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: AlertingRule, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string query = 2; */
+        if (message.query !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.query);
+        /* double threshold = 3; */
+        if (message.threshold !== 0)
+            writer.tag(3, WireType.Bit64).double(message.threshold);
+        /* google.protobuf.Duration duration = 4; */
+        if (message.duration)
+            Duration.internalBinaryWrite(message.duration, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.profilestore.v1alpha1.LabelSet labels = 5; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* map<string, string> annotations = 6; */
+        for (let k of globalThis.Object.keys(message.annotations))
+            writer.tag(6, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.annotations[k]).join();
+        /* google.protobuf.Timestamp last_evaluation = 7; */
+        if (message.lastEvaluation)
+            Timestamp.internalBinaryWrite(message.lastEvaluation, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* string last_error = 8; */
+        if (message.lastError !== "")
+            writer.tag(8, WireType.LengthDelimited).string(message.lastError);
+        /* repeated parca.alerts.v1alpha1.Alert alerts = 9; */
+        for (let i = 0; i < message.alerts.length; i++)
+            Alert.internalBinaryWrite(message.alerts[i], writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.alerts.v1alpha1.AlertingRule
+ */
+export const AlertingRule = new AlertingRule$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class Alert$Type extends MessageType<Alert> {
+    constructor() {
+        super("parca.alerts.v1alpha1.Alert", [
+            { no: 1, name: "state", kind: "enum", T: () => ["parca.alerts.v1alpha1.Alert.State", Alert_State, "STATE_"] },
+            { no: 2, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 3, name: "annotations", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 4, name: "value", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 5, name: "active_at", kind: "message", T: () => Timestamp },
+            { no: 6, name: "fired_at", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<Alert>): Alert {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.state = 0;
+        message.annotations = {};
+        message.value = 0;
+        if (value !== undefined)
+            reflectionMergePartial<Alert>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Alert): Alert {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.alerts.v1alpha1.Alert.State state */ 1:
+                    message.state = reader.int32();
+                    break;
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 2:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* map<string, string> annotations */ 3:
+                    this.binaryReadMap3(message.annotations, reader, options);
+                    break;
+                case /* double value */ 4:
+                    message.value = reader.double();
+                    break;
+                case /* google.protobuf.Timestamp active_at */ 5:
+                    message.activeAt = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.activeAt);
+                    break;
+                case /* google.protobuf.Timestamp fired_at */ 6:
+                    message.firedAt = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.firedAt);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap3(map: Alert["annotations"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof Alert["annotations"] | undefined, val: Alert["annotations"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for parca.alerts.v1alpha1.Alert.annotations");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: Alert, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.alerts.v1alpha1.Alert.State state = 1; */
+        if (message.state !== 0)
+            writer.tag(1, WireType.Varint).int32(message.state);
+        /* parca.profilestore.v1alpha1.LabelSet labels = 2; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* map<string, string> annotations = 3; */
+        for (let k of globalThis.Object.keys(message.annotations))
+            writer.tag(3, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.annotations[k]).join();
+        /* double value = 4; */
+        if (message.value !== 0)
+            writer.tag(4, WireType.Bit64).double(message.value);
+        /* google.protobuf.Timestamp active_at = 5; */
+        if (message.activeAt)
+            Timestamp.internalBinaryWrite(message.activeAt, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp fired_at = 6; */
+        if (message.firedAt)
+            Timestamp.internalBinaryWrite(message.firedAt, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.alerts.v1alpha1.Alert
+ */
+export const Alert = new Alert$Type();
+/**
+ * @generated ServiceType for protobuf service parca.alerts.v1alpha1.AlertsService
+ */
+export const AlertsService = new ServiceType("parca.alerts.v1alpha1.AlertsService", [
+    { name: "Rules", options: { "google.api.http": { get: "/alerts/rules" } }, I: RulesRequest, O: RulesResponse }
+]);
@@ -0,0 +1,79 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/annotation/v1alpha1/annotation.proto" (package "parca.annotation.v1alpha1", syntax proto3)
+// tslint:disable
+import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
+import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
+import { AnnotationService } from "./annotation";
+import type { DeleteAnnotationResponse } from "./annotation";
+import type { DeleteAnnotationRequest } from "./annotation";
+import type { QueryAnnotationsResponse } from "./annotation";
+import type { QueryAnnotationsRequest } from "./annotation";
+import { stackIntercept } from "@protobuf-ts/runtime-rpc";
+import type { CreateAnnotationResponse } from "./annotation";
+import type { CreateAnnotationRequest } from "./annotation";
+import type { UnaryCall } from "@protobuf-ts/runtime-rpc";
+import type { RpcOptions } from "@protobuf-ts/runtime-rpc";
+/**
+ * AnnotationService stores events such as deploys and incidents, so they can be shown alongside profiling data
+ *
+ * @generated from protobuf service parca.annotation.v1alpha1.AnnotationService
+ */
+export interface IAnnotationServiceClient {
+    /**
+     * CreateAnnotation stores a new annotation
+     *
+     * @generated from protobuf rpc: CreateAnnotation
+     */
+    createAnnotation(input: CreateAnnotationRequest, options?: RpcOptions): UnaryCall<CreateAnnotationRequest, CreateAnnotationResponse>;
+    /**
+     * QueryAnnotations returns the annotations overlapping a time window
+     *
+     * @generated from protobuf rpc: QueryAnnotations
+     */
+    queryAnnotations(input: QueryAnnotationsRequest, options?: RpcOptions): UnaryCall<QueryAnnotationsRequest, QueryAnnotationsResponse>;
+    /**
+     * DeleteAnnotation deletes an annotation
+     *
+     * @generated from protobuf rpc: DeleteAnnotation
+     */
+    deleteAnnotation(input: DeleteAnnotationRequest, options?: RpcOptions): UnaryCall<DeleteAnnotationRequest, DeleteAnnotationResponse>;
+}
+/**
+ * AnnotationService stores events such as deploys and incidents, so they can be shown alongside profiling data
+ *
+ * @generated from protobuf service parca.annotation.v1alpha1.AnnotationService
+ */
+export class AnnotationServiceClient implements IAnnotationServiceClient, ServiceInfo {
+    typeName = AnnotationService.typeName;
+    methods = AnnotationService.methods;
+    options = AnnotationService.options;
+    constructor(private readonly _transport: RpcTransport) {
+    }
+    /**
+     * CreateAnnotation stores a new annotation
+     *
+     * @generated from protobuf rpc: CreateAnnotation
+     */
+    createAnnotation(input: CreateAnnotationRequest, options?: RpcOptions): UnaryCall<CreateAnnotationRequest, CreateAnnotationResponse> {
+        const method = this.methods[0], opt = this._transport.mergeOptions(options);
+        return stackIntercept<CreateAnnotationRequest, CreateAnnotationResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * QueryAnnotations returns the annotations overlapping a time window
+     *
+     * @generated from protobuf rpc: QueryAnnotations
+     */
+    queryAnnotations(input: QueryAnnotationsRequest, options?: RpcOptions): UnaryCall<QueryAnnotationsRequest, QueryAnnotationsResponse> {
+        const method = this.methods[1], opt = this._transport.mergeOptions(options);
+        return stackIntercept<QueryAnnotationsRequest, QueryAnnotationsResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * DeleteAnnotation deletes an annotation
+     *
+     * @generated from protobuf rpc: DeleteAnnotation
+     */
+    deleteAnnotation(input: DeleteAnnotationRequest, options?: RpcOptions): UnaryCall<DeleteAnnotationRequest, DeleteAnnotationResponse> {
+        const method = this.methods[2], opt = this._transport.mergeOptions(options);
+        return stackIntercept<DeleteAnnotationRequest, DeleteAnnotationResponse>("unary", this._transport, method, opt, input);
+    }
+}
@@ -0,0 +1,641 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/annotation/v1alpha1/annotation.proto" (package "parca.annotation.v1alpha1", syntax proto3)
+// tslint:disable
+import { ServiceType } from "@protobuf-ts/runtime-rpc";
+import type { BinaryWriteOptions } from "@protobuf-ts/runtime";
+import type { IBinaryWriter } from "@protobuf-ts/runtime";
+import { WireType } from "@protobuf-ts/runtime";
+import type { BinaryReadOptions } from "@protobuf-ts/runtime";
+import type { IBinaryReader } from "@protobuf-ts/runtime";
+import { UnknownFieldHandler } from "@protobuf-ts/runtime";
+import type { PartialMessage } from "@protobuf-ts/runtime";
+import { reflectionMergePartial } from "@protobuf-ts/runtime";
+import { MessageType } from "@protobuf-ts/runtime";
+import { Timestamp } from "../../../google/protobuf/timestamp";
+/**
+ * Annotation is an event at a point in time or over a time range
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.Annotation
+ */
+export interface Annotation {
+    /**
+     * id is the id of the annotation, it is assigned on creation
+     *
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+    /**
+     * selector is the label selector of the series the annotation applies to, for example {job="api"}.
+     * An empty selector applies to all series.
+     *
+     * @generated from protobuf field: string selector = 2
+     */
+    selector: string;
+    /**
+     * start is the time the event started
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 3
+     */
+    start?: Timestamp;
+    /**
+     * end is the time the event ended, it equals start for events at a point in time
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 4
+     */
+    end?: Timestamp;
+    /**
+     * title is a short summary of the event
+     *
+     * @generated from protobuf field: string title = 5
+     */
+    title: string;
+    /**
+     * text is a free-text description of the event
+     *
+     * @generated from protobuf field: string text = 6
+     */
+    text: string;
+    /**
+     * metadata are free-form key value pairs, for example the commit of a deploy
+     *
+     * @generated from protobuf field: map<string, string> metadata = 7
+     */
+    metadata: {
+        [key: string]: string;
+    };
+}
+/**
+ * CreateAnnotationRequest is the request to create an annotation
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.CreateAnnotationRequest
+ */
+export interface CreateAnnotationRequest {
+    /**
+     * selector is the label selector of the series the annotation applies to
+     *
+     * @generated from protobuf field: string selector = 1
+     */
+    selector: string;
+    /**
+     * start is the time the event started
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2
+     */
+    start?: Timestamp;
+    /**
+     * end is the time the event ended, events without an end are at a point in time. Events must not last longer
+     * than 31 days.
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3
+     */
+    end?: Timestamp;
+    /**
+     * title is a short summary of the event
+     *
+     * @generated from protobuf field: string title = 4
+     */
+    title: string;
+    /**
+     * text is a free-text description of the event
+     *
+     * @generated from protobuf field: string text = 5
+     */
+    text: string;
+    /**
+     * metadata are free-form key value pairs
+     *
+     * @generated from protobuf field: map<string, string> metadata = 6
+     */
+    metadata: {
+        [key: string]: string;
+    };
+}
+/**
+ * CreateAnnotationResponse is the response to creating an annotation
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.CreateAnnotationResponse
+ */
+export interface CreateAnnotationResponse {
+    /**
+     * annotation is the created annotation
+     *
+     * @generated from protobuf field: parca.annotation.v1alpha1.Annotation annotation = 1
+     */
+    annotation?: Annotation;
+}
+/**
+ * QueryAnnotationsRequest is the request for the annotations of a time window
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.QueryAnnotationsRequest
+ */
+export interface QueryAnnotationsRequest {
+    /**
+     * start is the beginning of the time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 1
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window, the time window must not be longer than 92 days
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 2
+     */
+    end?: Timestamp;
+    /**
+     * query is an optional profile query or label selector, only annotations whose selector matches the labels of
+     * its equality matchers are returned
+     *
+     * @generated from protobuf field: string query = 3
+     */
+    query: string;
+}
+/**
+ * QueryAnnotationsResponse contains the annotations of a time window
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.QueryAnnotationsResponse
+ */
+export interface QueryAnnotationsResponse {
+    /**
+     * annotations are the annotations sorted by start
+     *
+     * @generated from protobuf field: repeated parca.annotation.v1alpha1.Annotation annotations = 1
+     */
+    annotations: Annotation[];
+}
+/**
+ * DeleteAnnotationRequest is the request to delete an annotation
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.DeleteAnnotationRequest
+ */
+export interface DeleteAnnotationRequest {
+    /**
+     * id is the id of the annotation
+     *
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+}
+/**
+ * DeleteAnnotationResponse is the response to deleting an annotation
+ *
+ * @generated from protobuf message parca.annotation.v1alpha1.DeleteAnnotationResponse
+ */
+export interface DeleteAnnotationResponse {
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class Annotation$Type extends MessageType<Annotation> {
+    constructor() {
+        super("parca.annotation.v1alpha1.Annotation", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "start", kind: "message", T: () => Timestamp },
+            { no: 4, name: "end", kind: "message", T: () => Timestamp },
+            { no: 5, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "metadata", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+        ]);
+    }
+    create(value?: PartialMessage<Annotation>): Annotation {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.selector = "";
+        message.title = "";
+        message.text = "";
+        message.metadata = {};
+        if (value !== undefined)
+            reflectionMergePartial<Annotation>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Annotation): Annotation {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* string selector */ 2:
+                    message.selector = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 3:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 4:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string title */ 5:
+                    message.title = reader.string();
+                    break;
+                case /* string text */ 6:
+                    message.text = reader.string();
+                    break;
+                case /* map<string, string> metadata */ 7:
+                    this.binaryReadMap7(message.metadata, reader, options);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap7(map: Annotation["metadata"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof Annotation["metadata"] | undefined, val: Annotation["metadata"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for parca.annotation.v1alpha1.Annotation.metadata");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: Annotation, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string selector = 2; */
+        if (message.selector !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.selector);
+        /* google.protobuf.Timestamp start = 3; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 4; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* string title = 5; */
+        if (message.title !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.title);
+        /* string text = 6; */
+        if (message.text !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.text);
+        /* map<string, string> metadata = 7; */
+        for (let k of globalThis.Object.keys(message.metadata))
+            writer.tag(7, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.metadata[k]).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.Annotation
+ */
+export const Annotation = new Annotation$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CreateAnnotationRequest$Type extends MessageType<CreateAnnotationRequest> {
+    constructor() {
+        super("parca.annotation.v1alpha1.CreateAnnotationRequest", [
+            { no: 1, name: "selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "title", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "metadata", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+        ]);
+    }
+    create(value?: PartialMessage<CreateAnnotationRequest>): CreateAnnotationRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.selector = "";
+        message.title = "";
+        message.text = "";
+        message.metadata = {};
+        if (value !== undefined)
+            reflectionMergePartial<CreateAnnotationRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CreateAnnotationRequest): CreateAnnotationRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string selector */ 1:
+                    message.selector = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string title */ 4:
+                    message.title = reader.string();
+                    break;
+                case /* string text */ 5:
+                    message.text = reader.string();
+                    break;
+                case /* map<string, string> metadata */ 6:
+                    this.binaryReadMap6(message.metadata, reader, options);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap6(map: CreateAnnotationRequest["metadata"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof CreateAnnotationRequest["metadata"] | undefined, val: CreateAnnotationRequest["metadata"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for parca.annotation.v1alpha1.CreateAnnotationRequest.metadata");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
+    internalBinaryWrite(message: CreateAnnotationRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string selector = 1; */
+        if (message.selector !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.selector);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* string title = 4; */
+        if (message.title !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.title);
+        /* string text = 5; */
+        if (message.text !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.text);
+        /* map<string, string> metadata = 6; */
+        for (let k of globalThis.Object.keys(message.metadata))
+            writer.tag(6, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.metadata[k]).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.CreateAnnotationRequest
+ */
+export const CreateAnnotationRequest = new CreateAnnotationRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CreateAnnotationResponse$Type extends MessageType<CreateAnnotationResponse> {
+    constructor() {
+        super("parca.annotation.v1alpha1.CreateAnnotationResponse", [
+            { no: 1, name: "annotation", kind: "message", T: () => Annotation }
+        ]);
+    }
+    create(value?: PartialMessage<CreateAnnotationResponse>): CreateAnnotationResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<CreateAnnotationResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CreateAnnotationResponse): CreateAnnotationResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.annotation.v1alpha1.Annotation annotation */ 1:
+                    message.annotation = Annotation.internalBinaryRead(reader, reader.uint32(), options, message.annotation);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: CreateAnnotationResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.annotation.v1alpha1.Annotation annotation = 1; */
+        if (message.annotation)
+            Annotation.internalBinaryWrite(message.annotation, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.CreateAnnotationResponse
+ */
+export const CreateAnnotationResponse = new CreateAnnotationResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryAnnotationsRequest$Type extends MessageType<QueryAnnotationsRequest> {
+    constructor() {
+        super("parca.annotation.v1alpha1.QueryAnnotationsRequest", [
+            { no: 1, name: "start", kind: "message", T: () => Timestamp },
+            { no: 2, name: "end", kind: "message", T: () => Timestamp },
+            { no: 3, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<QueryAnnotationsRequest>): QueryAnnotationsRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        if (value !== undefined)
+            reflectionMergePartial<QueryAnnotationsRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryAnnotationsRequest): QueryAnnotationsRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp start */ 1:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 2:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* string query */ 3:
+                    message.query = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryAnnotationsRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp start = 1; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 2; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* string query = 3; */
+        if (message.query !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.query);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.QueryAnnotationsRequest
+ */
+export const QueryAnnotationsRequest = new QueryAnnotationsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryAnnotationsResponse$Type extends MessageType<QueryAnnotationsResponse> {
+    constructor() {
+        super("parca.annotation.v1alpha1.QueryAnnotationsResponse", [
+            { no: 1, name: "annotations", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Annotation }
+        ]);
+    }
+    create(value?: PartialMessage<QueryAnnotationsResponse>): QueryAnnotationsResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.annotations = [];
+        if (value !== undefined)
+            reflectionMergePartial<QueryAnnotationsResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryAnnotationsResponse): QueryAnnotationsResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.annotation.v1alpha1.Annotation annotations */ 1:
+                    message.annotations.push(Annotation.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryAnnotationsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.annotation.v1alpha1.Annotation annotations = 1; */
+        for (let i = 0; i < message.annotations.length; i++)
+            Annotation.internalBinaryWrite(message.annotations[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.QueryAnnotationsResponse
+ */
+export const QueryAnnotationsResponse = new QueryAnnotationsResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DeleteAnnotationRequest$Type extends MessageType<DeleteAnnotationRequest> {
+    constructor() {
+        super("parca.annotation.v1alpha1.DeleteAnnotationRequest", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<DeleteAnnotationRequest>): DeleteAnnotationRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        if (value !== undefined)
+            reflectionMergePartial<DeleteAnnotationRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DeleteAnnotationRequest): DeleteAnnotationRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DeleteAnnotationRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.DeleteAnnotationRequest
+ */
+export const DeleteAnnotationRequest = new DeleteAnnotationRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DeleteAnnotationResponse$Type extends MessageType<DeleteAnnotationResponse> {
+    constructor() {
+        super("parca.annotation.v1alpha1.DeleteAnnotationResponse", []);
+    }
+    create(value?: PartialMessage<DeleteAnnotationResponse>): DeleteAnnotationResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<DeleteAnnotationResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DeleteAnnotationResponse): DeleteAnnotationResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DeleteAnnotationResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.annotation.v1alpha1.DeleteAnnotationResponse
+ */
+export const DeleteAnnotationResponse = new DeleteAnnotationResponse$Type();
+/**
+ * @generated ServiceType for protobuf service parca.annotation.v1alpha1.AnnotationService
+ */
+export const AnnotationService = new ServiceType("parca.annotation.v1alpha1.AnnotationService", [
+    { name: "CreateAnnotation", options: { "google.api.http": { post: "/annotations", body: "*" } }, I: CreateAnnotationRequest, O: CreateAnnotationResponse },
+    { name: "QueryAnnotations", options: { "google.api.http": { get: "/annotations" } }, I: QueryAnnotationsRequest, O: QueryAnnotationsResponse },
+    { name: "DeleteAnnotation", options: { "google.api.http": { delete: "/annotations/{id}" } }, I: DeleteAnnotationRequest, O: DeleteAnnotationResponse }
+]);
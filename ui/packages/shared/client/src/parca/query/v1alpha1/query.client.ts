@@ -4,6 +4,8 @@
 import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
 import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
 import { QueryService } from "./query";
+import type { TopRegressionsResponse } from "./query";
+import type { TopRegressionsRequest } from "./query";
 import type { HasProfileDataResponse } from "./query";
 import type { HasProfileDataRequest } from "./query";
 import type { ShareProfileResponse } from "./query";
@@ -42,7 +44,7 @@ export interface IQueryServiceClient {
      */
     query(input: QueryRequest, options?: RpcOptions): UnaryCall<QueryRequest, QueryResponse>;
     /**
-     * Series is unimplemented
+     * Series returns the label sets of the series matching the selectors within a time window
      *
      * @generated from protobuf rpc: Series
      */
@@ -77,6 +79,12 @@ export interface IQueryServiceClient {
      * @generated from protobuf rpc: HasProfileData
      */
     hasProfileData(input: HasProfileDataRequest, options?: RpcOptions): UnaryCall<HasProfileDataRequest, HasProfileDataResponse>;
+    /**
+     * TopRegressions compares the profiles of a baseline and a candidate time window and returns the functions whose share of the profile grew the most
+     *
+     * @generated from protobuf rpc: TopRegressions
+     */
+    topRegressions(input: TopRegressionsRequest, options?: RpcOptions): UnaryCall<TopRegressionsRequest, TopRegressionsResponse>;
 }
 /**
  * QueryService is the service that provides APIs to retrieve and inspect profiles
@@ -108,7 +116,7 @@ export class QueryServiceClient implements IQueryServiceClient, ServiceInfo {
         return stackIntercept<QueryRequest, QueryResponse>("unary", this._transport, method, opt, input);
     }
     /**
-     * Series is unimplemented
+     * Series returns the label sets of the series matching the selectors within a time window
      *
      * @generated from protobuf rpc: Series
      */
@@ -161,4 +169,13 @@ export class QueryServiceClient implements IQueryServiceClient, ServiceInfo {
         const method = this.methods[7], opt = this._transport.mergeOptions(options);
         return stackIntercept<HasProfileDataRequest, HasProfileDataResponse>("unary", this._transport, method, opt, input);
     }
+    /**
+     * TopRegressions compares the profiles of a baseline and a candidate time window and returns the functions whose share of the profile grew the most
+     *
+     * @generated from protobuf rpc: TopRegressions
+     */
+    topRegressions(input: TopRegressionsRequest, options?: RpcOptions): UnaryCall<TopRegressionsRequest, TopRegressionsResponse> {
+        const method = this.methods[8], opt = this._transport.mergeOptions(options);
+        return stackIntercept<TopRegressionsRequest, TopRegressionsResponse>("unary", this._transport, method, opt, input);
+    }
 }
@@ -16,6 +16,7 @@ import { Function } from "../../metastore/v1alpha1/metastore";
 import { Mapping } from "../../metastore/v1alpha1/metastore";
 import { Location } from "../../metastore/v1alpha1/metastore";
 import { LabelSet } from "../../profilestore/v1alpha1/profilestore";
+import { Annotation } from "../../annotation/v1alpha1/annotation";
 import { Duration } from "../../../google/protobuf/duration";
 import { Timestamp } from "../../../google/protobuf/timestamp";
 /**
@@ -135,6 +136,14 @@ export interface QueryRangeRequest {
      * @generated from protobuf field: repeated string sum_by = 6
      */
     sumBy: string[];
+    /**
+     * filter is a set of filters to apply, only samples of stacks matching the
+     * stack filters are included in the series values. Frame filters are not
+     * supported and are rejected.
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Filter filter = 7
+     */
+    filter: Filter[];
 }
 /**
  * QueryRangeResponse is the set of matching profile values
@@ -148,6 +157,13 @@ export interface QueryRangeResponse {
      * @generated from protobuf field: repeated parca.query.v1alpha1.MetricsSeries series = 1
      */
     series: MetricsSeries[];
+    /**
+     * annotations are the annotations overlapping the time window whose selector matches the labels of the equality
+     * matchers of the query
+     *
+     * @generated from protobuf field: repeated parca.annotation.v1alpha1.Annotation annotations = 2
+     */
+    annotations: Annotation[];
 }
 /**
  * MetricsSeries is a set of labels and corresponding sample values
@@ -224,7 +240,8 @@ export interface MetricsSample {
  */
 export interface MergeProfile {
     /**
-     * query is the query string to match profiles for merge
+     * query is the query string to match profiles for merge. Derived profile types configured on the server are queried
+     * as derived:<name>{...} and only support the arrow flame graph and table reports.
      *
      * @generated from protobuf field: string query = 1
      */
@@ -242,6 +259,38 @@ export interface MergeProfile {
      */
     end?: Timestamp;
 }
+/**
+ * MultiMetricProfile contains parameters for merging two profile types, for example on-CPU and off-CPU time,
+ * into a single flame graph. Both profile types have to have the same unit.
+ *
+ * @generated from protobuf message parca.query.v1alpha1.MultiMetricProfile
+ */
+export interface MultiMetricProfile {
+    /**
+     * query is the query string of the primary profile type
+     *
+     * @generated from protobuf field: string query = 1
+     */
+    query: string;
+    /**
+     * secondary_query is the query string of the secondary profile type
+     *
+     * @generated from protobuf field: string secondary_query = 2
+     */
+    secondaryQuery: string;
+    /**
+     * start is the beginning of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 3
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the evaluation time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 4
+     */
+    end?: Timestamp;
+}
 /**
  * SingleProfile contains parameters for a single profile query request
  *
@@ -285,6 +334,14 @@ export interface DiffProfile {
      * @generated from protobuf field: optional bool absolute = 3
      */
     absolute?: boolean;
+    /**
+     * significance tests per function whether its values in the individual profiles of a and b differ significantly,
+     * using the Mann-Whitney U test. Both selections have to be merges. The two-sided p-values are added as p_value
+     * column to the table and arrow flame graph reports.
+     *
+     * @generated from protobuf field: bool significance = 4
+     */
+    significance: boolean;
 }
 /**
  * ProfileDiffSelection contains the parameters of a diff selection
@@ -383,6 +440,14 @@ export interface QueryRequest {
          * @generated from protobuf field: parca.query.v1alpha1.SingleProfile single = 4
          */
         single: SingleProfile;
+    } | {
+        oneofKind: "multiMetric";
+        /**
+         * multi_metric contains the multi metric query options
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.MultiMetricProfile multi_metric = 28
+         */
+        multiMetric: MultiMetricProfile;
     } | {
         oneofKind: undefined;
     };
@@ -406,7 +471,8 @@ export interface QueryRequest {
      */
     nodeTrimThreshold?: number;
     /**
-     * group_by indicates the fields to group by
+     * group_by indicates the fields to group by. Grouping a REPORT_TYPE_SPEEDSCOPE report by timestamp returns an
+     * evented instead of a sampled profile.
      *
      * @generated from protobuf field: optional parca.query.v1alpha1.GroupBy group_by = 8
      */
@@ -442,6 +508,101 @@ export interface QueryRequest {
      * @generated from protobuf field: optional string sandwich_by_function = 13
      */
     sandwichByFunction?: string;
+    /**
+     * node_count is the maximum number of nodes in callgraph reports, the nodes with the highest
+     * flat values are kept. If unset, nodes with a small cumulative value are pruned instead.
+     *
+     * @generated from protobuf field: optional uint32 node_count = 14
+     */
+    nodeCount?: number;
+    /**
+     * focus is a regular expression, only samples with a frame matching it are kept. Frames are
+     * matched by their function name, filename and mapping file, just like pprof's focus option.
+     * Unlike the regular expressions of filter, the focus, ignore, hide, show, show_from and
+     * prune_from regular expressions are case-sensitive, as they are in pprof.
+     *
+     * @generated from protobuf field: optional string focus = 15
+     */
+    focus?: string;
+    /**
+     * ignore is a regular expression, samples with a frame matching it are dropped.
+     *
+     * @generated from protobuf field: optional string ignore = 16
+     */
+    ignore?: string;
+    /**
+     * hide is a regular expression, frames matching it are removed from the stacks while their
+     * samples are kept.
+     *
+     * @generated from protobuf field: optional string hide = 17
+     */
+    hide?: string;
+    /**
+     * show is a regular expression, only frames matching it are kept in the stacks while their
+     * samples are kept.
+     *
+     * @generated from protobuf field: optional string show = 18
+     */
+    show?: string;
+    /**
+     * show_from is a regular expression matched against function names, all frames above the
+     * highest matching frame are removed. Samples without a matching frame are dropped.
+     *
+     * @generated from protobuf field: optional string show_from = 19
+     */
+    showFrom?: string;
+    /**
+     * prune_from is a regular expression matched against function names, all frames below the
+     * highest matching frame are removed.
+     *
+     * @generated from protobuf field: optional string prune_from = 20
+     */
+    pruneFrom?: string;
+    /**
+     * callers_callees_function is a regular expression matched against function names, the callers
+     * and callees of the matching functions are returned by the REPORT_TYPE_CALLERS_CALLEES report.
+     *
+     * @generated from protobuf field: optional string callers_callees_function = 21
+     */
+    callersCalleesFunction?: string;
+    /**
+     * disassembly_reference references the function to disassemble for REPORT_TYPE_DISASSEMBLY
+     *
+     * @generated from protobuf field: optional parca.query.v1alpha1.DisassemblyReference disassembly_reference = 22
+     */
+    disassemblyReference?: DisassemblyReference;
+    /**
+     * label_breakdown_function is a regular expression matching the names of the functions to break down by
+     * REPORT_TYPE_LABEL_BREAKDOWN. All samples are broken down if it is empty.
+     *
+     * @generated from protobuf field: optional string label_breakdown_function = 23
+     */
+    labelBreakdownFunction?: string;
+    /**
+     * label_breakdown_label limits REPORT_TYPE_LABEL_BREAKDOWN to a single label, all labels are broken down if it is
+     * empty.
+     *
+     * @generated from protobuf field: optional string label_breakdown_label = 24
+     */
+    labelBreakdownLabel?: string;
+    /**
+     * heatmap_bucket_count is the number of time buckets of REPORT_TYPE_HEATMAP, defaults to 60.
+     *
+     * @generated from protobuf field: optional uint32 heatmap_bucket_count = 25
+     */
+    heatmapBucketCount?: number;
+    /**
+     * heatmap_function_count is the number of functions, or stack roots, of REPORT_TYPE_HEATMAP, defaults to 20.
+     *
+     * @generated from protobuf field: optional uint32 heatmap_function_count = 26
+     */
+    heatmapFunctionCount?: number;
+    /**
+     * heatmap_by_root groups REPORT_TYPE_HEATMAP by the roots of the stacks instead of by all of their functions.
+     *
+     * @generated from protobuf field: optional bool heatmap_by_root = 27
+     */
+    heatmapByRoot?: boolean;
 }
 /**
  * Mode is the type of query request
@@ -466,7 +627,13 @@ export enum QueryRequest_Mode {
      *
      * @generated from protobuf enum value: MODE_MERGE = 2;
      */
-    MERGE = 2
+    MERGE = 2,
+    /**
+     * MODE_MULTI_METRIC merges two profile types into a single REPORT_TYPE_FLAMEGRAPH_ARROW
+     *
+     * @generated from protobuf enum value: MODE_MULTI_METRIC = 3;
+     */
+    MULTI_METRIC = 3
 }
 /**
  * ReportType is the type of report to return
@@ -534,7 +701,88 @@ export enum QueryRequest_ReportType {
      *
      * @generated from protobuf enum value: REPORT_TYPE_FLAMECHART = 9;
      */
-    FLAMECHART = 9
+    FLAMECHART = 9,
+    /**
+     * REPORT_TYPE_FOLDED contains the stacks in the folded format, one line per stack with the
+     * frames from root to leaf separated by semicolons followed by the value, e.g. `main;foo;bar 1234`.
+     * Diff reports contain the base and the compared value, e.g. `main;foo;bar 100 150`.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_FOLDED = 10;
+     */
+    FOLDED = 10,
+    /**
+     * REPORT_TYPE_SPEEDSCOPE contains the report as a speedscope file format JSON document.
+     * The timestamp field is not added to the group by fields like for REPORT_TYPE_FLAMECHART: only if the query
+     * is grouped by timestamp an evented profile with the samples laid out by time is returned, otherwise a sampled
+     * profile with identical stacks merged.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_SPEEDSCOPE = 11;
+     */
+    SPEEDSCOPE = 11,
+    /**
+     * REPORT_TYPE_CHROME_TRACE contains the flame chart of the report as a Chrome trace event JSON
+     * document, which can be loaded into ui.perfetto.dev. Each label set the query is grouped by
+     * results in a separate track.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_CHROME_TRACE = 12;
+     */
+    CHROME_TRACE = 12,
+    /**
+     * REPORT_TYPE_CALLGRAPH_DOT contains the callgraph rendered in the Graphviz DOT format, an image
+     * can be rendered from it with `dot -Tsvg`.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_CALLGRAPH_DOT = 13;
+     */
+    CALLGRAPH_DOT = 13,
+    /**
+     * REPORT_TYPE_CALLERS_CALLEES contains the direct callers and callees of the functions matching
+     * callers_callees_function, like pprof's peek command.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_CALLERS_CALLEES = 15;
+     */
+    CALLERS_CALLEES = 15,
+    /**
+     * REPORT_TYPE_DISASSEMBLY contains the disassembly of the function referenced by
+     * disassembly_reference annotated with the samples of each instruction. It requires the
+     * executable to be uploaded.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_DISASSEMBLY = 16;
+     */
+    DISASSEMBLY = 16,
+    /**
+     * REPORT_TYPE_LABEL_BREAKDOWN contains the cumulative value of the functions matching
+     * label_breakdown_function grouped by the values of each label, or only of label_breakdown_label.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_LABEL_BREAKDOWN = 17;
+     */
+    LABEL_BREAKDOWN = 17,
+    /**
+     * REPORT_TYPE_HEATMAP contains the values of the top functions, or of the stack roots, per time bucket of the
+     * merge range. Only supported for merge queries.
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_HEATMAP = 18;
+     */
+    HEATMAP = 18
+}
+/**
+ * DisassemblyReference references the function to disassemble.
+ *
+ * @generated from protobuf message parca.query.v1alpha1.DisassemblyReference
+ */
+export interface DisassemblyReference {
+    /**
+     * build_id is the build ID of the executable.
+     *
+     * @generated from protobuf field: string build_id = 1
+     */
+    buildId: string;
+    /**
+     * address is an address of the executable as it appears in the profile, the function
+     * containing it is disassembled.
+     *
+     * @generated from protobuf field: uint64 address = 2
+     */
+    address: bigint;
 }
 /**
  * FilterCriteria defines the various criteria that can be used to filter stack frames or stacks
@@ -638,6 +886,24 @@ export interface StringCondition {
          * @generated from protobuf field: string not_starts_with = 6
          */
         notStartsWith: string;
+    } | {
+        oneofKind: "matchesRegex";
+        /**
+         * matches_regex matches strings that match the specified RE2 regular expression, case-insensitively like the
+         * other conditions
+         *
+         * @generated from protobuf field: string matches_regex = 7
+         */
+        matchesRegex: string;
+    } | {
+        oneofKind: "notMatchesRegex";
+        /**
+         * not_matches_regex matches strings that do not match the specified RE2 regular expression, case-insensitively
+         * like the other conditions
+         *
+         * @generated from protobuf field: string not_matches_regex = 8
+         */
+        notMatchesRegex: string;
     } | {
         oneofKind: undefined;
     };
@@ -669,10 +935,53 @@ export interface NumberCondition {
          * @generated from protobuf field: uint64 not_equal = 2
          */
         notEqual: bigint;
+    } | {
+        oneofKind: "greaterThan";
+        /**
+         * greater_than matches numbers that are strictly greater than the specified value
+         *
+         * @generated from protobuf field: uint64 greater_than = 3
+         */
+        greaterThan: bigint;
+    } | {
+        oneofKind: "lessThan";
+        /**
+         * less_than matches numbers that are strictly less than the specified value
+         *
+         * @generated from protobuf field: uint64 less_than = 4
+         */
+        lessThan: bigint;
+    } | {
+        oneofKind: "range";
+        /**
+         * range matches numbers that are within the specified inclusive range
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.NumberRange range = 5
+         */
+        range: NumberRange;
     } | {
         oneofKind: undefined;
     };
 }
+/**
+ * NumberRange is an inclusive range of numbers
+ *
+ * @generated from protobuf message parca.query.v1alpha1.NumberRange
+ */
+export interface NumberRange {
+    /**
+     * start is the inclusive lower bound of the range
+     *
+     * @generated from protobuf field: uint64 start = 1
+     */
+    start: bigint;
+    /**
+     * end is the inclusive upper bound of the range
+     *
+     * @generated from protobuf field: uint64 end = 2
+     */
+    end: bigint;
+}
 /**
  * Filter to apply to the query request
  *
@@ -1037,7 +1346,11 @@ export interface Flamegraph {
  */
 export interface FlamegraphArrow {
     /**
-     * record is the arrow record containing the actual flamegraph data
+     * record is the arrow record containing the actual flamegraph data. For MODE_MULTI_METRIC the cumulative and flat
+     * values are the ones of the primary profile type, the cumulative_secondary and flat_secondary columns hold the
+     * ones of the secondary profile type. For derived profile types the cumulative and flat values are the ones of the
+     * denominator, the cumulative_numerator and flat_numerator columns hold the ones of the numerator, and ratio and
+     * flat_ratio columns are added.
      *
      * @generated from protobuf field: bytes record = 1
      */
@@ -1069,6 +1382,9 @@ export interface FlamegraphArrow {
 export interface Source {
     /**
      * An arrow record that contains a row per source code line with value and diff columns for flat and cumulative.
+     * Diff profiles additionally have the flat and cumulative values of the base profile in the
+     * cumulative_base_scaled and flat_base_scaled columns. Like the diff columns, they are scaled to the
+     * total of the larger of the two profiles unless the diff is absolute.
      *
      * @generated from protobuf field: bytes record = 1
      */
@@ -1283,446 +1599,1879 @@ export interface CallgraphEdge {
      * @generated from protobuf field: bool is_collapsed = 5
      */
     isCollapsed: boolean;
+    /**
+     * is_inlined indicates if the source and target are inlined functions of the same location
+     *
+     * @generated from protobuf field: bool is_inlined = 6
+     */
+    isInlined: boolean;
 }
 /**
- * Callgraph is the callgraph report type
+ * Disassembly is the disassembly report type
  *
- * @generated from protobuf message parca.query.v1alpha1.Callgraph
+ * @generated from protobuf message parca.query.v1alpha1.Disassembly
  */
-export interface Callgraph {
-    /**
-     * nodes are the nodes in the callgraph
-     *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNode nodes = 1
-     */
-    nodes: CallgraphNode[];
+export interface Disassembly {
     /**
-     * edges are the edges connecting nodes in the callgraph
+     * function is the name of the disassembled function
      *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphEdge edges = 2
+     * @generated from protobuf field: string function = 1
      */
-    edges: CallgraphEdge[];
+    function: string;
     /**
-     * cumulative is the total cumulative value of the callgraph
-     * Use total from the top level query response instead.
+     * start is the address of the first instruction of the function within the executable
      *
-     * @deprecated
-     * @generated from protobuf field: int64 cumulative = 3 [deprecated = true]
+     * @generated from protobuf field: uint64 start = 2
      */
-    cumulative: bigint;
-}
-/**
- * QueryResponse is the returned report for the given query
- *
- * @generated from protobuf message parca.query.v1alpha1.QueryResponse
- */
-export interface QueryResponse {
+    start: bigint;
     /**
-     * report is the generated report
+     * end is the address after the last instruction of the function within the executable
      *
-     * @generated from protobuf oneof: report
+     * @generated from protobuf field: uint64 end = 3
      */
-    report: {
-        oneofKind: "flamegraph";
-        /**
-         * flamegraph is a flamegraph representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5
-         */
-        flamegraph: Flamegraph;
-    } | {
-        oneofKind: "pprof";
-        /**
-         * pprof is a pprof profile as compressed bytes
-         *
-         * @generated from protobuf field: bytes pprof = 6
-         */
-        pprof: Uint8Array;
-    } | {
-        oneofKind: "top";
-        /**
-         * top is a top list representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7
-         */
-        top: Top;
-    } | {
-        oneofKind: "callgraph";
-        /**
-         * callgraph is a callgraph nodes and edges representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Callgraph callgraph = 8
-         */
-        callgraph: Callgraph;
-    } | {
-        oneofKind: "flamegraphArrow";
-        /**
-         * flamegraph_arrow is a flamegraph encoded as a arrow record
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.FlamegraphArrow flamegraph_arrow = 11
-         */
-        flamegraphArrow: FlamegraphArrow;
-    } | {
-        oneofKind: "source";
-        /**
-         * source is the source report type result
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Source source = 12
-         */
-        source: Source;
-    } | {
-        oneofKind: "tableArrow";
-        /**
-         * table_arrow is a table encoded as a arrow record
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.TableArrow table_arrow = 13
-         */
-        tableArrow: TableArrow;
-    } | {
-        oneofKind: "profileMetadata";
-        /**
-         * profile_metadata contains metadata about the profile i.e. binaries, labels
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.ProfileMetadata profile_metadata = 14
-         */
-        profileMetadata: ProfileMetadata;
-    } | {
-        oneofKind: undefined;
-    };
+    end: bigint;
     /**
-     * total is the total number of samples shown in the report.
+     * instructions are the instructions of the function ordered by their address
      *
-     * @generated from protobuf field: int64 total = 9
+     * @generated from protobuf field: repeated parca.query.v1alpha1.DisassemblyInstruction instructions = 4
      */
-    total: bigint;
+    instructions: DisassemblyInstruction[];
     /**
-     * filtered is the number of samples filtered out of the report.
+     * unit is the unit of the values
      *
-     * @generated from protobuf field: int64 filtered = 10
+     * @generated from protobuf field: string unit = 5
      */
-    filtered: bigint;
+    unit: string;
 }
 /**
- * SeriesRequest is unimplemented
+ * DisassemblyInstruction is an instruction annotated with the samples of its address. Consecutive
+ * instructions of the same source line can be grouped to interleave the source code.
  *
- * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
+ * @generated from protobuf message parca.query.v1alpha1.DisassemblyInstruction
  */
-export interface SeriesRequest {
+export interface DisassemblyInstruction {
     /**
-     * match ...
+     * address is the address of the instruction within the executable
      *
-     * @generated from protobuf field: repeated string match = 1
+     * @generated from protobuf field: uint64 address = 1
      */
-    match: string[];
+    address: bigint;
     /**
-     * start ...
+     * text is the instruction in the GNU assembler syntax
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2
+     * @generated from protobuf field: string text = 2
      */
-    start?: Timestamp;
+    text: string;
     /**
-     * end ...
+     * flat is the value of the samples whose leaf is the instruction
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3
+     * @generated from protobuf field: int64 flat = 3
      */
-    end?: Timestamp;
-}
-/**
- * SeriesResponse is unimplemented
- *
- * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
- */
-export interface SeriesResponse {
+    flat: bigint;
+    /**
+     * cumulative is the value of the samples containing the instruction
+     *
+     * @generated from protobuf field: int64 cumulative = 4
+     */
+    cumulative: bigint;
+    /**
+     * filename is the source file of the instruction, empty if no debug information is available
+     *
+     * @generated from protobuf field: string filename = 5
+     */
+    filename: string;
+    /**
+     * line is the source line of the instruction, zero if no debug information is available
+     *
+     * @generated from protobuf field: int64 line = 6
+     */
+    line: bigint;
 }
 /**
- * LabelsRequest are the request values for labels
+ * LabelBreakdown is the label breakdown report type
  *
- * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
+ * @generated from protobuf message parca.query.v1alpha1.LabelBreakdown
  */
-export interface LabelsRequest {
+export interface LabelBreakdown {
     /**
-     * match are the set of matching strings
+     * labels are the broken down labels ordered by name
      *
-     * @generated from protobuf field: repeated string match = 1
+     * @generated from protobuf field: repeated parca.query.v1alpha1.LabelBreakdownLabel labels = 1
      */
-    match: string[];
+    labels: LabelBreakdownLabel[];
     /**
-     * start is the start of the time window to perform the query
+     * cumulative is the value of the samples containing the requested functions
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2
+     * @generated from protobuf field: int64 cumulative = 2
      */
-    start?: Timestamp;
+    cumulative: bigint;
     /**
-     * end is the end of the time window to perform the query
+     * unit is the unit of the values
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3
+     * @generated from protobuf field: string unit = 3
      */
-    end?: Timestamp;
+    unit: string;
+}
+/**
+ * LabelBreakdownLabel is the breakdown of the requested functions by the values of a label
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelBreakdownLabel
+ */
+export interface LabelBreakdownLabel {
     /**
-     * profile_type is the type of profile to filter by
+     * name is the name of the label
      *
-     * @generated from protobuf field: optional string profile_type = 4
+     * @generated from protobuf field: string name = 1
      */
-    profileType?: string;
+    name: string;
+    /**
+     * values are the values of the label ordered by their cumulative value, the largest first
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.LabelBreakdownValue values = 2
+     */
+    values: LabelBreakdownValue[];
 }
 /**
- * LabelsResponse is the set of matching label names
+ * LabelBreakdownValue is the value of the requested functions in the samples with a label value
  *
- * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ * @generated from protobuf message parca.query.v1alpha1.LabelBreakdownValue
  */
-export interface LabelsResponse {
+export interface LabelBreakdownValue {
     /**
-     * / label_names are the set of matching label names
+     * value is the label value, empty for the samples without the label
      *
-     * @generated from protobuf field: repeated string label_names = 1
+     * @generated from protobuf field: string value = 1
      */
-    labelNames: string[];
+    value: string;
     /**
-     * warnings is unimplemented
+     * cumulative is the value of the samples with the label value containing the requested functions
      *
-     * @generated from protobuf field: repeated string warnings = 2
+     * @generated from protobuf field: int64 cumulative = 2
      */
-    warnings: string[];
+    cumulative: bigint;
+    /**
+     * percentage is the share of the cumulative value in the cumulative value of the requested functions
+     *
+     * @generated from protobuf field: double percentage = 3
+     */
+    percentage: number;
 }
 /**
- * ValuesRequest are the request values for a values request
+ * Heatmap is the heatmap report type
  *
- * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ * @generated from protobuf message parca.query.v1alpha1.Heatmap
  */
-export interface ValuesRequest {
+export interface Heatmap {
     /**
-     * label_name is the label name to match values against
+     * start is the start of the first time bucket
      *
-     * @generated from protobuf field: string label_name = 1
+     * @generated from protobuf field: google.protobuf.Timestamp start = 1
      */
-    labelName: string;
+    start?: Timestamp;
     /**
-     * match are the set of matching strings to match values against
+     * bucket_duration is the duration of each time bucket
      *
-     * @generated from protobuf field: repeated string match = 2
+     * @generated from protobuf field: google.protobuf.Duration bucket_duration = 2
      */
-    match: string[];
+    bucketDuration?: Duration;
     /**
-     * start is the start of the time window to perform the query
+     * totals are the values of all samples per time bucket
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 3
+     * @generated from protobuf field: repeated int64 totals = 3
      */
-    start?: Timestamp;
+    totals: bigint[];
     /**
-     * end is the end of the time window to perform the query
+     * rows are the top functions, or stack roots, ordered by their total value, the largest first
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 4
+     * @generated from protobuf field: repeated parca.query.v1alpha1.HeatmapRow rows = 4
      */
-    end?: Timestamp;
+    rows: HeatmapRow[];
     /**
-     * profile_type is the type of profile to filter by
+     * unit is the unit of the values
      *
-     * @generated from protobuf field: optional string profile_type = 5
+     * @generated from protobuf field: string unit = 5
      */
-    profileType?: string;
+    unit: string;
 }
 /**
- * ValuesResponse are the set of matching values
+ * HeatmapRow is the cumulative value of a function, or stack root, per time bucket
  *
- * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ * @generated from protobuf message parca.query.v1alpha1.HeatmapRow
  */
-export interface ValuesResponse {
+export interface HeatmapRow {
     /**
-     * label_values are the set of matching label values
+     * name is the name of the function
      *
-     * @generated from protobuf field: repeated string label_values = 1
+     * @generated from protobuf field: string name = 1
      */
-    labelValues: string[];
+    name: string;
     /**
-     * warnings is unimplemented
+     * total is the cumulative value of the function over all time buckets
      *
-     * @generated from protobuf field: repeated string warnings = 2
+     * @generated from protobuf field: int64 total = 2
      */
-    warnings: string[];
+    total: bigint;
+    /**
+     * values are the cumulative values of the function per time bucket
+     *
+     * @generated from protobuf field: repeated int64 values = 3
+     */
+    values: bigint[];
 }
 /**
- * ValueType represents a value, including its type and unit
+ * CallersCallees is the callers and callees report type
  *
- * @generated from protobuf message parca.query.v1alpha1.ValueType
+ * @generated from protobuf message parca.query.v1alpha1.CallersCallees
  */
-export interface ValueType {
+export interface CallersCallees {
     /**
-     * type is the type of the value
+     * functions are the functions matching the requested regular expression, ordered by their
+     * cumulative value
      *
-     * @generated from protobuf field: string type = 1
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallersCalleesFunction functions = 1
      */
-    type: string;
+    functions: CallersCalleesFunction[];
     /**
-     * unit is the unit of the value
+     * unit is the unit of the values
      *
      * @generated from protobuf field: string unit = 2
      */
     unit: string;
 }
 /**
- * ShareProfileRequest represents the query denoting the profile and a description about the profile
+ * CallersCalleesFunction is a function with its direct callers and callees
  *
- * @generated from protobuf message parca.query.v1alpha1.ShareProfileRequest
+ * @generated from protobuf message parca.query.v1alpha1.CallersCalleesFunction
  */
-export interface ShareProfileRequest {
+export interface CallersCalleesFunction {
     /**
-     * QueryRequest that refers to the profile to be shared
+     * function is the function itself, its percentages are relative to the total of the report
      *
-     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest query_request = 1
+     * @generated from protobuf field: parca.query.v1alpha1.CallersCalleesEntry function = 1
      */
-    queryRequest?: QueryRequest;
+    function?: CallersCalleesEntry;
     /**
-     * description about the profile
+     * callers are the functions directly calling the function, ordered by their cumulative value
      *
-     * @generated from protobuf field: optional string description = 2
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallersCalleesEntry callers = 2
      */
-    description?: string;
-}
-/**
- * ShareProfileResponse represents the shared link of a profile
- *
- * @generated from protobuf message parca.query.v1alpha1.ShareProfileResponse
- */
-export interface ShareProfileResponse {
+    callers: CallersCalleesEntry[];
     /**
-     * link to access the profile
+     * callees are the functions directly called by the function, ordered by their cumulative value
      *
-     * @generated from protobuf field: string link = 1
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallersCalleesEntry callees = 3
      */
-    link: string;
+    callees: CallersCalleesEntry[];
 }
 /**
- * TableArrow has the table encoded as a arrow record
+ * CallersCalleesEntry is a row of the callers and callees report. For callers and callees the
+ * values are those of the call from or to the function and the percentages are relative to the
+ * cumulative value of the function.
  *
- * @generated from protobuf message parca.query.v1alpha1.TableArrow
+ * @generated from protobuf message parca.query.v1alpha1.CallersCalleesEntry
  */
-export interface TableArrow {
+export interface CallersCalleesEntry {
     /**
-     * record is the arrow record containing the actual table data
+     * name is the function name, unsymbolized frames are named by their address
      *
-     * @generated from protobuf field: bytes record = 1
+     * @generated from protobuf field: string name = 1
      */
-    record: Uint8Array;
+    name: string;
     /**
-     * unit is the unit represented by the flame graph
+     * filename is the file of the function, or the mapping file of unsymbolized frames
      *
-     * @generated from protobuf field: string unit = 2
+     * @generated from protobuf field: string filename = 2
      */
-    unit: string;
-}
-/**
- * ProfileMetadata contains metadata about the profile i.e. binaries, labels
- *
- * @generated from protobuf message parca.query.v1alpha1.ProfileMetadata
- */
-export interface ProfileMetadata {
+    filename: string;
     /**
-     * mapping_files is the list of binaries in the profile
+     * flat is the value of the samples ending in the function, or ending in the call for callers
+     * and callees
      *
-     * @generated from protobuf field: repeated string mapping_files = 1
+     * @generated from protobuf field: int64 flat = 3
      */
-    mappingFiles: string[];
+    flat: bigint;
     /**
-     * labels is the list of labels in the profile
+     * cumulative is the value of the samples containing the function, or the call for callers and
+     * callees
      *
-     * @generated from protobuf field: repeated string labels = 2
+     * @generated from protobuf field: int64 cumulative = 4
      */
-    labels: string[];
+    cumulative: bigint;
+    /**
+     * flat_percentage is the flat value as a percentage
+     *
+     * @generated from protobuf field: double flat_percentage = 5
+     */
+    flatPercentage: number;
+    /**
+     * cumulative_percentage is the cumulative value as a percentage
+     *
+     * @generated from protobuf field: double cumulative_percentage = 6
+     */
+    cumulativePercentage: number;
+    /**
+     * diff is the diff of the cumulative value between two profiles
+     *
+     * @generated from protobuf field: int64 diff = 7
+     */
+    diff: bigint;
 }
 /**
- * HasProfileDataRequest is the request to check if there is profile data in the store
+ * Callgraph is the callgraph report type
  *
- * @generated from protobuf message parca.query.v1alpha1.HasProfileDataRequest
+ * @generated from protobuf message parca.query.v1alpha1.Callgraph
  */
-export interface HasProfileDataRequest {
+export interface Callgraph {
+    /**
+     * nodes are the nodes in the callgraph
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNode nodes = 1
+     */
+    nodes: CallgraphNode[];
+    /**
+     * edges are the edges connecting nodes in the callgraph
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphEdge edges = 2
+     */
+    edges: CallgraphEdge[];
+    /**
+     * cumulative is the total cumulative value of the callgraph
+     * Use total from the top level query response instead.
+     *
+     * @deprecated
+     * @generated from protobuf field: int64 cumulative = 3 [deprecated = true]
+     */
+    cumulative: bigint;
 }
 /**
- * HasProfileDataResponse is the response indicating whether there is profile data in the store
+ * QueryResponse is the returned report for the given query
  *
- * @generated from protobuf message parca.query.v1alpha1.HasProfileDataResponse
+ * @generated from protobuf message parca.query.v1alpha1.QueryResponse
  */
-export interface HasProfileDataResponse {
+export interface QueryResponse {
     /**
-     * has_data indicates whether there is profile data in the store
+     * report is the generated report
      *
-     * @generated from protobuf field: bool has_data = 1
+     * @generated from protobuf oneof: report
      */
-    hasData: boolean;
-}
-// @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
-    constructor() {
-        super("parca.query.v1alpha1.ProfileTypesRequest", [
-            { no: 1, name: "start", kind: "message", T: () => Timestamp },
-            { no: 2, name: "end", kind: "message", T: () => Timestamp }
-        ]);
-    }
-    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
-        const message = globalThis.Object.create((this.messagePrototype!));
-        if (value !== undefined)
-            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
-        return message;
-    }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
-        let message = target ?? this.create(), end = reader.pos + length;
-        while (reader.pos < end) {
-            let [fieldNo, wireType] = reader.tag();
-            switch (fieldNo) {
-                case /* optional google.protobuf.Timestamp start */ 1:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
-                    break;
-                case /* optional google.protobuf.Timestamp end */ 2:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
-                    break;
-                default:
-                    let u = options.readUnknownField;
-                    if (u === "throw")
-                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
-                    let d = reader.skip(wireType);
-                    if (u !== false)
-                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
-            }
-        }
-        return message;
-    }
-    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* optional google.protobuf.Timestamp start = 1; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* optional google.protobuf.Timestamp end = 2; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        let u = options.writeUnknownFields;
-        if (u !== false)
-            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
-        return writer;
-    }
-}
-/**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
- */
+    report: {
+        oneofKind: "flamegraph";
+        /**
+         * flamegraph is a flamegraph representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5
+         */
+        flamegraph: Flamegraph;
+    } | {
+        oneofKind: "pprof";
+        /**
+         * pprof is a pprof profile as compressed bytes
+         *
+         * @generated from protobuf field: bytes pprof = 6
+         */
+        pprof: Uint8Array;
+    } | {
+        oneofKind: "top";
+        /**
+         * top is a top list representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7
+         */
+        top: Top;
+    } | {
+        oneofKind: "callgraph";
+        /**
+         * callgraph is a callgraph nodes and edges representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Callgraph callgraph = 8
+         */
+        callgraph: Callgraph;
+    } | {
+        oneofKind: "flamegraphArrow";
+        /**
+         * flamegraph_arrow is a flamegraph encoded as a arrow record
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.FlamegraphArrow flamegraph_arrow = 11
+         */
+        flamegraphArrow: FlamegraphArrow;
+    } | {
+        oneofKind: "source";
+        /**
+         * source is the source report type result
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Source source = 12
+         */
+        source: Source;
+    } | {
+        oneofKind: "tableArrow";
+        /**
+         * table_arrow is a table encoded as a arrow record
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.TableArrow table_arrow = 13
+         */
+        tableArrow: TableArrow;
+    } | {
+        oneofKind: "profileMetadata";
+        /**
+         * profile_metadata contains metadata about the profile i.e. binaries, labels
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.ProfileMetadata profile_metadata = 14
+         */
+        profileMetadata: ProfileMetadata;
+    } | {
+        oneofKind: "folded";
+        /**
+         * folded is the report in the folded stacks format
+         *
+         * @generated from protobuf field: bytes folded = 15
+         */
+        folded: Uint8Array;
+    } | {
+        oneofKind: "speedscope";
+        /**
+         * speedscope is the report in the speedscope file format
+         *
+         * @generated from protobuf field: bytes speedscope = 16
+         */
+        speedscope: Uint8Array;
+    } | {
+        oneofKind: "chromeTrace";
+        /**
+         * chrome_trace is the report in the Chrome trace event format
+         *
+         * @generated from protobuf field: bytes chrome_trace = 17
+         */
+        chromeTrace: Uint8Array;
+    } | {
+        oneofKind: "callgraphDot";
+        /**
+         * callgraph_dot is the callgraph in the Graphviz DOT format
+         *
+         * @generated from protobuf field: bytes callgraph_dot = 18
+         */
+        callgraphDot: Uint8Array;
+    } | {
+        oneofKind: "callersCallees";
+        /**
+         * callers_callees contains the direct callers and callees of the requested functions
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.CallersCallees callers_callees = 20
+         */
+        callersCallees: CallersCallees;
+    } | {
+        oneofKind: "disassembly";
+        /**
+         * disassembly contains the annotated disassembly of the requested function
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Disassembly disassembly = 21
+         */
+        disassembly: Disassembly;
+    } | {
+        oneofKind: "labelBreakdown";
+        /**
+         * label_breakdown contains the values of the requested functions grouped by label
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.LabelBreakdown label_breakdown = 22
+         */
+        labelBreakdown: LabelBreakdown;
+    } | {
+        oneofKind: "heatmap";
+        /**
+         * heatmap contains the values of the top functions per time bucket
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Heatmap heatmap = 23
+         */
+        heatmap: Heatmap;
+    } | {
+        oneofKind: undefined;
+    };
+    /**
+     * total is the total number of samples shown in the report.
+     *
+     * @generated from protobuf field: int64 total = 9
+     */
+    total: bigint;
+    /**
+     * filtered is the number of samples filtered out of the report.
+     *
+     * @generated from protobuf field: int64 filtered = 10
+     */
+    filtered: bigint;
+}
+/**
+ * SeriesRequest is the request for the series matching a set of selectors
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
+ */
+export interface SeriesRequest {
+    /**
+     * match are the series selectors, a series is returned if it matches any of them.
+     * A selector may omit the profile type, e.g. `{namespace="parca"}`. If empty all series are returned.
+     *
+     * @generated from protobuf field: repeated string match = 1
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3
+     */
+    end?: Timestamp;
+}
+/**
+ * SeriesResponse is the set of series matching the selectors
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
+ */
+export interface SeriesResponse {
+    /**
+     * series is the set of matching series
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Series series = 1
+     */
+    series: Series[];
+}
+/**
+ * Series is a distinct label set of a profile type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Series
+ */
+export interface Series {
+    /**
+     * labelset is the set of key value pairs identifying the series
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labelset = 1
+     */
+    labelset?: LabelSet;
+    /**
+     * profile_type is the profile type of the series
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.ProfileType profile_type = 2
+     */
+    profileType?: ProfileType;
+    /**
+     * first_seen is the timestamp of the first sample of the series within the time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp first_seen = 3
+     */
+    firstSeen?: Timestamp;
+    /**
+     * last_seen is the timestamp of the last sample of the series within the time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp last_seen = 4
+     */
+    lastSeen?: Timestamp;
+}
+/**
+ * LabelsRequest are the request values for labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
+ */
+export interface LabelsRequest {
+    /**
+     * match are the set of matching strings
+     *
+     * @generated from protobuf field: repeated string match = 1
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3
+     */
+    end?: Timestamp;
+    /**
+     * profile_type is the type of profile to filter by
+     *
+     * @generated from protobuf field: optional string profile_type = 4
+     */
+    profileType?: string;
+}
+/**
+ * LabelsResponse is the set of matching label names
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ */
+export interface LabelsResponse {
+    /**
+     * / label_names are the set of matching label names
+     *
+     * @generated from protobuf field: repeated string label_names = 1
+     */
+    labelNames: string[];
+    /**
+     * warnings is unimplemented
+     *
+     * @generated from protobuf field: repeated string warnings = 2
+     */
+    warnings: string[];
+}
+/**
+ * ValuesRequest are the request values for a values request
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ */
+export interface ValuesRequest {
+    /**
+     * label_name is the label name to match values against
+     *
+     * @generated from protobuf field: string label_name = 1
+     */
+    labelName: string;
+    /**
+     * match are the set of matching strings to match values against
+     *
+     * @generated from protobuf field: repeated string match = 2
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 3
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 4
+     */
+    end?: Timestamp;
+    /**
+     * profile_type is the type of profile to filter by
+     *
+     * @generated from protobuf field: optional string profile_type = 5
+     */
+    profileType?: string;
+}
+/**
+ * ValuesResponse are the set of matching values
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ */
+export interface ValuesResponse {
+    /**
+     * label_values are the set of matching label values
+     *
+     * @generated from protobuf field: repeated string label_values = 1
+     */
+    labelValues: string[];
+    /**
+     * warnings is unimplemented
+     *
+     * @generated from protobuf field: repeated string warnings = 2
+     */
+    warnings: string[];
+}
+/**
+ * ValueType represents a value, including its type and unit
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValueType
+ */
+export interface ValueType {
+    /**
+     * type is the type of the value
+     *
+     * @generated from protobuf field: string type = 1
+     */
+    type: string;
+    /**
+     * unit is the unit of the value
+     *
+     * @generated from protobuf field: string unit = 2
+     */
+    unit: string;
+}
+/**
+ * ShareProfileRequest represents the query denoting the profile and a description about the profile
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ShareProfileRequest
+ */
+export interface ShareProfileRequest {
+    /**
+     * QueryRequest that refers to the profile to be shared
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest query_request = 1
+     */
+    queryRequest?: QueryRequest;
+    /**
+     * description about the profile
+     *
+     * @generated from protobuf field: optional string description = 2
+     */
+    description?: string;
+}
+/**
+ * ShareProfileResponse represents the shared link of a profile
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ShareProfileResponse
+ */
+export interface ShareProfileResponse {
+    /**
+     * link to access the profile
+     *
+     * @generated from protobuf field: string link = 1
+     */
+    link: string;
+}
+/**
+ * TableArrow has the table encoded as a arrow record
+ *
+ * @generated from protobuf message parca.query.v1alpha1.TableArrow
+ */
+export interface TableArrow {
+    /**
+     * record is the arrow record containing the actual table data. For derived profile types the cumulative and flat
+     * values are the ones of the denominator, the cumulative_numerator and flat_numerator columns hold the ones of the
+     * numerator, and cumulative_ratio and flat_ratio columns are added.
+     *
+     * @generated from protobuf field: bytes record = 1
+     */
+    record: Uint8Array;
+    /**
+     * unit is the unit represented by the flame graph
+     *
+     * @generated from protobuf field: string unit = 2
+     */
+    unit: string;
+}
+/**
+ * ProfileMetadata contains metadata about the profile i.e. binaries, labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ProfileMetadata
+ */
+export interface ProfileMetadata {
+    /**
+     * mapping_files is the list of binaries in the profile
+     *
+     * @generated from protobuf field: repeated string mapping_files = 1
+     */
+    mappingFiles: string[];
+    /**
+     * labels is the list of labels in the profile
+     *
+     * @generated from protobuf field: repeated string labels = 2
+     */
+    labels: string[];
+}
+/**
+ * HasProfileDataRequest is the request to check if there is profile data in the store
+ *
+ * @generated from protobuf message parca.query.v1alpha1.HasProfileDataRequest
+ */
+export interface HasProfileDataRequest {
+}
+/**
+ * HasProfileDataResponse is the response indicating whether there is profile data in the store
+ *
+ * @generated from protobuf message parca.query.v1alpha1.HasProfileDataResponse
+ */
+export interface HasProfileDataResponse {
+    /**
+     * has_data indicates whether there is profile data in the store
+     *
+     * @generated from protobuf field: bool has_data = 1
+     */
+    hasData: boolean;
+}
+/**
+ * TopRegressionsRequest is the request to compare the profiles of two time windows per function
+ *
+ * @generated from protobuf message parca.query.v1alpha1.TopRegressionsRequest
+ */
+export interface TopRegressionsRequest {
+    /**
+     * query is the query string to match profiles against, the profiles of each window are merged
+     *
+     * @generated from protobuf field: string query = 1
+     */
+    query: string;
+    /**
+     * baseline_start is the start of the baseline time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp baseline_start = 2
+     */
+    baselineStart?: Timestamp;
+    /**
+     * baseline_end is the end of the baseline time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp baseline_end = 3
+     */
+    baselineEnd?: Timestamp;
+    /**
+     * candidate_start is the start of the candidate time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp candidate_start = 4
+     */
+    candidateStart?: Timestamp;
+    /**
+     * candidate_end is the end of the candidate time window
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp candidate_end = 5
+     */
+    candidateEnd?: Timestamp;
+    /**
+     * min_significance is the minimum increase of either the flat or the cumulative share of a function, in percentage
+     * points, for it to be reported. Defaults to 0.1 percentage points.
+     *
+     * @generated from protobuf field: optional double min_significance = 6
+     */
+    minSignificance?: number;
+    /**
+     * limit is the maximum number of functions to return, all regressed functions are returned if zero
+     *
+     * @generated from protobuf field: uint32 limit = 7
+     */
+    limit: number;
+}
+/**
+ * TopRegressionsResponse is the list of functions whose share of the profile grew the most
+ *
+ * @generated from protobuf message parca.query.v1alpha1.TopRegressionsResponse
+ */
+export interface TopRegressionsResponse {
+    /**
+     * regressions are the regressed functions ordered by their score, the largest first
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.TopRegression regressions = 1
+     */
+    regressions: TopRegression[];
+    /**
+     * unit is the unit of the sample values, the per second values are in unit per second
+     *
+     * @generated from protobuf field: string unit = 2
+     */
+    unit: string;
+    /**
+     * baseline_total_per_second is the total value of the baseline profile per second of the baseline time window
+     *
+     * @generated from protobuf field: double baseline_total_per_second = 3
+     */
+    baselineTotalPerSecond: number;
+    /**
+     * candidate_total_per_second is the total value of the candidate profile per second of the candidate time window
+     *
+     * @generated from protobuf field: double candidate_total_per_second = 4
+     */
+    candidateTotalPerSecond: number;
+}
+/**
+ * TopRegression is the change of a function between the baseline and the candidate profile
+ *
+ * @generated from protobuf message parca.query.v1alpha1.TopRegression
+ */
+export interface TopRegression {
+    /**
+     * function_name is the name of the function, or the address of unsymbolized locations
+     *
+     * @generated from protobuf field: string function_name = 1
+     */
+    functionName: string;
+    /**
+     * function_file_name is the file of the function, or the mapping file of unsymbolized locations
+     *
+     * @generated from protobuf field: string function_file_name = 2
+     */
+    functionFileName: string;
+    /**
+     * baseline_flat_per_second is the flat value of the function in the baseline profile per second
+     *
+     * @generated from protobuf field: double baseline_flat_per_second = 3
+     */
+    baselineFlatPerSecond: number;
+    /**
+     * baseline_cumulative_per_second is the cumulative value of the function in the baseline profile per second
+     *
+     * @generated from protobuf field: double baseline_cumulative_per_second = 4
+     */
+    baselineCumulativePerSecond: number;
+    /**
+     * candidate_flat_per_second is the flat value of the function in the candidate profile per second
+     *
+     * @generated from protobuf field: double candidate_flat_per_second = 5
+     */
+    candidateFlatPerSecond: number;
+    /**
+     * candidate_cumulative_per_second is the cumulative value of the function in the candidate profile per second
+     *
+     * @generated from protobuf field: double candidate_cumulative_per_second = 6
+     */
+    candidateCumulativePerSecond: number;
+    /**
+     * flat_share_change is the change of the flat share of the function in percentage points
+     *
+     * @generated from protobuf field: double flat_share_change = 7
+     */
+    flatShareChange: number;
+    /**
+     * cumulative_share_change is the change of the cumulative share of the function in percentage points
+     *
+     * @generated from protobuf field: double cumulative_share_change = 8
+     */
+    cumulativeShareChange: number;
+    /**
+     * score is the sum of the flat and cumulative share changes, so functions that got more expensive themselves rank
+     * above the functions that only call them
+     *
+     * @generated from protobuf field: double score = 9
+     */
+    score: number;
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesRequest", [
+            { no: 1, name: "start", kind: "message", T: () => Timestamp },
+            { no: 2, name: "end", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* optional google.protobuf.Timestamp start */ 1:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* optional google.protobuf.Timestamp end */ 2:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* optional google.protobuf.Timestamp start = 1; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* optional google.protobuf.Timestamp end = 2; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
+ */
 export const ProfileTypesRequest = new ProfileTypesRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesResponse", [
+            { no: 1, name: "types", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => ProfileType }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.types = [];
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
+                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
+        for (let i = 0; i < message.types.length; i++)
+            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ */
+export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileType$Type extends MessageType<ProfileType> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileType", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileType>): ProfileType {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.sampleType = "";
+        message.sampleUnit = "";
+        message.periodType = "";
+        message.periodUnit = "";
+        message.delta = false;
+        if (value !== undefined)
+            reflectionMergePartial<ProfileType>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string sample_type */ 2:
+                    message.sampleType = reader.string();
+                    break;
+                case /* string sample_unit */ 3:
+                    message.sampleUnit = reader.string();
+                    break;
+                case /* string period_type */ 4:
+                    message.periodType = reader.string();
+                    break;
+                case /* string period_unit */ 5:
+                    message.periodUnit = reader.string();
+                    break;
+                case /* bool delta */ 6:
+                    message.delta = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string sample_type = 2; */
+        if (message.sampleType !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
+        /* string sample_unit = 3; */
+        if (message.sampleUnit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
+        /* string period_type = 4; */
+        if (message.periodType !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
+        /* string period_unit = 5; */
+        if (message.periodUnit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
+        /* bool delta = 6; */
+        if (message.delta !== false)
+            writer.tag(6, WireType.Varint).bool(message.delta);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ */
+export const ProfileType = new ProfileType$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeRequest", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "step", kind: "message", T: () => Duration },
+            { no: 6, name: "sum_by", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "filter", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Filter }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        message.limit = 0;
+        message.sumBy = [];
+        message.filter = [];
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* uint32 limit */ 4:
+                    message.limit = reader.uint32();
+                    break;
+                case /* google.protobuf.Duration step */ 5:
+                    message.step = Duration.internalBinaryRead(reader, reader.uint32(), options, message.step);
+                    break;
+                case /* repeated string sum_by */ 6:
+                    message.sumBy.push(reader.string());
+                    break;
+                case /* repeated parca.query.v1alpha1.Filter filter */ 7:
+                    message.filter.push(Filter.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 4; */
+        if (message.limit !== 0)
+            writer.tag(4, WireType.Varint).uint32(message.limit);
+        /* google.protobuf.Duration step = 5; */
+        if (message.step)
+            Duration.internalBinaryWrite(message.step, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* repeated string sum_by = 6; */
+        for (let i = 0; i < message.sumBy.length; i++)
+            writer.tag(6, WireType.LengthDelimited).string(message.sumBy[i]);
+        /* repeated parca.query.v1alpha1.Filter filter = 7; */
+        for (let i = 0; i < message.filter.length; i++)
+            Filter.internalBinaryWrite(message.filter[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ */
+export const QueryRangeRequest = new QueryRangeRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => MetricsSeries },
+            { no: 2, name: "annotations", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Annotation }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.series = [];
+        message.annotations = [];
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
+                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.annotation.v1alpha1.Annotation annotations */ 2:
+                    message.annotations.push(Annotation.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.annotation.v1alpha1.Annotation annotations = 2; */
+        for (let i = 0; i < message.annotations.length; i++)
+            Annotation.internalBinaryWrite(message.annotations[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ */
+export const QueryRangeResponse = new QueryRangeResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSeries$Type extends MessageType<MetricsSeries> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSeries", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "samples", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => MetricsSample },
+            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
+            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.samples = [];
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSeries>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
+                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* parca.query.v1alpha1.ValueType period_type */ 3:
+                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+                    break;
+                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
+                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
+        for (let i = 0; i < message.samples.length; i++)
+            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType period_type = 3; */
+        if (message.periodType)
+            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType sample_type = 4; */
+        if (message.sampleType)
+            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ */
+export const MetricsSeries = new MetricsSeries$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSample$Type extends MessageType<MetricsSample> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSample", [
+            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
+            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "value_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 4, name: "duration", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 5, name: "count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSample>): MetricsSample {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.value = 0n;
+        message.valuePerSecond = 0;
+        message.duration = 0n;
+        message.count = 0;
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSample>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp timestamp */ 1:
+                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                    break;
+                case /* int64 value */ 2:
+                    message.value = reader.int64().toBigInt();
+                    break;
+                case /* double value_per_second */ 3:
+                    message.valuePerSecond = reader.double();
+                    break;
+                case /* int64 duration */ 4:
+                    message.duration = reader.int64().toBigInt();
+                    break;
+                case /* int32 count */ 5:
+                    message.count = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp timestamp = 1; */
+        if (message.timestamp)
+            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 value = 2; */
+        if (message.value !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.value);
+        /* double value_per_second = 3; */
+        if (message.valuePerSecond !== 0)
+            writer.tag(3, WireType.Bit64).double(message.valuePerSecond);
+        /* int64 duration = 4; */
+        if (message.duration !== 0n)
+            writer.tag(4, WireType.Varint).int64(message.duration);
+        /* int32 count = 5; */
+        if (message.count !== 0)
+            writer.tag(5, WireType.Varint).int32(message.count);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ */
+export const MetricsSample = new MetricsSample$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MergeProfile$Type extends MessageType<MergeProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.MergeProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<MergeProfile>): MergeProfile {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        if (value !== undefined)
+            reflectionMergePartial<MergeProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ */
+export const MergeProfile = new MergeProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MultiMetricProfile$Type extends MessageType<MultiMetricProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.MultiMetricProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "secondary_query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "start", kind: "message", T: () => Timestamp },
+            { no: 4, name: "end", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<MultiMetricProfile>): MultiMetricProfile {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        message.secondaryQuery = "";
+        if (value !== undefined)
+            reflectionMergePartial<MultiMetricProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MultiMetricProfile): MultiMetricProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* string secondary_query */ 2:
+                    message.secondaryQuery = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 3:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 4:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MultiMetricProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* string secondary_query = 2; */
+        if (message.secondaryQuery !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.secondaryQuery);
+        /* google.protobuf.Timestamp start = 3; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 4; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MultiMetricProfile
+ */
+export const MultiMetricProfile = new MultiMetricProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SingleProfile$Type extends MessageType<SingleProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.SingleProfile", [
+            { no: 1, name: "time", kind: "message", T: () => Timestamp },
+            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SingleProfile>): SingleProfile {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        if (value !== undefined)
+            reflectionMergePartial<SingleProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp time */ 1:
+                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                    break;
+                case /* string query */ 2:
+                    message.query = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp time = 1; */
+        if (message.time)
+            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string query = 2; */
+        if (message.query !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.query);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ */
+export const SingleProfile = new SingleProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DiffProfile$Type extends MessageType<DiffProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.DiffProfile", [
+            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
+            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection },
+            { no: 3, name: "absolute", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ },
+            { no: 4, name: "significance", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<DiffProfile>): DiffProfile {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.significance = false;
+        if (value !== undefined)
+            reflectionMergePartial<DiffProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
+                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
+                    break;
+                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
+                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
+                    break;
+                case /* optional bool absolute */ 3:
+                    message.absolute = reader.bool();
+                    break;
+                case /* bool significance */ 4:
+                    message.significance = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
+        if (message.a)
+            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
+        if (message.b)
+            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* optional bool absolute = 3; */
+        if (message.absolute !== undefined)
+            writer.tag(3, WireType.Varint).bool(message.absolute);
+        /* bool significance = 4; */
+        if (message.significance !== false)
+            writer.tag(4, WireType.Varint).bool(message.significance);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ */
+export const DiffProfile = new DiffProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileDiffSelection", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
+            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.mode = 0;
+        message.options = { oneofKind: undefined };
+        if (value !== undefined)
+            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 3:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.MergeProfile merge = 2; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 3; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ */
+export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRequest$Type extends MessageType<QueryRequest> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileTypesResponse", [
-            { no: 1, name: "types", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => ProfileType }
+        super("parca.query.v1alpha1.QueryRequest", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
+            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
+            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
+            { no: 28, name: "multi_metric", kind: "message", oneof: "options", T: () => MultiMetricProfile },
+            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] },
+            { no: 6, name: "filter_query", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "node_trim_threshold", kind: "scalar", opt: true, T: 2 /*ScalarType.FLOAT*/ },
+            { no: 8, name: "group_by", kind: "message", T: () => GroupBy },
+            { no: 9, name: "source_reference", kind: "message", T: () => SourceReference },
+            { no: 10, name: "runtime_filter", kind: "message", T: () => RuntimeFilter },
+            { no: 11, name: "invert_call_stack", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ },
+            { no: 12, name: "filter", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Filter },
+            { no: 13, name: "sandwich_by_function", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "node_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 15, name: "focus", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 16, name: "ignore", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 17, name: "hide", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 18, name: "show", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 19, name: "show_from", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 20, name: "prune_from", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 21, name: "callers_callees_function", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 22, name: "disassembly_reference", kind: "message", T: () => DisassemblyReference },
+            { no: 23, name: "label_breakdown_function", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 24, name: "label_breakdown_label", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 25, name: "heatmap_bucket_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 26, name: "heatmap_function_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 27, name: "heatmap_by_root", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
+    create(value?: PartialMessage<QueryRequest>): QueryRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.types = [];
+        message.mode = 0;
+        message.options = { oneofKind: undefined };
+        message.reportType = 0;
+        message.filter = [];
         if (value !== undefined)
-            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+            reflectionMergePartial<QueryRequest>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
-                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
+                    message.options = {
+                        oneofKind: "diff",
+                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 4:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.MultiMetricProfile multi_metric */ 28:
+                    message.options = {
+                        oneofKind: "multiMetric",
+                        multiMetric: MultiMetricProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).multiMetric)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
+                    message.reportType = reader.int32();
+                    break;
+                case /* optional string filter_query = 6 [deprecated = true] */ 6:
+                    message.filterQuery = reader.string();
+                    break;
+                case /* optional float node_trim_threshold */ 7:
+                    message.nodeTrimThreshold = reader.float();
+                    break;
+                case /* optional parca.query.v1alpha1.GroupBy group_by */ 8:
+                    message.groupBy = GroupBy.internalBinaryRead(reader, reader.uint32(), options, message.groupBy);
+                    break;
+                case /* optional parca.query.v1alpha1.SourceReference source_reference */ 9:
+                    message.sourceReference = SourceReference.internalBinaryRead(reader, reader.uint32(), options, message.sourceReference);
+                    break;
+                case /* optional parca.query.v1alpha1.RuntimeFilter runtime_filter = 10 [deprecated = true] */ 10:
+                    message.runtimeFilter = RuntimeFilter.internalBinaryRead(reader, reader.uint32(), options, message.runtimeFilter);
+                    break;
+                case /* optional bool invert_call_stack */ 11:
+                    message.invertCallStack = reader.bool();
+                    break;
+                case /* repeated parca.query.v1alpha1.Filter filter */ 12:
+                    message.filter.push(Filter.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* optional string sandwich_by_function */ 13:
+                    message.sandwichByFunction = reader.string();
+                    break;
+                case /* optional uint32 node_count */ 14:
+                    message.nodeCount = reader.uint32();
+                    break;
+                case /* optional string focus */ 15:
+                    message.focus = reader.string();
+                    break;
+                case /* optional string ignore */ 16:
+                    message.ignore = reader.string();
+                    break;
+                case /* optional string hide */ 17:
+                    message.hide = reader.string();
+                    break;
+                case /* optional string show */ 18:
+                    message.show = reader.string();
+                    break;
+                case /* optional string show_from */ 19:
+                    message.showFrom = reader.string();
+                    break;
+                case /* optional string prune_from */ 20:
+                    message.pruneFrom = reader.string();
+                    break;
+                case /* optional string callers_callees_function */ 21:
+                    message.callersCalleesFunction = reader.string();
+                    break;
+                case /* optional parca.query.v1alpha1.DisassemblyReference disassembly_reference */ 22:
+                    message.disassemblyReference = DisassemblyReference.internalBinaryRead(reader, reader.uint32(), options, message.disassemblyReference);
+                    break;
+                case /* optional string label_breakdown_function */ 23:
+                    message.labelBreakdownFunction = reader.string();
+                    break;
+                case /* optional string label_breakdown_label */ 24:
+                    message.labelBreakdownLabel = reader.string();
+                    break;
+                case /* optional uint32 heatmap_bucket_count */ 25:
+                    message.heatmapBucketCount = reader.uint32();
+                    break;
+                case /* optional uint32 heatmap_function_count */ 26:
+                    message.heatmapFunctionCount = reader.uint32();
+                    break;
+                case /* optional bool heatmap_by_root */ 27:
+                    message.heatmapByRoot = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1735,10 +3484,227 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
-        for (let i = 0; i < message.types.length; i++)
-            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.DiffProfile diff = 2; */
+        if (message.options.oneofKind === "diff")
+            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.MergeProfile merge = 3; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 4; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
+        if (message.reportType !== 0)
+            writer.tag(5, WireType.Varint).int32(message.reportType);
+        /* optional string filter_query = 6 [deprecated = true]; */
+        if (message.filterQuery !== undefined)
+            writer.tag(6, WireType.LengthDelimited).string(message.filterQuery);
+        /* optional float node_trim_threshold = 7; */
+        if (message.nodeTrimThreshold !== undefined)
+            writer.tag(7, WireType.Bit32).float(message.nodeTrimThreshold);
+        /* optional parca.query.v1alpha1.GroupBy group_by = 8; */
+        if (message.groupBy)
+            GroupBy.internalBinaryWrite(message.groupBy, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* optional parca.query.v1alpha1.SourceReference source_reference = 9; */
+        if (message.sourceReference)
+            SourceReference.internalBinaryWrite(message.sourceReference, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        /* optional parca.query.v1alpha1.RuntimeFilter runtime_filter = 10 [deprecated = true]; */
+        if (message.runtimeFilter)
+            RuntimeFilter.internalBinaryWrite(message.runtimeFilter, writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* optional bool invert_call_stack = 11; */
+        if (message.invertCallStack !== undefined)
+            writer.tag(11, WireType.Varint).bool(message.invertCallStack);
+        /* repeated parca.query.v1alpha1.Filter filter = 12; */
+        for (let i = 0; i < message.filter.length; i++)
+            Filter.internalBinaryWrite(message.filter[i], writer.tag(12, WireType.LengthDelimited).fork(), options).join();
+        /* optional string sandwich_by_function = 13; */
+        if (message.sandwichByFunction !== undefined)
+            writer.tag(13, WireType.LengthDelimited).string(message.sandwichByFunction);
+        /* optional uint32 node_count = 14; */
+        if (message.nodeCount !== undefined)
+            writer.tag(14, WireType.Varint).uint32(message.nodeCount);
+        /* optional string focus = 15; */
+        if (message.focus !== undefined)
+            writer.tag(15, WireType.LengthDelimited).string(message.focus);
+        /* optional string ignore = 16; */
+        if (message.ignore !== undefined)
+            writer.tag(16, WireType.LengthDelimited).string(message.ignore);
+        /* optional string hide = 17; */
+        if (message.hide !== undefined)
+            writer.tag(17, WireType.LengthDelimited).string(message.hide);
+        /* optional string show = 18; */
+        if (message.show !== undefined)
+            writer.tag(18, WireType.LengthDelimited).string(message.show);
+        /* optional string show_from = 19; */
+        if (message.showFrom !== undefined)
+            writer.tag(19, WireType.LengthDelimited).string(message.showFrom);
+        /* optional string prune_from = 20; */
+        if (message.pruneFrom !== undefined)
+            writer.tag(20, WireType.LengthDelimited).string(message.pruneFrom);
+        /* optional string callers_callees_function = 21; */
+        if (message.callersCalleesFunction !== undefined)
+            writer.tag(21, WireType.LengthDelimited).string(message.callersCalleesFunction);
+        /* optional parca.query.v1alpha1.DisassemblyReference disassembly_reference = 22; */
+        if (message.disassemblyReference)
+            DisassemblyReference.internalBinaryWrite(message.disassemblyReference, writer.tag(22, WireType.LengthDelimited).fork(), options).join();
+        /* optional string label_breakdown_function = 23; */
+        if (message.labelBreakdownFunction !== undefined)
+            writer.tag(23, WireType.LengthDelimited).string(message.labelBreakdownFunction);
+        /* optional string label_breakdown_label = 24; */
+        if (message.labelBreakdownLabel !== undefined)
+            writer.tag(24, WireType.LengthDelimited).string(message.labelBreakdownLabel);
+        /* optional uint32 heatmap_bucket_count = 25; */
+        if (message.heatmapBucketCount !== undefined)
+            writer.tag(25, WireType.Varint).uint32(message.heatmapBucketCount);
+        /* optional uint32 heatmap_function_count = 26; */
+        if (message.heatmapFunctionCount !== undefined)
+            writer.tag(26, WireType.Varint).uint32(message.heatmapFunctionCount);
+        /* optional bool heatmap_by_root = 27; */
+        if (message.heatmapByRoot !== undefined)
+            writer.tag(27, WireType.Varint).bool(message.heatmapByRoot);
+        /* parca.query.v1alpha1.MultiMetricProfile multi_metric = 28; */
+        if (message.options.oneofKind === "multiMetric")
+            MultiMetricProfile.internalBinaryWrite(message.options.multiMetric, writer.tag(28, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
+ */
+export const QueryRequest = new QueryRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DisassemblyReference$Type extends MessageType<DisassemblyReference> {
+    constructor() {
+        super("parca.query.v1alpha1.DisassemblyReference", [
+            { no: 1, name: "build_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ }
+        ]);
+    }
+    create(value?: PartialMessage<DisassemblyReference>): DisassemblyReference {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.buildId = "";
+        message.address = 0n;
+        if (value !== undefined)
+            reflectionMergePartial<DisassemblyReference>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassemblyReference): DisassemblyReference {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string build_id */ 1:
+                    message.buildId = reader.string();
+                    break;
+                case /* uint64 address */ 2:
+                    message.address = reader.uint64().toBigInt();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DisassemblyReference, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string build_id = 1; */
+        if (message.buildId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.buildId);
+        /* uint64 address = 2; */
+        if (message.address !== 0n)
+            writer.tag(2, WireType.Varint).uint64(message.address);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassemblyReference
+ */
+export const DisassemblyReference = new DisassemblyReference$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class FilterCriteria$Type extends MessageType<FilterCriteria> {
+    constructor() {
+        super("parca.query.v1alpha1.FilterCriteria", [
+            { no: 1, name: "function_name", kind: "message", T: () => StringCondition },
+            { no: 2, name: "system_name", kind: "message", T: () => StringCondition },
+            { no: 3, name: "binary", kind: "message", T: () => StringCondition },
+            { no: 4, name: "filename", kind: "message", T: () => StringCondition },
+            { no: 5, name: "address", kind: "message", T: () => NumberCondition },
+            { no: 6, name: "line_number", kind: "message", T: () => NumberCondition }
+        ]);
+    }
+    create(value?: PartialMessage<FilterCriteria>): FilterCriteria {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<FilterCriteria>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FilterCriteria): FilterCriteria {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.StringCondition function_name */ 1:
+                    message.functionName = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.functionName);
+                    break;
+                case /* parca.query.v1alpha1.StringCondition system_name */ 2:
+                    message.systemName = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.systemName);
+                    break;
+                case /* parca.query.v1alpha1.StringCondition binary */ 3:
+                    message.binary = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.binary);
+                    break;
+                case /* parca.query.v1alpha1.StringCondition filename */ 4:
+                    message.filename = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.filename);
+                    break;
+                case /* parca.query.v1alpha1.NumberCondition address */ 5:
+                    message.address = NumberCondition.internalBinaryRead(reader, reader.uint32(), options, message.address);
+                    break;
+                case /* parca.query.v1alpha1.NumberCondition line_number */ 6:
+                    message.lineNumber = NumberCondition.internalBinaryRead(reader, reader.uint32(), options, message.lineNumber);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: FilterCriteria, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.StringCondition function_name = 1; */
+        if (message.functionName)
+            StringCondition.internalBinaryWrite(message.functionName, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.StringCondition system_name = 2; */
+        if (message.systemName)
+            StringCondition.internalBinaryWrite(message.systemName, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.StringCondition binary = 3; */
+        if (message.binary)
+            StringCondition.internalBinaryWrite(message.binary, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.StringCondition filename = 4; */
+        if (message.filename)
+            StringCondition.internalBinaryWrite(message.filename, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.NumberCondition address = 5; */
+        if (message.address)
+            NumberCondition.internalBinaryWrite(message.address, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.NumberCondition line_number = 6; */
+        if (message.lineNumber)
+            NumberCondition.internalBinaryWrite(message.lineNumber, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1746,55 +3712,82 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FilterCriteria
  */
-export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+export const FilterCriteria = new FilterCriteria$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileType$Type extends MessageType<ProfileType> {
+class StringCondition$Type extends MessageType<StringCondition> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileType", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.StringCondition", [
+            { no: 1, name: "equal", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "not_equal", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "contains", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "not_contains", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "starts_with", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "not_starts_with", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "matches_regex", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "not_matches_regex", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileType>): ProfileType {
+    create(value?: PartialMessage<StringCondition>): StringCondition {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.name = "";
-        message.sampleType = "";
-        message.sampleUnit = "";
-        message.periodType = "";
-        message.periodUnit = "";
-        message.delta = false;
+        message.condition = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<ProfileType>(this, message, value);
+            reflectionMergePartial<StringCondition>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StringCondition): StringCondition {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
+                case /* string equal */ 1:
+                    message.condition = {
+                        oneofKind: "equal",
+                        equal: reader.string()
+                    };
                     break;
-                case /* string sample_type */ 2:
-                    message.sampleType = reader.string();
+                case /* string not_equal */ 2:
+                    message.condition = {
+                        oneofKind: "notEqual",
+                        notEqual: reader.string()
+                    };
                     break;
-                case /* string sample_unit */ 3:
-                    message.sampleUnit = reader.string();
+                case /* string contains */ 3:
+                    message.condition = {
+                        oneofKind: "contains",
+                        contains: reader.string()
+                    };
                     break;
-                case /* string period_type */ 4:
-                    message.periodType = reader.string();
+                case /* string not_contains */ 4:
+                    message.condition = {
+                        oneofKind: "notContains",
+                        notContains: reader.string()
+                    };
                     break;
-                case /* string period_unit */ 5:
-                    message.periodUnit = reader.string();
+                case /* string starts_with */ 5:
+                    message.condition = {
+                        oneofKind: "startsWith",
+                        startsWith: reader.string()
+                    };
                     break;
-                case /* bool delta */ 6:
-                    message.delta = reader.bool();
+                case /* string not_starts_with */ 6:
+                    message.condition = {
+                        oneofKind: "notStartsWith",
+                        notStartsWith: reader.string()
+                    };
+                    break;
+                case /* string matches_regex */ 7:
+                    message.condition = {
+                        oneofKind: "matchesRegex",
+                        matchesRegex: reader.string()
+                    };
+                    break;
+                case /* string not_matches_regex */ 8:
+                    message.condition = {
+                        oneofKind: "notMatchesRegex",
+                        notMatchesRegex: reader.string()
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1807,25 +3800,31 @@ class ProfileType$Type extends MessageType<ProfileType> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string sample_type = 2; */
-        if (message.sampleType !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
-        /* string sample_unit = 3; */
-        if (message.sampleUnit !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
-        /* string period_type = 4; */
-        if (message.periodType !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
-        /* string period_unit = 5; */
-        if (message.periodUnit !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
-        /* bool delta = 6; */
-        if (message.delta !== false)
-            writer.tag(6, WireType.Varint).bool(message.delta);
+    internalBinaryWrite(message: StringCondition, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string equal = 1; */
+        if (message.condition.oneofKind === "equal")
+            writer.tag(1, WireType.LengthDelimited).string(message.condition.equal);
+        /* string not_equal = 2; */
+        if (message.condition.oneofKind === "notEqual")
+            writer.tag(2, WireType.LengthDelimited).string(message.condition.notEqual);
+        /* string contains = 3; */
+        if (message.condition.oneofKind === "contains")
+            writer.tag(3, WireType.LengthDelimited).string(message.condition.contains);
+        /* string not_contains = 4; */
+        if (message.condition.oneofKind === "notContains")
+            writer.tag(4, WireType.LengthDelimited).string(message.condition.notContains);
+        /* string starts_with = 5; */
+        if (message.condition.oneofKind === "startsWith")
+            writer.tag(5, WireType.LengthDelimited).string(message.condition.startsWith);
+        /* string not_starts_with = 6; */
+        if (message.condition.oneofKind === "notStartsWith")
+            writer.tag(6, WireType.LengthDelimited).string(message.condition.notStartsWith);
+        /* string matches_regex = 7; */
+        if (message.condition.oneofKind === "matchesRegex")
+            writer.tag(7, WireType.LengthDelimited).string(message.condition.matchesRegex);
+        /* string not_matches_regex = 8; */
+        if (message.condition.oneofKind === "notMatchesRegex")
+            writer.tag(8, WireType.LengthDelimited).string(message.condition.notMatchesRegex);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1833,52 +3832,61 @@ class ProfileType$Type extends MessageType<ProfileType> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ * @generated MessageType for protobuf message parca.query.v1alpha1.StringCondition
  */
-export const ProfileType = new ProfileType$Type();
+export const StringCondition = new StringCondition$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+class NumberCondition$Type extends MessageType<NumberCondition> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeRequest", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp },
-            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
-            { no: 5, name: "step", kind: "message", T: () => Duration },
-            { no: 6, name: "sum_by", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.NumberCondition", [
+            { no: 1, name: "equal", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 2, name: "not_equal", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "greater_than", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "less_than", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 5, name: "range", kind: "message", oneof: "condition", T: () => NumberRange }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
+    create(value?: PartialMessage<NumberCondition>): NumberCondition {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.query = "";
-        message.limit = 0;
-        message.sumBy = [];
+        message.condition = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+            reflectionMergePartial<NumberCondition>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: NumberCondition): NumberCondition {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
-                    break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* uint64 equal */ 1:
+                    message.condition = {
+                        oneofKind: "equal",
+                        equal: reader.uint64().toBigInt()
+                    };
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* uint64 not_equal */ 2:
+                    message.condition = {
+                        oneofKind: "notEqual",
+                        notEqual: reader.uint64().toBigInt()
+                    };
                     break;
-                case /* uint32 limit */ 4:
-                    message.limit = reader.uint32();
+                case /* uint64 greater_than */ 3:
+                    message.condition = {
+                        oneofKind: "greaterThan",
+                        greaterThan: reader.uint64().toBigInt()
+                    };
                     break;
-                case /* google.protobuf.Duration step */ 5:
-                    message.step = Duration.internalBinaryRead(reader, reader.uint32(), options, message.step);
+                case /* uint64 less_than */ 4:
+                    message.condition = {
+                        oneofKind: "lessThan",
+                        lessThan: reader.uint64().toBigInt()
+                    };
                     break;
-                case /* repeated string sum_by */ 6:
-                    message.sumBy.push(reader.string());
+                case /* parca.query.v1alpha1.NumberRange range */ 5:
+                    message.condition = {
+                        oneofKind: "range",
+                        range: NumberRange.internalBinaryRead(reader, reader.uint32(), options, (message.condition as any).range)
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1891,25 +3899,22 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* uint32 limit = 4; */
-        if (message.limit !== 0)
-            writer.tag(4, WireType.Varint).uint32(message.limit);
-        /* google.protobuf.Duration step = 5; */
-        if (message.step)
-            Duration.internalBinaryWrite(message.step, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
-        /* repeated string sum_by = 6; */
-        for (let i = 0; i < message.sumBy.length; i++)
-            writer.tag(6, WireType.LengthDelimited).string(message.sumBy[i]);
+    internalBinaryWrite(message: NumberCondition, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 equal = 1; */
+        if (message.condition.oneofKind === "equal")
+            writer.tag(1, WireType.Varint).uint64(message.condition.equal);
+        /* uint64 not_equal = 2; */
+        if (message.condition.oneofKind === "notEqual")
+            writer.tag(2, WireType.Varint).uint64(message.condition.notEqual);
+        /* uint64 greater_than = 3; */
+        if (message.condition.oneofKind === "greaterThan")
+            writer.tag(3, WireType.Varint).uint64(message.condition.greaterThan);
+        /* uint64 less_than = 4; */
+        if (message.condition.oneofKind === "lessThan")
+            writer.tag(4, WireType.Varint).uint64(message.condition.lessThan);
+        /* parca.query.v1alpha1.NumberRange range = 5; */
+        if (message.condition.oneofKind === "range")
+            NumberRange.internalBinaryWrite(message.condition.range, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1917,30 +3922,35 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.NumberCondition
  */
-export const QueryRangeRequest = new QueryRangeRequest$Type();
+export const NumberCondition = new NumberCondition$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+class NumberRange$Type extends MessageType<NumberRange> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeResponse", [
-            { no: 1, name: "series", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => MetricsSeries }
+        super("parca.query.v1alpha1.NumberRange", [
+            { no: 1, name: "start", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 2, name: "end", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
+    create(value?: PartialMessage<NumberRange>): NumberRange {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.series = [];
+        message.start = 0n;
+        message.end = 0n;
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+            reflectionMergePartial<NumberRange>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: NumberRange): NumberRange {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
-                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                case /* uint64 start */ 1:
+                    message.start = reader.uint64().toBigInt();
+                    break;
+                case /* uint64 end */ 2:
+                    message.end = reader.uint64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1953,10 +3963,13 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
-        for (let i = 0; i < message.series.length; i++)
-            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: NumberRange, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 start = 1; */
+        if (message.start !== 0n)
+            writer.tag(1, WireType.Varint).uint64(message.start);
+        /* uint64 end = 2; */
+        if (message.end !== 0n)
+            writer.tag(2, WireType.Varint).uint64(message.end);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1964,42 +3977,40 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.NumberRange
  */
-export const QueryRangeResponse = new QueryRangeResponse$Type();
+export const NumberRange = new NumberRange$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSeries$Type extends MessageType<MetricsSeries> {
+class Filter$Type extends MessageType<Filter> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSeries", [
-            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
-            { no: 2, name: "samples", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => MetricsSample },
-            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
-            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        super("parca.query.v1alpha1.Filter", [
+            { no: 1, name: "stack_filter", kind: "message", oneof: "filter", T: () => StackFilter },
+            { no: 2, name: "frame_filter", kind: "message", oneof: "filter", T: () => FrameFilter }
         ]);
     }
-    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
+    create(value?: PartialMessage<Filter>): Filter {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.samples = [];
+        message.filter = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<MetricsSeries>(this, message, value);
+            reflectionMergePartial<Filter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Filter): Filter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
-            let [fieldNo, wireType] = reader.tag();
-            switch (fieldNo) {
-                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
-                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
-                    break;
-                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
-                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
-                    break;
-                case /* parca.query.v1alpha1.ValueType period_type */ 3:
-                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.StackFilter stack_filter */ 1:
+                    message.filter = {
+                        oneofKind: "stackFilter",
+                        stackFilter: StackFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).stackFilter)
+                    };
                     break;
-                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
-                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                case /* parca.query.v1alpha1.FrameFilter frame_filter */ 2:
+                    message.filter = {
+                        oneofKind: "frameFilter",
+                        frameFilter: FrameFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).frameFilter)
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2012,19 +4023,13 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
-        if (message.labelset)
-            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
-        for (let i = 0; i < message.samples.length; i++)
-            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType period_type = 3; */
-        if (message.periodType)
-            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType sample_type = 4; */
-        if (message.sampleType)
-            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Filter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.StackFilter stack_filter = 1; */
+        if (message.filter.oneofKind === "stackFilter")
+            StackFilter.internalBinaryWrite(message.filter.stackFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.FrameFilter frame_filter = 2; */
+        if (message.filter.oneofKind === "frameFilter")
+            FrameFilter.internalBinaryWrite(message.filter.frameFilter, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2032,49 +4037,40 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Filter
  */
-export const MetricsSeries = new MetricsSeries$Type();
+export const Filter = new Filter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSample$Type extends MessageType<MetricsSample> {
+class StackFilter$Type extends MessageType<StackFilter> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSample", [
-            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
-            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 3, name: "value_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
-            { no: 4, name: "duration", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 5, name: "count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        super("parca.query.v1alpha1.StackFilter", [
+            { no: 1, name: "function_name_stack_filter", kind: "message", oneof: "filter", T: () => FunctionNameStackFilter },
+            { no: 2, name: "criteria", kind: "message", oneof: "filter", T: () => FilterCriteria }
         ]);
     }
-    create(value?: PartialMessage<MetricsSample>): MetricsSample {
+    create(value?: PartialMessage<StackFilter>): StackFilter {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.value = 0n;
-        message.valuePerSecond = 0;
-        message.duration = 0n;
-        message.count = 0;
+        message.filter = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<MetricsSample>(this, message, value);
+            reflectionMergePartial<StackFilter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StackFilter): StackFilter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp timestamp */ 1:
-                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
-                    break;
-                case /* int64 value */ 2:
-                    message.value = reader.int64().toBigInt();
-                    break;
-                case /* double value_per_second */ 3:
-                    message.valuePerSecond = reader.double();
-                    break;
-                case /* int64 duration */ 4:
-                    message.duration = reader.int64().toBigInt();
+                case /* parca.query.v1alpha1.FunctionNameStackFilter function_name_stack_filter = 1 [deprecated = true] */ 1:
+                    message.filter = {
+                        oneofKind: "functionNameStackFilter",
+                        functionNameStackFilter: FunctionNameStackFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).functionNameStackFilter)
+                    };
                     break;
-                case /* int32 count */ 5:
-                    message.count = reader.int32();
+                case /* parca.query.v1alpha1.FilterCriteria criteria */ 2:
+                    message.filter = {
+                        oneofKind: "criteria",
+                        criteria: FilterCriteria.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).criteria)
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2087,22 +4083,13 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp timestamp = 1; */
-        if (message.timestamp)
-            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 value = 2; */
-        if (message.value !== 0n)
-            writer.tag(2, WireType.Varint).int64(message.value);
-        /* double value_per_second = 3; */
-        if (message.valuePerSecond !== 0)
-            writer.tag(3, WireType.Bit64).double(message.valuePerSecond);
-        /* int64 duration = 4; */
-        if (message.duration !== 0n)
-            writer.tag(4, WireType.Varint).int64(message.duration);
-        /* int32 count = 5; */
-        if (message.count !== 0)
-            writer.tag(5, WireType.Varint).int32(message.count);
+    internalBinaryWrite(message: StackFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.FunctionNameStackFilter function_name_stack_filter = 1 [deprecated = true]; */
+        if (message.filter.oneofKind === "functionNameStackFilter")
+            FunctionNameStackFilter.internalBinaryWrite(message.filter.functionNameStackFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.FilterCriteria criteria = 2; */
+        if (message.filter.oneofKind === "criteria")
+            FilterCriteria.internalBinaryWrite(message.filter.criteria, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2110,38 +4097,35 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ * @generated MessageType for protobuf message parca.query.v1alpha1.StackFilter
  */
-export const MetricsSample = new MetricsSample$Type();
+export const StackFilter = new StackFilter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MergeProfile$Type extends MessageType<MergeProfile> {
+class FunctionNameStackFilter$Type extends MessageType<FunctionNameStackFilter> {
     constructor() {
-        super("parca.query.v1alpha1.MergeProfile", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+        super("parca.query.v1alpha1.FunctionNameStackFilter", [
+            { no: 1, name: "function_to_filter", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "exclude", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<MergeProfile>): MergeProfile {
+    create(value?: PartialMessage<FunctionNameStackFilter>): FunctionNameStackFilter {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.query = "";
+        message.functionToFilter = "";
+        message.exclude = false;
         if (value !== undefined)
-            reflectionMergePartial<MergeProfile>(this, message, value);
+            reflectionMergePartial<FunctionNameStackFilter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FunctionNameStackFilter): FunctionNameStackFilter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
-                    break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* string function_to_filter */ 1:
+                    message.functionToFilter = reader.string();
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* bool exclude */ 2:
+                    message.exclude = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2154,16 +4138,13 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: FunctionNameStackFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string function_to_filter = 1; */
+        if (message.functionToFilter !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.functionToFilter);
+        /* bool exclude = 2; */
+        if (message.exclude !== false)
+            writer.tag(2, WireType.Varint).bool(message.exclude);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2171,34 +4152,40 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FunctionNameStackFilter
  */
-export const MergeProfile = new MergeProfile$Type();
+export const FunctionNameStackFilter = new FunctionNameStackFilter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class SingleProfile$Type extends MessageType<SingleProfile> {
+class FrameFilter$Type extends MessageType<FrameFilter> {
     constructor() {
-        super("parca.query.v1alpha1.SingleProfile", [
-            { no: 1, name: "time", kind: "message", T: () => Timestamp },
-            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.FrameFilter", [
+            { no: 1, name: "binary_frame_filter", kind: "message", oneof: "filter", T: () => BinaryFrameFilter },
+            { no: 2, name: "criteria", kind: "message", oneof: "filter", T: () => FilterCriteria }
         ]);
     }
-    create(value?: PartialMessage<SingleProfile>): SingleProfile {
+    create(value?: PartialMessage<FrameFilter>): FrameFilter {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.query = "";
+        message.filter = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<SingleProfile>(this, message, value);
+            reflectionMergePartial<FrameFilter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FrameFilter): FrameFilter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp time */ 1:
-                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                case /* parca.query.v1alpha1.BinaryFrameFilter binary_frame_filter = 1 [deprecated = true] */ 1:
+                    message.filter = {
+                        oneofKind: "binaryFrameFilter",
+                        binaryFrameFilter: BinaryFrameFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).binaryFrameFilter)
+                    };
                     break;
-                case /* string query */ 2:
-                    message.query = reader.string();
+                case /* parca.query.v1alpha1.FilterCriteria criteria */ 2:
+                    message.filter = {
+                        oneofKind: "criteria",
+                        criteria: FilterCriteria.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).criteria)
+                    };
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2211,13 +4198,13 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp time = 1; */
-        if (message.time)
-            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* string query = 2; */
-        if (message.query !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.query);
+    internalBinaryWrite(message: FrameFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.BinaryFrameFilter binary_frame_filter = 1 [deprecated = true]; */
+        if (message.filter.oneofKind === "binaryFrameFilter")
+            BinaryFrameFilter.internalBinaryWrite(message.filter.binaryFrameFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.FilterCriteria criteria = 2; */
+        if (message.filter.oneofKind === "criteria")
+            FilterCriteria.internalBinaryWrite(message.filter.criteria, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2225,37 +4212,30 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FrameFilter
  */
-export const SingleProfile = new SingleProfile$Type();
+export const FrameFilter = new FrameFilter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class DiffProfile$Type extends MessageType<DiffProfile> {
+class BinaryFrameFilter$Type extends MessageType<BinaryFrameFilter> {
     constructor() {
-        super("parca.query.v1alpha1.DiffProfile", [
-            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
-            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection },
-            { no: 3, name: "absolute", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.BinaryFrameFilter", [
+            { no: 1, name: "include_binaries", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<DiffProfile>): DiffProfile {
+    create(value?: PartialMessage<BinaryFrameFilter>): BinaryFrameFilter {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.includeBinaries = [];
         if (value !== undefined)
-            reflectionMergePartial<DiffProfile>(this, message, value);
+            reflectionMergePartial<BinaryFrameFilter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: BinaryFrameFilter): BinaryFrameFilter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
-                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
-                    break;
-                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
-                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
-                    break;
-                case /* optional bool absolute */ 3:
-                    message.absolute = reader.bool();
+                case /* repeated string include_binaries */ 1:
+                    message.includeBinaries.push(reader.string());
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2268,16 +4248,10 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
-        if (message.a)
-            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
-        if (message.b)
-            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* optional bool absolute = 3; */
-        if (message.absolute !== undefined)
-            writer.tag(3, WireType.Varint).bool(message.absolute);
+    internalBinaryWrite(message: BinaryFrameFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated string include_binaries = 1; */
+        for (let i = 0; i < message.includeBinaries.length; i++)
+            writer.tag(1, WireType.LengthDelimited).string(message.includeBinaries[i]);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2285,45 +4259,40 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.BinaryFrameFilter
  */
-export const DiffProfile = new DiffProfile$Type();
+export const BinaryFrameFilter = new BinaryFrameFilter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
-    constructor() {
-        super("parca.query.v1alpha1.ProfileDiffSelection", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
-            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+class RuntimeFilter$Type extends MessageType<RuntimeFilter> {
+    constructor() {
+        super("parca.query.v1alpha1.RuntimeFilter", [
+            { no: 1, name: "show_python", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 2, name: "show_ruby", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 3, name: "show_interpreted_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
+    create(value?: PartialMessage<RuntimeFilter>): RuntimeFilter {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.mode = 0;
-        message.options = { oneofKind: undefined };
+        message.showPython = false;
+        message.showRuby = false;
+        message.showInterpretedOnly = false;
         if (value !== undefined)
-            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+            reflectionMergePartial<RuntimeFilter>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RuntimeFilter): RuntimeFilter {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
-                    message.mode = reader.int32();
+                case /* bool show_python */ 1:
+                    message.showPython = reader.bool();
                     break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
+                case /* bool show_ruby */ 2:
+                    message.showRuby = reader.bool();
                     break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 3:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
+                case /* bool show_interpreted_only */ 3:
+                    message.showInterpretedOnly = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2336,16 +4305,16 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.MergeProfile merge = 2; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 3; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: RuntimeFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bool show_python = 1; */
+        if (message.showPython !== false)
+            writer.tag(1, WireType.Varint).bool(message.showPython);
+        /* bool show_ruby = 2; */
+        if (message.showRuby !== false)
+            writer.tag(2, WireType.Varint).bool(message.showRuby);
+        /* bool show_interpreted_only = 3; */
+        if (message.showInterpretedOnly !== false)
+            writer.tag(3, WireType.Varint).bool(message.showInterpretedOnly);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2353,90 +4322,40 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ * @generated MessageType for protobuf message parca.query.v1alpha1.RuntimeFilter
  */
-export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+export const RuntimeFilter = new RuntimeFilter$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRequest$Type extends MessageType<QueryRequest> {
+class SourceReference$Type extends MessageType<SourceReference> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRequest", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
-            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
-            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
-            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] },
-            { no: 6, name: "filter_query", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
-            { no: 7, name: "node_trim_threshold", kind: "scalar", opt: true, T: 2 /*ScalarType.FLOAT*/ },
-            { no: 8, name: "group_by", kind: "message", T: () => GroupBy },
-            { no: 9, name: "source_reference", kind: "message", T: () => SourceReference },
-            { no: 10, name: "runtime_filter", kind: "message", T: () => RuntimeFilter },
-            { no: 11, name: "invert_call_stack", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ },
-            { no: 12, name: "filter", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Filter },
-            { no: 13, name: "sandwich_by_function", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.SourceReference", [
+            { no: 1, name: "build_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "source_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRequest>): QueryRequest {
+    create(value?: PartialMessage<SourceReference>): SourceReference {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.mode = 0;
-        message.options = { oneofKind: undefined };
-        message.reportType = 0;
-        message.filter = [];
+        message.buildId = "";
+        message.filename = "";
+        message.sourceOnly = false;
         if (value !== undefined)
-            reflectionMergePartial<QueryRequest>(this, message, value);
+            reflectionMergePartial<SourceReference>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceReference): SourceReference {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
-                    message.mode = reader.int32();
-                    break;
-                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
-                    message.options = {
-                        oneofKind: "diff",
-                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
-                    };
-                    break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
-                    break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 4:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
-                    break;
-                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
-                    message.reportType = reader.int32();
-                    break;
-                case /* optional string filter_query = 6 [deprecated = true] */ 6:
-                    message.filterQuery = reader.string();
-                    break;
-                case /* optional float node_trim_threshold */ 7:
-                    message.nodeTrimThreshold = reader.float();
-                    break;
-                case /* optional parca.query.v1alpha1.GroupBy group_by */ 8:
-                    message.groupBy = GroupBy.internalBinaryRead(reader, reader.uint32(), options, message.groupBy);
-                    break;
-                case /* optional parca.query.v1alpha1.SourceReference source_reference */ 9:
-                    message.sourceReference = SourceReference.internalBinaryRead(reader, reader.uint32(), options, message.sourceReference);
-                    break;
-                case /* optional parca.query.v1alpha1.RuntimeFilter runtime_filter = 10 [deprecated = true] */ 10:
-                    message.runtimeFilter = RuntimeFilter.internalBinaryRead(reader, reader.uint32(), options, message.runtimeFilter);
-                    break;
-                case /* optional bool invert_call_stack */ 11:
-                    message.invertCallStack = reader.bool();
+                case /* string build_id */ 1:
+                    message.buildId = reader.string();
                     break;
-                case /* repeated parca.query.v1alpha1.Filter filter */ 12:
-                    message.filter.push(Filter.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string filename */ 2:
+                    message.filename = reader.string();
                     break;
-                case /* optional string sandwich_by_function */ 13:
-                    message.sandwichByFunction = reader.string();
+                case /* bool source_only */ 3:
+                    message.sourceOnly = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2449,46 +4368,16 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.DiffProfile diff = 2; */
-        if (message.options.oneofKind === "diff")
-            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.MergeProfile merge = 3; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 4; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
-        if (message.reportType !== 0)
-            writer.tag(5, WireType.Varint).int32(message.reportType);
-        /* optional string filter_query = 6 [deprecated = true]; */
-        if (message.filterQuery !== undefined)
-            writer.tag(6, WireType.LengthDelimited).string(message.filterQuery);
-        /* optional float node_trim_threshold = 7; */
-        if (message.nodeTrimThreshold !== undefined)
-            writer.tag(7, WireType.Bit32).float(message.nodeTrimThreshold);
-        /* optional parca.query.v1alpha1.GroupBy group_by = 8; */
-        if (message.groupBy)
-            GroupBy.internalBinaryWrite(message.groupBy, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
-        /* optional parca.query.v1alpha1.SourceReference source_reference = 9; */
-        if (message.sourceReference)
-            SourceReference.internalBinaryWrite(message.sourceReference, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
-        /* optional parca.query.v1alpha1.RuntimeFilter runtime_filter = 10 [deprecated = true]; */
-        if (message.runtimeFilter)
-            RuntimeFilter.internalBinaryWrite(message.runtimeFilter, writer.tag(10, WireType.LengthDelimited).fork(), options).join();
-        /* optional bool invert_call_stack = 11; */
-        if (message.invertCallStack !== undefined)
-            writer.tag(11, WireType.Varint).bool(message.invertCallStack);
-        /* repeated parca.query.v1alpha1.Filter filter = 12; */
-        for (let i = 0; i < message.filter.length; i++)
-            Filter.internalBinaryWrite(message.filter[i], writer.tag(12, WireType.LengthDelimited).fork(), options).join();
-        /* optional string sandwich_by_function = 13; */
-        if (message.sandwichByFunction !== undefined)
-            writer.tag(13, WireType.LengthDelimited).string(message.sandwichByFunction);
+    internalBinaryWrite(message: SourceReference, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string build_id = 1; */
+        if (message.buildId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.buildId);
+        /* string filename = 2; */
+        if (message.filename !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.filename);
+        /* bool source_only = 3; */
+        if (message.sourceOnly !== false)
+            writer.tag(3, WireType.Varint).bool(message.sourceOnly);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2496,49 +4385,30 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceReference
  */
-export const QueryRequest = new QueryRequest$Type();
+export const SourceReference = new SourceReference$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FilterCriteria$Type extends MessageType<FilterCriteria> {
+class GroupBy$Type extends MessageType<GroupBy> {
     constructor() {
-        super("parca.query.v1alpha1.FilterCriteria", [
-            { no: 1, name: "function_name", kind: "message", T: () => StringCondition },
-            { no: 2, name: "system_name", kind: "message", T: () => StringCondition },
-            { no: 3, name: "binary", kind: "message", T: () => StringCondition },
-            { no: 4, name: "filename", kind: "message", T: () => StringCondition },
-            { no: 5, name: "address", kind: "message", T: () => NumberCondition },
-            { no: 6, name: "line_number", kind: "message", T: () => NumberCondition }
+        super("parca.query.v1alpha1.GroupBy", [
+            { no: 1, name: "fields", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<FilterCriteria>): FilterCriteria {
+    create(value?: PartialMessage<GroupBy>): GroupBy {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.fields = [];
         if (value !== undefined)
-            reflectionMergePartial<FilterCriteria>(this, message, value);
+            reflectionMergePartial<GroupBy>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FilterCriteria): FilterCriteria {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GroupBy): GroupBy {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.StringCondition function_name */ 1:
-                    message.functionName = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.functionName);
-                    break;
-                case /* parca.query.v1alpha1.StringCondition system_name */ 2:
-                    message.systemName = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.systemName);
-                    break;
-                case /* parca.query.v1alpha1.StringCondition binary */ 3:
-                    message.binary = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.binary);
-                    break;
-                case /* parca.query.v1alpha1.StringCondition filename */ 4:
-                    message.filename = StringCondition.internalBinaryRead(reader, reader.uint32(), options, message.filename);
-                    break;
-                case /* parca.query.v1alpha1.NumberCondition address */ 5:
-                    message.address = NumberCondition.internalBinaryRead(reader, reader.uint32(), options, message.address);
-                    break;
-                case /* parca.query.v1alpha1.NumberCondition line_number */ 6:
-                    message.lineNumber = NumberCondition.internalBinaryRead(reader, reader.uint32(), options, message.lineNumber);
+                case /* repeated string fields */ 1:
+                    message.fields.push(reader.string());
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2551,25 +4421,10 @@ class FilterCriteria$Type extends MessageType<FilterCriteria> {
         }
         return message;
     }
-    internalBinaryWrite(message: FilterCriteria, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.StringCondition function_name = 1; */
-        if (message.functionName)
-            StringCondition.internalBinaryWrite(message.functionName, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.StringCondition system_name = 2; */
-        if (message.systemName)
-            StringCondition.internalBinaryWrite(message.systemName, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.StringCondition binary = 3; */
-        if (message.binary)
-            StringCondition.internalBinaryWrite(message.binary, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.StringCondition filename = 4; */
-        if (message.filename)
-            StringCondition.internalBinaryWrite(message.filename, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.NumberCondition address = 5; */
-        if (message.address)
-            NumberCondition.internalBinaryWrite(message.address, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.NumberCondition line_number = 6; */
-        if (message.lineNumber)
-            NumberCondition.internalBinaryWrite(message.lineNumber, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: GroupBy, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated string fields = 1; */
+        for (let i = 0; i < message.fields.length; i++)
+            writer.tag(1, WireType.LengthDelimited).string(message.fields[i]);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2577,68 +4432,45 @@ class FilterCriteria$Type extends MessageType<FilterCriteria> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FilterCriteria
+ * @generated MessageType for protobuf message parca.query.v1alpha1.GroupBy
  */
-export const FilterCriteria = new FilterCriteria$Type();
+export const GroupBy = new GroupBy$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class StringCondition$Type extends MessageType<StringCondition> {
+class Top$Type extends MessageType<Top> {
     constructor() {
-        super("parca.query.v1alpha1.StringCondition", [
-            { no: 1, name: "equal", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "not_equal", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "contains", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "not_contains", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "starts_with", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "not_starts_with", kind: "scalar", oneof: "condition", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.Top", [
+            { no: 1, name: "list", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TopNode },
+            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<StringCondition>): StringCondition {
+    create(value?: PartialMessage<Top>): Top {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.condition = { oneofKind: undefined };
+        message.list = [];
+        message.reported = 0;
+        message.total = 0;
+        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<StringCondition>(this, message, value);
+            reflectionMergePartial<Top>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StringCondition): StringCondition {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string equal */ 1:
-                    message.condition = {
-                        oneofKind: "equal",
-                        equal: reader.string()
-                    };
-                    break;
-                case /* string not_equal */ 2:
-                    message.condition = {
-                        oneofKind: "notEqual",
-                        notEqual: reader.string()
-                    };
-                    break;
-                case /* string contains */ 3:
-                    message.condition = {
-                        oneofKind: "contains",
-                        contains: reader.string()
-                    };
+                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
+                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* string not_contains */ 4:
-                    message.condition = {
-                        oneofKind: "notContains",
-                        notContains: reader.string()
-                    };
+                case /* int32 reported */ 2:
+                    message.reported = reader.int32();
                     break;
-                case /* string starts_with */ 5:
-                    message.condition = {
-                        oneofKind: "startsWith",
-                        startsWith: reader.string()
-                    };
+                case /* int32 total = 3 [deprecated = true] */ 3:
+                    message.total = reader.int32();
                     break;
-                case /* string not_starts_with */ 6:
-                    message.condition = {
-                        oneofKind: "notStartsWith",
-                        notStartsWith: reader.string()
-                    };
+                case /* string unit */ 4:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2651,25 +4483,19 @@ class StringCondition$Type extends MessageType<StringCondition> {
         }
         return message;
     }
-    internalBinaryWrite(message: StringCondition, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string equal = 1; */
-        if (message.condition.oneofKind === "equal")
-            writer.tag(1, WireType.LengthDelimited).string(message.condition.equal);
-        /* string not_equal = 2; */
-        if (message.condition.oneofKind === "notEqual")
-            writer.tag(2, WireType.LengthDelimited).string(message.condition.notEqual);
-        /* string contains = 3; */
-        if (message.condition.oneofKind === "contains")
-            writer.tag(3, WireType.LengthDelimited).string(message.condition.contains);
-        /* string not_contains = 4; */
-        if (message.condition.oneofKind === "notContains")
-            writer.tag(4, WireType.LengthDelimited).string(message.condition.notContains);
-        /* string starts_with = 5; */
-        if (message.condition.oneofKind === "startsWith")
-            writer.tag(5, WireType.LengthDelimited).string(message.condition.startsWith);
-        /* string not_starts_with = 6; */
-        if (message.condition.oneofKind === "notStartsWith")
-            writer.tag(6, WireType.LengthDelimited).string(message.condition.notStartsWith);
+    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.TopNode list = 1; */
+        for (let i = 0; i < message.list.length; i++)
+            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int32 reported = 2; */
+        if (message.reported !== 0)
+            writer.tag(2, WireType.Varint).int32(message.reported);
+        /* int32 total = 3 [deprecated = true]; */
+        if (message.total !== 0)
+            writer.tag(3, WireType.Varint).int32(message.total);
+        /* string unit = 4; */
+        if (message.unit !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2677,40 +4503,44 @@ class StringCondition$Type extends MessageType<StringCondition> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.StringCondition
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Top
  */
-export const StringCondition = new StringCondition$Type();
+export const Top = new Top$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class NumberCondition$Type extends MessageType<NumberCondition> {
+class TopNode$Type extends MessageType<TopNode> {
     constructor() {
-        super("parca.query.v1alpha1.NumberCondition", [
-            { no: 1, name: "equal", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 2, name: "not_equal", kind: "scalar", oneof: "condition", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ }
+        super("parca.query.v1alpha1.TopNode", [
+            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<NumberCondition>): NumberCondition {
+    create(value?: PartialMessage<TopNode>): TopNode {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.condition = { oneofKind: undefined };
+        message.cumulative = 0n;
+        message.flat = 0n;
+        message.diff = 0n;
         if (value !== undefined)
-            reflectionMergePartial<NumberCondition>(this, message, value);
+            reflectionMergePartial<TopNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: NumberCondition): NumberCondition {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* uint64 equal */ 1:
-                    message.condition = {
-                        oneofKind: "equal",
-                        equal: reader.uint64().toBigInt()
-                    };
+                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
+                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
                     break;
-                case /* uint64 not_equal */ 2:
-                    message.condition = {
-                        oneofKind: "notEqual",
-                        notEqual: reader.uint64().toBigInt()
-                    };
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toBigInt();
+                    break;
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toBigInt();
+                    break;
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2723,13 +4553,19 @@ class NumberCondition$Type extends MessageType<NumberCondition> {
         }
         return message;
     }
-    internalBinaryWrite(message: NumberCondition, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* uint64 equal = 1; */
-        if (message.condition.oneofKind === "equal")
-            writer.tag(1, WireType.Varint).uint64(message.condition.equal);
-        /* uint64 not_equal = 2; */
-        if (message.condition.oneofKind === "notEqual")
-            writer.tag(2, WireType.Varint).uint64(message.condition.notEqual);
+    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
+        if (message.meta)
+            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 3; */
+        if (message.flat !== 0n)
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 diff = 4; */
+        if (message.diff !== 0n)
+            writer.tag(4, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2737,40 +4573,41 @@ class NumberCondition$Type extends MessageType<NumberCondition> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.NumberCondition
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
  */
-export const NumberCondition = new NumberCondition$Type();
+export const TopNode = new TopNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Filter$Type extends MessageType<Filter> {
+class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.Filter", [
-            { no: 1, name: "stack_filter", kind: "message", oneof: "filter", T: () => StackFilter },
-            { no: 2, name: "frame_filter", kind: "message", oneof: "filter", T: () => FrameFilter }
+        super("parca.query.v1alpha1.TopNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line }
         ]);
     }
-    create(value?: PartialMessage<Filter>): Filter {
+    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.filter = { oneofKind: undefined };
         if (value !== undefined)
-            reflectionMergePartial<Filter>(this, message, value);
+            reflectionMergePartial<TopNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Filter): Filter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.StackFilter stack_filter */ 1:
-                    message.filter = {
-                        oneofKind: "stackFilter",
-                        stackFilter: StackFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).stackFilter)
-                    };
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* parca.query.v1alpha1.FrameFilter frame_filter */ 2:
-                    message.filter = {
-                        oneofKind: "frameFilter",
-                        frameFilter: FrameFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).frameFilter)
-                    };
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
+                    break;
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                    break;
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2783,13 +4620,19 @@ class Filter$Type extends MessageType<Filter> {
         }
         return message;
     }
-    internalBinaryWrite(message: Filter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.StackFilter stack_filter = 1; */
-        if (message.filter.oneofKind === "stackFilter")
-            StackFilter.internalBinaryWrite(message.filter.stackFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.FrameFilter frame_filter = 2; */
-        if (message.filter.oneofKind === "frameFilter")
-            FrameFilter.internalBinaryWrite(message.filter.frameFilter, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2797,40 +4640,74 @@ class Filter$Type extends MessageType<Filter> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Filter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
  */
-export const Filter = new Filter$Type();
+export const TopNodeMeta = new TopNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class StackFilter$Type extends MessageType<StackFilter> {
+class Flamegraph$Type extends MessageType<Flamegraph> {
     constructor() {
-        super("parca.query.v1alpha1.StackFilter", [
-            { no: 1, name: "function_name_stack_filter", kind: "message", oneof: "filter", T: () => FunctionNameStackFilter },
-            { no: 2, name: "criteria", kind: "message", oneof: "filter", T: () => FilterCriteria }
+        super("parca.query.v1alpha1.Flamegraph", [
+            { no: 1, name: "root", kind: "message", T: () => FlamegraphRootNode },
+            { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 5, name: "string_table", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "locations", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Location },
+            { no: 7, name: "mapping", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Mapping },
+            { no: 8, name: "function", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Function },
+            { no: 9, name: "untrimmed_total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 10, name: "trimmed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<StackFilter>): StackFilter {
+    create(value?: PartialMessage<Flamegraph>): Flamegraph {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.filter = { oneofKind: undefined };
+        message.total = 0n;
+        message.unit = "";
+        message.height = 0;
+        message.stringTable = [];
+        message.locations = [];
+        message.mapping = [];
+        message.function = [];
+        message.untrimmedTotal = 0n;
+        message.trimmed = 0n;
         if (value !== undefined)
-            reflectionMergePartial<StackFilter>(this, message, value);
+            reflectionMergePartial<Flamegraph>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: StackFilter): StackFilter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Flamegraph): Flamegraph {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.FunctionNameStackFilter function_name_stack_filter = 1 [deprecated = true] */ 1:
-                    message.filter = {
-                        oneofKind: "functionNameStackFilter",
-                        functionNameStackFilter: FunctionNameStackFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).functionNameStackFilter)
-                    };
+                case /* parca.query.v1alpha1.FlamegraphRootNode root */ 1:
+                    message.root = FlamegraphRootNode.internalBinaryRead(reader, reader.uint32(), options, message.root);
                     break;
-                case /* parca.query.v1alpha1.FilterCriteria criteria */ 2:
-                    message.filter = {
-                        oneofKind: "criteria",
-                        criteria: FilterCriteria.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).criteria)
-                    };
+                case /* int64 total = 2 [deprecated = true] */ 2:
+                    message.total = reader.int64().toBigInt();
+                    break;
+                case /* string unit */ 3:
+                    message.unit = reader.string();
+                    break;
+                case /* int32 height */ 4:
+                    message.height = reader.int32();
+                    break;
+                case /* repeated string string_table */ 5:
+                    message.stringTable.push(reader.string());
+                    break;
+                case /* repeated parca.metastore.v1alpha1.Location locations */ 6:
+                    message.locations.push(Location.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.metastore.v1alpha1.Mapping mapping */ 7:
+                    message.mapping.push(Mapping.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.metastore.v1alpha1.Function function */ 8:
+                    message.function.push(Function.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int64 untrimmed_total = 9 [deprecated = true] */ 9:
+                    message.untrimmedTotal = reader.int64().toBigInt();
+                    break;
+                case /* int64 trimmed */ 10:
+                    message.trimmed = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2843,13 +4720,37 @@ class StackFilter$Type extends MessageType<StackFilter> {
         }
         return message;
     }
-    internalBinaryWrite(message: StackFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.FunctionNameStackFilter function_name_stack_filter = 1 [deprecated = true]; */
-        if (message.filter.oneofKind === "functionNameStackFilter")
-            FunctionNameStackFilter.internalBinaryWrite(message.filter.functionNameStackFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.FilterCriteria criteria = 2; */
-        if (message.filter.oneofKind === "criteria")
-            FilterCriteria.internalBinaryWrite(message.filter.criteria, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Flamegraph, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.FlamegraphRootNode root = 1; */
+        if (message.root)
+            FlamegraphRootNode.internalBinaryWrite(message.root, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 total = 2 [deprecated = true]; */
+        if (message.total !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.total);
+        /* string unit = 3; */
+        if (message.unit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
+        /* int32 height = 4; */
+        if (message.height !== 0)
+            writer.tag(4, WireType.Varint).int32(message.height);
+        /* repeated string string_table = 5; */
+        for (let i = 0; i < message.stringTable.length; i++)
+            writer.tag(5, WireType.LengthDelimited).string(message.stringTable[i]);
+        /* repeated parca.metastore.v1alpha1.Location locations = 6; */
+        for (let i = 0; i < message.locations.length; i++)
+            Location.internalBinaryWrite(message.locations[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.metastore.v1alpha1.Mapping mapping = 7; */
+        for (let i = 0; i < message.mapping.length; i++)
+            Mapping.internalBinaryWrite(message.mapping[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.metastore.v1alpha1.Function function = 8; */
+        for (let i = 0; i < message.function.length; i++)
+            Function.internalBinaryWrite(message.function[i], writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* int64 untrimmed_total = 9 [deprecated = true]; */
+        if (message.untrimmedTotal !== 0n)
+            writer.tag(9, WireType.Varint).int64(message.untrimmedTotal);
+        /* int64 trimmed = 10; */
+        if (message.trimmed !== 0n)
+            writer.tag(10, WireType.Varint).int64(message.trimmed);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2857,35 +4758,45 @@ class StackFilter$Type extends MessageType<StackFilter> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.StackFilter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Flamegraph
  */
-export const StackFilter = new StackFilter$Type();
+export const Flamegraph = new Flamegraph$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FunctionNameStackFilter$Type extends MessageType<FunctionNameStackFilter> {
+class FlamegraphArrow$Type extends MessageType<FlamegraphArrow> {
     constructor() {
-        super("parca.query.v1alpha1.FunctionNameStackFilter", [
-            { no: 1, name: "function_to_filter", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "exclude", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.FlamegraphArrow", [
+            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
+            { no: 2, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "trimmed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<FunctionNameStackFilter>): FunctionNameStackFilter {
+    create(value?: PartialMessage<FlamegraphArrow>): FlamegraphArrow {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.functionToFilter = "";
-        message.exclude = false;
+        message.record = new Uint8Array(0);
+        message.unit = "";
+        message.height = 0;
+        message.trimmed = 0n;
         if (value !== undefined)
-            reflectionMergePartial<FunctionNameStackFilter>(this, message, value);
+            reflectionMergePartial<FlamegraphArrow>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FunctionNameStackFilter): FunctionNameStackFilter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphArrow): FlamegraphArrow {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string function_to_filter */ 1:
-                    message.functionToFilter = reader.string();
+                case /* bytes record */ 1:
+                    message.record = reader.bytes();
                     break;
-                case /* bool exclude */ 2:
-                    message.exclude = reader.bool();
+                case /* string unit */ 2:
+                    message.unit = reader.string();
+                    break;
+                case /* int32 height */ 3:
+                    message.height = reader.int32();
+                    break;
+                case /* int64 trimmed */ 4:
+                    message.trimmed = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2898,13 +4809,19 @@ class FunctionNameStackFilter$Type extends MessageType<FunctionNameStackFilter>
         }
         return message;
     }
-    internalBinaryWrite(message: FunctionNameStackFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string function_to_filter = 1; */
-        if (message.functionToFilter !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.functionToFilter);
-        /* bool exclude = 2; */
-        if (message.exclude !== false)
-            writer.tag(2, WireType.Varint).bool(message.exclude);
+    internalBinaryWrite(message: FlamegraphArrow, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bytes record = 1; */
+        if (message.record.length)
+            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
+        /* string unit = 2; */
+        if (message.unit !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.unit);
+        /* int32 height = 3; */
+        if (message.height !== 0)
+            writer.tag(3, WireType.Varint).int32(message.height);
+        /* int64 trimmed = 4; */
+        if (message.trimmed !== 0n)
+            writer.tag(4, WireType.Varint).int64(message.trimmed);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2912,40 +4829,40 @@ class FunctionNameStackFilter$Type extends MessageType<FunctionNameStackFilter>
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FunctionNameStackFilter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphArrow
  */
-export const FunctionNameStackFilter = new FunctionNameStackFilter$Type();
+export const FlamegraphArrow = new FlamegraphArrow$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FrameFilter$Type extends MessageType<FrameFilter> {
+class Source$Type extends MessageType<Source> {
     constructor() {
-        super("parca.query.v1alpha1.FrameFilter", [
-            { no: 1, name: "binary_frame_filter", kind: "message", oneof: "filter", T: () => BinaryFrameFilter },
-            { no: 2, name: "criteria", kind: "message", oneof: "filter", T: () => FilterCriteria }
+        super("parca.query.v1alpha1.Source", [
+            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
+            { no: 2, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<FrameFilter>): FrameFilter {
+    create(value?: PartialMessage<Source>): Source {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.filter = { oneofKind: undefined };
+        message.record = new Uint8Array(0);
+        message.source = "";
+        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<FrameFilter>(this, message, value);
+            reflectionMergePartial<Source>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FrameFilter): FrameFilter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.BinaryFrameFilter binary_frame_filter = 1 [deprecated = true] */ 1:
-                    message.filter = {
-                        oneofKind: "binaryFrameFilter",
-                        binaryFrameFilter: BinaryFrameFilter.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).binaryFrameFilter)
-                    };
+                case /* bytes record */ 1:
+                    message.record = reader.bytes();
                     break;
-                case /* parca.query.v1alpha1.FilterCriteria criteria */ 2:
-                    message.filter = {
-                        oneofKind: "criteria",
-                        criteria: FilterCriteria.internalBinaryRead(reader, reader.uint32(), options, (message.filter as any).criteria)
-                    };
+                case /* string source */ 2:
+                    message.source = reader.string();
+                    break;
+                case /* string unit */ 3:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -2958,13 +4875,16 @@ class FrameFilter$Type extends MessageType<FrameFilter> {
         }
         return message;
     }
-    internalBinaryWrite(message: FrameFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.BinaryFrameFilter binary_frame_filter = 1 [deprecated = true]; */
-        if (message.filter.oneofKind === "binaryFrameFilter")
-            BinaryFrameFilter.internalBinaryWrite(message.filter.binaryFrameFilter, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.FilterCriteria criteria = 2; */
-        if (message.filter.oneofKind === "criteria")
-            FilterCriteria.internalBinaryWrite(message.filter.criteria, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bytes record = 1; */
+        if (message.record.length)
+            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
+        /* string source = 2; */
+        if (message.source !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.source);
+        /* string unit = 3; */
+        if (message.unit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2972,30 +4892,40 @@ class FrameFilter$Type extends MessageType<FrameFilter> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FrameFilter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Source
  */
-export const FrameFilter = new FrameFilter$Type();
+export const Source = new Source$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class BinaryFrameFilter$Type extends MessageType<BinaryFrameFilter> {
+class FlamegraphRootNode$Type extends MessageType<FlamegraphRootNode> {
     constructor() {
-        super("parca.query.v1alpha1.BinaryFrameFilter", [
-            { no: 1, name: "include_binaries", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.FlamegraphRootNode", [
+            { no: 1, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 2, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "children", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => FlamegraphNode }
         ]);
     }
-    create(value?: PartialMessage<BinaryFrameFilter>): BinaryFrameFilter {
+    create(value?: PartialMessage<FlamegraphRootNode>): FlamegraphRootNode {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.includeBinaries = [];
+        message.cumulative = 0n;
+        message.diff = 0n;
+        message.children = [];
         if (value !== undefined)
-            reflectionMergePartial<BinaryFrameFilter>(this, message, value);
+            reflectionMergePartial<FlamegraphRootNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: BinaryFrameFilter): BinaryFrameFilter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphRootNode): FlamegraphRootNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated string include_binaries */ 1:
-                    message.includeBinaries.push(reader.string());
+                case /* int64 cumulative */ 1:
+                    message.cumulative = reader.int64().toBigInt();
+                    break;
+                case /* int64 diff */ 2:
+                    message.diff = reader.int64().toBigInt();
+                    break;
+                case /* repeated parca.query.v1alpha1.FlamegraphNode children */ 3:
+                    message.children.push(FlamegraphNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3008,10 +4938,16 @@ class BinaryFrameFilter$Type extends MessageType<BinaryFrameFilter> {
         }
         return message;
     }
-    internalBinaryWrite(message: BinaryFrameFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated string include_binaries = 1; */
-        for (let i = 0; i < message.includeBinaries.length; i++)
-            writer.tag(1, WireType.LengthDelimited).string(message.includeBinaries[i]);
+    internalBinaryWrite(message: FlamegraphRootNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int64 cumulative = 1; */
+        if (message.cumulative !== 0n)
+            writer.tag(1, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 2; */
+        if (message.diff !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.diff);
+        /* repeated parca.query.v1alpha1.FlamegraphNode children = 3; */
+        for (let i = 0; i < message.children.length; i++)
+            FlamegraphNode.internalBinaryWrite(message.children[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3019,40 +4955,44 @@ class BinaryFrameFilter$Type extends MessageType<BinaryFrameFilter> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.BinaryFrameFilter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphRootNode
  */
-export const BinaryFrameFilter = new BinaryFrameFilter$Type();
+export const FlamegraphRootNode = new FlamegraphRootNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class RuntimeFilter$Type extends MessageType<RuntimeFilter> {
+class FlamegraphNode$Type extends MessageType<FlamegraphNode> {
     constructor() {
-        super("parca.query.v1alpha1.RuntimeFilter", [
-            { no: 1, name: "show_python", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 2, name: "show_ruby", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
-            { no: 3, name: "show_interpreted_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.FlamegraphNode", [
+            { no: 1, name: "meta", kind: "message", T: () => FlamegraphNodeMeta },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "children", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => FlamegraphNode }
         ]);
     }
-    create(value?: PartialMessage<RuntimeFilter>): RuntimeFilter {
+    create(value?: PartialMessage<FlamegraphNode>): FlamegraphNode {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.showPython = false;
-        message.showRuby = false;
-        message.showInterpretedOnly = false;
+        message.cumulative = 0n;
+        message.diff = 0n;
+        message.children = [];
         if (value !== undefined)
-            reflectionMergePartial<RuntimeFilter>(this, message, value);
+            reflectionMergePartial<FlamegraphNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RuntimeFilter): RuntimeFilter {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphNode): FlamegraphNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* bool show_python */ 1:
-                    message.showPython = reader.bool();
+                case /* parca.query.v1alpha1.FlamegraphNodeMeta meta */ 1:
+                    message.meta = FlamegraphNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
                     break;
-                case /* bool show_ruby */ 2:
-                    message.showRuby = reader.bool();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* bool show_interpreted_only */ 3:
-                    message.showInterpretedOnly = reader.bool();
+                case /* int64 diff */ 3:
+                    message.diff = reader.int64().toBigInt();
+                    break;
+                case /* repeated parca.query.v1alpha1.FlamegraphNode children */ 4:
+                    message.children.push(FlamegraphNode.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3065,16 +5005,19 @@ class RuntimeFilter$Type extends MessageType<RuntimeFilter> {
         }
         return message;
     }
-    internalBinaryWrite(message: RuntimeFilter, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* bool show_python = 1; */
-        if (message.showPython !== false)
-            writer.tag(1, WireType.Varint).bool(message.showPython);
-        /* bool show_ruby = 2; */
-        if (message.showRuby !== false)
-            writer.tag(2, WireType.Varint).bool(message.showRuby);
-        /* bool show_interpreted_only = 3; */
-        if (message.showInterpretedOnly !== false)
-            writer.tag(3, WireType.Varint).bool(message.showInterpretedOnly);
+    internalBinaryWrite(message: FlamegraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.FlamegraphNodeMeta meta = 1; */
+        if (message.meta)
+            FlamegraphNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 3; */
+        if (message.diff !== 0n)
+            writer.tag(3, WireType.Varint).int64(message.diff);
+        /* repeated parca.query.v1alpha1.FlamegraphNode children = 4; */
+        for (let i = 0; i < message.children.length; i++)
+            FlamegraphNode.internalBinaryWrite(message.children[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3082,40 +5025,51 @@ class RuntimeFilter$Type extends MessageType<RuntimeFilter> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.RuntimeFilter
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphNode
  */
-export const RuntimeFilter = new RuntimeFilter$Type();
+export const FlamegraphNode = new FlamegraphNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class SourceReference$Type extends MessageType<SourceReference> {
+class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.SourceReference", [
-            { no: 1, name: "build_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "source_only", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.FlamegraphNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line },
+            { no: 5, name: "location_index", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 6, name: "line_index", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
         ]);
     }
-    create(value?: PartialMessage<SourceReference>): SourceReference {
+    create(value?: PartialMessage<FlamegraphNodeMeta>): FlamegraphNodeMeta {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.buildId = "";
-        message.filename = "";
-        message.sourceOnly = false;
+        message.locationIndex = 0;
+        message.lineIndex = 0;
         if (value !== undefined)
-            reflectionMergePartial<SourceReference>(this, message, value);
+            reflectionMergePartial<FlamegraphNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceReference): SourceReference {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphNodeMeta): FlamegraphNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string build_id */ 1:
-                    message.buildId = reader.string();
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* string filename */ 2:
-                    message.filename = reader.string();
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* bool source_only */ 3:
-                    message.sourceOnly = reader.bool();
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                    break;
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                    break;
+                case /* uint32 location_index */ 5:
+                    message.locationIndex = reader.uint32();
+                    break;
+                case /* uint32 line_index */ 6:
+                    message.lineIndex = reader.uint32();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3128,16 +5082,25 @@ class SourceReference$Type extends MessageType<SourceReference> {
         }
         return message;
     }
-    internalBinaryWrite(message: SourceReference, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string build_id = 1; */
-        if (message.buildId !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.buildId);
-        /* string filename = 2; */
-        if (message.filename !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.filename);
-        /* bool source_only = 3; */
-        if (message.sourceOnly !== false)
-            writer.tag(3, WireType.Varint).bool(message.sourceOnly);
+    internalBinaryWrite(message: FlamegraphNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 location_index = 5; */
+        if (message.locationIndex !== 0)
+            writer.tag(5, WireType.Varint).uint32(message.locationIndex);
+        /* uint32 line_index = 6; */
+        if (message.lineIndex !== 0)
+            writer.tag(6, WireType.Varint).uint32(message.lineIndex);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3145,30 +5108,44 @@ class SourceReference$Type extends MessageType<SourceReference> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.SourceReference
+ * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
  */
-export const SourceReference = new SourceReference$Type();
+export const FlamegraphNodeMeta = new FlamegraphNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class GroupBy$Type extends MessageType<GroupBy> {
+class CallgraphNode$Type extends MessageType<CallgraphNode> {
     constructor() {
-        super("parca.query.v1alpha1.GroupBy", [
-            { no: 1, name: "fields", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.CallgraphNode", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "meta", kind: "message", T: () => CallgraphNodeMeta },
+            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<GroupBy>): GroupBy {
+    create(value?: PartialMessage<CallgraphNode>): CallgraphNode {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.fields = [];
+        message.id = "";
+        message.cumulative = 0n;
+        message.flat = 0n;
         if (value !== undefined)
-            reflectionMergePartial<GroupBy>(this, message, value);
+            reflectionMergePartial<CallgraphNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GroupBy): GroupBy {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNode): CallgraphNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated string fields */ 1:
-                    message.fields.push(reader.string());
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* parca.query.v1alpha1.CallgraphNodeMeta meta */ 2:
+                    message.meta = CallgraphNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
+                    break;
+                case /* int64 cumulative */ 3:
+                    message.cumulative = reader.int64().toBigInt();
+                    break;
+                case /* int64 flat */ 4:
+                    message.flat = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3181,10 +5158,19 @@ class GroupBy$Type extends MessageType<GroupBy> {
         }
         return message;
     }
-    internalBinaryWrite(message: GroupBy, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated string fields = 1; */
-        for (let i = 0; i < message.fields.length; i++)
-            writer.tag(1, WireType.LengthDelimited).string(message.fields[i]);
+    internalBinaryWrite(message: CallgraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* parca.query.v1alpha1.CallgraphNodeMeta meta = 2; */
+        if (message.meta)
+            CallgraphNodeMeta.internalBinaryWrite(message.meta, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 3; */
+        if (message.cumulative !== 0n)
+            writer.tag(3, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 4; */
+        if (message.flat !== 0n)
+            writer.tag(4, WireType.Varint).int64(message.flat);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3192,45 +5178,41 @@ class GroupBy$Type extends MessageType<GroupBy> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.GroupBy
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNode
  */
-export const GroupBy = new GroupBy$Type();
+export const CallgraphNode = new CallgraphNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Top$Type extends MessageType<Top> {
+class CallgraphNodeMeta$Type extends MessageType<CallgraphNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.Top", [
-            { no: 1, name: "list", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TopNode },
-            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.CallgraphNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line }
         ]);
     }
-    create(value?: PartialMessage<Top>): Top {
+    create(value?: PartialMessage<CallgraphNodeMeta>): CallgraphNodeMeta {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.list = [];
-        message.reported = 0;
-        message.total = 0;
-        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<Top>(this, message, value);
+            reflectionMergePartial<CallgraphNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNodeMeta): CallgraphNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
-                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* int32 reported */ 2:
-                    message.reported = reader.int32();
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* int32 total = 3 [deprecated = true] */ 3:
-                    message.total = reader.int32();
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* string unit */ 4:
-                    message.unit = reader.string();
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3243,19 +5225,19 @@ class Top$Type extends MessageType<Top> {
         }
         return message;
     }
-    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.TopNode list = 1; */
-        for (let i = 0; i < message.list.length; i++)
-            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int32 reported = 2; */
-        if (message.reported !== 0)
-            writer.tag(2, WireType.Varint).int32(message.reported);
-        /* int32 total = 3 [deprecated = true]; */
-        if (message.total !== 0)
-            writer.tag(3, WireType.Varint).int32(message.total);
-        /* string unit = 4; */
-        if (message.unit !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.unit);
+    internalBinaryWrite(message: CallgraphNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3263,44 +5245,55 @@ class Top$Type extends MessageType<Top> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Top
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNodeMeta
  */
-export const Top = new Top$Type();
+export const CallgraphNodeMeta = new CallgraphNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNode$Type extends MessageType<TopNode> {
+class CallgraphEdge$Type extends MessageType<CallgraphEdge> {
     constructor() {
-        super("parca.query.v1alpha1.TopNode", [
-            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
-            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        super("parca.query.v1alpha1.CallgraphEdge", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 5, name: "is_collapsed", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 6, name: "is_inlined", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
-    create(value?: PartialMessage<TopNode>): TopNode {
+    create(value?: PartialMessage<CallgraphEdge>): CallgraphEdge {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.source = "";
+        message.target = "";
         message.cumulative = 0n;
-        message.flat = 0n;
-        message.diff = 0n;
+        message.isCollapsed = false;
+        message.isInlined = false;
         if (value !== undefined)
-            reflectionMergePartial<TopNode>(this, message, value);
+            reflectionMergePartial<CallgraphEdge>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphEdge): CallgraphEdge {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
-                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
+                case /* string id */ 1:
+                    message.id = reader.string();
                     break;
-                case /* int64 cumulative */ 2:
+                case /* string source */ 2:
+                    message.source = reader.string();
+                    break;
+                case /* string target */ 3:
+                    message.target = reader.string();
+                    break;
+                case /* int64 cumulative */ 4:
                     message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* int64 flat */ 3:
-                    message.flat = reader.int64().toBigInt();
+                case /* bool is_collapsed */ 5:
+                    message.isCollapsed = reader.bool();
                     break;
-                case /* int64 diff */ 4:
-                    message.diff = reader.int64().toBigInt();
+                case /* bool is_inlined */ 6:
+                    message.isInlined = reader.bool();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3313,19 +5306,25 @@ class TopNode$Type extends MessageType<TopNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
-        if (message.meta)
-            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 cumulative = 2; */
+    internalBinaryWrite(message: CallgraphEdge, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string source = 2; */
+        if (message.source !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.source);
+        /* string target = 3; */
+        if (message.target !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.target);
+        /* int64 cumulative = 4; */
         if (message.cumulative !== 0n)
-            writer.tag(2, WireType.Varint).int64(message.cumulative);
-        /* int64 flat = 3; */
-        if (message.flat !== 0n)
-            writer.tag(3, WireType.Varint).int64(message.flat);
-        /* int64 diff = 4; */
-        if (message.diff !== 0n)
-            writer.tag(4, WireType.Varint).int64(message.diff);
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* bool is_collapsed = 5; */
+        if (message.isCollapsed !== false)
+            writer.tag(5, WireType.Varint).bool(message.isCollapsed);
+        /* bool is_inlined = 6; */
+        if (message.isInlined !== false)
+            writer.tag(6, WireType.Varint).bool(message.isInlined);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3333,41 +5332,50 @@ class TopNode$Type extends MessageType<TopNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphEdge
  */
-export const TopNode = new TopNode$Type();
+export const CallgraphEdge = new CallgraphEdge$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
+class Disassembly$Type extends MessageType<Disassembly> {
     constructor() {
-        super("parca.query.v1alpha1.TopNodeMeta", [
-            { no: 1, name: "location", kind: "message", T: () => Location },
-            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
-            { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line }
+        super("parca.query.v1alpha1.Disassembly", [
+            { no: 1, name: "function", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "end", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "instructions", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => DisassemblyInstruction },
+            { no: 5, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
+    create(value?: PartialMessage<Disassembly>): Disassembly {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.function = "";
+        message.start = 0n;
+        message.end = 0n;
+        message.instructions = [];
+        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<TopNodeMeta>(this, message, value);
+            reflectionMergePartial<Disassembly>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Disassembly): Disassembly {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.metastore.v1alpha1.Location location */ 1:
-                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
+                case /* string function */ 1:
+                    message.function = reader.string();
                     break;
-                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
-                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
+                case /* uint64 start */ 2:
+                    message.start = reader.uint64().toBigInt();
                     break;
-                case /* parca.metastore.v1alpha1.Function function */ 3:
-                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                case /* uint64 end */ 3:
+                    message.end = reader.uint64().toBigInt();
                     break;
-                case /* parca.metastore.v1alpha1.Line line */ 4:
-                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                case /* repeated parca.query.v1alpha1.DisassemblyInstruction instructions */ 4:
+                    message.instructions.push(DisassemblyInstruction.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string unit */ 5:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3380,19 +5388,22 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.metastore.v1alpha1.Location location = 1; */
-        if (message.location)
-            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
-        if (message.mapping)
-            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Function function = 3; */
-        if (message.function)
-            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Line line = 4; */
-        if (message.line)
-            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Disassembly, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string function = 1; */
+        if (message.function !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.function);
+        /* uint64 start = 2; */
+        if (message.start !== 0n)
+            writer.tag(2, WireType.Varint).uint64(message.start);
+        /* uint64 end = 3; */
+        if (message.end !== 0n)
+            writer.tag(3, WireType.Varint).uint64(message.end);
+        /* repeated parca.query.v1alpha1.DisassemblyInstruction instructions = 4; */
+        for (let i = 0; i < message.instructions.length; i++)
+            DisassemblyInstruction.internalBinaryWrite(message.instructions[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* string unit = 5; */
+        if (message.unit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3400,74 +5411,55 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Disassembly
  */
-export const TopNodeMeta = new TopNodeMeta$Type();
+export const Disassembly = new Disassembly$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Flamegraph$Type extends MessageType<Flamegraph> {
+class DisassemblyInstruction$Type extends MessageType<DisassemblyInstruction> {
     constructor() {
-        super("parca.query.v1alpha1.Flamegraph", [
-            { no: 1, name: "root", kind: "message", T: () => FlamegraphRootNode },
-            { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 5, name: "string_table", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "locations", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Location },
-            { no: 7, name: "mapping", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Mapping },
-            { no: 8, name: "function", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Function },
-            { no: 9, name: "untrimmed_total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 10, name: "trimmed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        super("parca.query.v1alpha1.DisassemblyInstruction", [
+            { no: 1, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 2, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 5, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "line", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<Flamegraph>): Flamegraph {
+    create(value?: PartialMessage<DisassemblyInstruction>): DisassemblyInstruction {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.total = 0n;
-        message.unit = "";
-        message.height = 0;
-        message.stringTable = [];
-        message.locations = [];
-        message.mapping = [];
-        message.function = [];
-        message.untrimmedTotal = 0n;
-        message.trimmed = 0n;
+        message.address = 0n;
+        message.text = "";
+        message.flat = 0n;
+        message.cumulative = 0n;
+        message.filename = "";
+        message.line = 0n;
         if (value !== undefined)
-            reflectionMergePartial<Flamegraph>(this, message, value);
+            reflectionMergePartial<DisassemblyInstruction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Flamegraph): Flamegraph {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassemblyInstruction): DisassemblyInstruction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.FlamegraphRootNode root */ 1:
-                    message.root = FlamegraphRootNode.internalBinaryRead(reader, reader.uint32(), options, message.root);
-                    break;
-                case /* int64 total = 2 [deprecated = true] */ 2:
-                    message.total = reader.int64().toBigInt();
-                    break;
-                case /* string unit */ 3:
-                    message.unit = reader.string();
-                    break;
-                case /* int32 height */ 4:
-                    message.height = reader.int32();
+                case /* uint64 address */ 1:
+                    message.address = reader.uint64().toBigInt();
                     break;
-                case /* repeated string string_table */ 5:
-                    message.stringTable.push(reader.string());
-                    break;
-                case /* repeated parca.metastore.v1alpha1.Location locations */ 6:
-                    message.locations.push(Location.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string text */ 2:
+                    message.text = reader.string();
                     break;
-                case /* repeated parca.metastore.v1alpha1.Mapping mapping */ 7:
-                    message.mapping.push(Mapping.internalBinaryRead(reader, reader.uint32(), options));
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toBigInt();
                     break;
-                case /* repeated parca.metastore.v1alpha1.Function function */ 8:
-                    message.function.push(Function.internalBinaryRead(reader, reader.uint32(), options));
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* int64 untrimmed_total = 9 [deprecated = true] */ 9:
-                    message.untrimmedTotal = reader.int64().toBigInt();
+                case /* string filename */ 5:
+                    message.filename = reader.string();
                     break;
-                case /* int64 trimmed */ 10:
-                    message.trimmed = reader.int64().toBigInt();
+                case /* int64 line */ 6:
+                    message.line = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3480,37 +5472,25 @@ class Flamegraph$Type extends MessageType<Flamegraph> {
         }
         return message;
     }
-    internalBinaryWrite(message: Flamegraph, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.FlamegraphRootNode root = 1; */
-        if (message.root)
-            FlamegraphRootNode.internalBinaryWrite(message.root, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 total = 2 [deprecated = true]; */
-        if (message.total !== 0n)
-            writer.tag(2, WireType.Varint).int64(message.total);
-        /* string unit = 3; */
-        if (message.unit !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.unit);
-        /* int32 height = 4; */
-        if (message.height !== 0)
-            writer.tag(4, WireType.Varint).int32(message.height);
-        /* repeated string string_table = 5; */
-        for (let i = 0; i < message.stringTable.length; i++)
-            writer.tag(5, WireType.LengthDelimited).string(message.stringTable[i]);
-        /* repeated parca.metastore.v1alpha1.Location locations = 6; */
-        for (let i = 0; i < message.locations.length; i++)
-            Location.internalBinaryWrite(message.locations[i], writer.tag(6, WireType.LengthDelimited).fork(), options).join();
-        /* repeated parca.metastore.v1alpha1.Mapping mapping = 7; */
-        for (let i = 0; i < message.mapping.length; i++)
-            Mapping.internalBinaryWrite(message.mapping[i], writer.tag(7, WireType.LengthDelimited).fork(), options).join();
-        /* repeated parca.metastore.v1alpha1.Function function = 8; */
-        for (let i = 0; i < message.function.length; i++)
-            Function.internalBinaryWrite(message.function[i], writer.tag(8, WireType.LengthDelimited).fork(), options).join();
-        /* int64 untrimmed_total = 9 [deprecated = true]; */
-        if (message.untrimmedTotal !== 0n)
-            writer.tag(9, WireType.Varint).int64(message.untrimmedTotal);
-        /* int64 trimmed = 10; */
-        if (message.trimmed !== 0n)
-            writer.tag(10, WireType.Varint).int64(message.trimmed);
+    internalBinaryWrite(message: DisassemblyInstruction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 address = 1; */
+        if (message.address !== 0n)
+            writer.tag(1, WireType.Varint).uint64(message.address);
+        /* string text = 2; */
+        if (message.text !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.text);
+        /* int64 flat = 3; */
+        if (message.flat !== 0n)
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== 0n)
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* string filename = 5; */
+        if (message.filename !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.filename);
+        /* int64 line = 6; */
+        if (message.line !== 0n)
+            writer.tag(6, WireType.Varint).int64(message.line);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3518,45 +5498,40 @@ class Flamegraph$Type extends MessageType<Flamegraph> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Flamegraph
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassemblyInstruction
  */
-export const Flamegraph = new Flamegraph$Type();
+export const DisassemblyInstruction = new DisassemblyInstruction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FlamegraphArrow$Type extends MessageType<FlamegraphArrow> {
+class LabelBreakdown$Type extends MessageType<LabelBreakdown> {
     constructor() {
-        super("parca.query.v1alpha1.FlamegraphArrow", [
-            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
-            { no: 2, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 4, name: "trimmed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        super("parca.query.v1alpha1.LabelBreakdown", [
+            { no: 1, name: "labels", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => LabelBreakdownLabel },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<FlamegraphArrow>): FlamegraphArrow {
+    create(value?: PartialMessage<LabelBreakdown>): LabelBreakdown {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.record = new Uint8Array(0);
+        message.labels = [];
+        message.cumulative = 0n;
         message.unit = "";
-        message.height = 0;
-        message.trimmed = 0n;
         if (value !== undefined)
-            reflectionMergePartial<FlamegraphArrow>(this, message, value);
+            reflectionMergePartial<LabelBreakdown>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphArrow): FlamegraphArrow {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: LabelBreakdown): LabelBreakdown {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* bytes record */ 1:
-                    message.record = reader.bytes();
+                case /* repeated parca.query.v1alpha1.LabelBreakdownLabel labels */ 1:
+                    message.labels.push(LabelBreakdownLabel.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* string unit */ 2:
-                    message.unit = reader.string();
-                    break;
-                case /* int32 height */ 3:
-                    message.height = reader.int32();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* int64 trimmed */ 4:
-                    message.trimmed = reader.int64().toBigInt();
+                case /* string unit */ 3:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3569,19 +5544,16 @@ class FlamegraphArrow$Type extends MessageType<FlamegraphArrow> {
         }
         return message;
     }
-    internalBinaryWrite(message: FlamegraphArrow, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* bytes record = 1; */
-        if (message.record.length)
-            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
-        /* string unit = 2; */
+    internalBinaryWrite(message: LabelBreakdown, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.LabelBreakdownLabel labels = 1; */
+        for (let i = 0; i < message.labels.length; i++)
+            LabelBreakdownLabel.internalBinaryWrite(message.labels[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* string unit = 3; */
         if (message.unit !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.unit);
-        /* int32 height = 3; */
-        if (message.height !== 0)
-            writer.tag(3, WireType.Varint).int32(message.height);
-        /* int64 trimmed = 4; */
-        if (message.trimmed !== 0n)
-            writer.tag(4, WireType.Varint).int64(message.trimmed);
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3589,40 +5561,35 @@ class FlamegraphArrow$Type extends MessageType<FlamegraphArrow> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphArrow
+ * @generated MessageType for protobuf message parca.query.v1alpha1.LabelBreakdown
  */
-export const FlamegraphArrow = new FlamegraphArrow$Type();
+export const LabelBreakdown = new LabelBreakdown$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Source$Type extends MessageType<Source> {
+class LabelBreakdownLabel$Type extends MessageType<LabelBreakdownLabel> {
     constructor() {
-        super("parca.query.v1alpha1.Source", [
-            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
-            { no: 2, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.LabelBreakdownLabel", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "values", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => LabelBreakdownValue }
         ]);
     }
-    create(value?: PartialMessage<Source>): Source {
+    create(value?: PartialMessage<LabelBreakdownLabel>): LabelBreakdownLabel {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.record = new Uint8Array(0);
-        message.source = "";
-        message.unit = "";
+        message.name = "";
+        message.values = [];
         if (value !== undefined)
-            reflectionMergePartial<Source>(this, message, value);
+            reflectionMergePartial<LabelBreakdownLabel>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: LabelBreakdownLabel): LabelBreakdownLabel {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* bytes record */ 1:
-                    message.record = reader.bytes();
-                    break;
-                case /* string source */ 2:
-                    message.source = reader.string();
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
-                case /* string unit */ 3:
-                    message.unit = reader.string();
+                case /* repeated parca.query.v1alpha1.LabelBreakdownValue values */ 2:
+                    message.values.push(LabelBreakdownValue.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3635,16 +5602,13 @@ class Source$Type extends MessageType<Source> {
         }
         return message;
     }
-    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* bytes record = 1; */
-        if (message.record.length)
-            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
-        /* string source = 2; */
-        if (message.source !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.source);
-        /* string unit = 3; */
-        if (message.unit !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.unit);
+    internalBinaryWrite(message: LabelBreakdownLabel, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* repeated parca.query.v1alpha1.LabelBreakdownValue values = 2; */
+        for (let i = 0; i < message.values.length; i++)
+            LabelBreakdownValue.internalBinaryWrite(message.values[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3652,40 +5616,40 @@ class Source$Type extends MessageType<Source> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Source
+ * @generated MessageType for protobuf message parca.query.v1alpha1.LabelBreakdownLabel
  */
-export const Source = new Source$Type();
+export const LabelBreakdownLabel = new LabelBreakdownLabel$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FlamegraphRootNode$Type extends MessageType<FlamegraphRootNode> {
+class LabelBreakdownValue$Type extends MessageType<LabelBreakdownValue> {
     constructor() {
-        super("parca.query.v1alpha1.FlamegraphRootNode", [
-            { no: 1, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 2, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 3, name: "children", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => FlamegraphNode }
+        super("parca.query.v1alpha1.LabelBreakdownValue", [
+            { no: 1, name: "value", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "percentage", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ }
         ]);
     }
-    create(value?: PartialMessage<FlamegraphRootNode>): FlamegraphRootNode {
+    create(value?: PartialMessage<LabelBreakdownValue>): LabelBreakdownValue {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.value = "";
         message.cumulative = 0n;
-        message.diff = 0n;
-        message.children = [];
+        message.percentage = 0;
         if (value !== undefined)
-            reflectionMergePartial<FlamegraphRootNode>(this, message, value);
+            reflectionMergePartial<LabelBreakdownValue>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphRootNode): FlamegraphRootNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: LabelBreakdownValue): LabelBreakdownValue {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* int64 cumulative */ 1:
-                    message.cumulative = reader.int64().toBigInt();
+                case /* string value */ 1:
+                    message.value = reader.string();
                     break;
-                case /* int64 diff */ 2:
-                    message.diff = reader.int64().toBigInt();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* repeated parca.query.v1alpha1.FlamegraphNode children */ 3:
-                    message.children.push(FlamegraphNode.internalBinaryRead(reader, reader.uint32(), options));
+                case /* double percentage */ 3:
+                    message.percentage = reader.double();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3698,16 +5662,16 @@ class FlamegraphRootNode$Type extends MessageType<FlamegraphRootNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: FlamegraphRootNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* int64 cumulative = 1; */
+    internalBinaryWrite(message: LabelBreakdownValue, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string value = 1; */
+        if (message.value !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.value);
+        /* int64 cumulative = 2; */
         if (message.cumulative !== 0n)
-            writer.tag(1, WireType.Varint).int64(message.cumulative);
-        /* int64 diff = 2; */
-        if (message.diff !== 0n)
-            writer.tag(2, WireType.Varint).int64(message.diff);
-        /* repeated parca.query.v1alpha1.FlamegraphNode children = 3; */
-        for (let i = 0; i < message.children.length; i++)
-            FlamegraphNode.internalBinaryWrite(message.children[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* double percentage = 3; */
+        if (message.percentage !== 0)
+            writer.tag(3, WireType.Bit64).double(message.percentage);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3715,44 +5679,52 @@ class FlamegraphRootNode$Type extends MessageType<FlamegraphRootNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphRootNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.LabelBreakdownValue
  */
-export const FlamegraphRootNode = new FlamegraphRootNode$Type();
+export const LabelBreakdownValue = new LabelBreakdownValue$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FlamegraphNode$Type extends MessageType<FlamegraphNode> {
+class Heatmap$Type extends MessageType<Heatmap> {
     constructor() {
-        super("parca.query.v1alpha1.FlamegraphNode", [
-            { no: 1, name: "meta", kind: "message", T: () => FlamegraphNodeMeta },
-            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 3, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 4, name: "children", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => FlamegraphNode }
+        super("parca.query.v1alpha1.Heatmap", [
+            { no: 1, name: "start", kind: "message", T: () => Timestamp },
+            { no: 2, name: "bucket_duration", kind: "message", T: () => Duration },
+            { no: 3, name: "totals", kind: "scalar", repeat: 1 /*RepeatType.PACKED*/, T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 4, name: "rows", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => HeatmapRow },
+            { no: 5, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<FlamegraphNode>): FlamegraphNode {
+    create(value?: PartialMessage<Heatmap>): Heatmap {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.cumulative = 0n;
-        message.diff = 0n;
-        message.children = [];
+        message.totals = [];
+        message.rows = [];
+        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<FlamegraphNode>(this, message, value);
+            reflectionMergePartial<Heatmap>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphNode): FlamegraphNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Heatmap): Heatmap {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.FlamegraphNodeMeta meta */ 1:
-                    message.meta = FlamegraphNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
+                case /* google.protobuf.Timestamp start */ 1:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
                     break;
-                case /* int64 cumulative */ 2:
-                    message.cumulative = reader.int64().toBigInt();
+                case /* google.protobuf.Duration bucket_duration */ 2:
+                    message.bucketDuration = Duration.internalBinaryRead(reader, reader.uint32(), options, message.bucketDuration);
                     break;
-                case /* int64 diff */ 3:
-                    message.diff = reader.int64().toBigInt();
+                case /* repeated int64 totals */ 3:
+                    if (wireType === WireType.LengthDelimited)
+                        for (let e = reader.int32() + reader.pos; reader.pos < e;)
+                            message.totals.push(reader.int64().toBigInt());
+                    else
+                        message.totals.push(reader.int64().toBigInt());
                     break;
-                case /* repeated parca.query.v1alpha1.FlamegraphNode children */ 4:
-                    message.children.push(FlamegraphNode.internalBinaryRead(reader, reader.uint32(), options));
+                case /* repeated parca.query.v1alpha1.HeatmapRow rows */ 4:
+                    message.rows.push(HeatmapRow.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string unit */ 5:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3765,19 +5737,26 @@ class FlamegraphNode$Type extends MessageType<FlamegraphNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: FlamegraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.FlamegraphNodeMeta meta = 1; */
-        if (message.meta)
-            FlamegraphNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 cumulative = 2; */
-        if (message.cumulative !== 0n)
-            writer.tag(2, WireType.Varint).int64(message.cumulative);
-        /* int64 diff = 3; */
-        if (message.diff !== 0n)
-            writer.tag(3, WireType.Varint).int64(message.diff);
-        /* repeated parca.query.v1alpha1.FlamegraphNode children = 4; */
-        for (let i = 0; i < message.children.length; i++)
-            FlamegraphNode.internalBinaryWrite(message.children[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Heatmap, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp start = 1; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Duration bucket_duration = 2; */
+        if (message.bucketDuration)
+            Duration.internalBinaryWrite(message.bucketDuration, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated int64 totals = 3; */
+        if (message.totals.length) {
+            writer.tag(3, WireType.LengthDelimited).fork();
+            for (let i = 0; i < message.totals.length; i++)
+                writer.int64(message.totals[i]);
+            writer.join();
+        }
+        /* repeated parca.query.v1alpha1.HeatmapRow rows = 4; */
+        for (let i = 0; i < message.rows.length; i++)
+            HeatmapRow.internalBinaryWrite(message.rows[i], writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* string unit = 5; */
+        if (message.unit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3785,51 +5764,44 @@ class FlamegraphNode$Type extends MessageType<FlamegraphNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Heatmap
  */
-export const FlamegraphNode = new FlamegraphNode$Type();
+export const Heatmap = new Heatmap$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
+class HeatmapRow$Type extends MessageType<HeatmapRow> {
     constructor() {
-        super("parca.query.v1alpha1.FlamegraphNodeMeta", [
-            { no: 1, name: "location", kind: "message", T: () => Location },
-            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
-            { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line },
-            { no: 5, name: "location_index", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
-            { no: 6, name: "line_index", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        super("parca.query.v1alpha1.HeatmapRow", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "values", kind: "scalar", repeat: 1 /*RepeatType.PACKED*/, T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<FlamegraphNodeMeta>): FlamegraphNodeMeta {
+    create(value?: PartialMessage<HeatmapRow>): HeatmapRow {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.locationIndex = 0;
-        message.lineIndex = 0;
+        message.name = "";
+        message.total = 0n;
+        message.values = [];
         if (value !== undefined)
-            reflectionMergePartial<FlamegraphNodeMeta>(this, message, value);
+            reflectionMergePartial<HeatmapRow>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: FlamegraphNodeMeta): FlamegraphNodeMeta {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: HeatmapRow): HeatmapRow {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.metastore.v1alpha1.Location location */ 1:
-                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
-                    break;
-                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
-                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
-                    break;
-                case /* parca.metastore.v1alpha1.Function function */ 3:
-                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
-                    break;
-                case /* parca.metastore.v1alpha1.Line line */ 4:
-                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
-                case /* uint32 location_index */ 5:
-                    message.locationIndex = reader.uint32();
+                case /* int64 total */ 2:
+                    message.total = reader.int64().toBigInt();
                     break;
-                case /* uint32 line_index */ 6:
-                    message.lineIndex = reader.uint32();
+                case /* repeated int64 values */ 3:
+                    if (wireType === WireType.LengthDelimited)
+                        for (let e = reader.int32() + reader.pos; reader.pos < e;)
+                            message.values.push(reader.int64().toBigInt());
+                    else
+                        message.values.push(reader.int64().toBigInt());
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3842,25 +5814,20 @@ class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
         }
         return message;
     }
-    internalBinaryWrite(message: FlamegraphNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.metastore.v1alpha1.Location location = 1; */
-        if (message.location)
-            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
-        if (message.mapping)
-            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Function function = 3; */
-        if (message.function)
-            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Line line = 4; */
-        if (message.line)
-            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* uint32 location_index = 5; */
-        if (message.locationIndex !== 0)
-            writer.tag(5, WireType.Varint).uint32(message.locationIndex);
-        /* uint32 line_index = 6; */
-        if (message.lineIndex !== 0)
-            writer.tag(6, WireType.Varint).uint32(message.lineIndex);
+    internalBinaryWrite(message: HeatmapRow, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* int64 total = 2; */
+        if (message.total !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.total);
+        /* repeated int64 values = 3; */
+        if (message.values.length) {
+            writer.tag(3, WireType.LengthDelimited).fork();
+            for (let i = 0; i < message.values.length; i++)
+                writer.int64(message.values[i]);
+            writer.join();
+        }
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3868,44 +5835,35 @@ class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
+ * @generated MessageType for protobuf message parca.query.v1alpha1.HeatmapRow
  */
-export const FlamegraphNodeMeta = new FlamegraphNodeMeta$Type();
+export const HeatmapRow = new HeatmapRow$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class CallgraphNode$Type extends MessageType<CallgraphNode> {
+class CallersCallees$Type extends MessageType<CallersCallees> {
     constructor() {
-        super("parca.query.v1alpha1.CallgraphNode", [
-            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "meta", kind: "message", T: () => CallgraphNodeMeta },
-            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 4, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        super("parca.query.v1alpha1.CallersCallees", [
+            { no: 1, name: "functions", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => CallersCalleesFunction },
+            { no: 2, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<CallgraphNode>): CallgraphNode {
+    create(value?: PartialMessage<CallersCallees>): CallersCallees {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.id = "";
-        message.cumulative = 0n;
-        message.flat = 0n;
+        message.functions = [];
+        message.unit = "";
         if (value !== undefined)
-            reflectionMergePartial<CallgraphNode>(this, message, value);
+            reflectionMergePartial<CallersCallees>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNode): CallgraphNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallersCallees): CallersCallees {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string id */ 1:
-                    message.id = reader.string();
-                    break;
-                case /* parca.query.v1alpha1.CallgraphNodeMeta meta */ 2:
-                    message.meta = CallgraphNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
-                    break;
-                case /* int64 cumulative */ 3:
-                    message.cumulative = reader.int64().toBigInt();
+                case /* repeated parca.query.v1alpha1.CallersCalleesFunction functions */ 1:
+                    message.functions.push(CallersCalleesFunction.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* int64 flat */ 4:
-                    message.flat = reader.int64().toBigInt();
+                case /* string unit */ 2:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3918,19 +5876,13 @@ class CallgraphNode$Type extends MessageType<CallgraphNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: CallgraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string id = 1; */
-        if (message.id !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.id);
-        /* parca.query.v1alpha1.CallgraphNodeMeta meta = 2; */
-        if (message.meta)
-            CallgraphNodeMeta.internalBinaryWrite(message.meta, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* int64 cumulative = 3; */
-        if (message.cumulative !== 0n)
-            writer.tag(3, WireType.Varint).int64(message.cumulative);
-        /* int64 flat = 4; */
-        if (message.flat !== 0n)
-            writer.tag(4, WireType.Varint).int64(message.flat);
+    internalBinaryWrite(message: CallersCallees, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.CallersCalleesFunction functions = 1; */
+        for (let i = 0; i < message.functions.length; i++)
+            CallersCalleesFunction.internalBinaryWrite(message.functions[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string unit = 2; */
+        if (message.unit !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -3938,41 +5890,39 @@ class CallgraphNode$Type extends MessageType<CallgraphNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallersCallees
  */
-export const CallgraphNode = new CallgraphNode$Type();
+export const CallersCallees = new CallersCallees$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class CallgraphNodeMeta$Type extends MessageType<CallgraphNodeMeta> {
+class CallersCalleesFunction$Type extends MessageType<CallersCalleesFunction> {
     constructor() {
-        super("parca.query.v1alpha1.CallgraphNodeMeta", [
-            { no: 1, name: "location", kind: "message", T: () => Location },
-            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
-            { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line }
+        super("parca.query.v1alpha1.CallersCalleesFunction", [
+            { no: 1, name: "function", kind: "message", T: () => CallersCalleesEntry },
+            { no: 2, name: "callers", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => CallersCalleesEntry },
+            { no: 3, name: "callees", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => CallersCalleesEntry }
         ]);
     }
-    create(value?: PartialMessage<CallgraphNodeMeta>): CallgraphNodeMeta {
+    create(value?: PartialMessage<CallersCalleesFunction>): CallersCalleesFunction {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.callers = [];
+        message.callees = [];
         if (value !== undefined)
-            reflectionMergePartial<CallgraphNodeMeta>(this, message, value);
+            reflectionMergePartial<CallersCalleesFunction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNodeMeta): CallgraphNodeMeta {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallersCalleesFunction): CallersCalleesFunction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.metastore.v1alpha1.Location location */ 1:
-                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
-                    break;
-                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
-                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
+                case /* parca.query.v1alpha1.CallersCalleesEntry function */ 1:
+                    message.function = CallersCalleesEntry.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* parca.metastore.v1alpha1.Function function */ 3:
-                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                case /* repeated parca.query.v1alpha1.CallersCalleesEntry callers */ 2:
+                    message.callers.push(CallersCalleesEntry.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* parca.metastore.v1alpha1.Line line */ 4:
-                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                case /* repeated parca.query.v1alpha1.CallersCalleesEntry callees */ 3:
+                    message.callees.push(CallersCalleesEntry.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -3985,19 +5935,16 @@ class CallgraphNodeMeta$Type extends MessageType<CallgraphNodeMeta> {
         }
         return message;
     }
-    internalBinaryWrite(message: CallgraphNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.metastore.v1alpha1.Location location = 1; */
-        if (message.location)
-            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
-        if (message.mapping)
-            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Function function = 3; */
+    internalBinaryWrite(message: CallersCalleesFunction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.CallersCalleesEntry function = 1; */
         if (message.function)
-            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Line line = 4; */
-        if (message.line)
-            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+            CallersCalleesEntry.internalBinaryWrite(message.function, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.CallersCalleesEntry callers = 2; */
+        for (let i = 0; i < message.callers.length; i++)
+            CallersCalleesEntry.internalBinaryWrite(message.callers[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.CallersCalleesEntry callees = 3; */
+        for (let i = 0; i < message.callees.length; i++)
+            CallersCalleesEntry.internalBinaryWrite(message.callees[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4005,50 +5952,60 @@ class CallgraphNodeMeta$Type extends MessageType<CallgraphNodeMeta> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNodeMeta
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallersCalleesFunction
  */
-export const CallgraphNodeMeta = new CallgraphNodeMeta$Type();
+export const CallersCalleesFunction = new CallersCalleesFunction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class CallgraphEdge$Type extends MessageType<CallgraphEdge> {
-    constructor() {
-        super("parca.query.v1alpha1.CallgraphEdge", [
-            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+class CallersCalleesEntry$Type extends MessageType<CallersCalleesEntry> {
+    constructor() {
+        super("parca.query.v1alpha1.CallersCalleesEntry", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
             { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
-            { no: 5, name: "is_collapsed", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+            { no: 5, name: "flat_percentage", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 6, name: "cumulative_percentage", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 7, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
     }
-    create(value?: PartialMessage<CallgraphEdge>): CallgraphEdge {
+    create(value?: PartialMessage<CallersCalleesEntry>): CallersCalleesEntry {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.id = "";
-        message.source = "";
-        message.target = "";
+        message.name = "";
+        message.filename = "";
+        message.flat = 0n;
         message.cumulative = 0n;
-        message.isCollapsed = false;
+        message.flatPercentage = 0;
+        message.cumulativePercentage = 0;
+        message.diff = 0n;
         if (value !== undefined)
-            reflectionMergePartial<CallgraphEdge>(this, message, value);
+            reflectionMergePartial<CallersCalleesEntry>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphEdge): CallgraphEdge {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallersCalleesEntry): CallersCalleesEntry {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string id */ 1:
-                    message.id = reader.string();
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
-                case /* string source */ 2:
-                    message.source = reader.string();
+                case /* string filename */ 2:
+                    message.filename = reader.string();
                     break;
-                case /* string target */ 3:
-                    message.target = reader.string();
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toBigInt();
                     break;
                 case /* int64 cumulative */ 4:
                     message.cumulative = reader.int64().toBigInt();
                     break;
-                case /* bool is_collapsed */ 5:
-                    message.isCollapsed = reader.bool();
+                case /* double flat_percentage */ 5:
+                    message.flatPercentage = reader.double();
+                    break;
+                case /* double cumulative_percentage */ 6:
+                    message.cumulativePercentage = reader.double();
+                    break;
+                case /* int64 diff */ 7:
+                    message.diff = reader.int64().toBigInt();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -4061,22 +6018,28 @@ class CallgraphEdge$Type extends MessageType<CallgraphEdge> {
         }
         return message;
     }
-    internalBinaryWrite(message: CallgraphEdge, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string id = 1; */
-        if (message.id !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.id);
-        /* string source = 2; */
-        if (message.source !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.source);
-        /* string target = 3; */
-        if (message.target !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.target);
+    internalBinaryWrite(message: CallersCalleesEntry, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string filename = 2; */
+        if (message.filename !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.filename);
+        /* int64 flat = 3; */
+        if (message.flat !== 0n)
+            writer.tag(3, WireType.Varint).int64(message.flat);
         /* int64 cumulative = 4; */
         if (message.cumulative !== 0n)
             writer.tag(4, WireType.Varint).int64(message.cumulative);
-        /* bool is_collapsed = 5; */
-        if (message.isCollapsed !== false)
-            writer.tag(5, WireType.Varint).bool(message.isCollapsed);
+        /* double flat_percentage = 5; */
+        if (message.flatPercentage !== 0)
+            writer.tag(5, WireType.Bit64).double(message.flatPercentage);
+        /* double cumulative_percentage = 6; */
+        if (message.cumulativePercentage !== 0)
+            writer.tag(6, WireType.Bit64).double(message.cumulativePercentage);
+        /* int64 diff = 7; */
+        if (message.diff !== 0n)
+            writer.tag(7, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4084,9 +6047,9 @@ class CallgraphEdge$Type extends MessageType<CallgraphEdge> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphEdge
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallersCalleesEntry
  */
-export const CallgraphEdge = new CallgraphEdge$Type();
+export const CallersCalleesEntry = new CallersCalleesEntry$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class Callgraph$Type extends MessageType<Callgraph> {
     constructor() {
@@ -4162,6 +6125,14 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
             { no: 12, name: "source", kind: "message", oneof: "report", T: () => Source },
             { no: 13, name: "table_arrow", kind: "message", oneof: "report", T: () => TableArrow },
             { no: 14, name: "profile_metadata", kind: "message", oneof: "report", T: () => ProfileMetadata },
+            { no: 15, name: "folded", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
+            { no: 16, name: "speedscope", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
+            { no: 17, name: "chrome_trace", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
+            { no: 18, name: "callgraph_dot", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
+            { no: 20, name: "callers_callees", kind: "message", oneof: "report", T: () => CallersCallees },
+            { no: 21, name: "disassembly", kind: "message", oneof: "report", T: () => Disassembly },
+            { no: 22, name: "label_breakdown", kind: "message", oneof: "report", T: () => LabelBreakdown },
+            { no: 23, name: "heatmap", kind: "message", oneof: "report", T: () => Heatmap },
             { no: 9, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
             { no: 10, name: "filtered", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
         ]);
@@ -4228,6 +6199,54 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
                         profileMetadata: ProfileMetadata.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).profileMetadata)
                     };
                     break;
+                case /* bytes folded */ 15:
+                    message.report = {
+                        oneofKind: "folded",
+                        folded: reader.bytes()
+                    };
+                    break;
+                case /* bytes speedscope */ 16:
+                    message.report = {
+                        oneofKind: "speedscope",
+                        speedscope: reader.bytes()
+                    };
+                    break;
+                case /* bytes chrome_trace */ 17:
+                    message.report = {
+                        oneofKind: "chromeTrace",
+                        chromeTrace: reader.bytes()
+                    };
+                    break;
+                case /* bytes callgraph_dot */ 18:
+                    message.report = {
+                        oneofKind: "callgraphDot",
+                        callgraphDot: reader.bytes()
+                    };
+                    break;
+                case /* parca.query.v1alpha1.CallersCallees callers_callees */ 20:
+                    message.report = {
+                        oneofKind: "callersCallees",
+                        callersCallees: CallersCallees.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).callersCallees)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Disassembly disassembly */ 21:
+                    message.report = {
+                        oneofKind: "disassembly",
+                        disassembly: Disassembly.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).disassembly)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.LabelBreakdown label_breakdown */ 22:
+                    message.report = {
+                        oneofKind: "labelBreakdown",
+                        labelBreakdown: LabelBreakdown.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).labelBreakdown)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Heatmap heatmap */ 23:
+                    message.report = {
+                        oneofKind: "heatmap",
+                        heatmap: Heatmap.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).heatmap)
+                    };
+                    break;
                 case /* int64 total */ 9:
                     message.total = reader.int64().toBigInt();
                     break;
@@ -4276,6 +6295,30 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         /* parca.query.v1alpha1.ProfileMetadata profile_metadata = 14; */
         if (message.report.oneofKind === "profileMetadata")
             ProfileMetadata.internalBinaryWrite(message.report.profileMetadata, writer.tag(14, WireType.LengthDelimited).fork(), options).join();
+        /* bytes folded = 15; */
+        if (message.report.oneofKind === "folded")
+            writer.tag(15, WireType.LengthDelimited).bytes(message.report.folded);
+        /* bytes speedscope = 16; */
+        if (message.report.oneofKind === "speedscope")
+            writer.tag(16, WireType.LengthDelimited).bytes(message.report.speedscope);
+        /* bytes chrome_trace = 17; */
+        if (message.report.oneofKind === "chromeTrace")
+            writer.tag(17, WireType.LengthDelimited).bytes(message.report.chromeTrace);
+        /* bytes callgraph_dot = 18; */
+        if (message.report.oneofKind === "callgraphDot")
+            writer.tag(18, WireType.LengthDelimited).bytes(message.report.callgraphDot);
+        /* parca.query.v1alpha1.CallersCallees callers_callees = 20; */
+        if (message.report.oneofKind === "callersCallees")
+            CallersCallees.internalBinaryWrite(message.report.callersCallees, writer.tag(20, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Disassembly disassembly = 21; */
+        if (message.report.oneofKind === "disassembly")
+            Disassembly.internalBinaryWrite(message.report.disassembly, writer.tag(21, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.LabelBreakdown label_breakdown = 22; */
+        if (message.report.oneofKind === "labelBreakdown")
+            LabelBreakdown.internalBinaryWrite(message.report.labelBreakdown, writer.tag(22, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Heatmap heatmap = 23; */
+        if (message.report.oneofKind === "heatmap")
+            Heatmap.internalBinaryWrite(message.report.heatmap, writer.tag(23, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4350,10 +6393,13 @@ export const SeriesRequest = new SeriesRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class SeriesResponse$Type extends MessageType<SeriesResponse> {
     constructor() {
-        super("parca.query.v1alpha1.SeriesResponse", []);
+        super("parca.query.v1alpha1.SeriesResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Series }
+        ]);
     }
     create(value?: PartialMessage<SeriesResponse>): SeriesResponse {
         const message = globalThis.Object.create((this.messagePrototype!));
+        message.series = [];
         if (value !== undefined)
             reflectionMergePartial<SeriesResponse>(this, message, value);
         return message;
@@ -4363,6 +6409,9 @@ class SeriesResponse$Type extends MessageType<SeriesResponse> {
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.Series series */ 1:
+                    message.series.push(Series.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -4375,6 +6424,9 @@ class SeriesResponse$Type extends MessageType<SeriesResponse> {
         return message;
     }
     internalBinaryWrite(message: SeriesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.Series series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            Series.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -4386,6 +6438,73 @@ class SeriesResponse$Type extends MessageType<SeriesResponse> {
  */
 export const SeriesResponse = new SeriesResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class Series$Type extends MessageType<Series> {
+    constructor() {
+        super("parca.query.v1alpha1.Series", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "profile_type", kind: "message", T: () => ProfileType },
+            { no: 3, name: "first_seen", kind: "message", T: () => Timestamp },
+            { no: 4, name: "last_seen", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<Series>): Series {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<Series>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Series): Series {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* parca.query.v1alpha1.ProfileType profile_type */ 2:
+                    message.profileType = ProfileType.internalBinaryRead(reader, reader.uint32(), options, message.profileType);
+                    break;
+                case /* google.protobuf.Timestamp first_seen */ 3:
+                    message.firstSeen = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.firstSeen);
+                    break;
+                case /* google.protobuf.Timestamp last_seen */ 4:
+                    message.lastSeen = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.lastSeen);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Series, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileType profile_type = 2; */
+        if (message.profileType)
+            ProfileType.internalBinaryWrite(message.profileType, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp first_seen = 3; */
+        if (message.firstSeen)
+            Timestamp.internalBinaryWrite(message.firstSeen, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp last_seen = 4; */
+        if (message.lastSeen)
+            Timestamp.internalBinaryWrite(message.lastSeen, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Series
+ */
+export const Series = new Series$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class LabelsRequest$Type extends MessageType<LabelsRequest> {
     constructor() {
         super("parca.query.v1alpha1.LabelsRequest", [
@@ -4989,6 +7108,278 @@ class HasProfileDataResponse$Type extends MessageType<HasProfileDataResponse> {
  * @generated MessageType for protobuf message parca.query.v1alpha1.HasProfileDataResponse
  */
 export const HasProfileDataResponse = new HasProfileDataResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TopRegressionsRequest$Type extends MessageType<TopRegressionsRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.TopRegressionsRequest", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "baseline_start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "baseline_end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "candidate_start", kind: "message", T: () => Timestamp },
+            { no: 5, name: "candidate_end", kind: "message", T: () => Timestamp },
+            { no: 6, name: "min_significance", kind: "scalar", opt: true, T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 7, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TopRegressionsRequest>): TopRegressionsRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.query = "";
+        message.limit = 0;
+        if (value !== undefined)
+            reflectionMergePartial<TopRegressionsRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopRegressionsRequest): TopRegressionsRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp baseline_start */ 2:
+                    message.baselineStart = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.baselineStart);
+                    break;
+                case /* google.protobuf.Timestamp baseline_end */ 3:
+                    message.baselineEnd = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.baselineEnd);
+                    break;
+                case /* google.protobuf.Timestamp candidate_start */ 4:
+                    message.candidateStart = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.candidateStart);
+                    break;
+                case /* google.protobuf.Timestamp candidate_end */ 5:
+                    message.candidateEnd = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.candidateEnd);
+                    break;
+                case /* optional double min_significance */ 6:
+                    message.minSignificance = reader.double();
+                    break;
+                case /* uint32 limit */ 7:
+                    message.limit = reader.uint32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TopRegressionsRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp baseline_start = 2; */
+        if (message.baselineStart)
+            Timestamp.internalBinaryWrite(message.baselineStart, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp baseline_end = 3; */
+        if (message.baselineEnd)
+            Timestamp.internalBinaryWrite(message.baselineEnd, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp candidate_start = 4; */
+        if (message.candidateStart)
+            Timestamp.internalBinaryWrite(message.candidateStart, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp candidate_end = 5; */
+        if (message.candidateEnd)
+            Timestamp.internalBinaryWrite(message.candidateEnd, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* optional double min_significance = 6; */
+        if (message.minSignificance !== undefined)
+            writer.tag(6, WireType.Bit64).double(message.minSignificance);
+        /* uint32 limit = 7; */
+        if (message.limit !== 0)
+            writer.tag(7, WireType.Varint).uint32(message.limit);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopRegressionsRequest
+ */
+export const TopRegressionsRequest = new TopRegressionsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TopRegressionsResponse$Type extends MessageType<TopRegressionsResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.TopRegressionsResponse", [
+            { no: 1, name: "regressions", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TopRegression },
+            { no: 2, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "baseline_total_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 4, name: "candidate_total_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TopRegressionsResponse>): TopRegressionsResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.regressions = [];
+        message.unit = "";
+        message.baselineTotalPerSecond = 0;
+        message.candidateTotalPerSecond = 0;
+        if (value !== undefined)
+            reflectionMergePartial<TopRegressionsResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopRegressionsResponse): TopRegressionsResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.TopRegression regressions */ 1:
+                    message.regressions.push(TopRegression.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string unit */ 2:
+                    message.unit = reader.string();
+                    break;
+                case /* double baseline_total_per_second */ 3:
+                    message.baselineTotalPerSecond = reader.double();
+                    break;
+                case /* double candidate_total_per_second */ 4:
+                    message.candidateTotalPerSecond = reader.double();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TopRegressionsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.TopRegression regressions = 1; */
+        for (let i = 0; i < message.regressions.length; i++)
+            TopRegression.internalBinaryWrite(message.regressions[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string unit = 2; */
+        if (message.unit !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.unit);
+        /* double baseline_total_per_second = 3; */
+        if (message.baselineTotalPerSecond !== 0)
+            writer.tag(3, WireType.Bit64).double(message.baselineTotalPerSecond);
+        /* double candidate_total_per_second = 4; */
+        if (message.candidateTotalPerSecond !== 0)
+            writer.tag(4, WireType.Bit64).double(message.candidateTotalPerSecond);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopRegressionsResponse
+ */
+export const TopRegressionsResponse = new TopRegressionsResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TopRegression$Type extends MessageType<TopRegression> {
+    constructor() {
+        super("parca.query.v1alpha1.TopRegression", [
+            { no: 1, name: "function_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "function_file_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "baseline_flat_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 4, name: "baseline_cumulative_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 5, name: "candidate_flat_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 6, name: "candidate_cumulative_per_second", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 7, name: "flat_share_change", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 8, name: "cumulative_share_change", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ },
+            { no: 9, name: "score", kind: "scalar", T: 1 /*ScalarType.DOUBLE*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TopRegression>): TopRegression {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.functionName = "";
+        message.functionFileName = "";
+        message.baselineFlatPerSecond = 0;
+        message.baselineCumulativePerSecond = 0;
+        message.candidateFlatPerSecond = 0;
+        message.candidateCumulativePerSecond = 0;
+        message.flatShareChange = 0;
+        message.cumulativeShareChange = 0;
+        message.score = 0;
+        if (value !== undefined)
+            reflectionMergePartial<TopRegression>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopRegression): TopRegression {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string function_name */ 1:
+                    message.functionName = reader.string();
+                    break;
+                case /* string function_file_name */ 2:
+                    message.functionFileName = reader.string();
+                    break;
+                case /* double baseline_flat_per_second */ 3:
+                    message.baselineFlatPerSecond = reader.double();
+                    break;
+                case /* double baseline_cumulative_per_second */ 4:
+                    message.baselineCumulativePerSecond = reader.double();
+                    break;
+                case /* double candidate_flat_per_second */ 5:
+                    message.candidateFlatPerSecond = reader.double();
+                    break;
+                case /* double candidate_cumulative_per_second */ 6:
+                    message.candidateCumulativePerSecond = reader.double();
+                    break;
+                case /* double flat_share_change */ 7:
+                    message.flatShareChange = reader.double();
+                    break;
+                case /* double cumulative_share_change */ 8:
+                    message.cumulativeShareChange = reader.double();
+                    break;
+                case /* double score */ 9:
+                    message.score = reader.double();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TopRegression, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string function_name = 1; */
+        if (message.functionName !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.functionName);
+        /* string function_file_name = 2; */
+        if (message.functionFileName !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.functionFileName);
+        /* double baseline_flat_per_second = 3; */
+        if (message.baselineFlatPerSecond !== 0)
+            writer.tag(3, WireType.Bit64).double(message.baselineFlatPerSecond);
+        /* double baseline_cumulative_per_second = 4; */
+        if (message.baselineCumulativePerSecond !== 0)
+            writer.tag(4, WireType.Bit64).double(message.baselineCumulativePerSecond);
+        /* double candidate_flat_per_second = 5; */
+        if (message.candidateFlatPerSecond !== 0)
+            writer.tag(5, WireType.Bit64).double(message.candidateFlatPerSecond);
+        /* double candidate_cumulative_per_second = 6; */
+        if (message.candidateCumulativePerSecond !== 0)
+            writer.tag(6, WireType.Bit64).double(message.candidateCumulativePerSecond);
+        /* double flat_share_change = 7; */
+        if (message.flatShareChange !== 0)
+            writer.tag(7, WireType.Bit64).double(message.flatShareChange);
+        /* double cumulative_share_change = 8; */
+        if (message.cumulativeShareChange !== 0)
+            writer.tag(8, WireType.Bit64).double(message.cumulativeShareChange);
+        /* double score = 9; */
+        if (message.score !== 0)
+            writer.tag(9, WireType.Bit64).double(message.score);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopRegression
+ */
+export const TopRegression = new TopRegression$Type();
 /**
  * @generated ServiceType for protobuf service parca.query.v1alpha1.QueryService
  */
@@ -5000,5 +7391,6 @@ export const QueryService = new ServiceType("parca.query.v1alpha1.QueryService",
     { name: "Labels", options: { "google.api.http": { get: "/profiles/labels" } }, I: LabelsRequest, O: LabelsResponse },
     { name: "Values", options: { "google.api.http": { get: "/profiles/labels/{label_name}/values" } }, I: ValuesRequest, O: ValuesResponse },
     { name: "ShareProfile", options: { "google.api.http": { post: "/profiles/share", body: "*" } }, I: ShareProfileRequest, O: ShareProfileResponse },
-    { name: "HasProfileData", options: { "google.api.http": { get: "/profiles/has_profile_data" } }, I: HasProfileDataRequest, O: HasProfileDataResponse }
+    { name: "HasProfileData", options: { "google.api.http": { get: "/profiles/has_profile_data" } }, I: HasProfileDataRequest, O: HasProfileDataResponse },
+    { name: "TopRegressions", options: { "google.api.http": { get: "/profiles/top_regressions" } }, I: TopRegressionsRequest, O: TopRegressionsResponse }
 ]);
@@ -0,0 +1,113 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/view/v1alpha1/view.proto" (package "parca.view.v1alpha1", syntax proto3)
+// tslint:disable
+import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
+import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
+import { ViewService } from "./view";
+import type { DeleteViewResponse } from "./view";
+import type { DeleteViewRequest } from "./view";
+import type { UpdateViewResponse } from "./view";
+import type { UpdateViewRequest } from "./view";
+import type { ListViewsResponse } from "./view";
+import type { ListViewsRequest } from "./view";
+import type { GetViewResponse } from "./view";
+import type { GetViewRequest } from "./view";
+import { stackIntercept } from "@protobuf-ts/runtime-rpc";
+import type { CreateViewResponse } from "./view";
+import type { CreateViewRequest } from "./view";
+import type { UnaryCall } from "@protobuf-ts/runtime-rpc";
+import type { RpcOptions } from "@protobuf-ts/runtime-rpc";
+/**
+ * ViewService stores named queries, so they can be linked to by their id
+ *
+ * @generated from protobuf service parca.view.v1alpha1.ViewService
+ */
+export interface IViewServiceClient {
+    /**
+     * CreateView stores a new view
+     *
+     * @generated from protobuf rpc: CreateView
+     */
+    createView(input: CreateViewRequest, options?: RpcOptions): UnaryCall<CreateViewRequest, CreateViewResponse>;
+    /**
+     * GetView returns a view and its query request
+     *
+     * @generated from protobuf rpc: GetView
+     */
+    getView(input: GetViewRequest, options?: RpcOptions): UnaryCall<GetViewRequest, GetViewResponse>;
+    /**
+     * ListViews returns all views
+     *
+     * @generated from protobuf rpc: ListViews
+     */
+    listViews(input: ListViewsRequest, options?: RpcOptions): UnaryCall<ListViewsRequest, ListViewsResponse>;
+    /**
+     * UpdateView replaces the name, description and query of a view
+     *
+     * @generated from protobuf rpc: UpdateView
+     */
+    updateView(input: UpdateViewRequest, options?: RpcOptions): UnaryCall<UpdateViewRequest, UpdateViewResponse>;
+    /**
+     * DeleteView deletes a view
+     *
+     * @generated from protobuf rpc: DeleteView
+     */
+    deleteView(input: DeleteViewRequest, options?: RpcOptions): UnaryCall<DeleteViewRequest, DeleteViewResponse>;
+}
+/**
+ * ViewService stores named queries, so they can be linked to by their id
+ *
+ * @generated from protobuf service parca.view.v1alpha1.ViewService
+ */
+export class ViewServiceClient implements IViewServiceClient, ServiceInfo {
+    typeName = ViewService.typeName;
+    methods = ViewService.methods;
+    options = ViewService.options;
+    constructor(private readonly _transport: RpcTransport) {
+    }
+    /**
+     * CreateView stores a new view
+     *
+     * @generated from protobuf rpc: CreateView
+     */
+    createView(input: CreateViewRequest, options?: RpcOptions): UnaryCall<CreateViewRequest, CreateViewResponse> {
+        const method = this.methods[0], opt = this._transport.mergeOptions(options);
+        return stackIntercept<CreateViewRequest, CreateViewResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * GetView returns a view and its query request
+     *
+     * @generated from protobuf rpc: GetView
+     */
+    getView(input: GetViewRequest, options?: RpcOptions): UnaryCall<GetViewRequest, GetViewResponse> {
+        const method = this.methods[1], opt = this._transport.mergeOptions(options);
+        return stackIntercept<GetViewRequest, GetViewResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * ListViews returns all views
+     *
+     * @generated from protobuf rpc: ListViews
+     */
+    listViews(input: ListViewsRequest, options?: RpcOptions): UnaryCall<ListViewsRequest, ListViewsResponse> {
+        const method = this.methods[2], opt = this._transport.mergeOptions(options);
+        return stackIntercept<ListViewsRequest, ListViewsResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * UpdateView replaces the name, description and query of a view
+     *
+     * @generated from protobuf rpc: UpdateView
+     */
+    updateView(input: UpdateViewRequest, options?: RpcOptions): UnaryCall<UpdateViewRequest, UpdateViewResponse> {
+        const method = this.methods[3], opt = this._transport.mergeOptions(options);
+        return stackIntercept<UpdateViewRequest, UpdateViewResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * DeleteView deletes a view
+     *
+     * @generated from protobuf rpc: DeleteView
+     */
+    deleteView(input: DeleteViewRequest, options?: RpcOptions): UnaryCall<DeleteViewRequest, DeleteViewResponse> {
+        const method = this.methods[4], opt = this._transport.mergeOptions(options);
+        return stackIntercept<DeleteViewRequest, DeleteViewResponse>("unary", this._transport, method, opt, input);
+    }
+}
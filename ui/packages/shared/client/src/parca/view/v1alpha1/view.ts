@@ -0,0 +1,1052 @@
+// @generated by protobuf-ts 2.11.1 with parameter generate_dependencies
+// @generated from protobuf file "parca/view/v1alpha1/view.proto" (package "parca.view.v1alpha1", syntax proto3)
+// tslint:disable
+import { ServiceType } from "@protobuf-ts/runtime-rpc";
+import type { BinaryWriteOptions } from "@protobuf-ts/runtime";
+import type { IBinaryWriter } from "@protobuf-ts/runtime";
+import { WireType } from "@protobuf-ts/runtime";
+import type { BinaryReadOptions } from "@protobuf-ts/runtime";
+import type { IBinaryReader } from "@protobuf-ts/runtime";
+import { UnknownFieldHandler } from "@protobuf-ts/runtime";
+import type { PartialMessage } from "@protobuf-ts/runtime";
+import { reflectionMergePartial } from "@protobuf-ts/runtime";
+import { MessageType } from "@protobuf-ts/runtime";
+import { QueryRequest } from "../../query/v1alpha1/query";
+import { Duration } from "../../../google/protobuf/duration";
+import { QueryRequest_ReportType } from "../../query/v1alpha1/query";
+import { GroupBy } from "../../query/v1alpha1/query";
+import { Filter } from "../../query/v1alpha1/query";
+import { Timestamp } from "../../../google/protobuf/timestamp";
+/**
+ * View is a named query
+ *
+ * @generated from protobuf message parca.view.v1alpha1.View
+ */
+export interface View {
+    /**
+     * id is the id of the view, it is assigned on creation
+     *
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+    /**
+     * name is the name of the view
+     *
+     * @generated from protobuf field: string name = 2
+     */
+    name: string;
+    /**
+     * description is the description of the view
+     *
+     * @generated from protobuf field: string description = 3
+     */
+    description: string;
+    /**
+     * query is the query of the view
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.ViewQuery query = 4
+     */
+    query?: ViewQuery;
+    /**
+     * created_at is the time the view was created
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp created_at = 5
+     */
+    createdAt?: Timestamp;
+    /**
+     * updated_at is the time the view was last updated
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp updated_at = 6
+     */
+    updatedAt?: Timestamp;
+}
+/**
+ * ViewQuery is the query of a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.ViewQuery
+ */
+export interface ViewQuery {
+    /**
+     * selector is the query string to match profiles against
+     *
+     * @generated from protobuf field: string selector = 1
+     */
+    selector: string;
+    /**
+     * time_range is the time range of the query
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.TimeRange time_range = 2
+     */
+    timeRange?: TimeRange;
+    /**
+     * filter is a varying set of filter to apply to the query
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Filter filter = 3
+     */
+    filter: Filter[];
+    /**
+     * group_by indicates the fields to group by
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.GroupBy group_by = 4
+     */
+    groupBy?: GroupBy;
+    /**
+     * report_type is the type of report to return
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest.ReportType report_type = 5
+     */
+    reportType: QueryRequest_ReportType;
+    /**
+     * sandwich_by_function is a function name to use for sandwich view functionality
+     *
+     * @generated from protobuf field: optional string sandwich_by_function = 6
+     */
+    sandwichByFunction?: string;
+}
+/**
+ * TimeRange is an absolute time range or a range relative to the time the view is read
+ *
+ * @generated from protobuf message parca.view.v1alpha1.TimeRange
+ */
+export interface TimeRange {
+    /**
+     * range is the time range
+     *
+     * @generated from protobuf oneof: range
+     */
+    range: {
+        oneofKind: "absolute";
+        /**
+         * absolute is a fixed time range
+         *
+         * @generated from protobuf field: parca.view.v1alpha1.AbsoluteTimeRange absolute = 1
+         */
+        absolute: AbsoluteTimeRange;
+    } | {
+        oneofKind: "relative";
+        /**
+         * relative is the duration up to the time the view is read, for example the last hour
+         *
+         * @generated from protobuf field: google.protobuf.Duration relative = 2
+         */
+        relative: Duration;
+    } | {
+        oneofKind: undefined;
+    };
+}
+/**
+ * AbsoluteTimeRange is a fixed time range
+ *
+ * @generated from protobuf message parca.view.v1alpha1.AbsoluteTimeRange
+ */
+export interface AbsoluteTimeRange {
+    /**
+     * start is the beginning of the time range
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 1
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time range
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 2
+     */
+    end?: Timestamp;
+}
+/**
+ * CreateViewRequest is the request to create a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.CreateViewRequest
+ */
+export interface CreateViewRequest {
+    /**
+     * name is the name of the view
+     *
+     * @generated from protobuf field: string name = 1
+     */
+    name: string;
+    /**
+     * description is the description of the view
+     *
+     * @generated from protobuf field: string description = 2
+     */
+    description: string;
+    /**
+     * query is the query of the view
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.ViewQuery query = 3
+     */
+    query?: ViewQuery;
+}
+/**
+ * CreateViewResponse is the response to creating a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.CreateViewResponse
+ */
+export interface CreateViewResponse {
+    /**
+     * view is the created view
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.View view = 1
+     */
+    view?: View;
+}
+/**
+ * GetViewRequest is the request for a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.GetViewRequest
+ */
+export interface GetViewRequest {
+    /**
+     * id is the id of the view
+     *
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+}
+/**
+ * GetViewResponse contains a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.GetViewResponse
+ */
+export interface GetViewResponse {
+    /**
+     * view is the requested view
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.View view = 1
+     */
+    view?: View;
+    /**
+     * query_request is the merge query request of the view, relative time ranges are resolved at the time of the request
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest query_request = 2
+     */
+    queryRequest?: QueryRequest;
+}
+/**
+ * ListViewsRequest is the request for all views
+ *
+ * @generated from protobuf message parca.view.v1alpha1.ListViewsRequest
+ */
+export interface ListViewsRequest {
+}
+/**
+ * ListViewsResponse contains all views
+ *
+ * @generated from protobuf message parca.view.v1alpha1.ListViewsResponse
+ */
+export interface ListViewsResponse {
+    /**
+     * views are the views sorted by name
+     *
+     * @generated from protobuf field: repeated parca.view.v1alpha1.View views = 1
+     */
+    views: View[];
+}
+/**
+ * UpdateViewRequest is the request to update a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.UpdateViewRequest
+ */
+export interface UpdateViewRequest {
+    /**
+     * view is the updated view, the timestamps are ignored
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.View view = 1
+     */
+    view?: View;
+}
+/**
+ * UpdateViewResponse is the response to updating a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.UpdateViewResponse
+ */
+export interface UpdateViewResponse {
+    /**
+     * view is the updated view
+     *
+     * @generated from protobuf field: parca.view.v1alpha1.View view = 1
+     */
+    view?: View;
+}
+/**
+ * DeleteViewRequest is the request to delete a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.DeleteViewRequest
+ */
+export interface DeleteViewRequest {
+    /**
+     * id is the id of the view
+     *
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+}
+/**
+ * DeleteViewResponse is the response to deleting a view
+ *
+ * @generated from protobuf message parca.view.v1alpha1.DeleteViewResponse
+ */
+export interface DeleteViewResponse {
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class View$Type extends MessageType<View> {
+    constructor() {
+        super("parca.view.v1alpha1.View", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "query", kind: "message", T: () => ViewQuery },
+            { no: 5, name: "created_at", kind: "message", T: () => Timestamp },
+            { no: 6, name: "updated_at", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<View>): View {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.name = "";
+        message.description = "";
+        if (value !== undefined)
+            reflectionMergePartial<View>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: View): View {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* string name */ 2:
+                    message.name = reader.string();
+                    break;
+                case /* string description */ 3:
+                    message.description = reader.string();
+                    break;
+                case /* parca.view.v1alpha1.ViewQuery query */ 4:
+                    message.query = ViewQuery.internalBinaryRead(reader, reader.uint32(), options, message.query);
+                    break;
+                case /* google.protobuf.Timestamp created_at */ 5:
+                    message.createdAt = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.createdAt);
+                    break;
+                case /* google.protobuf.Timestamp updated_at */ 6:
+                    message.updatedAt = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.updatedAt);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: View, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string name = 2; */
+        if (message.name !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.name);
+        /* string description = 3; */
+        if (message.description !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.description);
+        /* parca.view.v1alpha1.ViewQuery query = 4; */
+        if (message.query)
+            ViewQuery.internalBinaryWrite(message.query, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp created_at = 5; */
+        if (message.createdAt)
+            Timestamp.internalBinaryWrite(message.createdAt, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp updated_at = 6; */
+        if (message.updatedAt)
+            Timestamp.internalBinaryWrite(message.updatedAt, writer.tag(6, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.View
+ */
+export const View = new View$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ViewQuery$Type extends MessageType<ViewQuery> {
+    constructor() {
+        super("parca.view.v1alpha1.ViewQuery", [
+            { no: 1, name: "selector", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "time_range", kind: "message", T: () => TimeRange },
+            { no: 3, name: "filter", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Filter },
+            { no: 4, name: "group_by", kind: "message", T: () => GroupBy },
+            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] },
+            { no: 6, name: "sandwich_by_function", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ViewQuery>): ViewQuery {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.selector = "";
+        message.filter = [];
+        message.reportType = 0;
+        if (value !== undefined)
+            reflectionMergePartial<ViewQuery>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ViewQuery): ViewQuery {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string selector */ 1:
+                    message.selector = reader.string();
+                    break;
+                case /* parca.view.v1alpha1.TimeRange time_range */ 2:
+                    message.timeRange = TimeRange.internalBinaryRead(reader, reader.uint32(), options, message.timeRange);
+                    break;
+                case /* repeated parca.query.v1alpha1.Filter filter */ 3:
+                    message.filter.push(Filter.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* parca.query.v1alpha1.GroupBy group_by */ 4:
+                    message.groupBy = GroupBy.internalBinaryRead(reader, reader.uint32(), options, message.groupBy);
+                    break;
+                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
+                    message.reportType = reader.int32();
+                    break;
+                case /* optional string sandwich_by_function */ 6:
+                    message.sandwichByFunction = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ViewQuery, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string selector = 1; */
+        if (message.selector !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.selector);
+        /* parca.view.v1alpha1.TimeRange time_range = 2; */
+        if (message.timeRange)
+            TimeRange.internalBinaryWrite(message.timeRange, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.Filter filter = 3; */
+        for (let i = 0; i < message.filter.length; i++)
+            Filter.internalBinaryWrite(message.filter[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.GroupBy group_by = 4; */
+        if (message.groupBy)
+            GroupBy.internalBinaryWrite(message.groupBy, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
+        if (message.reportType !== 0)
+            writer.tag(5, WireType.Varint).int32(message.reportType);
+        /* optional string sandwich_by_function = 6; */
+        if (message.sandwichByFunction !== undefined)
+            writer.tag(6, WireType.LengthDelimited).string(message.sandwichByFunction);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.ViewQuery
+ */
+export const ViewQuery = new ViewQuery$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TimeRange$Type extends MessageType<TimeRange> {
+    constructor() {
+        super("parca.view.v1alpha1.TimeRange", [
+            { no: 1, name: "absolute", kind: "message", oneof: "range", T: () => AbsoluteTimeRange },
+            { no: 2, name: "relative", kind: "message", oneof: "range", T: () => Duration }
+        ]);
+    }
+    create(value?: PartialMessage<TimeRange>): TimeRange {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.range = { oneofKind: undefined };
+        if (value !== undefined)
+            reflectionMergePartial<TimeRange>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TimeRange): TimeRange {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.view.v1alpha1.AbsoluteTimeRange absolute */ 1:
+                    message.range = {
+                        oneofKind: "absolute",
+                        absolute: AbsoluteTimeRange.internalBinaryRead(reader, reader.uint32(), options, (message.range as any).absolute)
+                    };
+                    break;
+                case /* google.protobuf.Duration relative */ 2:
+                    message.range = {
+                        oneofKind: "relative",
+                        relative: Duration.internalBinaryRead(reader, reader.uint32(), options, (message.range as any).relative)
+                    };
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TimeRange, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.view.v1alpha1.AbsoluteTimeRange absolute = 1; */
+        if (message.range.oneofKind === "absolute")
+            AbsoluteTimeRange.internalBinaryWrite(message.range.absolute, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Duration relative = 2; */
+        if (message.range.oneofKind === "relative")
+            Duration.internalBinaryWrite(message.range.relative, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.TimeRange
+ */
+export const TimeRange = new TimeRange$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class AbsoluteTimeRange$Type extends MessageType<AbsoluteTimeRange> {
+    constructor() {
+        super("parca.view.v1alpha1.AbsoluteTimeRange", [
+            { no: 1, name: "start", kind: "message", T: () => Timestamp },
+            { no: 2, name: "end", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<AbsoluteTimeRange>): AbsoluteTimeRange {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<AbsoluteTimeRange>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: AbsoluteTimeRange): AbsoluteTimeRange {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp start */ 1:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 2:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: AbsoluteTimeRange, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp start = 1; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 2; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.AbsoluteTimeRange
+ */
+export const AbsoluteTimeRange = new AbsoluteTimeRange$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CreateViewRequest$Type extends MessageType<CreateViewRequest> {
+    constructor() {
+        super("parca.view.v1alpha1.CreateViewRequest", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "description", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "query", kind: "message", T: () => ViewQuery }
+        ]);
+    }
+    create(value?: PartialMessage<CreateViewRequest>): CreateViewRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.name = "";
+        message.description = "";
+        if (value !== undefined)
+            reflectionMergePartial<CreateViewRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CreateViewRequest): CreateViewRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string description */ 2:
+                    message.description = reader.string();
+                    break;
+                case /* parca.view.v1alpha1.ViewQuery query */ 3:
+                    message.query = ViewQuery.internalBinaryRead(reader, reader.uint32(), options, message.query);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: CreateViewRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string description = 2; */
+        if (message.description !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.description);
+        /* parca.view.v1alpha1.ViewQuery query = 3; */
+        if (message.query)
+            ViewQuery.internalBinaryWrite(message.query, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.CreateViewRequest
+ */
+export const CreateViewRequest = new CreateViewRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CreateViewResponse$Type extends MessageType<CreateViewResponse> {
+    constructor() {
+        super("parca.view.v1alpha1.CreateViewResponse", [
+            { no: 1, name: "view", kind: "message", T: () => View }
+        ]);
+    }
+    create(value?: PartialMessage<CreateViewResponse>): CreateViewResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<CreateViewResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CreateViewResponse): CreateViewResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.view.v1alpha1.View view */ 1:
+                    message.view = View.internalBinaryRead(reader, reader.uint32(), options, message.view);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: CreateViewResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.view.v1alpha1.View view = 1; */
+        if (message.view)
+            View.internalBinaryWrite(message.view, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.CreateViewResponse
+ */
+export const CreateViewResponse = new CreateViewResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GetViewRequest$Type extends MessageType<GetViewRequest> {
+    constructor() {
+        super("parca.view.v1alpha1.GetViewRequest", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<GetViewRequest>): GetViewRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        if (value !== undefined)
+            reflectionMergePartial<GetViewRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetViewRequest): GetViewRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GetViewRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.GetViewRequest
+ */
+export const GetViewRequest = new GetViewRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class GetViewResponse$Type extends MessageType<GetViewResponse> {
+    constructor() {
+        super("parca.view.v1alpha1.GetViewResponse", [
+            { no: 1, name: "view", kind: "message", T: () => View },
+            { no: 2, name: "query_request", kind: "message", T: () => QueryRequest }
+        ]);
+    }
+    create(value?: PartialMessage<GetViewResponse>): GetViewResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<GetViewResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: GetViewResponse): GetViewResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.view.v1alpha1.View view */ 1:
+                    message.view = View.internalBinaryRead(reader, reader.uint32(), options, message.view);
+                    break;
+                case /* parca.query.v1alpha1.QueryRequest query_request */ 2:
+                    message.queryRequest = QueryRequest.internalBinaryRead(reader, reader.uint32(), options, message.queryRequest);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: GetViewResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.view.v1alpha1.View view = 1; */
+        if (message.view)
+            View.internalBinaryWrite(message.view, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRequest query_request = 2; */
+        if (message.queryRequest)
+            QueryRequest.internalBinaryWrite(message.queryRequest, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.GetViewResponse
+ */
+export const GetViewResponse = new GetViewResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ListViewsRequest$Type extends MessageType<ListViewsRequest> {
+    constructor() {
+        super("parca.view.v1alpha1.ListViewsRequest", []);
+    }
+    create(value?: PartialMessage<ListViewsRequest>): ListViewsRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<ListViewsRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListViewsRequest): ListViewsRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ListViewsRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.ListViewsRequest
+ */
+export const ListViewsRequest = new ListViewsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ListViewsResponse$Type extends MessageType<ListViewsResponse> {
+    constructor() {
+        super("parca.view.v1alpha1.ListViewsResponse", [
+            { no: 1, name: "views", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => View }
+        ]);
+    }
+    create(value?: PartialMessage<ListViewsResponse>): ListViewsResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.views = [];
+        if (value !== undefined)
+            reflectionMergePartial<ListViewsResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListViewsResponse): ListViewsResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.view.v1alpha1.View views */ 1:
+                    message.views.push(View.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ListViewsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.view.v1alpha1.View views = 1; */
+        for (let i = 0; i < message.views.length; i++)
+            View.internalBinaryWrite(message.views[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.ListViewsResponse
+ */
+export const ListViewsResponse = new ListViewsResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class UpdateViewRequest$Type extends MessageType<UpdateViewRequest> {
+    constructor() {
+        super("parca.view.v1alpha1.UpdateViewRequest", [
+            { no: 1, name: "view", kind: "message", T: () => View }
+        ]);
+    }
+    create(value?: PartialMessage<UpdateViewRequest>): UpdateViewRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<UpdateViewRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: UpdateViewRequest): UpdateViewRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.view.v1alpha1.View view */ 1:
+                    message.view = View.internalBinaryRead(reader, reader.uint32(), options, message.view);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: UpdateViewRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.view.v1alpha1.View view = 1; */
+        if (message.view)
+            View.internalBinaryWrite(message.view, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.UpdateViewRequest
+ */
+export const UpdateViewRequest = new UpdateViewRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class UpdateViewResponse$Type extends MessageType<UpdateViewResponse> {
+    constructor() {
+        super("parca.view.v1alpha1.UpdateViewResponse", [
+            { no: 1, name: "view", kind: "message", T: () => View }
+        ]);
+    }
+    create(value?: PartialMessage<UpdateViewResponse>): UpdateViewResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<UpdateViewResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: UpdateViewResponse): UpdateViewResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.view.v1alpha1.View view */ 1:
+                    message.view = View.internalBinaryRead(reader, reader.uint32(), options, message.view);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: UpdateViewResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.view.v1alpha1.View view = 1; */
+        if (message.view)
+            View.internalBinaryWrite(message.view, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.UpdateViewResponse
+ */
+export const UpdateViewResponse = new UpdateViewResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DeleteViewRequest$Type extends MessageType<DeleteViewRequest> {
+    constructor() {
+        super("parca.view.v1alpha1.DeleteViewRequest", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<DeleteViewRequest>): DeleteViewRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        if (value !== undefined)
+            reflectionMergePartial<DeleteViewRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DeleteViewRequest): DeleteViewRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DeleteViewRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.DeleteViewRequest
+ */
+export const DeleteViewRequest = new DeleteViewRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DeleteViewResponse$Type extends MessageType<DeleteViewResponse> {
+    constructor() {
+        super("parca.view.v1alpha1.DeleteViewResponse", []);
+    }
+    create(value?: PartialMessage<DeleteViewResponse>): DeleteViewResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<DeleteViewResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DeleteViewResponse): DeleteViewResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DeleteViewResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.view.v1alpha1.DeleteViewResponse
+ */
+export const DeleteViewResponse = new DeleteViewResponse$Type();
+/**
+ * @generated ServiceType for protobuf service parca.view.v1alpha1.ViewService
+ */
+export const ViewService = new ServiceType("parca.view.v1alpha1.ViewService", [
+    { name: "CreateView", options: { "google.api.http": { post: "/views", body: "*" } }, I: CreateViewRequest, O: CreateViewResponse },
+    { name: "GetView", options: { "google.api.http": { get: "/views/{id}" } }, I: GetViewRequest, O: GetViewResponse },
+    { name: "ListViews", options: { "google.api.http": { get: "/views" } }, I: ListViewsRequest, O: ListViewsResponse },
+    { name: "UpdateView", options: { "google.api.http": { put: "/views/{view.id}", body: "view" } }, I: UpdateViewRequest, O: UpdateViewResponse },
+    { name: "DeleteView", options: { "google.api.http": { delete: "/views/{id}" } }, I: DeleteViewRequest, O: DeleteViewResponse }
+]);
@@ -65,6 +65,7 @@ export const useQueryRange = (
           step: Duration.create(stepDuration),
           limit: 0,
           sumBy,
+          filter: [],
         },
         {meta: metadata, abort: signal}
       );
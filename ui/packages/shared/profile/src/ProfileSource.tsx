@@ -170,6 +170,7 @@ export class ProfileDiffSource implements ProfileSource {
           a: this.a.DiffSelection(),
           b: this.b.DiffSelection(),
           absolute: this.absolute,
+          significance: false,
         },
       },
       reportType: QueryRequest_ReportType.FLAMEGRAPH_ARROW,